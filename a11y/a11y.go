@@ -0,0 +1,116 @@
+// Package a11y provides accessible building blocks for common patterns that
+// are easy to get subtly wrong by hand: skip links, visually hidden text and
+// live regions.
+//
+// SkipLink and VisuallyHidden rely on the rules in [Style], which should be
+// included once in the page's <head>.
+//
+// Example:
+//
+//	HTML()(
+//		HEAD()(a11y.Style()),
+//		BODY()(
+//			a11y.SkipLink("main")("Skip to content"),
+//			NAV()(...),
+//			MAIN(AttrID("main"), AttrTabIndex("-1"))(...),
+//			a11y.LiveRegion(a11y.Polite, AttrID("status"))(),
+//		),
+//	)
+package a11y
+
+import h "github.com/assaidy/hyper/v2"
+
+// Class names used by the components and defined by [Style].
+const (
+	ClassVisuallyHidden = "visually-hidden"
+	ClassSkipLink       = "skip-link"
+)
+
+// visuallyHiddenCSS hides content visually while keeping it available to
+// screen readers (unlike display:none or the hidden attribute).
+const visuallyHiddenCSS = "position:absolute;width:1px;height:1px;padding:0;margin:-1px;overflow:hidden;clip:rect(0,0,0,0);white-space:nowrap;border:0"
+
+// Style returns a <style> element with the rules used by [SkipLink] and [VisuallyHidden].
+func Style(attrs ...h.Attribute) h.HyperNode {
+	return h.STYLE(attrs...)(h.RawText(
+		"." + ClassVisuallyHidden + "{" + visuallyHiddenCSS + "}" +
+			"." + ClassSkipLink + ":not(:focus):not(:active){" + visuallyHiddenCSS + "}" +
+			"." + ClassSkipLink + "{position:absolute;top:.5rem;left:.5rem;z-index:9999;padding:.5rem 1rem;background:#fff;color:#000}",
+	))
+}
+
+// SkipLink creates a link to the element with targetID that stays hidden until
+// it receives keyboard focus, letting keyboard users bypass repeated navigation.
+// It should be the first focusable element on the page.
+//
+// For the focus to move reliably, the target should be focusable, e.g. a
+// <main> with tabindex="-1".
+//
+// Example:
+//
+//	a11y.SkipLink("main")("Skip to main content")
+func SkipLink(targetID string, attrs ...h.Attribute) h.ElementBuilder {
+	return h.WithChildren(h.Element{
+		Tag:        "a",
+		Attributes: append([]h.Attribute{h.AttrHref("#" + targetID), h.AttrClass(ClassSkipLink)}, attrs...),
+	})
+}
+
+// VisuallyHidden creates a <span> that is announced by screen readers but not
+// displayed, for context that is obvious visually but not from the markup alone.
+//
+// Example:
+//
+//	A(AttrHref("/posts/42"))("Read more", a11y.VisuallyHidden()(" about ", post.Title))
+func VisuallyHidden(attrs ...h.Attribute) h.ElementBuilder {
+	return h.WithChildren(h.Element{
+		Tag:        "span",
+		Attributes: append([]h.Attribute{h.AttrClass(ClassVisuallyHidden)}, attrs...),
+	})
+}
+
+// Politeness controls how urgently a live region's updates are announced.
+type Politeness string
+
+const (
+	// Polite updates are announced when the user is idle (role="status").
+	Polite Politeness = "polite"
+	// Assertive updates interrupt the user immediately (role="alert"). Reserve
+	// them for time-sensitive errors.
+	Assertive Politeness = "assertive"
+	// Off updates are not announced unless the region has focus.
+	Off Politeness = "off"
+)
+
+var politenessRoles = map[Politeness]string{
+	Polite:    "status",
+	Assertive: "alert",
+	Off:       "",
+}
+
+// LiveRegion creates a <div> whose content changes are announced by screen
+// readers, e.g. form submission results or a cart counter updated by htmx.
+//
+// The region must be present in the page before its content changes, so
+// render it empty on page load and swap its content later.
+//
+// Example:
+//
+//	a11y.LiveRegion(a11y.Polite, AttrID("cart-status"))()
+func LiveRegion(politeness Politeness, attrs ...h.Attribute) h.ElementBuilder {
+	role, ok := politenessRoles[politeness]
+	if !ok {
+		panic("invalid live region politeness")
+	}
+
+	element := h.Element{Tag: "div"}
+	if role != "" {
+		element.Attributes = append(element.Attributes, h.AttrRole(role))
+	}
+	element.Attributes = append(element.Attributes,
+		h.AttrAriaLive(string(politeness)),
+		h.AttrAriaAtomic("true"),
+	)
+	element.Attributes = append(element.Attributes, attrs...)
+	return h.WithChildren(element)
+}
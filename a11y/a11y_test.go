@@ -0,0 +1,54 @@
+package a11y
+
+import (
+	"bytes"
+	"testing"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+func TestComponents(t *testing.T) {
+	tests := []struct {
+		name     string
+		node     h.HyperNode
+		expected string
+	}{
+		{
+			name:     "Skip link",
+			node:     SkipLink("main")("Skip to content"),
+			expected: `<a href="#main" class="skip-link">Skip to content</a>`,
+		},
+		{
+			name:     "Visually hidden",
+			node:     VisuallyHidden()("about cats"),
+			expected: `<span class="visually-hidden">about cats</span>`,
+		},
+		{
+			name:     "Polite live region",
+			node:     LiveRegion(Polite, h.AttrID("status"))(),
+			expected: `<div role="status" aria-live="polite" aria-atomic="true" id="status"></div>`,
+		},
+		{
+			name:     "Assertive live region",
+			node:     LiveRegion(Assertive)("Payment failed"),
+			expected: `<div role="alert" aria-live="assertive" aria-atomic="true">Payment failed</div>`,
+		},
+		{
+			name:     "Silent live region",
+			node:     LiveRegion(Off)(),
+			expected: `<div aria-live="off" aria-atomic="true"></div>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := h.Render(&buf, tt.node); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("got %q, want %q", buf.String(), tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package h
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// ClassHeadingAnchor is the default class of the links added by
+// [HeadingAnchors].
+const ClassHeadingAnchor = "heading-anchor"
+
+// HeadingAnchorOptions configures [HeadingAnchors]. All fields are optional.
+type HeadingAnchorOptions struct {
+	// Selector selects the headings given a link; defaults to all headings.
+	Selector string
+	// Slug returns the id of a heading without one from its text; defaults
+	// to lowercase letters and digits with dashes between words ("Getting
+	// started" becomes "getting-started"). Ids already used in the page are
+	// suffixed with a counter.
+	Slug   func(text string) string
+	Symbol string // Content of the links; defaults to "#"
+	Label  string // Accessible name of the links; defaults to "Permalink"
+	Class  string // Class of the links; defaults to [ClassHeadingAnchor]
+}
+
+// HeadingAnchors returns node with a permalink appended to its headings,
+// linking to the heading's id, which is added when missing. Include
+// [HeadingAnchorStyles] to hide the links until their heading is hovered
+// or they are focused.
+//
+// Example:
+//
+//	article = HeadingAnchors(article, HeadingAnchorOptions{Selector: "h2, h3"})
+func HeadingAnchors(node HyperNode, options ...HeadingAnchorOptions) HyperNode {
+	var o HeadingAnchorOptions
+	if len(options) != 0 {
+		o = options[0]
+	}
+	if o.Selector == "" {
+		o.Selector = "h1, h2, h3, h4, h5, h6"
+	}
+	if o.Slug == nil {
+		o.Slug = headingID
+	}
+	o.Symbol = IfElse(o.Symbol != "", o.Symbol, "#")
+	o.Label = IfElse(o.Label != "", o.Label, "Permalink")
+	o.Class = IfElse(o.Class != "", o.Class, ClassHeadingAnchor)
+
+	used := map[string]int{}
+	return Transform(node, o.Selector, func(e Element) HyperNode {
+		id, ok := e.Attribute("id")
+		if !ok {
+			id = o.Slug(strings.TrimSpace(plainText(Element{Children: e.Children})))
+			if used[id]++; used[id] > 1 {
+				id += "-" + strconv.Itoa(used[id])
+			}
+			e.Attributes = append(e.Attributes, AttrID(id))
+		}
+		e.Children = append(e.Children, Text(" "), A(
+			AttrHref("#"+id),
+			AttrClass(o.Class),
+			AttrAriaLabel(o.Label),
+		)(o.Symbol))
+		return e
+	})
+}
+
+// headingAnchorCSS hides the permalinks of [HeadingAnchors] until needed,
+// keeping them reachable with the keyboard.
+const headingAnchorCSS = `.heading-anchor{opacity:0;text-decoration:none;transition:opacity .15s}` +
+	`:hover>.heading-anchor,.heading-anchor:focus{opacity:1}`
+
+// HeadingAnchorStyles returns the <style> element hiding the permalinks of
+// [HeadingAnchors] with the default class until their heading is hovered
+// or they are focused. It carries the CSP nonce of ctx (see [NonceKey]).
+func HeadingAnchorStyles(ctx context.Context) Element {
+	var attrs []Attribute
+	if nonce := Nonce(ctx); nonce != "" {
+		attrs = append(attrs, AttrNonce(nonce))
+	}
+	return STYLE(attrs...)(RawText(headingAnchorCSS))
+}
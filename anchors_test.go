@@ -0,0 +1,56 @@
+package h
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestHeadingAnchors(t *testing.T) {
+	article := ARTICLE()(
+		H1(AttrID("top"))("Guide"),
+		H2()("Getting started"),
+		H2()("Getting started"),
+		P()("Text"),
+	)
+
+	var buf bytes.Buffer
+	if err := Render(&buf, HeadingAnchors(article)); err != nil {
+		t.Fatal(err)
+	}
+	expected := `<article>` +
+		`<h1 id="top">Guide <a href="#top" class="heading-anchor" aria-label="Permalink">#</a></h1>` +
+		`<h2 id="getting-started">Getting started <a href="#getting-started" class="heading-anchor" aria-label="Permalink">#</a></h2>` +
+		`<h2 id="getting-started-2">Getting started <a href="#getting-started-2" class="heading-anchor" aria-label="Permalink">#</a></h2>` +
+		`<p>Text</p></article>`
+	if buf.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, buf.String())
+	}
+
+	buf.Reset()
+	node := HeadingAnchors(article, HeadingAnchorOptions{
+		Selector: "h2",
+		Slug:     func(text string) string { return "s-" + strings.ReplaceAll(strings.ToLower(text), " ", "_") },
+		Symbol:   "¶",
+	})
+	if err := Render(&buf, node); err != nil {
+		t.Fatal(err)
+	}
+	expected = `<article><h1 id="top">Guide</h1>` +
+		`<h2 id="s-getting_started">Getting started <a href="#s-getting_started" class="heading-anchor" aria-label="Permalink">¶</a></h2>` +
+		`<h2 id="s-getting_started-2">Getting started <a href="#s-getting_started-2" class="heading-anchor" aria-label="Permalink">¶</a></h2>` +
+		`<p>Text</p></article>`
+	if buf.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, buf.String())
+	}
+
+	buf.Reset()
+	ctx := WithValue(context.Background(), NonceKey, "abc")
+	if err := Render(&buf, HeadingAnchorStyles(ctx)); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(buf.String(), `<style nonce="abc">.heading-anchor{`) {
+		t.Errorf("expected a style element with the nonce, got %q", buf.String())
+	}
+}
@@ -0,0 +1,173 @@
+// Package attr provides attribute constructors whose values are typed, so
+// the compiler and IDE completion catch what the string-based h.Attr*
+// constructors can't: a misspelled input type, an unknown rel keyword, a
+// class list built by hand.
+//
+// The constructors return h.Attribute values, used alongside the h.Attr*
+// ones and grouped with h.Attributes:
+//
+//	h.INPUT(attr.Type(attr.InputEmail), attr.Class("input", h.IfElse(invalid, "input-error", "")))
+//
+//	var external = h.Attributes{attr.Target(attr.TargetBlank), attr.Rel(attr.RelNoOpener, attr.RelNoReferrer)}
+//	h.A(attr.Href(u), external)("Docs")
+package attr
+
+import (
+	"slices"
+	"strings"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+// Class returns a class attribute listing classes, skipping empty and
+// duplicate ones, so classes can be added conditionally.
+//
+// Example:
+//
+//	attr.Class("btn", h.IfElse(primary, "btn-primary", ""), size) // class="btn btn-primary btn-sm"
+func Class(classes ...string) h.PairAttribute {
+	var list []string
+	for _, class := range classes {
+		for name := range strings.FieldsSeq(class) {
+			if !slices.Contains(list, name) {
+				list = append(list, name)
+			}
+		}
+	}
+	return h.AttrClass(strings.Join(list, " "))
+}
+
+// Href returns an href attribute linking to url.
+func Href(url string) h.PairAttribute {
+	return h.AttrHref(url)
+}
+
+// Src returns a src attribute loading url.
+func Src(url string) h.PairAttribute {
+	return h.AttrSrc(url)
+}
+
+// InputType is the type of an <input> or <button>.
+type InputType string
+
+const (
+	InputButton        InputType = h.TypeButton
+	InputCheckbox      InputType = h.TypeCheckbox
+	InputColor         InputType = h.TypeColor
+	InputDate          InputType = h.TypeDate
+	InputDateTimeLocal InputType = h.TypeDateTimeLocal
+	InputEmail         InputType = h.TypeEmail
+	InputFile          InputType = h.TypeFile
+	InputHidden        InputType = h.TypeHidden
+	InputImage         InputType = h.TypeImage
+	InputMonth         InputType = h.TypeMonth
+	InputNumber        InputType = h.TypeNumber
+	InputPassword      InputType = h.TypePassword
+	InputRadio         InputType = h.TypeRadio
+	InputRange         InputType = h.TypeRange
+	InputReset         InputType = h.TypeReset
+	InputSearch        InputType = h.TypeSearch
+	InputSubmit        InputType = h.TypeSubmit
+	InputTel           InputType = h.TypeTel
+	InputText          InputType = h.TypeText
+	InputTime          InputType = h.TypeTime
+	InputURL           InputType = h.TypeUrl
+	InputWeek          InputType = h.TypeWeek
+)
+
+// Type returns a type attribute.
+func Type(t InputType) h.PairAttribute {
+	return h.AttrType(string(t))
+}
+
+// TargetName is the browsing context a link or form opens in.
+type TargetName string
+
+const (
+	TargetBlank  TargetName = h.TargetBlank
+	TargetSelf   TargetName = h.TargetSelf
+	TargetParent TargetName = h.TargetParent
+	TargetTop    TargetName = h.TargetTop
+)
+
+// Target returns a target attribute. Pair TargetBlank with
+// Rel(RelNoOpener) for links to other sites.
+func Target(t TargetName) h.PairAttribute {
+	return h.AttrTarget(string(t))
+}
+
+// RelType is a keyword of the rel attribute.
+type RelType string
+
+const (
+	RelAlternate  RelType = h.RelAlternate
+	RelAuthor     RelType = h.RelAuthor
+	RelBookmark   RelType = h.RelBookmark
+	RelCanonical  RelType = h.RelCanonical
+	RelExternal   RelType = h.RelExternal
+	RelHelp       RelType = h.RelHelp
+	RelIcon       RelType = h.RelIcon
+	RelLicense    RelType = h.RelLicense
+	RelManifest   RelType = h.RelManifest
+	RelNext       RelType = h.RelNext
+	RelNoFollow   RelType = h.RelNoFollow
+	RelNoOpener   RelType = h.RelNoOpener
+	RelNoReferrer RelType = h.RelNoReferrer
+	RelPreconnect RelType = h.RelPreconnect
+	RelPrefetch   RelType = h.RelPrefetch
+	RelPreload    RelType = h.RelPreload
+	RelPrev       RelType = h.RelPrev
+	RelSearch     RelType = h.RelSearch
+	RelStylesheet RelType = h.RelStylesheet
+	RelTag        RelType = h.RelTag
+)
+
+// Rel returns a rel attribute listing rels.
+func Rel(rels ...RelType) h.PairAttribute {
+	list := make([]string, len(rels))
+	for i, rel := range rels {
+		list[i] = string(rel)
+	}
+	return h.AttrRel(strings.Join(list, " "))
+}
+
+// LoadingMode is when an image or iframe loads.
+type LoadingMode string
+
+const (
+	LoadingLazy  LoadingMode = h.LoadingLazy
+	LoadingEager LoadingMode = h.LoadingEager
+)
+
+// Loading returns a loading attribute.
+func Loading(mode LoadingMode) h.PairAttribute {
+	return h.AttrLoading(string(mode))
+}
+
+// FormMethod is the method a form is submitted with.
+type FormMethod string
+
+const (
+	MethodGet    FormMethod = h.MethodGet
+	MethodPost   FormMethod = h.MethodPost
+	MethodDialog FormMethod = h.MethodDialog
+)
+
+// Method returns a method attribute.
+func Method(method FormMethod) h.PairAttribute {
+	return h.AttrMethod(string(method))
+}
+
+// Direction is the direction of text.
+type Direction string
+
+const (
+	DirLTR  Direction = h.DirLtr
+	DirRTL  Direction = h.DirRtl
+	DirAuto Direction = h.DirAuto
+)
+
+// Dir returns a dir attribute.
+func Dir(direction Direction) h.PairAttribute {
+	return h.AttrDir(string(direction))
+}
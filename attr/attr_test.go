@@ -0,0 +1,38 @@
+package attr
+
+import (
+	"bytes"
+	"testing"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+func render(t *testing.T, node h.HyperNode) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := h.Render(&buf, node); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestClass(t *testing.T) {
+	got := render(t, h.DIV(Class("btn", "", "btn-primary  btn", " btn-sm "))())
+	if expected := `<div class="btn btn-primary btn-sm"></div>`; got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestTyped(t *testing.T) {
+	got := render(t, h.Group(
+		h.INPUT(Type(InputEmail)),
+		h.A(Href("https://example.com"), h.Attributes{Target(TargetBlank), Rel(RelNoOpener, RelNoReferrer)})("x"),
+		h.IMG(Src("/a.png"), Loading(LoadingLazy)),
+	))
+	expected := `<input type="email">` +
+		`<a href="https://example.com" target="_blank" rel="noopener noreferrer">x</a>` +
+		`<img src="/a.png" loading="lazy">`
+	if got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"fmt"
 	"html"
+	"maps"
+	"slices"
 	"strings"
 )
 
@@ -26,14 +28,39 @@ func (me PairAttribute) Render(buf *bytes.Buffer) error {
 	}
 
 	buf.WriteByte(' ')
-	buf.WriteString(html.EscapeString(k))
+	writeAttrKey(buf, k)
 	buf.WriteString(`="`)
-	buf.WriteString(strings.ReplaceAll(me.Value, `"`, "&quot;"))
+	writeAttrValue(buf, me.Value)
 	buf.WriteByte('"')
 
 	return nil
 }
 
+// writeAttrKey writes an HTML-escaped attribute key. Keys almost never need
+// escaping, so they are scanned first and written as they are when possible.
+func writeAttrKey(buf *bytes.Buffer, key string) {
+	if strings.ContainsAny(key, `<>&'"`) {
+		buf.WriteString(html.EscapeString(key))
+		return
+	}
+	buf.WriteString(key)
+}
+
+// writeAttrValue writes an attribute value with its double quotes escaped,
+// copying the segments between quotes without allocating.
+func writeAttrValue(buf *bytes.Buffer, value string) {
+	for {
+		i := strings.IndexByte(value, '"')
+		if i < 0 {
+			buf.WriteString(value)
+			return
+		}
+		buf.WriteString(value[:i])
+		buf.WriteString("&quot;")
+		value = value[i+1:]
+	}
+}
+
 // BooleanAttribute represents an HTML boolean attribute that is either present or absent.
 // When IsActive is true, the attribute is rendered; otherwise it is omitted.
 type BooleanAttribute struct {
@@ -49,12 +76,25 @@ func (me BooleanAttribute) Render(buf *bytes.Buffer) error {
 
 	if me.IsActive {
 		buf.WriteByte(' ')
-		buf.WriteString(html.EscapeString(k))
+		writeAttrKey(buf, k)
 	}
 
 	return nil
 }
 
+// Attributes is a group of attributes used as a single one, for helpers
+// setting several related attributes at once (see [MinMax]).
+type Attributes []Attribute
+
+func (me Attributes) Render(buf *bytes.Buffer) error {
+	for _, attr := range me {
+		if err := attr.Render(buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Attr creates an attribute from a key and value.
 // If value is a string, it creates a PairAttribute (key="value").
 // If value is a bool, it creates a BooleanAttribute (present when true, absent when false).
@@ -79,6 +119,33 @@ func attr(key string, value any) Attribute {
 	}
 }
 
+// StyleAttr creates a style attribute from CSS property/value pairs.
+// Properties are written in sorted order so the output is deterministic,
+// and properties with an empty value are skipped.
+//
+// Example:
+//
+//	StyleAttr(map[string]string{
+//		"color":   primary.Var(),
+//		"padding": "1rem",
+//	}) // -> style="color:var(--color-primary);padding:1rem"
+func StyleAttr(properties map[string]string) PairAttribute {
+	var style strings.Builder
+	for _, property := range slices.Sorted(maps.Keys(properties)) {
+		value := properties[property]
+		if value == "" {
+			continue
+		}
+		if style.Len() != 0 {
+			style.WriteByte(';')
+		}
+		style.WriteString(property)
+		style.WriteByte(':')
+		style.WriteString(value)
+	}
+	return AttrStyle(style.String())
+}
+
 func makePairAttribute(key string) func(value string) PairAttribute {
 	return func(value string) PairAttribute {
 		return PairAttribute{Key: key, Value: value}
@@ -108,6 +175,68 @@ var (
 	AttrAlpha = makePairAttribute("alpha")
 	// AttrAlt provides alternative text for an image.
 	AttrAlt = makePairAttribute("alt")
+	// AttrAriaActiveDescendant identifies the currently active descendant of a composite widget.
+	AttrAriaActiveDescendant = makePairAttribute("aria-activedescendant")
+	// AttrAriaAtomic indicates whether assistive technologies present all or only changed parts of a live region.
+	AttrAriaAtomic = makePairAttribute("aria-atomic")
+	// AttrAriaAutocomplete indicates how input completion suggestions are presented.
+	AttrAriaAutocomplete = makePairAttribute("aria-autocomplete")
+	// AttrAriaBusy indicates that an element is being modified and assistive technologies should wait.
+	AttrAriaBusy = makePairAttribute("aria-busy")
+	// AttrAriaChecked indicates the checked state of checkboxes, radio buttons and other widgets.
+	AttrAriaChecked = makePairAttribute("aria-checked")
+	// AttrAriaControls identifies the elements whose contents are controlled by the element.
+	AttrAriaControls = makePairAttribute("aria-controls")
+	// AttrAriaCurrent indicates the element that represents the current item within a set (e.g. the current page).
+	AttrAriaCurrent = makePairAttribute("aria-current")
+	// AttrAriaDescribedBy identifies the elements that describe the element.
+	AttrAriaDescribedBy = makePairAttribute("aria-describedby")
+	// AttrAriaDisabled indicates that the element is perceivable but disabled.
+	AttrAriaDisabled = makePairAttribute("aria-disabled")
+	// AttrAriaErrorMessage identifies the element that provides an error message for the element.
+	AttrAriaErrorMessage = makePairAttribute("aria-errormessage")
+	// AttrAriaExpanded indicates whether a grouping element controlled by the element is expanded or collapsed.
+	AttrAriaExpanded = makePairAttribute("aria-expanded")
+	// AttrAriaHasPopup indicates the type of popup element that can be triggered by the element.
+	AttrAriaHasPopup = makePairAttribute("aria-haspopup")
+	// AttrAriaHidden indicates whether the element is exposed to the accessibility API.
+	AttrAriaHidden = makePairAttribute("aria-hidden")
+	// AttrAriaInvalid indicates that the entered value does not conform to the expected format.
+	AttrAriaInvalid = makePairAttribute("aria-invalid")
+	// AttrAriaKeyShortcuts indicates the keyboard shortcuts that activate or focus the element.
+	AttrAriaKeyShortcuts = makePairAttribute("aria-keyshortcuts")
+	// AttrAriaLabel defines a string value that labels the element.
+	AttrAriaLabel = makePairAttribute("aria-label")
+	// AttrAriaLabelledBy identifies the elements that label the element.
+	AttrAriaLabelledBy = makePairAttribute("aria-labelledby")
+	// AttrAriaLevel defines the hierarchical level of the element within a structure.
+	AttrAriaLevel = makePairAttribute("aria-level")
+	// AttrAriaLive indicates that the element will be updated and how assistive technologies should announce it.
+	AttrAriaLive = makePairAttribute("aria-live")
+	// AttrAriaModal indicates whether the element is modal when displayed.
+	AttrAriaModal = makePairAttribute("aria-modal")
+	// AttrAriaMultiSelectable indicates that the user may select more than one item.
+	AttrAriaMultiSelectable = makePairAttribute("aria-multiselectable")
+	// AttrAriaOrientation indicates whether the element's orientation is horizontal or vertical.
+	AttrAriaOrientation = makePairAttribute("aria-orientation")
+	// AttrAriaPressed indicates the pressed state of toggle buttons.
+	AttrAriaPressed = makePairAttribute("aria-pressed")
+	// AttrAriaReadOnly indicates that the element is not editable but otherwise operable.
+	AttrAriaReadOnly = makePairAttribute("aria-readonly")
+	// AttrAriaRequired indicates that user input is required on the element before a form may be submitted.
+	AttrAriaRequired = makePairAttribute("aria-required")
+	// AttrAriaSelected indicates the selection state of the element.
+	AttrAriaSelected = makePairAttribute("aria-selected")
+	// AttrAriaSort indicates whether items in a table or grid are sorted in ascending or descending order.
+	AttrAriaSort = makePairAttribute("aria-sort")
+	// AttrAriaValueMax defines the maximum allowed value for a range widget.
+	AttrAriaValueMax = makePairAttribute("aria-valuemax")
+	// AttrAriaValueMin defines the minimum allowed value for a range widget.
+	AttrAriaValueMin = makePairAttribute("aria-valuemin")
+	// AttrAriaValueNow defines the current value for a range widget.
+	AttrAriaValueNow = makePairAttribute("aria-valuenow")
+	// AttrAriaValueText defines the human readable text alternative of aria-valuenow.
+	AttrAriaValueText = makePairAttribute("aria-valuetext")
 	// AttrAs specifies the relation between the linked resource and the document.
 	AttrAs = makePairAttribute("as")
 	// AttrAsync indicates that the script should execute asynchronously.
@@ -182,6 +311,8 @@ var (
 	AttrEnterKeyHint = makePairAttribute("enterkeyhint")
 	// AttrElementTiming specifies that an element should be observed for performance.
 	AttrElementTiming = makePairAttribute("elementtiming")
+	// AttrFor specifies the id of the form control a label or output element is bound to.
+	AttrFor = makePairAttribute("for")
 	// AttrForm specifies the id of a form element that the element belongs to.
 	AttrForm = makePairAttribute("form")
 	// AttrFormAction specifies where to send the form data.
@@ -254,6 +385,8 @@ var (
 	AttrMuted = makeBooleanAttribute("muted")
 	// AttrName specifies the name of an element.
 	AttrName = makePairAttribute("name")
+	// AttrNonce specifies a cryptographic nonce allowing an inline script or style under a Content Security Policy.
+	AttrNonce = makePairAttribute("nonce")
 	// AttrNoValidate specifies that the form should not be validated.
 	AttrNoValidate = makeBooleanAttribute("novalidate")
 	// AttrOnAbort specifies the event handler for the abort event.
@@ -476,6 +609,10 @@ var (
 	AttrPlaysInline = makeBooleanAttribute("playsinline")
 	// AttrPoster specifies the preview image for a video.
 	AttrPoster = makePairAttribute("poster")
+	// AttrPopover makes an element a popover, shown and hidden by the browser.
+	AttrPopover = makePairAttribute("popover")
+	// AttrPopoverTarget specifies the id of the popover a button controls.
+	AttrPopoverTarget = makePairAttribute("popovertarget")
 	// AttrPopoverTargetAction specifies the action to perform with a popover element.
 	AttrPopoverTargetAction = makePairAttribute("popovertargetaction")
 	// AttrPreload specifies how to preload an audio/video.
@@ -676,6 +813,8 @@ const (
 	MethodGet = "get"
 	// MethodPost sends form data in the request body.
 	MethodPost = "post"
+	// MethodDialog closes the <dialog> containing the form instead of submitting it.
+	MethodDialog = "dialog"
 )
 
 // Enctype* constants are valid values for the enctype attribute on <form>.
@@ -832,6 +971,16 @@ const (
 	InputModeUrl = "url"
 )
 
+// Popover* constants are valid values for the popover attribute.
+const (
+	// PopoverAuto closes the popover on light dismiss and when another one opens.
+	PopoverAuto = "auto"
+	// PopoverManual only closes the popover explicitly.
+	PopoverManual = "manual"
+	// PopoverHint is for tooltips: it closes other hints but not auto popovers.
+	PopoverHint = "hint"
+)
+
 // PopoverTargetAction* constants are valid values for the popovertargetaction attribute.
 const (
 	// PopoverTargetActionHide hides the popover.
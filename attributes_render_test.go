@@ -0,0 +1,63 @@
+package h
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestAttributeEscaping(t *testing.T) {
+	tests := []struct {
+		attr     Attribute
+		expected string
+	}{
+		{attr: PairAttribute{Key: "class", Value: "btn primary"}, expected: ` class="btn primary"`},
+		{attr: PairAttribute{Key: "title", Value: `say "hi" & <bye>`}, expected: ` title="say &quot;hi&quot; & <bye>"`},
+		{attr: PairAttribute{Key: "data-x", Value: `"`}, expected: ` data-x="&quot;"`},
+		{attr: PairAttribute{Key: `a"b`, Value: ""}, expected: ` a&#34;b=""`},
+		{attr: BooleanAttribute{Key: "<hidden>", IsActive: true}, expected: ` &lt;hidden&gt;`},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := tt.attr.Render(&buf); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != tt.expected {
+			t.Errorf("expected %q, got %q", tt.expected, buf.String())
+		}
+	}
+}
+
+func TestAttributeRenderAllocations(t *testing.T) {
+	var buf bytes.Buffer
+	buf.Grow(1024)
+	attrs := []Attribute{
+		AttrClass("card card-primary"),
+		AttrTitle(`quoted "value"`),
+		AttrDisabled(true),
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		buf.Reset()
+		for _, attr := range attrs {
+			attr.Render(&buf)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("expected no allocations, got %v", allocs)
+	}
+}
+
+func BenchmarkAttributeRender(b *testing.B) {
+	var buf bytes.Buffer
+	attrs := []Attribute{
+		AttrClass("card card-primary"),
+		AttrHref("/users/42?tab=profile&sort=asc"),
+		AttrTitle(`quoted "value"`),
+		AttrDisabled(true),
+	}
+	for b.Loop() {
+		buf.Reset()
+		for _, attr := range attrs {
+			attr.Render(&buf)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+// Package auth provides the pages of a sign-in flow: login, registration,
+// password reset and two-factor verification. The forms are built with
+// forms.Field, post with h.FormTo (so they carry the CSRF token of the
+// request context), show their errors next to the fields, and set the
+// autocomplete attributes password managers rely on.
+//
+// They are styled through class names and the CSS custom properties of the
+// theme package (--color-primary, --color-background, --color-foreground,
+// --color-border, --color-error), so they follow the app's theme, including
+// dark mode; see [Style].
+package auth
+
+import (
+	"context"
+	"strconv"
+
+	h "github.com/assaidy/hyper/v2"
+	"github.com/assaidy/hyper/v2/forms"
+)
+
+// Class names set on the elements rendered by the package, as styling hooks.
+const (
+	ClassPage   = "auth-page"
+	ClassCard   = "auth-card"
+	ClassError  = "auth-error"
+	ClassNotice = "auth-notice"
+	ClassSubmit = "auth-submit"
+	ClassLinks  = "auth-links"
+	ClassOAuth  = "auth-oauth" // Sign-in with provider links, also classed "auth-oauth-<provider id>"
+)
+
+// css styles the pages with the theme tokens, falling back to a neutral
+// light palette when they are not defined.
+const css = `.auth-page{display:grid;place-items:center;min-height:100vh;margin:0;font-family:system-ui,sans-serif;` +
+	`background:var(--color-background,#f9fafb);color:var(--color-foreground,#111827)}` +
+	`.auth-card{width:min(24rem,100% - 2rem);padding:2rem;border:1px solid var(--color-border,#e5e7eb);border-radius:.75rem;background:var(--color-background,#fff)}` +
+	`.auth-card h1{margin:0 0 1.5rem;font-size:1.5rem}` +
+	`.auth-card .field{display:flex;flex-direction:column;gap:.25rem;margin-bottom:1rem}` +
+	`.auth-card input:not([type=checkbox]){padding:.5rem .75rem;border:1px solid var(--color-border,#d1d5db);border-radius:.375rem;font:inherit}` +
+	`.auth-card [aria-invalid=true]{border-color:var(--color-error,#dc2626)}` +
+	`.auth-card .field-help{margin:0;font-size:.875rem;opacity:.75}` +
+	`.auth-card .field-error p,.auth-error{margin:0;color:var(--color-error,#dc2626);font-size:.875rem}` +
+	`.auth-error,.auth-notice{margin-bottom:1rem}` +
+	`.auth-submit{width:100%;padding:.625rem;border:0;border-radius:.375rem;background:var(--color-primary,#2563eb);color:#fff;font:inherit;font-weight:600;cursor:pointer}` +
+	`.auth-links{display:flex;justify-content:space-between;margin-top:1rem;font-size:.875rem}` +
+	`.auth-links a{color:var(--color-primary,#2563eb)}` +
+	`.auth-oauth{display:flex;align-items:center;justify-content:center;gap:.5rem;margin-bottom:.75rem;padding:.625rem;border:1px solid var(--color-border,#d1d5db);border-radius:.375rem;color:inherit;font-weight:500;text-decoration:none}` +
+	`.auth-oauth-apple,.auth-oauth-github{border-color:#000;background:#000;color:#fff}`
+
+// Style returns a <style> element with the stylesheet of the pages, to
+// include in the head of custom layouts.
+func Style(attrs ...h.Attribute) h.HyperNode {
+	return h.STYLE(attrs...)(h.RawText(css))
+}
+
+// PageParams configures [Page]. All fields are optional.
+type PageParams struct {
+	// Layout wraps the page content in the app's own document (head,
+	// styles...), which must include [Style]. The default renders a minimal
+	// standalone document.
+	Layout func(title string, content h.HyperNode) h.HyperNode
+}
+
+// Page renders a full page holding card, one of the forms of the package.
+//
+// Example:
+//
+//	auth.Page("Sign in", auth.LoginForm(r.Context(), auth.LoginParams{Action: "/login"}))
+func Page(title string, card h.HyperNode, params ...PageParams) h.HyperNode {
+	var p PageParams
+	if len(params) != 0 {
+		p = params[0]
+	}
+	layout := p.Layout
+	if layout == nil {
+		layout = defaultLayout
+	}
+	return layout(title, h.MAIN(h.AttrClass(ClassPage))(card))
+}
+
+func defaultLayout(title string, content h.HyperNode) h.HyperNode {
+	return h.Group(
+		h.DOCTYPE(),
+		h.HTML(h.AttrLang("en"))(
+			h.HEAD()(
+				h.META(h.AttrCharset("utf-8")),
+				h.META(h.AttrName("viewport"), h.AttrContent("width=device-width, initial-scale=1")),
+				h.TITLE()(title),
+				Style(),
+			),
+			h.BODY(h.AttrStyle("margin:0"))(content),
+		),
+	)
+}
+
+// card renders the box of a form, with its heading and form-level error.
+func card(ctx context.Context, title, action, formError string, children ...any) h.HyperNode {
+	return h.DIV(h.AttrClass(ClassCard))(
+		h.H1()(title),
+		h.If(formError != "", h.P(h.AttrClass(ClassError), h.AttrRole("alert"))(formError)),
+		h.FormTo(ctx, h.MethodPost, action)(children...),
+	)
+}
+
+func submit(label string) h.HyperNode {
+	return h.BUTTON(h.AttrType(h.TypeSubmit), h.AttrClass(ClassSubmit))(label)
+}
+
+func links(children ...any) h.HyperNode {
+	return h.P(h.AttrClass(ClassLinks))(children...)
+}
+
+// LoginParams configures [LoginForm]. Action is required.
+type LoginParams struct {
+	Action            string              // URL the form posts to
+	Email             string              // Prefilled email, e.g. after a failed attempt
+	Error             string              // Form-level error, e.g. "Invalid email or password."
+	Errors            map[string][]string // Field errors, keyed by field name ("email", "password")
+	RememberMe        bool                // Shows a "Remember me" checkbox named "remember"
+	ForgotPasswordURL string              // Shows a link to the password reset page when set
+	RegisterURL       string              // Shows a link to the registration page when set
+}
+
+// LoginForm renders a login form posting "email" and "password".
+func LoginForm(ctx context.Context, params LoginParams) h.HyperNode {
+	return card(ctx, "Sign in", params.Action, params.Error,
+		forms.Field(forms.FieldParams{Label: "Email", Errors: params.Errors["email"]},
+			h.INPUT(h.AttrType(h.TypeEmail), h.AttrName("email"), h.AttrValue(params.Email),
+				h.AttrAutocomplete(h.AutocompleteUsername), h.AttrRequired(true), h.AttrAutofocus(params.Email == "")),
+		),
+		forms.Field(forms.FieldParams{Label: "Password", Errors: params.Errors["password"]},
+			h.INPUT(h.AttrType(h.TypePassword), h.AttrName("password"),
+				h.AttrAutocomplete(h.AutocompleteCurrentPassword), h.AttrRequired(true), h.AttrAutofocus(params.Email != "")),
+		),
+		h.If(params.RememberMe, h.DIV(h.AttrClass(forms.ClassField))(
+			h.LABEL()(h.INPUT(h.AttrType(h.TypeCheckbox), h.AttrName("remember"), h.AttrValue("1")), " Remember me"),
+		)),
+		submit("Sign in"),
+		h.If(params.ForgotPasswordURL != "" || params.RegisterURL != "", links(
+			h.If(params.ForgotPasswordURL != "", h.A(h.AttrHref(params.ForgotPasswordURL))("Forgot your password?")),
+			h.If(params.RegisterURL != "", h.A(h.AttrHref(params.RegisterURL))("Create an account")),
+		)),
+	)
+}
+
+// RegisterParams configures [RegisterForm]. Action is required.
+type RegisterParams struct {
+	Action         string              // URL the form posts to
+	Name, Email    string              // Prefilled values, e.g. after a failed attempt
+	Error          string              // Form-level error
+	Errors         map[string][]string // Field errors, keyed by field name ("name", "email", "password")
+	MinPasswordLen int                 // Minimum password length; defaults to 8
+	LoginURL       string              // Shows a link to the login page when set
+}
+
+// RegisterForm renders a registration form posting "name", "email" and
+// "password". The password is typed once: password managers generate it,
+// and a "show password" toggle serves better than a confirmation field.
+func RegisterForm(ctx context.Context, params RegisterParams) h.HyperNode {
+	minLength := h.IfElse(params.MinPasswordLen > 0, params.MinPasswordLen, 8)
+	return card(ctx, "Create an account", params.Action, params.Error,
+		forms.Field(forms.FieldParams{Label: "Name", Errors: params.Errors["name"]},
+			h.INPUT(h.AttrType(h.TypeText), h.AttrName("name"), h.AttrValue(params.Name),
+				h.AttrAutocomplete(h.AutocompleteName), h.AttrRequired(true), h.AttrAutofocus(true)),
+		),
+		forms.Field(forms.FieldParams{Label: "Email", Errors: params.Errors["email"]},
+			h.INPUT(h.AttrType(h.TypeEmail), h.AttrName("email"), h.AttrValue(params.Email),
+				h.AttrAutocomplete(h.AutocompleteEmail), h.AttrRequired(true)),
+		),
+		passwordField(params.Errors["password"], minLength),
+		submit("Create account"),
+		h.If(params.LoginURL != "", links(
+			h.SPAN()("Already have an account? ", h.A(h.AttrHref(params.LoginURL))("Sign in")),
+		)),
+	)
+}
+
+func passwordField(errors []string, minLength int) h.HyperNode {
+	return forms.Field(forms.FieldParams{
+		Label:  "Password",
+		Help:   "At least " + strconv.Itoa(minLength) + " characters.",
+		Errors: errors,
+	}, h.INPUT(h.AttrType(h.TypePassword), h.AttrName("password"),
+		h.AttrAutocomplete(h.AutocompleteNewPassword), h.AttrRequired(true), h.MinLength(minLength)))
+}
+
+// PasswordResetRequestParams configures [PasswordResetRequestForm]. Action
+// is required.
+type PasswordResetRequestParams struct {
+	Action string              // URL the form posts to
+	Email  string              // Prefilled email
+	Errors map[string][]string // Field errors, keyed by field name ("email")
+	// Sent shows that the reset email was sent. The message doesn't tell
+	// whether the address has an account, so the page can't be used to
+	// find out.
+	Sent     bool
+	LoginURL string // Shows a link back to the login page when set
+}
+
+// PasswordResetRequestForm renders the form asking for the email to send a
+// password reset link to, posting "email".
+func PasswordResetRequestForm(ctx context.Context, params PasswordResetRequestParams) h.HyperNode {
+	return card(ctx, "Reset your password", params.Action, "",
+		h.If(params.Sent, h.P(h.AttrClass(ClassNotice), h.AttrRole("status"))(
+			"If an account exists for this address, we sent it a link to reset its password.",
+		)),
+		forms.Field(forms.FieldParams{Label: "Email", Errors: params.Errors["email"]},
+			h.INPUT(h.AttrType(h.TypeEmail), h.AttrName("email"), h.AttrValue(params.Email),
+				h.AttrAutocomplete(h.AutocompleteEmail), h.AttrRequired(true), h.AttrAutofocus(true)),
+		),
+		submit("Send reset link"),
+		h.If(params.LoginURL != "", links(h.A(h.AttrHref(params.LoginURL))("Back to sign in"))),
+	)
+}
+
+// PasswordResetParams configures [PasswordResetForm]. Action and Token are
+// required.
+type PasswordResetParams struct {
+	Action         string              // URL the form posts to
+	Token          string              // Reset token from the emailed link, posted as "token"
+	Error          string              // Form-level error, e.g. "This link has expired."
+	Errors         map[string][]string // Field errors, keyed by field name ("password")
+	MinPasswordLen int                 // Minimum password length; defaults to 8
+}
+
+// PasswordResetForm renders the form choosing a new password, posting
+// "token" and "password".
+func PasswordResetForm(ctx context.Context, params PasswordResetParams) h.HyperNode {
+	minLength := h.IfElse(params.MinPasswordLen > 0, params.MinPasswordLen, 8)
+	return card(ctx, "Choose a new password", params.Action, params.Error,
+		h.INPUT(h.AttrType(h.TypeHidden), h.AttrName("token"), h.AttrValue(params.Token)),
+		passwordField(params.Errors["password"], minLength),
+		submit("Reset password"),
+	)
+}
+
+// TwoFactorParams configures [TwoFactorForm]. Action is required.
+type TwoFactorParams struct {
+	Action      string              // URL the form posts to
+	Error       string              // Form-level error
+	Errors      map[string][]string // Field errors, keyed by field name ("code")
+	Digits      int                 // Number of digits of the codes; defaults to 6
+	RecoveryURL string              // Shows a link to use a recovery code when set
+}
+
+// TwoFactorForm renders the form asking for the code of an authenticator
+// app or SMS, posting "code". The field brings up the numeric keyboard on
+// phones and lets browsers fill in codes received by SMS.
+func TwoFactorForm(ctx context.Context, params TwoFactorParams) h.HyperNode {
+	digits := h.IfElse(params.Digits > 0, params.Digits, 6)
+	return card(ctx, "Two-factor authentication", params.Action, params.Error,
+		forms.Field(forms.FieldParams{
+			Label:  "Verification code",
+			Help:   "Enter the " + strconv.Itoa(digits) + "-digit code from your authenticator app.",
+			Errors: params.Errors["code"],
+		}, h.INPUT(h.AttrType(h.TypeText), h.AttrName("code"),
+			h.AttrInputMode("numeric"), h.AttrPattern("[0-9]{"+strconv.Itoa(digits)+"}"), h.MaxLength(digits),
+			h.AttrAutocomplete(h.AutocompleteOneTimeCode), h.AttrRequired(true), h.AttrAutofocus(true))),
+		submit("Verify"),
+		h.If(params.RecoveryURL != "", links(h.A(h.AttrHref(params.RecoveryURL))("Use a recovery code"))),
+	)
+}
@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+func render(t *testing.T, node h.HyperNode) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := h.Render(&buf, node); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestLoginForm(t *testing.T) {
+	ctx := h.WithValue(context.Background(), h.CSRFKey, "tok")
+	html := render(t, LoginForm(ctx, LoginParams{
+		Action:            "/login",
+		Email:             "ada@example.com",
+		Error:             "Invalid email or password.",
+		Errors:            map[string][]string{"password": {"Required."}},
+		ForgotPasswordURL: "/reset",
+	}))
+	for _, expected := range []string{
+		`<p class="auth-error" role="alert">Invalid email or password.</p>`,
+		`<form method="post" action="/login"><input type="hidden" name="csrf_token" value="tok">`,
+		`name="email" value="ada@example.com" autocomplete="username" required`,
+		`autocomplete="current-password" required autofocus`,
+		`<p>Required.</p>`,
+		`<a href="/reset">Forgot your password?</a>`,
+	} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("expected %q in %q", expected, html)
+		}
+	}
+	if strings.Contains(html, "Remember me") || strings.Contains(html, "Create an account") {
+		t.Errorf("unexpected optional parts in %q", html)
+	}
+}
+
+func TestForms(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		node     h.HyperNode
+		expected []string
+	}{
+		{
+			node:     RegisterForm(ctx, RegisterParams{Action: "/register", LoginURL: "/login"}),
+			expected: []string{`autocomplete="new-password" required minlength="8"`, `autocomplete="email"`, `At least 8 characters.`},
+		},
+		{
+			node:     PasswordResetRequestForm(ctx, PasswordResetRequestParams{Action: "/reset", Sent: true}),
+			expected: []string{`role="status"`, `If an account exists`},
+		},
+		{
+			node:     PasswordResetForm(ctx, PasswordResetParams{Action: "/reset/confirm", Token: "abc"}),
+			expected: []string{`<input type="hidden" name="token" value="abc">`, `autocomplete="new-password"`},
+		},
+		{
+			node:     TwoFactorForm(ctx, TwoFactorParams{Action: "/2fa", Digits: 8}),
+			expected: []string{`inputmode="numeric" pattern="[0-9]{8}" maxlength="8" autocomplete="one-time-code"`},
+		},
+	}
+	for _, tt := range tests {
+		html := render(t, tt.node)
+		for _, expected := range tt.expected {
+			if !strings.Contains(html, expected) {
+				t.Errorf("expected %q in %q", expected, html)
+			}
+		}
+	}
+}
+
+func TestPage(t *testing.T) {
+	html := render(t, Page("Sign in", h.Text("card")))
+	if !strings.Contains(html, "<title>Sign in</title>") || !strings.Contains(html, `<main class="auth-page">card</main>`) || !strings.Contains(html, "var(--color-primary") {
+		t.Errorf("unexpected page %q", html)
+	}
+}
@@ -0,0 +1,124 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"net/url"
+	"strings"
+
+	h "github.com/assaidy/hyper/v2"
+	"github.com/assaidy/hyper/v2/icons"
+	"github.com/assaidy/hyper/v2/icons/brands"
+)
+
+// Provider is an OAuth 2.0 / OpenID Connect sign-in provider.
+type Provider struct {
+	ID      string   // Used in class names: "auth-oauth-google"
+	Name    string   // Shown in the button: "Sign in with Google"
+	AuthURL string   // Authorization endpoint
+	Scopes  []string // Scopes requested when OAuthParams.Scopes is empty
+	Icon    func(opts ...icons.Options) h.HyperNode
+}
+
+// Providers with their authorization endpoints and scopes for signing in.
+var (
+	Google = Provider{
+		ID:      "google",
+		Name:    "Google",
+		AuthURL: "https://accounts.google.com/o/oauth2/v2/auth",
+		Scopes:  []string{"openid", "email", "profile"},
+		Icon:    brands.Google,
+	}
+	GitHub = Provider{
+		ID:      "github",
+		Name:    "GitHub",
+		AuthURL: "https://github.com/login/oauth/authorize",
+		Scopes:  []string{"read:user", "user:email"},
+		Icon:    brands.GitHub,
+	}
+	Apple = Provider{
+		ID:      "apple",
+		Name:    "Apple",
+		AuthURL: "https://appleid.apple.com/auth/authorize",
+		Scopes:  []string{"name", "email"},
+		Icon:    brands.Apple,
+	}
+	Microsoft = Provider{
+		ID:      "microsoft",
+		Name:    "Microsoft",
+		AuthURL: "https://login.microsoftonline.com/common/oauth2/v2.0/authorize",
+		Scopes:  []string{"openid", "email", "profile"},
+		Icon:    brands.Microsoft,
+	}
+)
+
+// OAuthParams are the parameters of an authorization request. ClientID,
+// RedirectURI and State are required.
+type OAuthParams struct {
+	ClientID    string
+	RedirectURI string
+	// State protects the callback against CSRF: generate it with NewState,
+	// keep it (e.g. in a cookie), and check the callback returns it.
+	State string
+	// Nonce binds the ID token to the session, for OpenID Connect
+	// providers; generate it with NewState too.
+	Nonce  string
+	Scopes []string // Overrides the provider's scopes
+}
+
+// NewState returns a random value for OAuthParams.State and Nonce.
+func NewState() string {
+	b := make([]byte, 32)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// AuthorizationURL returns the URL starting the sign-in with the provider
+// (authorization code flow).
+func (me Provider) AuthorizationURL(params OAuthParams) string {
+	scopes := h.IfElse(len(params.Scopes) != 0, params.Scopes, me.Scopes)
+	query := url.Values{
+		"response_type": {"code"},
+		"client_id":     {params.ClientID},
+		"redirect_uri":  {params.RedirectURI},
+		"state":         {params.State},
+	}
+	if len(scopes) != 0 {
+		query.Set("scope", strings.Join(scopes, " "))
+	}
+	if params.Nonce != "" {
+		query.Set("nonce", params.Nonce)
+	}
+	if me.ID == Apple.ID && len(scopes) != 0 {
+		// Apple only returns the name and email when the callback is
+		// posted.
+		query.Set("response_mode", "form_post")
+	}
+	return me.AuthURL + "?" + query.Encode()
+}
+
+// SignInWith renders a link starting the sign-in with provider, showing its
+// logo and "Sign in with <provider>". The logo is decorative, the text
+// being the accessible name.
+//
+// Example:
+//
+//	state := auth.NewState()
+//	http.SetCookie(w, &http.Cookie{Name: "oauth_state", Value: state, HttpOnly: true, Secure: true})
+//
+//	auth.SignInWith(auth.GitHub, auth.OAuthParams{
+//		ClientID:    githubClientID,
+//		RedirectURI: "https://example.com/auth/github/callback",
+//		State:       state,
+//	})
+func SignInWith(provider Provider, params OAuthParams, attrs ...h.Attribute) h.HyperNode {
+	attrs = append([]h.Attribute{
+		h.AttrHref(provider.AuthorizationURL(params)),
+		h.AttrClass(ClassOAuth + " " + ClassOAuth + "-" + provider.ID),
+	}, attrs...)
+	var icon h.HyperNode = h.Group()
+	if provider.Icon != nil {
+		icon = provider.Icon(icons.Options{Size: 20})
+	}
+	return h.A(attrs...)(icon, h.SPAN()("Sign in with "+provider.Name))
+}
@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestAuthorizationURL(t *testing.T) {
+	params := OAuthParams{ClientID: "id", RedirectURI: "https://example.com/cb", State: "st", Nonce: "no"}
+
+	u, err := url.Parse(Google.AuthorizationURL(params))
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := u.Query()
+	for key, expected := range map[string]string{
+		"response_type": "code",
+		"client_id":     "id",
+		"redirect_uri":  "https://example.com/cb",
+		"state":         "st",
+		"nonce":         "no",
+		"scope":         "openid email profile",
+	} {
+		if got := query.Get(key); got != expected {
+			t.Errorf("%s: expected %q, got %q", key, expected, got)
+		}
+	}
+	if query.Has("response_mode") {
+		t.Error("unexpected response_mode for Google")
+	}
+
+	u, _ = url.Parse(Apple.AuthorizationURL(params))
+	if u.Query().Get("response_mode") != "form_post" {
+		t.Error("expected response_mode=form_post for Apple")
+	}
+
+	params.Scopes = []string{"repo"}
+	if !strings.Contains(GitHub.AuthorizationURL(params), "scope=repo&") {
+		t.Error("expected the scopes to be overridden")
+	}
+
+	if a, b := NewState(), NewState(); len(a) != 43 || a == b {
+		t.Errorf("unexpected states %q, %q", a, b)
+	}
+}
+
+func TestSignInWith(t *testing.T) {
+	html := render(t, SignInWith(Microsoft, OAuthParams{ClientID: "id", State: "st"}))
+	for _, expected := range []string{
+		`<a href="https://login.microsoftonline.com/common/oauth2/v2.0/authorize?`,
+		`class="auth-oauth auth-oauth-microsoft"><svg`,
+		`aria-hidden="true"`,
+		`<span>Sign in with Microsoft</span></a>`,
+	} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("expected %q in %q", expected, html)
+		}
+	}
+
+	html = render(t, SignInWith(Provider{ID: "acme", Name: "Acme", AuthURL: "https://acme.example/auth"}, OAuthParams{}))
+	if !strings.Contains(html, `<span>Sign in with Acme</span>`) {
+		t.Errorf("unexpected link without icon %q", html)
+	}
+}
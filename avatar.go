@@ -0,0 +1,199 @@
+package h
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"hash/fnv"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// GravatarDefault* constants are valid values for the Gravatar "d" parameter,
+// which selects the image served when an email has no Gravatar.
+const (
+	// GravatarDefaultMysteryPerson serves a simple, cartoon-style silhouetted outline.
+	GravatarDefaultMysteryPerson = "mp"
+	// GravatarDefaultIdenticon serves a geometric pattern based on the email hash.
+	GravatarDefaultIdenticon = "identicon"
+	// GravatarDefaultMonsterID serves a generated monster with different colors and faces.
+	GravatarDefaultMonsterID = "monsterid"
+	// GravatarDefaultWavatar serves generated faces with differing features and backgrounds.
+	GravatarDefaultWavatar = "wavatar"
+	// GravatarDefaultRetro serves an 8-bit arcade-style pixelated face.
+	GravatarDefaultRetro = "retro"
+	// GravatarDefaultRobohash serves a generated robot with different colors and faces.
+	GravatarDefaultRobohash = "robohash"
+	// GravatarDefaultBlank serves a transparent PNG image.
+	GravatarDefaultBlank = "blank"
+	// GravatarDefaultNotFound makes Gravatar respond with 404 instead of an image.
+	GravatarDefaultNotFound = "404"
+)
+
+// avatarColors is the palette used for the background of initials avatars.
+// The color is picked deterministically from the name, so the same person
+// always gets the same color.
+var avatarColors = []string{
+	"#ef4444", "#f97316", "#f59e0b", "#84cc16", "#22c55e", "#14b8a6",
+	"#06b6d4", "#3b82f6", "#6366f1", "#8b5cf6", "#d946ef", "#ec4899",
+}
+
+// GravatarURL returns the Gravatar image URL for an email address.
+//
+// The email is trimmed and lower-cased before hashing, as required by Gravatar.
+// size is the image width/height in pixels (ignored when <= 0), and def selects
+// the fallback image (see the GravatarDefault* constants; ignored when empty).
+//
+// Example:
+//
+//	GravatarURL("Jane@Example.com", 64, GravatarDefaultIdenticon)
+//	// https://www.gravatar.com/avatar/<md5>?d=identicon&s=64
+func GravatarURL(email string, size int, def string) string {
+	sum := md5.Sum([]byte(strings.ToLower(strings.TrimSpace(email))))
+
+	query := url.Values{}
+	if size > 0 {
+		query.Set("s", strconv.Itoa(size))
+	}
+	if def != "" {
+		query.Set("d", def)
+	}
+
+	result := "https://www.gravatar.com/avatar/" + hex.EncodeToString(sum[:])
+	if len(query) != 0 {
+		result += "?" + query.Encode()
+	}
+	return result
+}
+
+// Avatar creates a square avatar image.
+//
+// The source decides what is rendered:
+//   - an email address renders its Gravatar over the initials of alt,
+//     which show when the email has no Gravatar,
+//   - any other non-empty value is used as the image URL as-is,
+//   - an empty source renders an inline SVG with the initials of alt.
+//
+// alt is used as the image's alternative text and as the name initials are taken from.
+//
+// Example:
+//
+//	Avatar(user.Email, 40, user.Name, AttrClass("rounded-full"))
+//	Avatar("", 40, "Jane Doe") // <svg ...>JD</svg>
+func Avatar(source string, size int, alt string, attrs ...Attribute) HyperNode {
+	source = strings.TrimSpace(source)
+	if source == "" {
+		return InitialsAvatar(alt, size, attrs...)
+	}
+
+	if isEmailAddress(source) {
+		return gravatarAvatar(source, size, alt, attrs)
+	}
+
+	dimension := strconv.Itoa(size)
+	element := Element{Tag: "img", IsVoid: true}
+	element.Attributes = append(element.Attributes,
+		AttrSrc(source),
+		AttrAlt(alt),
+		AttrWidth(dimension),
+		AttrHeight(dimension),
+	)
+	element.Attributes = append(element.Attributes, attrs...)
+	return element
+}
+
+// gravatarAvatar renders the Gravatar of email over the initials avatar of
+// name. Gravatar answers 404 for emails without one, and the broken image,
+// having an empty alternative text, renders nothing, uncovering the
+// initials, which name the avatar for assistive technologies.
+func gravatarAvatar(email string, size int, name string, attrs []Attribute) HyperNode {
+	dimension := strconv.Itoa(size)
+
+	box := Element{Tag: "span"}
+	box.Attributes = append(box.Attributes,
+		AttrStyle("display:inline-block;position:relative;overflow:hidden;width:"+dimension+"px;height:"+dimension+"px"),
+	)
+	box.Attributes = append(box.Attributes, attrs...)
+
+	image := Element{Tag: "img", IsVoid: true, Attributes: []Attribute{
+		AttrSrc(GravatarURL(email, size, GravatarDefaultNotFound)),
+		AttrAlt(""),
+		AttrWidth(dimension),
+		AttrHeight(dimension),
+		AttrStyle("position:absolute;top:0;left:0"),
+	}}
+	box.Children = append(box.Children, InitialsAvatar(name, size, AttrStyle("display:block")), image)
+	return box
+}
+
+// InitialsAvatar creates an inline SVG avatar showing up to two initials of name
+// on a colored background. The color is derived from name, so it is stable
+// across renders.
+//
+// Example:
+//
+//	InitialsAvatar("Ada Lovelace", 32) // renders "AL"
+func InitialsAvatar(name string, size int, attrs ...Attribute) HyperNode {
+	dimension := strconv.Itoa(size)
+
+	svg := Element{Tag: "svg"}
+	svg.Attributes = append(svg.Attributes,
+		Attr("xmlns", "http://www.w3.org/2000/svg"),
+		AttrWidth(dimension),
+		AttrHeight(dimension),
+		Attr("viewBox", "0 0 100 100"),
+		AttrRole("img"),
+		AttrAriaLabel(name),
+	)
+	svg.Attributes = append(svg.Attributes, attrs...)
+
+	background := Element{Tag: "rect", Attributes: []Attribute{
+		AttrWidth("100"),
+		AttrHeight("100"),
+		Attr("fill", avatarColor(name)),
+	}}
+	label := WithChildren(Element{Tag: "text", Attributes: []Attribute{
+		Attr("x", "50"),
+		Attr("y", "50"),
+		Attr("dy", ".35em"),
+		Attr("text-anchor", "middle"),
+		Attr("fill", "#ffffff"),
+		Attr("font-family", "system-ui, sans-serif"),
+		Attr("font-size", "40"),
+	}})(initials(name))
+
+	svg.Children = append(svg.Children, background, label)
+	return svg
+}
+
+// initials returns the upper-cased first letters of the first and last words of name.
+func initials(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	switch len(words) {
+	case 0:
+		return "?"
+	case 1:
+		first, _ := utf8.DecodeRuneInString(words[0])
+		return strings.ToUpper(string(first))
+	default:
+		first, _ := utf8.DecodeRuneInString(words[0])
+		last, _ := utf8.DecodeRuneInString(words[len(words)-1])
+		return strings.ToUpper(string(first) + string(last))
+	}
+}
+
+func avatarColor(name string) string {
+	hash := fnv.New32a()
+	hash.Write([]byte(name))
+	return avatarColors[hash.Sum32()%uint32(len(avatarColors))]
+}
+
+func isEmailAddress(s string) bool {
+	at := strings.LastIndexByte(s, '@')
+	return at > 0 && at < len(s)-1 && !strings.Contains(s, "://") && !strings.ContainsAny(s, " /")
+}
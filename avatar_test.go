@@ -0,0 +1,90 @@
+package h
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestGravatarURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		email    string
+		size     int
+		def      string
+		expected string
+	}{
+		{
+			name:     "Email is normalized before hashing",
+			email:    "  MyEmailAddress@example.com ",
+			expected: "https://www.gravatar.com/avatar/0bc83cb571cd1c50ba6f3e8a78ef1346",
+		},
+		{
+			name:     "Size and default params",
+			email:    "myemailaddress@example.com",
+			size:     64,
+			def:      GravatarDefaultIdenticon,
+			expected: "https://www.gravatar.com/avatar/0bc83cb571cd1c50ba6f3e8a78ef1346?d=identicon&s=64",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GravatarURL(tt.email, tt.size, tt.def); got != tt.expected {
+				t.Errorf("GravatarURL() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAvatar(t *testing.T) {
+	tests := []struct {
+		name     string
+		node     HyperNode
+		contains []string
+	}{
+		{
+			name: "Email renders gravatar image over initials",
+			node: Avatar("myemailaddress@example.com", 40, "Me", AttrClass("rounded")),
+			contains: []string{
+				`<span style="display:inline-block;position:relative;overflow:hidden;width:40px;height:40px" class="rounded"><svg`,
+				`aria-label="Me" style="display:block">`,
+				`>M</text></svg><img src="https://www.gravatar.com/avatar/0bc83cb571cd1c50ba6f3e8a78ef1346?d=404&s=40" alt="" width="40"`,
+			},
+		},
+		{
+			name:     "URL renders image as-is",
+			node:     Avatar("https://example.com/me.png", 32, "Me", AttrClass("rounded")),
+			contains: []string{`<img src="https://example.com/me.png" alt="Me" width="32" height="32" class="rounded">`},
+		},
+		{
+			name:     "Empty source renders initials",
+			node:     Avatar("", 32, "Ada Lovelace"),
+			contains: []string{"<svg", `aria-label="Ada Lovelace"`, ">AL</text>"},
+		},
+		{
+			name:     "Single word name",
+			node:     InitialsAvatar("ada", 32),
+			contains: []string{">A</text>"},
+		},
+		{
+			name:     "Empty name",
+			node:     InitialsAvatar("", 32),
+			contains: []string{">?</text>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Render(&buf, tt.node); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("Avatar() = %q, want it to contain %q", buf.String(), want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package h
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// Template inheritance, as with {{block}} in html/template or
+// {% extends %} in Jinja, built on slots: a base layout declares blocks
+// with [Block], and pages [Extend] it, replacing blocks with [Define]. Unlike
+// [WithSlots], the result still has its blocks, so it can be extended in
+// turn, and a definition can include the content it replaces with [Super].
+
+// Block declares a block called name in a base layout, with its default
+// content. It is a [Slot], so layouts with blocks work with [WithSlots] too.
+//
+// Example:
+//
+//	var base = HTML()(
+//		HEAD()(TITLE()(Block("title", "My App"))),
+//		BODY()(
+//			Block("nav", NAV()(A(AttrHref("/"))("Home"))),
+//			MAIN()(Block("content")),
+//		),
+//	)
+func Block(name string, content ...any) SlotDef {
+	return Slot(name, content...)
+}
+
+// BlockDef is the content replacing a block of an extended layout.
+type BlockDef struct {
+	Name     string
+	Children []HyperNode
+}
+
+// Define provides children replacing the block called name. Unlike [Fill],
+// defining a block more than once keeps the last definition.
+func Define(name string, children ...any) BlockDef {
+	element := Element{}
+	InsertChildren(&element, children...)
+	return BlockDef{Name: name, Children: element.Children}
+}
+
+// superNode stands for the content of the block replaced by a definition.
+type superNode struct{}
+
+var errSuperOutsideBlock = errors.New("h: Super used outside of a block definition")
+
+// Render fails: superNode is only valid in the children of a Define.
+func (superNode) Render(io.Writer) error {
+	return errSuperOutsideBlock
+}
+
+// Super stands, in the children of [Define], for the content of the block
+// being replaced, like {{ super() }} in Jinja.
+//
+// Example:
+//
+//	Define("title", "Settings · ", Super()) // <title>Settings · My App</title>
+func Super() HyperNode {
+	return superNode{}
+}
+
+// Extend returns base with its blocks replaced by defines. Blocks not
+// defined keep their content. Defining a block base doesn't declare makes
+// the render fail, catching typos early.
+//
+// Example:
+//
+//	var adminBase = Extend(base,
+//		Define("title", "Admin · ", Super()),
+//		Define("nav", AdminNav()),
+//	)
+//
+//	func UsersPage(users []User) HyperNode {
+//		return Extend(adminBase,
+//			Define("title", "Users · ", Super()), // Users · Admin · My App
+//			Define("content", UsersTable(users)),
+//		)
+//	}
+func Extend(base HyperNode, defines ...BlockDef) HyperNode {
+	content := map[string][]HyperNode{}
+	for _, define := range defines {
+		content[define.Name] = define.Children
+	}
+
+	var declared []string
+	result := extendBlocks(base, content, &declared)
+	for _, define := range defines {
+		if !slices.Contains(declared, define.Name) {
+			return errorNode{err: fmt.Errorf("h: layout has no block %q", define.Name)}
+		}
+	}
+	return result
+}
+
+func extendBlocks(node HyperNode, content map[string][]HyperNode, declared *[]string) HyperNode {
+	switch n := node.(type) {
+	case SlotDef:
+		*declared = append(*declared, n.Name)
+		children := n.Children
+		if defined, ok := content[n.Name]; ok {
+			children = replaceSuper(defined, n.Children)
+		}
+		return SlotDef{Name: n.Name, Children: extendBlocks(Element{Children: children}, content, declared).(Element).Children}
+	case Element:
+		children := make([]HyperNode, len(n.Children))
+		for i, child := range n.Children {
+			children[i] = extendBlocks(child, content, declared)
+		}
+		n.Children = children
+		return n
+	case Wrapper:
+		return mapWrapped(n, func(child HyperNode) HyperNode {
+			return extendBlocks(child, content, declared)
+		})
+	default:
+		return node
+	}
+}
+
+// replaceSuper returns nodes with their Super placeholders replaced by
+// parent, the content of the block they replace.
+func replaceSuper(nodes []HyperNode, parent []HyperNode) []HyperNode {
+	result := make([]HyperNode, 0, len(nodes))
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case superNode:
+			result = append(result, parent...)
+		case Element:
+			n.Children = replaceSuper(n.Children, parent)
+			result = append(result, n)
+		case Wrapper:
+			result = append(result, mapWrapped(n, func(child HyperNode) HyperNode {
+				replaced := replaceSuper([]HyperNode{child}, parent)
+				if len(replaced) == 1 {
+					return replaced[0]
+				}
+				return Element{Children: replaced}
+			}))
+		default:
+			result = append(result, node)
+		}
+	}
+	return result
+}
@@ -0,0 +1,73 @@
+package h
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestExtend(t *testing.T) {
+	base := BODY()(
+		HEADER()(Block("title", "App")),
+		MAIN()(Block("content")),
+	)
+	admin := Extend(base, Define("title", "Admin · ", Super()), Define("content", "Dashboard"))
+
+	tests := []struct {
+		name     string
+		node     HyperNode
+		expected string
+	}{
+		{
+			name:     "Base alone renders defaults",
+			node:     base,
+			expected: `<body><header>App</header><main></main></body>`,
+		},
+		{
+			name:     "Defined blocks",
+			node:     admin,
+			expected: `<body><header>Admin · App</header><main>Dashboard</main></body>`,
+		},
+		{
+			name:     "Extending an extended layout",
+			node:     Extend(admin, Define("title", SPAN()("Users · ", Super()))),
+			expected: `<body><header><span>Users · Admin · App</span></header><main>Dashboard</main></body>`,
+		},
+		{
+			name:     "Last definition wins",
+			node:     Extend(base, Define("content", "a"), Define("content", "b")),
+			expected: `<body><header>App</header><main>b</main></body>`,
+		},
+		{
+			name:     "Nested blocks",
+			node:     Extend(base, Define("content", Block("inner", "x")), Define("inner", "y")),
+			expected: `<body><header>App</header><main>y</main></body>`,
+		},
+		{
+			name:     "Filling an extended layout",
+			node:     WithSlots(admin, Fill("content", "Filled")),
+			expected: `<body><header>Admin · App</header><main>Filled</main></body>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Render(&buf, tt.node); err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, buf.String())
+			}
+		})
+	}
+}
+
+func TestExtendErrors(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, Extend(DIV()(Block("main")), Define("mian", "typo"))); err == nil {
+		t.Error("expected an error for an unknown block")
+	}
+	if err := Render(&buf, DIV()(Super())); err == nil {
+		t.Error("expected an error for Super outside of a block definition")
+	}
+}
@@ -0,0 +1,181 @@
+package h
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+)
+
+// OptionalNode is content a page can do without under load, created with
+// [Optional].
+type OptionalNode struct {
+	Node     HyperNode
+	Priority int
+}
+
+// Optional marks node as optional content, such as recommendations or
+// related items, which [RenderBudgeted] drops once its budget is exceeded.
+// The higher the priority, the longer the node is kept (see [Budget.Keep]).
+// Outside of RenderBudgeted, node renders unchanged.
+//
+// Example:
+//
+//	MAIN()(
+//		Article(post),
+//		Optional(RelatedPosts(post), 1),
+//		Optional(Comments(post), 2),
+//	)
+func Optional(node HyperNode, priority int) OptionalNode {
+	return OptionalNode{Node: node, Priority: priority}
+}
+
+func (me OptionalNode) Render(w io.Writer) error {
+	return me.Node.Render(w)
+}
+
+// RenderToBuffer implements [BufferRenderer].
+func (me OptionalNode) RenderToBuffer(buf *bytes.Buffer) error {
+	if node, ok := me.Node.(BufferRenderer); ok {
+		return node.RenderToBuffer(buf)
+	}
+	return me.Node.Render(buf)
+}
+
+// Unwrap implements [Wrapper].
+func (me OptionalNode) Unwrap() []HyperNode {
+	return []HyperNode{me.Node}
+}
+
+func (me OptionalNode) mapNodes(fn func(HyperNode) HyperNode) HyperNode {
+	return Optional(fn(me.Node), me.Priority)
+}
+
+// Budget bounds a render with [RenderBudgeted]. Zero fields mean no limit.
+type Budget struct {
+	// Time is the time after which optional nodes are dropped, measured from
+	// the start of the render.
+	Time time.Duration
+	// Bytes is the output size after which optional nodes are dropped. It is
+	// measured on the buffer of the element being rendered, which holds the
+	// whole page unless custom nodes not implementing [BufferRenderer] sit
+	// in between.
+	Bytes int
+	// Keep is the priority above which optional nodes are rendered even over
+	// budget. With the zero value, only nodes of positive priority are kept.
+	Keep int
+	// Logger receives a warning for each dropped node; defaults to
+	// slog.Default().
+	Logger *slog.Logger
+}
+
+// budgetState is the state of a budgeted render, shared by its optional
+// nodes.
+type budgetState struct {
+	ctx    context.Context
+	budget Budget
+	start  time.Time
+}
+
+// budgetedNode is an [OptionalNode] bound to the budget of a render.
+type budgetedNode struct {
+	OptionalNode
+	state *budgetState
+}
+
+func (me budgetedNode) Render(w io.Writer) error {
+	var buf bytes.Buffer
+	if err := me.RenderToBuffer(&buf); err != nil {
+		return err
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// RenderToBuffer implements [BufferRenderer].
+func (me budgetedNode) RenderToBuffer(buf *bytes.Buffer) error {
+	if err := me.state.ctx.Err(); err != nil {
+		return err
+	}
+	if reason := me.state.exceeded(buf); reason != "" && me.Priority <= me.state.budget.Keep {
+		logger := me.state.budget.Logger
+		if logger == nil {
+			logger = slog.Default()
+		}
+		logger.WarnContext(me.state.ctx, "render budget exceeded, optional node dropped",
+			"node", describeNode(me.Node), "priority", me.Priority, "reason", reason)
+		return nil
+	}
+	return me.OptionalNode.RenderToBuffer(buf)
+}
+
+func (me budgetedNode) mapNodes(fn func(HyperNode) HyperNode) HyperNode {
+	me.Node = fn(me.Node)
+	return me
+}
+
+// exceeded returns which part of the budget is exceeded, or "".
+func (me *budgetState) exceeded(buf *bytes.Buffer) string {
+	if me.budget.Time > 0 && time.Since(me.start) > me.budget.Time {
+		return "time"
+	}
+	if me.budget.Bytes > 0 && buf.Len() > me.budget.Bytes {
+		return "bytes"
+	}
+	return ""
+}
+
+// RenderBudgeted renders node to w like [Render], dropping the nodes marked
+// with [Optional] whose priority is not above budget.Keep once the time or byte
+// budget is exceeded, so an overloaded server keeps serving core content.
+// Each dropped node is logged. Optional nodes are found in elements and in
+// the wrappers of this package, such as [Named] and [Key]; other node types
+// are opaque (see [Wrapper]).
+//
+// The nodes are rendered with ctx like with [RenderCtx], and the render
+// stops at the next optional node once ctx is done, returning its error.
+//
+// Example:
+//
+//	err := RenderBudgeted(r.Context(), w, page, Budget{Time: 200 * time.Millisecond, Keep: 1})
+func RenderBudgeted(ctx context.Context, w io.Writer, node HyperNode, budget Budget) error {
+	state := &budgetState{ctx: ctx, budget: budget, start: time.Now()}
+	return RenderCtx(ctx, w, bindBudget(node, state))
+}
+
+// bindBudget returns a copy of node with its optional nodes bound to state.
+func bindBudget(node HyperNode, state *budgetState) HyperNode {
+	switch n := node.(type) {
+	case Element:
+		children := make([]HyperNode, len(n.Children))
+		for i, child := range n.Children {
+			children[i] = bindBudget(child, state)
+		}
+		n.Children = children
+		return n
+	case OptionalNode:
+		n.Node = bindBudget(n.Node, state)
+		return budgetedNode{OptionalNode: n, state: state}
+	case Wrapper:
+		return mapWrapped(n, func(child HyperNode) HyperNode {
+			return bindBudget(child, state)
+		})
+	default:
+		return node
+	}
+}
+
+// describeNode names node for logs.
+func describeNode(node HyperNode) string {
+	switch n := node.(type) {
+	case NamedNode:
+		return n.Name
+	case Element:
+		if n.Tag != "" {
+			return "<" + n.Tag + ">"
+		}
+	}
+	return fmt.Sprintf("%T", node)
+}
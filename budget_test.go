@@ -0,0 +1,98 @@
+package h
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestRenderBudgeted(t *testing.T) {
+	page := MAIN()(
+		P()(strings.Repeat("x", 20)),
+		Optional(Named("Related", ASIDE()("related")), 0),
+		Optional(SECTION()("comments"), 1),
+		Key("k", Optional(NAV()("more"), -1)),
+	)
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+
+	tests := []struct {
+		name     string
+		budget   Budget
+		expected string
+		dropped  []string
+	}{
+		{
+			name:     "Within budget",
+			budget:   Budget{Bytes: 1000, Logger: logger},
+			expected: "<main><p>xxxxxxxxxxxxxxxxxxxx</p><aside>related</aside><section>comments</section><nav>more</nav></main>",
+		},
+		{
+			name:     "Over byte budget",
+			budget:   Budget{Bytes: 10, Logger: logger},
+			expected: "<main><p>xxxxxxxxxxxxxxxxxxxx</p><section>comments</section></main>",
+			dropped:  []string{"node=Related", "node=<nav>"},
+		},
+		{
+			name:     "Over byte budget, keeping priority above 1 only",
+			budget:   Budget{Bytes: 10, Keep: 1, Logger: logger},
+			expected: "<main><p>xxxxxxxxxxxxxxxxxxxx</p></main>",
+			dropped:  []string{"node=Related", "node=<section>", "node=<nav>"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			logs.Reset()
+			var buf bytes.Buffer
+			if err := RenderBudgeted(context.Background(), &buf, page, test.budget); err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, buf.String())
+			}
+			if got := strings.Count(logs.String(), "optional node dropped"); got != len(test.dropped) {
+				t.Errorf("expected %d dropped nodes logged, got %d: %s", len(test.dropped), got, logs.String())
+			}
+			for _, dropped := range test.dropped {
+				if !strings.Contains(logs.String(), dropped) {
+					t.Errorf("expected %s to be logged, got %s", dropped, logs.String())
+				}
+			}
+		})
+	}
+
+	// Optional nodes render normally outside RenderBudgeted.
+	var buf bytes.Buffer
+	if err := Render(&buf, page); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "<nav>more</nav>") {
+		t.Errorf("expected optional nodes to render, got %q", buf.String())
+	}
+}
+
+func TestRenderBudgetedContext(t *testing.T) {
+	type key struct{}
+	page := MAIN()(
+		CtxFunc(func(ctx context.Context) HyperNode { return P()(ctx.Value(key{})) }),
+		Optional(CtxFunc(func(ctx context.Context) HyperNode { return ASIDE()(ctx.Value(key{})) }), 0),
+	)
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), key{}, "en"))
+	var buf bytes.Buffer
+	if err := RenderBudgeted(ctx, &buf, page, Budget{}); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "<main><p>en</p><aside>en</aside></main>"; buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+
+	cancel()
+	if err := RenderBudgeted(ctx, io.Discard, page, Budget{}); err != context.Canceled {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+}
@@ -0,0 +1,56 @@
+package h
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+// badge is a custom node implementing only HyperNode.
+type badge struct{ node Element }
+
+func newBadge(label string) badge { return badge{SPAN(AttrClass("badge"))(label)} }
+
+func (me badge) Render(w io.Writer) error { return me.node.Render(w) }
+
+// fastBadge also implements BufferRenderer.
+type fastBadge struct{ badge }
+
+func (me fastBadge) RenderToBuffer(buf *bytes.Buffer) error { return me.node.RenderToBuffer(buf) }
+
+func TestBufferRenderer(t *testing.T) {
+	var slow, fast bytes.Buffer
+	if err := Render(&slow, DIV()(newBadge("new"), Named("Badge", newBadge("hot")))); err != nil {
+		t.Fatal(err)
+	}
+	if err := Render(&fast, DIV()(fastBadge{newBadge("new")}, Named("Badge", fastBadge{newBadge("hot")}))); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `<div><span class="badge">new</span><span class="badge">hot</span></div>`
+	if slow.String() != expected || fast.String() != expected {
+		t.Errorf("expected %q, got %q and %q", expected, slow.String(), fast.String())
+	}
+}
+
+func benchmarkBadges(b *testing.B, child func(i int) HyperNode) {
+	children := make([]any, 100)
+	for i := range children {
+		children[i] = child(i)
+	}
+	page := UL()(children...)
+
+	var buf bytes.Buffer
+	for b.Loop() {
+		buf.Reset()
+		page.Render(&buf)
+	}
+}
+
+func BenchmarkCustomNode_Render(b *testing.B) {
+	benchmarkBadges(b, func(int) HyperNode { return newBadge("label") })
+}
+
+func BenchmarkCustomNode_RenderToBuffer(b *testing.B) {
+	benchmarkBadges(b, func(int) HyperNode { return fastBadge{newBadge("label")} })
+}
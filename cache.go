@@ -0,0 +1,175 @@
+package h
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RenderCache stores rendered output, such as the fragments cached by
+// [Memo]. Implementations must be safe for concurrent use. The package
+// ships an in-memory [LRUCache]; the rediscache package shares a cache
+// between instances of a multi-instance deployment.
+type RenderCache interface {
+	// Get returns the value cached for key, and whether there is one.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set caches value for key. A zero ttl means the value never expires.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// CacheLocker is implemented by render caches shared between instances,
+// such as the one of the rediscache package, to lease keys across
+// instances: [Memo] then renders a missing fragment on one instance at a
+// time while the others wait for its output (see [MemoOptions.LockTimeout]).
+type CacheLocker interface {
+	// TryLock acquires the lock called key for ttl unless another holder
+	// has it, reporting whether it did, and returns the function releasing
+	// it.
+	TryLock(ctx context.Context, key string, ttl time.Duration) (unlock func(), ok bool, err error)
+}
+
+// DefaultRenderCache is the cache used by [Memo] when none is configured.
+var DefaultRenderCache RenderCache = NewLRUCache(4096)
+
+// LRUCache is an in-memory [RenderCache] holding up to a fixed number of
+// entries, evicting the least recently used one when full.
+type LRUCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List // Front is the most recently used
+}
+
+type lruEntry struct {
+	key     string
+	value   []byte
+	expires time.Time // Zero when the entry never expires
+}
+
+// NewLRUCache creates an [LRUCache] holding up to capacity entries.
+func NewLRUCache(capacity int) *LRUCache {
+	if capacity <= 0 {
+		panic("h: LRU cache capacity must be positive")
+	}
+	return &LRUCache{capacity: capacity, entries: map[string]*list.Element{}, order: list.New()}
+}
+
+func (me *LRUCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+
+	element, ok := me.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := element.Value.(*lruEntry)
+	if !entry.expires.IsZero() && !time.Now().Before(entry.expires) {
+		me.order.Remove(element)
+		delete(me.entries, key)
+		return nil, false, nil
+	}
+	me.order.MoveToFront(element)
+	return entry.value, true, nil
+}
+
+func (me *LRUCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+
+	entry := &lruEntry{key: key, value: value}
+	if ttl > 0 {
+		entry.expires = time.Now().Add(ttl)
+	}
+	if element, ok := me.entries[key]; ok {
+		element.Value = entry
+		me.order.MoveToFront(element)
+		return nil
+	}
+
+	me.entries[key] = me.order.PushFront(entry)
+	if me.order.Len() > me.capacity {
+		oldest := me.order.Back()
+		me.order.Remove(oldest)
+		delete(me.entries, oldest.Value.(*lruEntry).key)
+	}
+	return nil
+}
+
+// Len returns the number of cached entries, including expired ones not yet evicted.
+func (me *LRUCache) Len() int {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	return me.order.Len()
+}
+
+// keys returns the keys of the entries, including expired ones not yet
+// evicted, most recently used first.
+func (me *LRUCache) keys() []string {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	keys := make([]string, 0, me.order.Len())
+	for element := me.order.Front(); element != nil; element = element.Next() {
+		keys = append(keys, element.Value.(*lruEntry).key)
+	}
+	return keys
+}
+
+// Purge removes all entries.
+func (me *LRUCache) Purge() {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	clear(me.entries)
+	me.order.Init()
+}
+
+// flightGroup deduplicates concurrent computations of the same key, so a
+// cache miss on a popular fragment renders it once rather than once per
+// waiting request.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flight
+}
+
+type flight struct {
+	done  chan struct{}
+	value []byte
+	err   error
+}
+
+// do calls fn once for concurrent callers with the same key, and returns
+// its result to all of them. fn runs in its own goroutine, and must not
+// depend on the context of any one caller: a caller whose ctx is done
+// stops waiting with its error, leaving the others waiting.
+func (me *flightGroup) do(ctx context.Context, key string, fn func() ([]byte, error)) ([]byte, error) {
+	me.mu.Lock()
+	if me.calls == nil {
+		me.calls = map[string]*flight{}
+	}
+	call, ok := me.calls[key]
+	if !ok {
+		call = &flight{done: make(chan struct{})}
+		me.calls[key] = call
+		go func() {
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					call.err = fmt.Errorf("h: panic: %v", recovered)
+				}
+				me.mu.Lock()
+				delete(me.calls, key)
+				me.mu.Unlock()
+				close(call.done)
+			}()
+			call.value, call.err = fn()
+		}()
+	}
+	me.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.value, call.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,100 @@
+package h
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLRUCache(t *testing.T) {
+	ctx := context.Background()
+	cache := NewLRUCache(2)
+
+	cache.Set(ctx, "a", []byte("A"), 0)
+	cache.Set(ctx, "b", []byte("B"), 0)
+	cache.Get(ctx, "a") // a is now the most recently used
+	cache.Set(ctx, "c", []byte("C"), 0)
+
+	if _, ok, _ := cache.Get(ctx, "b"); ok {
+		t.Error("expected b to be evicted")
+	}
+	if value, ok, _ := cache.Get(ctx, "a"); !ok || string(value) != "A" {
+		t.Errorf("expected a to be kept, got %q", value)
+	}
+
+	cache.Set(ctx, "d", []byte("D"), time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	if _, ok, _ := cache.Get(ctx, "d"); ok {
+		t.Error("expected d to be expired")
+	}
+
+	cache.Purge()
+	if cache.Len() != 0 {
+		t.Errorf("expected an empty cache, got %d entries", cache.Len())
+	}
+}
+
+func TestMemoSingleflight(t *testing.T) {
+	defer PurgeMemo()
+
+	var builds atomic.Int32
+	release := make(chan struct{})
+	build := func() HyperNode {
+		builds.Add(1)
+		<-release
+		return P()("slow")
+	}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			Render(io.Discard, Memo(context.Background(), "slow", build))
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if builds.Load() != 1 {
+		t.Errorf("expected 1 build, got %d", builds.Load())
+	}
+}
+
+func TestMemoSingleflightCancel(t *testing.T) {
+	defer PurgeMemo()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	build := func() HyperNode {
+		close(started)
+		<-release
+		return P()("slow")
+	}
+
+	// The request starting the render goes away while another waits.
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error)
+	go func() { first <- Render(io.Discard, Memo(ctx, "cancel", build)) }()
+	<-started
+	second := make(chan string)
+	go func() {
+		var buf strings.Builder
+		Render(&buf, Memo(context.Background(), "cancel", build))
+		second <- buf.String()
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	if err := <-first; err != context.Canceled {
+		t.Errorf("expected the cancelled request to fail with %v, got %v", context.Canceled, err)
+	}
+	close(release)
+	if output := <-second; output != "<p>slow</p>" {
+		t.Errorf("expected the waiting request to get the output, got %q", output)
+	}
+}
@@ -0,0 +1,174 @@
+package h
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CalendarParams configures a [Calendar]. All fields are optional.
+type CalendarParams struct {
+	// Locale is the BCP 47 language tag used for the month and weekday
+	// names and the first day of the week; defaults to English (US).
+	Locale string
+	// Today is marked with aria-current="date" when it is in the month.
+	Today time.Time
+	// NavURL returns the URL of the calendar of the given month. When set,
+	// the caption gets previous/next buttons fetching it with htmx and
+	// swapping the calendar in place.
+	NavURL     func(year int, month time.Month) string
+	Attributes []Attribute // Extra attributes for the <table>
+}
+
+// Class names set on the elements rendered by [Calendar], as styling hooks.
+const (
+	ClassCalendar        = "calendar"
+	ClassCalendarOutside = "calendar-outside" // Cells of days of the previous and next months
+)
+
+// calendarLocale holds the names used by a calendar in a language.
+type calendarLocale struct {
+	months       [12]string
+	weekdays     [7]string // From Sunday
+	shortDays    [7]string
+	firstWeekday time.Weekday
+	previous     string
+	next         string
+}
+
+var calendarLocales = map[string]calendarLocale{
+	"en": {
+		months:       [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		weekdays:     [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		shortDays:    [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		firstWeekday: time.Sunday,
+		previous:     "Previous month",
+		next:         "Next month",
+	},
+	"fr": {
+		months:       [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		weekdays:     [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		shortDays:    [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+		firstWeekday: time.Monday,
+		previous:     "Mois précédent",
+		next:         "Mois suivant",
+	},
+	"de": {
+		months:       [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		weekdays:     [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		shortDays:    [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+		firstWeekday: time.Monday,
+		previous:     "Vorheriger Monat",
+		next:         "Nächster Monat",
+	},
+	"es": {
+		months:       [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		weekdays:     [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		shortDays:    [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+		firstWeekday: time.Monday,
+		previous:     "Mes anterior",
+		next:         "Mes siguiente",
+	},
+	"ar": {
+		months:       [12]string{"يناير", "فبراير", "مارس", "أبريل", "مايو", "يونيو", "يوليو", "أغسطس", "سبتمبر", "أكتوبر", "نوفمبر", "ديسمبر"},
+		weekdays:     [7]string{"الأحد", "الاثنين", "الثلاثاء", "الأربعاء", "الخميس", "الجمعة", "السبت"},
+		shortDays:    [7]string{"أحد", "اثنين", "ثلاثاء", "أربعاء", "خميس", "جمعة", "سبت"},
+		firstWeekday: time.Saturday,
+		previous:     "الشهر السابق",
+		next:         "الشهر التالي",
+	},
+}
+
+// mondayFirstRegions are the English-speaking regions starting weeks on
+// Monday.
+var mondayFirstRegions = map[string]bool{"gb": true, "ie": true, "au": true, "nz": true}
+
+func calendarLocaleFor(locale string) calendarLocale {
+	language, region, _ := strings.Cut(strings.ToLower(locale), "-")
+	l, ok := calendarLocales[language]
+	if !ok {
+		l = calendarLocales["en"]
+	}
+	if language == "en" && mondayFirstRegions[region] {
+		l.firstWeekday = time.Monday
+	}
+	return l
+}
+
+// Calendar renders the month grid of year/month as a table, with a
+// caption naming the month, a header row of weekdays (abbreviated, with
+// their full name in abbr) and a row per week. Each day of the month gets
+// a cell holding what day returns for it; the days of the surrounding
+// months fill the first and last weeks with empty cells.
+//
+// Example:
+//
+//	Calendar(2024, time.March, func(date time.Time) HyperNode {
+//		return Group(
+//			strconv.Itoa(date.Day()),
+//			Range(eventsOn(date), EventBadge),
+//		)
+//	}, CalendarParams{
+//		Locale: Locale(ctx),
+//		NavURL: func(year int, month time.Month) string {
+//			return fmt.Sprintf("/calendar/%d/%d", year, month)
+//		},
+//	})
+func Calendar(year int, month time.Month, day func(date time.Time) HyperNode, params ...CalendarParams) Element {
+	var p CalendarParams
+	if len(params) != 0 {
+		p = params[0]
+	}
+	l := calendarLocaleFor(p.Locale)
+
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	title := l.months[first.Month()-1] + " " + strconv.Itoa(first.Year())
+
+	caption := CAPTION()(title)
+	if p.NavURL != nil {
+		previous, next := first.AddDate(0, -1, 0), first.AddDate(0, 1, 0)
+		navButton := func(date time.Time, label, symbol string) HyperNode {
+			return BUTTON(
+				AttrType(TypeButton),
+				AttrAriaLabel(label),
+				Attr("hx-get", p.NavURL(date.Year(), date.Month())),
+				Attr("hx-target", "closest table"),
+				Attr("hx-swap", "outerHTML"),
+			)(symbol)
+		}
+		caption = CAPTION()(navButton(previous, l.previous, "‹"), " ", title, " ", navButton(next, l.next, "›"))
+	}
+
+	var headers []any
+	for i := range 7 {
+		weekday := (int(l.firstWeekday) + i) % 7
+		headers = append(headers, TH(AttrScope("col"), Attr("abbr", l.weekdays[weekday]))(l.shortDays[weekday]))
+	}
+
+	today := p.Today
+	var weeks []any
+	date := first.AddDate(0, 0, -((int(first.Weekday()) - int(l.firstWeekday) + 7) % 7))
+	for date.Month() == month || date.Before(first) {
+		var cells []any
+		for range 7 {
+			if date.Month() != month {
+				cells = append(cells, TD(AttrClass(ClassCalendarOutside))())
+			} else {
+				attrs := []Attribute{Attr("data-date", date.Format(time.DateOnly))}
+				if !today.IsZero() && today.Year() == date.Year() && today.YearDay() == date.YearDay() {
+					attrs = append(attrs, AttrAriaCurrent("date"))
+				}
+				cells = append(cells, TD(attrs...)(day(date)))
+			}
+			date = date.AddDate(0, 0, 1)
+		}
+		weeks = append(weeks, TR()(cells...))
+	}
+
+	attrs := append([]Attribute{AttrClass(ClassCalendar)}, p.Attributes...)
+	return TABLE(attrs...)(
+		caption,
+		THEAD()(TR()(headers...)),
+		TBODY()(weeks...),
+	)
+}
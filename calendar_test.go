@@ -0,0 +1,58 @@
+package h
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCalendar(t *testing.T) {
+	day := func(date time.Time) HyperNode { return Text(strconv.Itoa(date.Day())) }
+
+	var buf bytes.Buffer
+	node := Calendar(2024, time.February, day, CalendarParams{Today: time.Date(2024, 2, 29, 15, 0, 0, 0, time.Local)})
+	if err := Render(&buf, node); err != nil {
+		t.Fatal(err)
+	}
+	html := buf.String()
+	for _, expected := range []string{
+		`<table class="calendar"><caption>February 2024</caption>`,
+		`<thead><tr><th scope="col" abbr="Sunday">Sun</th><th scope="col" abbr="Monday">Mon</th>`,
+		// February 2024 starts on a Thursday.
+		`<tbody><tr><td class="calendar-outside"></td><td class="calendar-outside"></td><td class="calendar-outside"></td><td class="calendar-outside"></td><td data-date="2024-02-01">1</td>`,
+		`<td data-date="2024-02-29" aria-current="date">29</td><td class="calendar-outside"></td><td class="calendar-outside"></td></tr></tbody></table>`,
+	} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("expected %q in %q", expected, html)
+		}
+	}
+	if weeks := strings.Count(html, "<tr>") - 1; weeks != 5 {
+		t.Errorf("expected 5 weeks, got %d", weeks)
+	}
+}
+
+func TestCalendarLocaleAndNavigation(t *testing.T) {
+	var buf bytes.Buffer
+	node := Calendar(2024, time.January, func(date time.Time) HyperNode { return Group() }, CalendarParams{
+		Locale: "fr-FR",
+		NavURL: func(year int, month time.Month) string {
+			return "/calendar/" + strconv.Itoa(year) + "/" + strconv.Itoa(int(month))
+		},
+	})
+	if err := Render(&buf, node); err != nil {
+		t.Fatal(err)
+	}
+	html := buf.String()
+	for _, expected := range []string{
+		`<button type="button" aria-label="Mois précédent" hx-get="/calendar/2023/12" hx-target="closest table" hx-swap="outerHTML">‹</button> janvier 2024 <button type="button" aria-label="Mois suivant" hx-get="/calendar/2024/2"`,
+		`<thead><tr><th scope="col" abbr="lundi">lun.</th>`,
+		// January 2024 starts on a Monday.
+		`<tbody><tr><td data-date="2024-01-01"></td>`,
+	} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("expected %q in %q", expected, html)
+		}
+	}
+}
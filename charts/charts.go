@@ -0,0 +1,314 @@
+// Package charts renders simple static charts as inline SVG nodes.
+//
+// The charts are computed on the server from numeric slices, so dashboards
+// can show sparklines, bar charts and pie charts without a JavaScript
+// charting library. Colors default to currentColor or [DefaultPalette], and
+// every chart is labelled for assistive technology.
+package charts
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+// DefaultPalette is used for bars and slices when no colors are given.
+var DefaultPalette = []string{
+	"#3b82f6", "#22c55e", "#f59e0b", "#ef4444", "#8b5cf6", "#14b8a6", "#ec4899", "#64748b",
+}
+
+// SparklineOptions customizes a [Sparkline]. All fields are optional.
+type SparklineOptions struct {
+	Width       int           // Width in pixels; defaults to 100
+	Height      int           // Height in pixels; defaults to 24
+	Stroke      string        // Line color; defaults to "currentColor"
+	StrokeWidth float64       // Line width; defaults to 1.5
+	Fill        string        // Area fill below the line; no area when empty
+	Title       string        // Accessible label of the chart
+	Attributes  []h.Attribute // Extra attributes appended to the <svg> element
+}
+
+// Sparkline creates a small line chart of values, scaled to fill the chart.
+//
+// Example:
+//
+//	Sparkline(dailySignups, SparklineOptions{Width: 120, Title: "Signups, last 30 days"})
+func Sparkline(values []float64, options ...SparklineOptions) h.HyperNode {
+	var opts SparklineOptions
+	if len(options) != 0 {
+		opts = options[0]
+	}
+	width := float64(orDefault(opts.Width, 100))
+	height := float64(orDefault(opts.Height, 24))
+	strokeWidth := opts.StrokeWidth
+	if strokeWidth <= 0 {
+		strokeWidth = 1.5
+	}
+
+	svg := newSVG(width, height, opts.Title, opts.Attributes)
+	if len(values) == 0 {
+		return svg
+	}
+
+	low, high := bounds(values)
+	// Keep the line inside the viewport, as half of it is drawn outside its path.
+	top, bottom := strokeWidth/2, height-strokeWidth/2
+
+	var points strings.Builder
+	for i, v := range values {
+		x := 0.0
+		if len(values) > 1 {
+			x = width * float64(i) / float64(len(values)-1)
+		}
+		y := bottom - (bottom-top)*scale(v, low, high)
+		if i > 0 {
+			points.WriteByte(' ')
+		}
+		points.WriteString(formatFloat(x))
+		points.WriteByte(',')
+		points.WriteString(formatFloat(y))
+	}
+
+	if opts.Fill != "" {
+		area := "0," + formatFloat(height) + " " + points.String() + " " + formatFloat(width) + "," + formatFloat(height)
+		svg.Children = append(svg.Children, h.Element{Tag: "polygon", Attributes: []h.Attribute{
+			h.Attr("points", area),
+			h.Attr("fill", opts.Fill),
+			h.Attr("stroke", "none"),
+		}})
+	}
+	svg.Children = append(svg.Children, h.Element{Tag: "polyline", Attributes: []h.Attribute{
+		h.Attr("points", points.String()),
+		h.Attr("fill", "none"),
+		h.Attr("stroke", h.IfElse(opts.Stroke != "", opts.Stroke, "currentColor")),
+		h.Attr("stroke-width", formatFloat(strokeWidth)),
+		h.Attr("stroke-linecap", "round"),
+		h.Attr("stroke-linejoin", "round"),
+	}})
+	return svg
+}
+
+// BarOptions customizes a [Bar] chart. All fields are optional.
+type BarOptions struct {
+	Width      int           // Width in pixels; defaults to 300
+	Height     int           // Height in pixels; defaults to 150
+	Gap        float64       // Space between bars as a fraction of the bar slot (0 to 1); defaults to 0.2
+	Colors     []string      // Bar colors, cycled; defaults to a single color from [DefaultPalette]
+	Labels     []string      // Per-bar labels, shown as tooltips
+	Title      string        // Accessible label of the chart
+	Attributes []h.Attribute // Extra attributes appended to the <svg> element
+}
+
+// Bar creates a vertical bar chart of values. Bars are scaled against the
+// largest value; zero and negative values produce empty bars.
+//
+// Example:
+//
+//	Bar(revenueByMonth, BarOptions{Labels: monthNames, Title: "Revenue"})
+func Bar(values []float64, options ...BarOptions) h.HyperNode {
+	var opts BarOptions
+	if len(options) != 0 {
+		opts = options[0]
+	}
+	width := float64(orDefault(opts.Width, 300))
+	height := float64(orDefault(opts.Height, 150))
+	gap := opts.Gap
+	if gap <= 0 || gap >= 1 {
+		gap = 0.2
+	}
+	colors := opts.Colors
+	if len(colors) == 0 {
+		colors = DefaultPalette[:1]
+	}
+
+	svg := newSVG(width, height, opts.Title, opts.Attributes)
+	if len(values) == 0 {
+		return svg
+	}
+
+	_, high := bounds(values)
+	slot := width / float64(len(values))
+	barWidth := slot * (1 - gap)
+
+	for i, v := range values {
+		barHeight := 0.0
+		if high > 0 && v > 0 {
+			barHeight = height * v / high
+		}
+		rect := h.Element{Tag: "rect", Attributes: []h.Attribute{
+			h.Attr("x", formatFloat(slot*float64(i)+(slot-barWidth)/2)),
+			h.Attr("y", formatFloat(height-barHeight)),
+			h.AttrWidth(formatFloat(barWidth)),
+			h.AttrHeight(formatFloat(barHeight)),
+			h.Attr("fill", colors[i%len(colors)]),
+		}}
+		rect.Children = append(rect.Children, tooltip(opts.Labels, i, v))
+		svg.Children = append(svg.Children, rect)
+	}
+	return svg
+}
+
+// PieOptions customizes a [Pie] chart. All fields are optional.
+type PieOptions struct {
+	Size       int           // Width and height in pixels; defaults to 150
+	Donut      float64       // Inner radius as a fraction of the radius (0 to 1); a full pie when zero
+	Colors     []string      // Slice colors, cycled; defaults to [DefaultPalette]
+	Labels     []string      // Per-slice labels, shown as tooltips
+	Title      string        // Accessible label of the chart
+	Attributes []h.Attribute // Extra attributes appended to the <svg> element
+}
+
+// Pie creates a pie (or donut) chart where each value is a slice proportional
+// to its share of the total. Negative values are ignored.
+//
+// Example:
+//
+//	Pie([]float64{62, 30, 8}, PieOptions{Labels: []string{"Chrome", "Firefox", "Other"}, Donut: 0.6})
+func Pie(values []float64, options ...PieOptions) h.HyperNode {
+	var opts PieOptions
+	if len(options) != 0 {
+		opts = options[0]
+	}
+	size := float64(orDefault(opts.Size, 150))
+	colors := opts.Colors
+	if len(colors) == 0 {
+		colors = DefaultPalette
+	}
+	donut := math.Min(math.Max(opts.Donut, 0), 0.99)
+
+	svg := newSVG(size, size, opts.Title, opts.Attributes)
+
+	total := 0.0
+	for _, v := range values {
+		if v > 0 {
+			total += v
+		}
+	}
+	if total == 0 {
+		return svg
+	}
+
+	center := size / 2
+	outer, inner := center, center*donut
+	angle := -math.Pi / 2 // start at 12 o'clock
+
+	for i, v := range values {
+		if v <= 0 {
+			continue
+		}
+		sweep := 2 * math.Pi * v / total
+
+		var slice h.Element
+		switch {
+		case v == total && inner == 0:
+			// A single arc cannot describe a full circle.
+			slice = h.Element{Tag: "circle", Attributes: []h.Attribute{
+				h.Attr("cx", formatFloat(center)),
+				h.Attr("cy", formatFloat(center)),
+				h.Attr("r", formatFloat(outer)),
+				h.Attr("fill", colors[i%len(colors)]),
+			}}
+		case v == total:
+			slice = h.Element{Tag: "circle", Attributes: []h.Attribute{
+				h.Attr("cx", formatFloat(center)),
+				h.Attr("cy", formatFloat(center)),
+				h.Attr("r", formatFloat((outer+inner)/2)),
+				h.Attr("fill", "none"),
+				h.Attr("stroke", colors[i%len(colors)]),
+				h.Attr("stroke-width", formatFloat(outer-inner)),
+			}}
+		default:
+			slice = h.Element{Tag: "path", Attributes: []h.Attribute{
+				h.Attr("d", arcPath(center, outer, inner, angle, angle+sweep)),
+				h.Attr("fill", colors[i%len(colors)]),
+			}}
+		}
+		slice.Children = append(slice.Children, tooltip(opts.Labels, i, v))
+		svg.Children = append(svg.Children, slice)
+		angle += sweep
+	}
+	return svg
+}
+
+// arcPath describes a pie slice (or a donut segment when inner > 0) between two angles.
+func arcPath(center, outer, inner, from, to float64) string {
+	largeArc := "0"
+	if to-from > math.Pi {
+		largeArc = "1"
+	}
+	point := func(radius, angle float64) string {
+		return formatFloat(center+radius*math.Cos(angle)) + "," + formatFloat(center+radius*math.Sin(angle))
+	}
+	radius := func(r float64) string {
+		return formatFloat(r) + "," + formatFloat(r)
+	}
+
+	var d strings.Builder
+	d.WriteString("M" + point(outer, from))
+	d.WriteString("A" + radius(outer) + " 0 " + largeArc + " 1 " + point(outer, to))
+	if inner > 0 {
+		d.WriteString("L" + point(inner, to))
+		d.WriteString("A" + radius(inner) + " 0 " + largeArc + " 0 " + point(inner, from))
+	} else {
+		d.WriteString("L" + point(0, 0))
+	}
+	d.WriteByte('Z')
+	return d.String()
+}
+
+func newSVG(width, height float64, title string, attrs []h.Attribute) h.Element {
+	svg := h.Element{Tag: "svg"}
+	svg.Attributes = append(svg.Attributes,
+		h.Attr("xmlns", "http://www.w3.org/2000/svg"),
+		h.AttrWidth(formatFloat(width)),
+		h.AttrHeight(formatFloat(height)),
+		h.Attr("viewBox", "0 0 "+formatFloat(width)+" "+formatFloat(height)),
+		h.AttrRole("img"),
+	)
+	if title != "" {
+		svg.Attributes = append(svg.Attributes, h.AttrAriaLabel(title))
+		svg.Children = append(svg.Children, h.WithChildren(h.Element{Tag: "title"})(title))
+	}
+	svg.Attributes = append(svg.Attributes, attrs...)
+	return svg
+}
+
+// tooltip returns the <title> shown when hovering a bar or slice.
+func tooltip(labels []string, i int, value float64) h.HyperNode {
+	text := formatFloat(value)
+	if i < len(labels) {
+		text = labels[i] + ": " + text
+	}
+	return h.WithChildren(h.Element{Tag: "title"})(text)
+}
+
+func bounds(values []float64) (low, high float64) {
+	low, high = values[0], values[0]
+	for _, v := range values[1:] {
+		low, high = math.Min(low, v), math.Max(high, v)
+	}
+	return low, high
+}
+
+// scale maps v from [low, high] to [0, 1]; flat series are drawn in the middle.
+func scale(v, low, high float64) float64 {
+	if high == low {
+		return 0.5
+	}
+	return (v - low) / (high - low)
+}
+
+// formatFloat formats coordinates with at most two decimals, which is more
+// precision than any screen can show and keeps the markup small.
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(math.Round(f*100)/100, 'f', -1, 64)
+}
+
+func orDefault(value, def int) int {
+	if value <= 0 {
+		return def
+	}
+	return value
+}
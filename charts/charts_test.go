@@ -0,0 +1,76 @@
+package charts
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+func render(t *testing.T, node h.HyperNode) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := h.Render(&buf, node); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	return buf.String()
+}
+
+func TestSparkline(t *testing.T) {
+	got := render(t, Sparkline([]float64{0, 10, 5}, SparklineOptions{Width: 100, Height: 20, StrokeWidth: 2, Title: "Trend"}))
+
+	for _, want := range []string{
+		`viewBox="0 0 100 20" role="img" aria-label="Trend"><title>Trend</title>`,
+		`<polyline points="0,19 50,1 100,10"`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Sparkline() = %q, want it to contain %q", got, want)
+		}
+	}
+}
+
+func TestBar(t *testing.T) {
+	got := render(t, Bar([]float64{5, 10}, BarOptions{Width: 100, Height: 50, Gap: 0.5, Labels: []string{"Jan"}}))
+
+	for _, want := range []string{
+		`<rect x="12.5" y="25" width="25" height="25" fill="#3b82f6"><title>Jan: 5</title></rect>`,
+		`<rect x="62.5" y="0" width="25" height="50" fill="#3b82f6"><title>10</title></rect>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Bar() = %q, want it to contain %q", got, want)
+		}
+	}
+}
+
+func TestPie(t *testing.T) {
+	tests := []struct {
+		name     string
+		node     h.HyperNode
+		contains string
+	}{
+		{
+			name:     "Half slices",
+			node:     Pie([]float64{1, 1}, PieOptions{Size: 100}),
+			contains: `<path d="M50,0A50,50 0 0 1 50,100L50,50Z" fill="#3b82f6">`,
+		},
+		{
+			name:     "Single value is a full circle",
+			node:     Pie([]float64{3}, PieOptions{Size: 100}),
+			contains: `<circle cx="50" cy="50" r="50" fill="#3b82f6">`,
+		},
+		{
+			name:     "Single value donut is a ring",
+			node:     Pie([]float64{3}, PieOptions{Size: 100, Donut: 0.5}),
+			contains: `<circle cx="50" cy="50" r="37.5" fill="none" stroke="#3b82f6" stroke-width="25">`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := render(t, tt.node); !strings.Contains(got, tt.contains) {
+				t.Errorf("Pie() = %q, want it to contain %q", got, tt.contains)
+			}
+		})
+	}
+}
@@ -0,0 +1,287 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"go/format"
+	"html"
+	"io"
+	"io/fs"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type options struct {
+	Package string
+	Names   []string // Icons to generate, without extension; all when nil
+}
+
+// svgNode is an element of an icon.
+type svgNode struct {
+	name     string
+	attrs    []xml.Attr
+	children []svgNode
+	text     string // Text content, for <text> elements
+}
+
+// icon is an SVG file, split into the attributes of its <svg> element and
+// its content.
+type icon struct {
+	name     string // File name without extension
+	attrs    map[string]string
+	children []svgNode
+}
+
+// setAttrs maps the attributes of the <svg> elements kept to the fields of
+// icons.Set, in the order of the struct.
+var setAttrs = []struct{ attr, field string }{
+	{"viewBox", "ViewBox"},
+	{"fill", "Fill"},
+	{"stroke", "Stroke"},
+	{"stroke-width", "StrokeWidth"},
+	{"stroke-linecap", "StrokeLinecap"},
+	{"stroke-linejoin", "StrokeLinejoin"},
+}
+
+// hoistedAttrs are the attributes moved from the shapes of the icons to
+// their set when all of them have the same value.
+var hoistedAttrs = []string{"stroke-linecap", "stroke-linejoin"}
+
+// generate returns the Go file rendering the SVG icons of fsys selected by
+// o.
+func generate(fsys fs.FS, o options) ([]byte, error) {
+	files, err := fs.Glob(fsys, "*.svg")
+	if err != nil {
+		return nil, err
+	}
+	available := map[string]string{}
+	for _, file := range files {
+		available[strings.TrimSuffix(file, ".svg")] = file
+	}
+
+	names := o.Names
+	if names == nil {
+		names = slices.Sorted(func(yield func(string) bool) {
+			for name := range available {
+				if !yield(name) {
+					return
+				}
+			}
+		})
+	}
+	if len(names) == 0 {
+		return nil, errors.New("no icons to generate")
+	}
+
+	var icons []icon
+	functions := map[string]string{}
+	for _, name := range names {
+		file, ok := available[name]
+		if !ok {
+			return nil, fmt.Errorf("no icon %q", name)
+		}
+		function := functionName(name)
+		if other, ok := functions[function]; ok {
+			return nil, fmt.Errorf("icons %q and %q are both named %s", other, name, function)
+		}
+		functions[function] = name
+
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		icon, err := parseIcon(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		icon.name = name
+		icons = append(icons, icon)
+	}
+	slices.SortFunc(icons, func(a, b icon) int { return strings.Compare(a.name, b.name) })
+
+	set, err := iconSet(icons)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by h-icons; DO NOT EDIT.\n\npackage %s\n\n", o.Package)
+	buf.WriteString("import (\n\th \"github.com/assaidy/hyper/v2\"\n\t\"github.com/assaidy/hyper/v2/icons\"\n)\n\n")
+	buf.WriteString("// set holds the presentation attributes shared by the icons of this file.\nvar set = icons.Set{\n")
+	for _, a := range setAttrs {
+		if value := set[a.attr]; value != "" {
+			fmt.Fprintf(&buf, "\t%s: %s,\n", a.field, strconv.Quote(value))
+		}
+	}
+	buf.WriteString("}\n")
+	for _, icon := range icons {
+		var body strings.Builder
+		for _, child := range icon.children {
+			writeSVG(&body, child)
+		}
+		fmt.Fprintf(&buf, "\n// %s renders the %s icon.\n", functionName(icon.name), icon.name)
+		fmt.Fprintf(&buf, "func %s(opts ...icons.Options) h.HyperNode {\n", functionName(icon.name))
+		fmt.Fprintf(&buf, "\treturn icons.New(set, %s, opts...)\n}\n", goString(body.String()))
+	}
+
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting the generated code: %w", err)
+	}
+	return code, nil
+}
+
+// parseIcon parses an SVG file. Comments and whitespace between elements
+// are dropped.
+func parseIcon(data []byte) (icon, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	var stack []svgNode
+	var root *svgNode
+	for {
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return icon{}, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			stack = append(stack, svgNode{name: qualifiedName(t.Name), attrs: t.Attr})
+		case xml.EndElement:
+			if len(stack) == 0 {
+				return icon{}, fmt.Errorf("unexpected </%s>", qualifiedName(t.Name))
+			}
+			node := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				root = &node
+				continue
+			}
+			parent := &stack[len(stack)-1]
+			parent.children = append(parent.children, node)
+		case xml.CharData:
+			if text := strings.TrimSpace(string(t)); text != "" && len(stack) > 0 {
+				stack[len(stack)-1].text += text
+			}
+		}
+	}
+	if root == nil || root.name != "svg" {
+		return icon{}, errors.New("no <svg> element")
+	}
+
+	attrs := map[string]string{}
+	for _, a := range root.attrs {
+		attrs[qualifiedName(a.Name)] = a.Value
+	}
+	return icon{attrs: attrs, children: root.children}, nil
+}
+
+// iconSet returns the presentation attributes shared by icons, moving
+// [hoistedAttrs] from their shapes when they all agree.
+func iconSet(icons []icon) (map[string]string, error) {
+	set := map[string]string{}
+	for _, a := range setAttrs {
+		set[a.attr] = icons[0].attrs[a.attr]
+		for _, icon := range icons[1:] {
+			if value := icon.attrs[a.attr]; value != set[a.attr] {
+				return nil, fmt.Errorf("icon %q has %s=%q, but %q has %q: generate icons of different sets apart", icon.name, a.attr, value, icons[0].name, set[a.attr])
+			}
+		}
+	}
+	if set["viewBox"] == "" {
+		return nil, fmt.Errorf("icon %q has no viewBox", icons[0].name)
+	}
+
+	for _, attr := range hoistedAttrs {
+		if set[attr] != "" {
+			continue
+		}
+		value, ok := sharedChildAttr(icons, attr)
+		if !ok {
+			continue
+		}
+		set[attr] = value
+		for i := range icons {
+			for j := range icons[i].children {
+				child := &icons[i].children[j]
+				child.attrs = slices.DeleteFunc(slices.Clone(child.attrs), func(a xml.Attr) bool { return qualifiedName(a.Name) == attr })
+			}
+		}
+	}
+	return set, nil
+}
+
+// sharedChildAttr returns the value of attr on all the top-level elements
+// of icons, if they all have the same.
+func sharedChildAttr(icons []icon, attr string) (string, bool) {
+	shared := ""
+	for _, icon := range icons {
+		for _, child := range icon.children {
+			i := slices.IndexFunc(child.attrs, func(a xml.Attr) bool { return qualifiedName(a.Name) == attr })
+			if i < 0 || shared != "" && child.attrs[i].Value != shared {
+				return "", false
+			}
+			shared = child.attrs[i].Value
+		}
+	}
+	return shared, shared != ""
+}
+
+func writeSVG(out *strings.Builder, node svgNode) {
+	out.WriteByte('<')
+	out.WriteString(node.name)
+	for _, a := range node.attrs {
+		out.WriteByte(' ')
+		out.WriteString(qualifiedName(a.Name))
+		out.WriteString(`="`)
+		out.WriteString(html.EscapeString(a.Value))
+		out.WriteByte('"')
+	}
+	if len(node.children) == 0 && node.text == "" {
+		out.WriteString("/>")
+		return
+	}
+	out.WriteByte('>')
+	out.WriteString(html.EscapeString(node.text))
+	for _, child := range node.children {
+		writeSVG(out, child)
+	}
+	out.WriteString("</" + node.name + ">")
+}
+
+// functionName returns the Go name of the icon called name: arrow-left
+// becomes ArrowLeft.
+func functionName(name string) string {
+	var b strings.Builder
+	for word := range strings.FieldsFuncSeq(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	function := b.String()
+	if function == "" || !unicode.IsLetter(rune(function[0])) {
+		function = "Icon" + function
+	}
+	return function
+}
+
+// goString returns s as a Go string literal, raw when possible.
+func goString(s string) string {
+	if strings.Contains(s, "`") {
+		return strconv.Quote(s)
+	}
+	return "`" + s + "`"
+}
+
+func qualifiedName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+const heroiconsSVG = `<svg xmlns="http://www.w3.org/2000/svg" fill="none" viewBox="0 0 24 24" stroke-width="1.5" stroke="currentColor" aria-hidden="true" data-slot="icon">
+  <path stroke-linecap="round" stroke-linejoin="round" d="%s"/>
+</svg>
+`
+
+func heroicon(d string) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte(strings.Replace(heroiconsSVG, "%s", d, 1))}
+}
+
+func TestGenerate(t *testing.T) {
+	fsys := fstest.MapFS{
+		"x-mark.svg":     heroicon("M6 18 18 6M6 6l12 12"),
+		"arrow-left.svg": heroicon("M10.5 19.5 3 12m0 0 7.5-7.5M3 12h18"),
+		"3d-box.svg":     heroicon("M1 1h2"),
+	}
+
+	code, err := generate(fsys, options{Package: "heroicons", Names: []string{"x-mark", "arrow-left"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "heroicons.go", code, 0); err != nil {
+		t.Fatalf("generated code doesn't parse: %v\n%s", err, code)
+	}
+	expected := []string{
+		"// Code generated by h-icons; DO NOT EDIT.\n\npackage heroicons\n",
+		"\tViewBox:        \"0 0 24 24\",\n\tFill:           \"none\",\n\tStroke:         \"currentColor\",\n\tStrokeWidth:    \"1.5\",\n\tStrokeLinecap:  \"round\",\n\tStrokeLinejoin: \"round\",\n}",
+		"// ArrowLeft renders the arrow-left icon.\nfunc ArrowLeft(opts ...icons.Options) h.HyperNode {\n\treturn icons.New(set, `<path d=\"M10.5 19.5 3 12m0 0 7.5-7.5M3 12h18\"/>`, opts...)\n}",
+		"func XMark(",
+	}
+	for _, e := range expected {
+		if !strings.Contains(string(code), e) {
+			t.Errorf("expected %q in:\n%s", e, code)
+		}
+	}
+	if strings.Index(string(code), "func ArrowLeft") > strings.Index(string(code), "func XMark") {
+		t.Errorf("expected icons sorted by name:\n%s", code)
+	}
+	if strings.Contains(string(code), "3dBox") {
+		t.Errorf("expected unlisted icons to be skipped:\n%s", code)
+	}
+
+	code, err = generate(fsys, options{Package: "heroicons"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(code), "func Icon3dBox(") {
+		t.Errorf("expected all icons without names, with Go names:\n%s", code)
+	}
+}
+
+func TestGenerateKeepsDifferingAttributes(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.svg": {Data: []byte(`<svg viewBox="0 0 24 24" stroke="currentColor"><path stroke-linecap="round" d="M1 1"/><circle cx="12" cy="12" r="3"/></svg>`)},
+		"b.svg": {Data: []byte(`<svg viewBox="0 0 24 24" stroke="currentColor"><text x="1">A &amp; B</text></svg>`)},
+	}
+	code, err := generate(fsys, options{Package: "set"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"`<path stroke-linecap=\"round\" d=\"M1 1\"/><circle cx=\"12\" cy=\"12\" r=\"3\"/>`",
+		"`<text x=\"1\">A &amp; B</text>`",
+	}
+	for _, e := range expected {
+		if !strings.Contains(string(code), e) {
+			t.Errorf("expected %q in:\n%s", e, code)
+		}
+	}
+	if strings.Contains(string(code), "StrokeLinecap") {
+		t.Errorf("expected no linecap hoisted when not all shapes have it:\n%s", code)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		fsys     fstest.MapFS
+		names    []string
+		expected string
+	}{
+		{
+			name:     "Unknown icon",
+			fsys:     fstest.MapFS{"a.svg": heroicon("M1 1")},
+			names:    []string{"b"},
+			expected: `no icon "b"`,
+		},
+		{
+			name: "Different sets",
+			fsys: fstest.MapFS{
+				"a.svg": {Data: []byte(`<svg viewBox="0 0 24 24"><path d="M1 1"/></svg>`)},
+				"b.svg": {Data: []byte(`<svg viewBox="0 0 20 20"><path d="M1 1"/></svg>`)},
+			},
+			expected: "different sets",
+		},
+		{
+			name: "Same Go name",
+			fsys: fstest.MapFS{
+				"arrow-left.svg": heroicon("M1 1"),
+				"arrow_left.svg": heroicon("M1 1"),
+			},
+			expected: "both named ArrowLeft",
+		},
+		{
+			name:     "Not an SVG",
+			fsys:     fstest.MapFS{"a.svg": {Data: []byte(`<html></html>`)}},
+			expected: "no <svg> element",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := generate(test.fsys, options{Package: "set", Names: test.names})
+			if err == nil || !strings.Contains(err.Error(), test.expected) {
+				t.Errorf("expected error containing %q, got %v", test.expected, err)
+			}
+		})
+	}
+}
+
+func TestParseNames(t *testing.T) {
+	names := parseNames("# Icons\nx-mark\n\n  arrow-left.svg \n")
+	if expected := []string{"x-mark", "arrow-left"}; !slices.Equal(names, expected) {
+		t.Errorf("expected %q, got %q", expected, names)
+	}
+}
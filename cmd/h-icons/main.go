@@ -0,0 +1,82 @@
+// Command h-icons generates a Go file rendering SVG icons with package
+// icons, from a directory of SVG files such as the icons of an upstream
+// set (Heroicons, Lucide...).
+//
+// Usage:
+//
+//	h-icons -pkg heroicons [-names icons.txt] [-o heroicons.go] svg-dir
+//
+// Each icon becomes a function named after its file in CamelCase
+// (arrow-left.svg becomes ArrowLeft) taking icons.Options and returning an
+// h.HyperNode. The presentation attributes of the <svg> elements, which
+// must be the same for all icons, become the icons.Set of the file; stroke
+// caps and joins set on the shapes of every icon alike are moved to it.
+//
+// Only the icons listed in the names file are generated, one name per
+// line without the .svg extension, so a package holds the icons an app
+// uses rather than a set of thousands; all of them are when -names isn't
+// given. Lines starting with # are comments. Apps select their own icons
+// with a go:generate directive:
+//
+//	//go:generate go run github.com/assaidy/hyper/v2/cmd/h-icons -pkg appicons -names icons.txt -o icons.go ../node_modules/lucide-static/icons
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func main() {
+	pkg := flag.String("pkg", "icons", "package of the generated file")
+	names := flag.String("names", "", "file listing the icons to generate, instead of all")
+	output := flag.String("o", "", "file to write, instead of stdout")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: h-icons [flags] svg-dir")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var selected []string
+	if *names != "" {
+		text, err := os.ReadFile(*names)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "h-icons:", err)
+			os.Exit(1)
+		}
+		selected = parseNames(string(text))
+	}
+
+	code, err := generate(os.DirFS(flag.Arg(0)), options{Package: *pkg, Names: selected})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "h-icons:", err)
+		os.Exit(1)
+	}
+
+	if *output == "" {
+		os.Stdout.Write(code)
+		return
+	}
+	if err := os.WriteFile(*output, code, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "h-icons:", err)
+		os.Exit(1)
+	}
+}
+
+// parseNames returns the icon names listed in text, one per line, skipping
+// blank lines and # comments.
+func parseNames(text string) []string {
+	var names []string
+	for line := range strings.Lines(text) {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			names = append(names, strings.TrimSuffix(line, ".svg"))
+		}
+	}
+	return names
+}
@@ -0,0 +1,478 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template/parse"
+)
+
+// builder converts a list of template nodes to the Go expressions of the
+// HTML nodes it renders.
+type builder struct {
+	c    *converter
+	file *parsedFile
+	fn   *function
+	dot  string            // Expression of the template dot
+	root string            // Expression of $
+	vars map[string]string // Go names of the template variables
+	pre  bool              // Inside a <pre> or a <textarea>, keeping whitespace
+
+	children []child
+	stack    []*openElement
+	mode     int
+	tag      []tagPiece // Pieces of the tag being read, in modeTag
+	quote    byte       // Quote of the attribute value being read, in modeTag
+	raw      []string   // Content of the element being read, in modeRaw
+	pos      parse.Pos  // Position of the current node, for warnings
+}
+
+func newBuilder(c *converter, file *parsedFile, fn *function, dot string) *builder {
+	return &builder{c: c, file: file, fn: fn, dot: dot, root: dot, vars: map[string]string{}}
+}
+
+// sub returns a builder for the content of a control structure, with dot
+// and the template variables vars.
+func (me *builder) sub(dot string, vars map[string]string) *builder {
+	return &builder{c: me.c, file: me.file, fn: me.fn, dot: dot, root: me.root, vars: vars, pre: me.inPre()}
+}
+
+// branch converts list with dot and vars.
+func (me *builder) branch(list *parse.ListNode, dot string, vars map[string]string) []child {
+	if list == nil {
+		return nil
+	}
+	b := me.sub(dot, vars)
+	b.nodes(list.Nodes)
+	return b.finish()
+}
+
+func (me *builder) warn(format string, args ...any) {
+	me.c.warn(me.file, me.pos, format, args...)
+}
+
+func (me *builder) add(c child) {
+	if len(me.stack) != 0 {
+		top := me.stack[len(me.stack)-1]
+		top.children = append(top.children, c)
+		return
+	}
+	me.children = append(me.children, c)
+}
+
+func (me *builder) nodes(nodes []parse.Node) {
+	for _, node := range nodes {
+		me.pos = node.Position()
+		if text, ok := node.(*parse.TextNode); ok {
+			me.text(string(text.Text))
+			continue
+		}
+		switch me.mode {
+		case modeTag:
+			me.tag = append(me.tag, tagPiece{node: node})
+			continue
+		case modeRaw:
+			me.rawAction(node)
+			continue
+		case modeComment:
+			continue
+		}
+
+		switch n := node.(type) {
+		case *parse.ActionNode:
+			if len(n.Pipe.Decl) != 0 {
+				me.warn("variable declarations aren't converted: %s", n)
+				me.add(child{code: "h.Group() /* TODO(h-migrate): " + comment(n) + " */", node: true})
+				continue
+			}
+			me.add(child{code: me.pipe(n.Pipe)})
+		case *parse.IfNode:
+			me.add(me.ifNode(n))
+		case *parse.RangeNode:
+			me.add(me.rangeNode(n))
+		case *parse.WithNode:
+			me.add(me.withNode(n))
+		case *parse.TemplateNode:
+			me.add(me.template(n))
+		case *parse.CommentNode:
+		default:
+			me.warn("%s isn't converted", n)
+			me.add(child{code: "h.Group() /* TODO(h-migrate): " + comment(n) + " */", node: true})
+		}
+	}
+}
+
+// ifNode converts {{if}} to h.If or h.IfElse, or to a function literal
+// when the content dereferences what the condition tests, since the
+// arguments of h.If are evaluated whatever the condition.
+func (me *builder) ifNode(n *parse.IfNode) child {
+	cond := me.condition(n.Pipe)
+	then := me.branch(n.List, me.dot, me.vars)
+	otherwise := me.branch(n.ElseList, me.dot, me.vars)
+
+	content := group(append(slices.Clone(then), otherwise...)).code
+	if !strings.Contains(content, cond+".") && !strings.Contains(content, cond+"[") {
+		if n.ElseList == nil {
+			return child{code: fmt.Sprintf("h.If(%s, %s)", cond, group(then).code), node: true}
+		}
+		return child{code: fmt.Sprintf("h.IfElse(%s, h.Group(%s), h.Group(%s))", cond, list(then), list(otherwise)), node: true}
+	}
+	return child{code: fmt.Sprintf("func() h.HyperNode {\nif %s {\nreturn %s\n}\nreturn %s\n}()", cond, group(then).code, group(otherwise).code), node: true}
+}
+
+// withNode converts {{with}} to a function literal binding the value.
+func (me *builder) withNode(n *parse.WithNode) child {
+	value := me.pipe(n.Pipe)
+	name := me.variable(n.Pipe, "value")
+	vars := me.scope(n.Pipe, name)
+	then := me.branch(n.List, name, vars)
+	otherwise := me.branch(n.ElseList, me.dot, me.vars)
+	if !uses(group(then).code, name) {
+		return child{code: fmt.Sprintf("func() h.HyperNode {\nif %s != nil {\nreturn %s\n}\nreturn %s\n}()",
+			value, group(then).code, group(otherwise).code), node: true}
+	}
+	return child{code: fmt.Sprintf("func() h.HyperNode {\nif %s := %s; %s != nil {\nreturn %s\n}\nreturn %s\n}()",
+		name, value, name, group(then).code, group(otherwise).code), node: true}
+}
+
+// rangeNode converts {{range}} to h.Range, or to a function literal with
+// a loop when it uses the index or has an {{else}}.
+func (me *builder) rangeNode(n *parse.RangeNode) child {
+	value := me.pipe(n.Pipe)
+	var index, element string
+	vars := map[string]string{}
+	for k, v := range me.vars {
+		vars[k] = v
+	}
+	switch len(n.Pipe.Decl) {
+	case 0:
+		element = me.variable(n.Pipe, "item")
+	case 1:
+		element = me.declare(vars, n.Pipe.Decl[0])
+	default:
+		index = me.declare(vars, n.Pipe.Decl[0])
+		element = me.declare(vars, n.Pipe.Decl[1])
+	}
+	body := me.branch(n.List, element, vars)
+	otherwise := me.branch(n.ElseList, me.dot, me.vars)
+
+	if index == "" && n.ElseList == nil {
+		elementType := "any /* TODO(h-migrate): element type */"
+		if name := lastIdent(n.Pipe); singular(name) != "" {
+			elementType = singular(name)
+		}
+		return child{code: fmt.Sprintf("h.Range(%s, func(%s %s) h.HyperNode {\nreturn %s\n})", value, element, elementType, group(body).code), node: true}
+	}
+
+	content := group(body).code
+	if index == "" || !uses(content, index) {
+		index = "_"
+	}
+	if !uses(content, element) {
+		element = "_"
+	}
+	loop := fmt.Sprintf("for %s, %s := range %s {\nnodes = append(nodes, %s)\n}\n", index, element, value, list(body))
+	if element == "_" {
+		loop = strings.Replace(loop, ", _ :=", " :=", 1)
+	}
+	empty := ""
+	if n.ElseList != nil {
+		empty = fmt.Sprintf("if len(%s) == 0 {\nreturn %s\n}\n", value, group(otherwise).code)
+	}
+	return child{code: fmt.Sprintf("func() h.HyperNode {\n%svar nodes []any\n%sreturn h.Group(nodes...)\n}()", empty, loop), node: true}
+}
+
+// template converts {{template}} to a call of the function of the
+// template, and {{block}} to h.Block.
+func (me *builder) template(n *parse.TemplateNode) child {
+	data := "nil"
+	if n.Pipe != nil {
+		data = me.pipe(n.Pipe)
+	}
+	if isBlock(me.file, n) {
+		b := &builder{c: me.c, file: me.file, fn: me.fn, dot: data, root: data, vars: map[string]string{}, pre: me.inPre()}
+		if tree, ok := me.file.trees[n.Name]; ok {
+			b.nodes(tree.Root.Nodes)
+		}
+		return child{code: fmt.Sprintf("h.Block(%q%s)", n.Name, prefixList(b.finish())), node: true}
+	}
+	if _, defined := me.file.trees[n.Name]; me.c.overrides[n.Name] && !defined {
+		return child{code: fmt.Sprintf("h.Block(%q)", n.Name), node: true}
+	}
+	return child{code: functionName(n.Name) + "(" + data + ")", node: true}
+}
+
+// condition returns the expression of the pipeline of an {{if}}.
+func (me *builder) condition(pipe *parse.PipeNode) string {
+	if len(pipe.Decl) != 0 {
+		me.warn("variable declarations in conditions aren't converted: %s", pipe)
+	}
+	return unparen(me.pipe(pipe))
+}
+
+// variable returns a Go name for the value of pipe, e.g. "user" for
+// .Users, or fallback.
+func (me *builder) variable(pipe *parse.PipeNode, fallback string) string {
+	name := lastIdent(pipe)
+	if s := singular(name); s != "" {
+		name = s
+	}
+	if name == "" {
+		name = fallback
+	}
+	return me.unique(unexported(name))
+}
+
+// declare names the template variable v in vars.
+func (me *builder) declare(vars map[string]string, v *parse.VariableNode) string {
+	name := me.unique(strings.TrimPrefix(v.Ident[0], "$"))
+	vars[v.Ident[0]] = name
+	return name
+}
+
+// scope returns the variables of the content of a {{with}}.
+func (me *builder) scope(pipe *parse.PipeNode, name string) map[string]string {
+	vars := map[string]string{}
+	for k, v := range me.vars {
+		vars[k] = v
+	}
+	for _, decl := range pipe.Decl {
+		vars[decl.Ident[0]] = name
+	}
+	return vars
+}
+
+// unique returns name, renamed when it is taken.
+func (me *builder) unique(name string) string {
+	taken := func(name string) bool {
+		if isKeyword(name) || name == me.dot || name == me.root {
+			return true
+		}
+		for _, v := range me.vars {
+			if v == name {
+				return true
+			}
+		}
+		return false
+	}
+	if !taken(name) {
+		return name
+	}
+	for i := 2; ; i++ {
+		if candidate := name + strconv.Itoa(i); !taken(candidate) {
+			return candidate
+		}
+	}
+}
+
+// lastIdent returns the last field of the value of pipe, e.g. "Users" for
+// .Org.Users.
+func lastIdent(pipe *parse.PipeNode) string {
+	if len(pipe.Cmds) == 0 || len(pipe.Cmds[len(pipe.Cmds)-1].Args) != 1 {
+		return ""
+	}
+	var idents []string
+	switch n := pipe.Cmds[len(pipe.Cmds)-1].Args[0].(type) {
+	case *parse.FieldNode:
+		idents = n.Ident
+	case *parse.VariableNode:
+		idents = n.Ident[1:]
+	case *parse.ChainNode:
+		idents = n.Field
+	}
+	if len(idents) == 0 {
+		return ""
+	}
+	return idents[len(idents)-1]
+}
+
+// pipe returns the expression of a pipeline.
+func (me *builder) pipe(pipe *parse.PipeNode) string {
+	result := ""
+	for i, cmd := range pipe.Cmds {
+		var piped []string
+		if i > 0 {
+			piped = []string{result}
+		}
+		result = me.command(cmd, piped)
+	}
+	return result
+}
+
+func (me *builder) command(cmd *parse.CommandNode, piped []string) string {
+	args := func() []string {
+		codes := make([]string, 0, len(cmd.Args))
+		for _, arg := range cmd.Args[1:] {
+			codes = append(codes, me.arg(arg))
+		}
+		return append(codes, piped...)
+	}
+	switch n := cmd.Args[0].(type) {
+	case *parse.IdentifierNode:
+		return me.call(n.Ident, args())
+	case *parse.FieldNode, *parse.ChainNode, *parse.VariableNode:
+		code := me.arg(n)
+		if len(cmd.Args) > 1 || len(piped) != 0 {
+			return code + "(" + strings.Join(args(), ", ") + ")"
+		}
+		return code
+	default:
+		if len(cmd.Args) > 1 || len(piped) != 0 {
+			me.warn("%s can't take arguments", n)
+		}
+		return me.arg(n)
+	}
+}
+
+func (me *builder) arg(node parse.Node) string {
+	switch n := node.(type) {
+	case *parse.DotNode:
+		if me.dot == "data" {
+			me.fn.use("")
+		}
+		return me.dot
+	case *parse.FieldNode:
+		return me.field(me.dot, n.Ident)
+	case *parse.VariableNode:
+		if n.Ident[0] == "$" {
+			return me.field(me.root, n.Ident[1:])
+		}
+		name, ok := me.vars[n.Ident[0]]
+		if !ok {
+			me.warn("variable %s isn't converted", n.Ident[0])
+			name = strings.TrimPrefix(n.Ident[0], "$")
+		}
+		return me.field(name, n.Ident[1:])
+	case *parse.ChainNode:
+		base := me.arg(n.Node)
+		if _, ok := n.Node.(*parse.PipeNode); !ok {
+			base = "(" + base + ")"
+		}
+		return me.field(base, n.Field)
+	case *parse.PipeNode:
+		code := me.pipe(n)
+		if strings.HasPrefix(code, "(") || !strings.Contains(stringPattern.ReplaceAllString(code, ""), " ") {
+			return code
+		}
+		return "(" + code + ")"
+	case *parse.IdentifierNode:
+		return me.call(n.Ident, nil)
+	case *parse.StringNode:
+		return n.Quoted
+	case *parse.NumberNode:
+		return n.Text
+	case *parse.BoolNode:
+		return strconv.FormatBool(n.True)
+	case *parse.NilNode:
+		return "nil"
+	default:
+		me.warn("%s isn't converted", n)
+		return "nil /* TODO(h-migrate): " + comment(n) + " */"
+	}
+}
+
+func (me *builder) field(base string, idents []string) string {
+	if base == "data" {
+		field := ""
+		if len(idents) != 0 {
+			field = idents[0]
+		}
+		me.fn.use(field)
+	}
+	if len(idents) == 0 {
+		return base
+	}
+	return base + "." + strings.Join(idents, ".")
+}
+
+// operators are the comparison functions of templates.
+var operators = map[string]string{"eq": "==", "ne": "!=", "lt": "<", "le": "<=", "gt": ">", "ge": ">="}
+
+// call returns the expression of a call of a template function.
+func (me *builder) call(name string, args []string) string {
+	arity := func(n int) bool {
+		if len(args) < n {
+			me.warn("%s needs %d arguments", name, n)
+			return false
+		}
+		return true
+	}
+	switch name {
+	case "and", "or":
+		if !arity(1) {
+			return "false"
+		}
+		return "(" + strings.Join(args, map[string]string{"and": " && ", "or": " || "}[name]) + ")"
+	case "not":
+		if !arity(1) {
+			return "false"
+		}
+		return "!" + args[0]
+	case "eq", "ne", "lt", "le", "gt", "ge":
+		if !arity(2) {
+			return "false"
+		}
+		var comparisons []string
+		for _, arg := range args[1:] {
+			comparisons = append(comparisons, args[0]+" "+operators[name]+" "+arg)
+		}
+		return "(" + strings.Join(comparisons, " || ") + ")"
+	case "len":
+		if !arity(1) {
+			return "0"
+		}
+		return "len(" + args[0] + ")"
+	case "index":
+		if !arity(1) {
+			return "nil"
+		}
+		code := args[0]
+		for _, key := range args[1:] {
+			code += "[" + key + "]"
+		}
+		return code
+	case "slice":
+		if !arity(1) {
+			return "nil"
+		}
+		return args[0] + "[" + strings.Join(args[1:], ":") + "]"
+	case "print", "printf", "println":
+		me.c.imports["fmt"] = true
+		return "fmt.S" + name + "(" + strings.Join(args, ", ") + ")"
+	case "urlquery":
+		me.c.imports["fmt"] = true
+		me.c.imports["net/url"] = true
+		return "url.QueryEscape(fmt.Sprint(" + strings.Join(args, ", ") + "))"
+	case "html", "js":
+		// Package h escapes output itself.
+		if len(args) == 1 {
+			return args[0]
+		}
+		me.c.imports["fmt"] = true
+		return "fmt.Sprint(" + strings.Join(args, ", ") + ")"
+	case "call":
+		if !arity(1) {
+			return "nil"
+		}
+		return args[0] + "(" + strings.Join(args[1:], ", ") + ")"
+	default:
+		me.warn("function %s isn't a builtin: define it in Go", name)
+		return name + "(" + strings.Join(args, ", ") + ")"
+	}
+}
+
+// stringExpr returns the expression of pipe as a string.
+func (me *builder) stringExpr(pipe *parse.PipeNode) string {
+	code := me.pipe(pipe)
+	if strings.HasPrefix(code, `"`) || strings.HasPrefix(code, "fmt.Sprint") || strings.HasPrefix(code, "url.QueryEscape(") {
+		return code
+	}
+	me.c.imports["fmt"] = true
+	return "fmt.Sprint(" + code + ")"
+}
+
+// comment returns node as text safe in a /* comment */.
+func comment(node parse.Node) string {
+	return strings.ReplaceAll(node.String(), "*/", "* /")
+}
@@ -0,0 +1,432 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"text/template/parse"
+	"unicode"
+)
+
+type sourceFile struct {
+	Name string
+	Text string
+}
+
+type options struct {
+	Package string
+	Left    string // Action delimiters, "{{" and "}}" when empty
+	Right   string
+}
+
+// warning is a construct that couldn't be converted.
+type warning struct {
+	Location string // file:line
+	Message  string
+}
+
+func (me warning) String() string {
+	return me.Location + ": " + me.Message
+}
+
+// parsedFile is a template file and the templates it defines.
+type parsedFile struct {
+	sourceFile
+	main  *parse.Tree
+	trees map[string]*parse.Tree
+}
+
+// function is the Go function a template converts to.
+type function struct {
+	name     string
+	doc      string
+	fields   []string // Fields of the data used by the template
+	usesData bool
+	body     string
+}
+
+func (me *function) use(field string) {
+	me.usesData = true
+	if field != "" && !slices.Contains(me.fields, field) {
+		me.fields = append(me.fields, field)
+	}
+}
+
+type converter struct {
+	options
+	files     []*parsedFile
+	imports   map[string]bool
+	warnings  []warning
+	overrides map[string]bool // Templates defined by pages extending a base template, converted to blocks
+	functions []*function
+}
+
+// convert converts files to a Go file.
+func convert(files []sourceFile, opts options) ([]byte, []warning, error) {
+	c := converter{options: opts, imports: map[string]bool{}, overrides: map[string]bool{}}
+	for _, file := range files {
+		main := parse.New(filepath.Base(file.Name))
+		main.Mode = parse.SkipFuncCheck
+		trees := map[string]*parse.Tree{}
+		if _, err := main.Parse(file.Text, opts.Left, opts.Right, trees); err != nil {
+			return nil, nil, err
+		}
+		delete(trees, main.Name)
+		c.files = append(c.files, &parsedFile{sourceFile: file, main: main, trees: trees})
+	}
+
+	for _, file := range c.files {
+		for _, name := range c.extended(file) {
+			c.overrides[name] = true
+		}
+	}
+	for _, file := range c.files {
+		c.convertFile(file)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Converted from html/template by h-migrate; review before use.\n\npackage %s\n\n", opts.Package)
+	buf.WriteString("import (\n")
+	for _, path := range []string{"fmt", "net/url"} {
+		if c.imports[path] {
+			fmt.Fprintf(&buf, "%q\n", path)
+		}
+	}
+	buf.WriteString("\nh \"github.com/assaidy/hyper/v2\"\n)\n")
+	for _, fn := range c.functions {
+		buf.WriteString("\n")
+		buf.WriteString(fn.doc)
+		dataType := "any"
+		if fn.usesData {
+			dataType = fn.name + "Data"
+			fmt.Fprintf(&buf, "//\n// TODO(h-migrate): define %s", dataType)
+			if len(fn.fields) != 0 {
+				fmt.Fprintf(&buf, ", with the fields %s", joinWords(fn.fields))
+			}
+			buf.WriteString(".\n")
+		}
+		fmt.Fprintf(&buf, "func %s(data %s) h.HyperNode {\nreturn %s\n}\n", fn.name, dataType, fn.body)
+	}
+
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		// Let the user fix the code rather than losing the conversion.
+		c.warnings = append(c.warnings, warning{Location: opts.Package, Message: "generated code doesn't parse: " + err.Error()})
+		return buf.Bytes(), c.warnings, nil
+	}
+	return code, c.warnings, nil
+}
+
+// extended returns the templates file defines to fill the blocks of the
+// base template its main template invokes, Jinja's {% extends %}, if any.
+func (me *converter) extended(file *parsedFile) []string {
+	if me.base(file) == nil {
+		return nil
+	}
+	invoked := map[string]bool{}
+	walkTemplates(file.main.Root, invoked)
+	for _, tree := range file.trees {
+		walkTemplates(tree.Root, invoked)
+	}
+	var names []string
+	for _, tree := range sortedTrees(file) {
+		if !invoked[tree.Name] {
+			names = append(names, tree.Name)
+		}
+	}
+	return names
+}
+
+// base returns the {{template}} of the main template of file when it is
+// the only thing it does.
+func (me *converter) base(file *parsedFile) *parse.TemplateNode {
+	var base *parse.TemplateNode
+	for _, node := range file.main.Root.Nodes {
+		switch n := node.(type) {
+		case *parse.TextNode:
+			if len(bytes.TrimSpace(n.Text)) != 0 {
+				return nil
+			}
+		case *parse.TemplateNode:
+			if base != nil || isBlock(file, n) {
+				return nil
+			}
+			base = n
+		default:
+			return nil
+		}
+	}
+	return base
+}
+
+func walkTemplates(node parse.Node, invoked map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			walkTemplates(child, invoked)
+		}
+	case *parse.TemplateNode:
+		invoked[n.Name] = true
+	case *parse.IfNode:
+		walkTemplates(n.List, invoked)
+		walkTemplates(n.ElseList, invoked)
+	case *parse.RangeNode:
+		walkTemplates(n.List, invoked)
+		walkTemplates(n.ElseList, invoked)
+	case *parse.WithNode:
+		walkTemplates(n.List, invoked)
+		walkTemplates(n.ElseList, invoked)
+	}
+}
+
+// isBlock reports whether node is a {{block}} rather than a {{template}},
+// which the parser doesn't tell apart.
+func isBlock(file *parsedFile, node *parse.TemplateNode) bool {
+	before := strings.TrimRight(file.Text[:node.Position()], " \t\r\n")
+	return strings.HasSuffix(before, "block")
+}
+
+func (me *converter) convertFile(file *parsedFile) {
+	// Blocks are converted where they are declared, pages' definitions of
+	// blocks where they extend their base.
+	skip := map[string]bool{}
+	for _, tree := range append([]*parse.Tree{file.main}, mapValues(file.trees)...) {
+		collectBlocks(file, tree.Root, skip)
+	}
+
+	if base := me.base(file); base != nil && len(me.extended(file)) != 0 {
+		fn := me.newFunction(file.main.Name, fmt.Sprintf("renders %s, extending %s.", file.main.Name, base.Name))
+		b := newBuilder(me, file, fn, "data")
+		defines := []string{b.template(base).code}
+		for _, name := range me.extended(file) {
+			skip[name] = true
+			d := newBuilder(me, file, fn, "data")
+			d.nodes(file.trees[name].Root.Nodes)
+			defines = append(defines, fmt.Sprintf("h.Define(%q%s)", name, prefixList(d.finish())))
+		}
+		fn.body = "h.Extend(" + strings.Join(defines, ",\n") + ",\n)"
+	} else if !isEmpty(file.main.Root) {
+		fn := me.newFunction(file.main.Name, fmt.Sprintf("renders %s.", file.main.Name))
+		b := newBuilder(me, file, fn, "data")
+		b.nodes(file.main.Root.Nodes)
+		fn.body = group(b.finish()).code
+	}
+
+	for _, tree := range sortedTrees(file) {
+		if skip[tree.Name] {
+			continue
+		}
+		fn := me.newFunction(tree.Name, fmt.Sprintf("renders the template %q of %s.", tree.Name, file.main.Name))
+		b := newBuilder(me, file, fn, "data")
+		b.nodes(tree.Root.Nodes)
+		fn.body = group(b.finish()).code
+	}
+}
+
+// sortedTrees returns the templates file defines, in order.
+func sortedTrees(file *parsedFile) []*parse.Tree {
+	trees := mapValues(file.trees)
+	sort.Slice(trees, func(i, j int) bool { return trees[i].Root.Position() < trees[j].Root.Position() })
+	return trees
+}
+
+func collectBlocks(file *parsedFile, node parse.Node, blocks map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			collectBlocks(file, child, blocks)
+		}
+	case *parse.TemplateNode:
+		if isBlock(file, n) {
+			blocks[n.Name] = true
+		}
+	case *parse.IfNode:
+		collectBlocks(file, n.List, blocks)
+		collectBlocks(file, n.ElseList, blocks)
+	case *parse.RangeNode:
+		collectBlocks(file, n.List, blocks)
+		collectBlocks(file, n.ElseList, blocks)
+	case *parse.WithNode:
+		collectBlocks(file, n.List, blocks)
+		collectBlocks(file, n.ElseList, blocks)
+	}
+}
+
+func (me *converter) newFunction(template, doc string) *function {
+	fn := &function{name: functionName(template)}
+	for _, other := range me.functions {
+		if other.name == fn.name {
+			me.warnings = append(me.warnings, warning{Location: template, Message: fmt.Sprintf("function %s is defined by several templates", fn.name)})
+		}
+	}
+	fn.doc = fmt.Sprintf("// %s %s\n", fn.name, doc)
+	me.functions = append(me.functions, fn)
+	return fn
+}
+
+func (me *converter) warn(file *parsedFile, pos parse.Pos, format string, args ...any) {
+	line := 1 + strings.Count(file.Text[:min(int(pos), len(file.Text))], "\n")
+	me.warnings = append(me.warnings, warning{
+		Location: fmt.Sprintf("%s:%d", file.Name, line),
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// functionName returns the Go function a template converts to, e.g.
+// "UserCard" for "user-card" or "partials/user_card.html".
+func functionName(template string) string {
+	template = strings.TrimSuffix(filepath.Base(template), filepath.Ext(template))
+	var name strings.Builder
+	upper := true
+	for _, r := range template {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		name.WriteRune(r)
+	}
+	if name.Len() == 0 || unicode.IsDigit(rune(name.String()[0])) {
+		return "T" + name.String()
+	}
+	return name.String()
+}
+
+// singular returns the singular of a plural English noun, or "" when
+// name doesn't look plural.
+func singular(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies") && len(name) > 3:
+		return name[:len(name)-3] + "y"
+	case strings.HasSuffix(name, "sses"), strings.HasSuffix(name, "xes"), strings.HasSuffix(name, "ches"), strings.HasSuffix(name, "shes"):
+		return name[:len(name)-2]
+	case strings.HasSuffix(name, "s") && !strings.HasSuffix(name, "ss") && len(name) > 1:
+		return name[:len(name)-1]
+	}
+	return ""
+}
+
+// unexported returns name with a lowercase first letter.
+func unexported(name string) string {
+	if name == "" {
+		return ""
+	}
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
+// reserved are the identifiers the generated code uses.
+var reserved = []string{"data", "h", "fmt", "url", "nodes", "any", "len", "string"}
+
+func isEmpty(list *parse.ListNode) bool {
+	for _, node := range list.Nodes {
+		if text, ok := node.(*parse.TextNode); !ok || len(bytes.TrimSpace(text.Text)) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func isKeyword(name string) bool {
+	return token.IsKeyword(name) || slices.Contains(reserved, name)
+}
+
+// child is the Go expression of a child of an element.
+type child struct {
+	code string
+	node bool // Of type h.HyperNode, rather than a string or another value
+}
+
+// group returns the expression of a HyperNode holding children.
+func group(children []child) child {
+	if len(children) == 1 && children[0].node {
+		return children[0]
+	}
+	return child{code: "h.Group(" + list(children) + ")", node: true}
+}
+
+// list returns children as arguments, one per line when there are
+// several.
+func list(children []child) string {
+	switch len(children) {
+	case 0:
+		return ""
+	case 1:
+		return children[0].code
+	}
+	codes := make([]string, len(children))
+	for i, c := range children {
+		codes[i] = c.code
+	}
+	return "\n" + strings.Join(codes, ",\n") + ",\n"
+}
+
+// prefixList returns children as arguments following others.
+func prefixList(children []child) string {
+	if len(children) == 0 {
+		return ""
+	}
+	if len(children) == 1 {
+		return ", " + children[0].code
+	}
+	return "," + list(children)
+}
+
+// unparen strips the parentheses around code.
+func unparen(code string) string {
+	if !strings.HasPrefix(code, "(") || !strings.HasSuffix(code, ")") {
+		return code
+	}
+	depth := 0
+	for i, r := range code {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 && i != len(code)-1 {
+				return code
+			}
+		}
+	}
+	return code[1 : len(code)-1]
+}
+
+var (
+	identifierPattern = regexp.MustCompile(`[\pL_][\pL\pN_]*`)
+	stringPattern     = regexp.MustCompile(`"(?:[^"\\]|\\.)*"|` + "`[^`]*`")
+)
+
+// uses reports whether code uses the identifier name.
+func uses(code, name string) bool {
+	return slices.Contains(identifierPattern.FindAllString(stringPattern.ReplaceAllString(code, ""), -1), name)
+}
+
+func joinWords(words []string) string {
+	if len(words) == 1 {
+		return words[0]
+	}
+	return strings.Join(words[:len(words)-1], ", ") + " and " + words[len(words)-1]
+}
+
+func mapValues[K comparable, V any](m map[K]V) []V {
+	values := make([]V, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	return values
+}
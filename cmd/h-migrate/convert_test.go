@@ -0,0 +1,181 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+func convertString(t *testing.T, files ...sourceFile) (string, []warning) {
+	t.Helper()
+	code, warnings, err := convert(files, options{Package: "views"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "views.go", code, 0); err != nil {
+		t.Fatalf("generated code doesn't parse: %v\n%s", err, code)
+	}
+	return string(code), warnings
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		expected []string
+	}{
+		{
+			name:     "Elements and text",
+			template: `<div id="app"><h1>Hello &amp; welcome</h1><img src="/a.png" alt=""><br></div>`,
+			expected: []string{`h.DIV(h.AttrID("app"))(`, `h.H1()("Hello & welcome")`, `h.IMG(h.AttrSrc("/a.png"), h.AttrAlt(""))`, `h.BR()`},
+		},
+		{
+			name:     "Actions",
+			template: `<p title="{{.Title}}">{{.Title}} by {{.Author.Name | printf "%q"}}</p>`,
+			expected: []string{`h.AttrTitle(fmt.Sprint(data.Title))`, `data.Title,`, `fmt.Sprintf("%q", data.Author.Name)`, `"fmt"`},
+		},
+		{
+			name:     "Conditions",
+			template: `{{if .Admin}}<b>admin</b>{{else}}user{{end}}{{if and .A (not .B)}}x{{end}}`,
+			expected: []string{`h.IfElse(data.Admin, h.Group(h.B()("admin")), h.Group("user"))`, `h.If(data.A && !data.B, h.Group("x"))`},
+		},
+		{
+			name:     "Conditions guarding content",
+			template: `{{if .User}}{{.User.Name}}{{end}}`,
+			expected: []string{"if data.User {\n\t\t\treturn h.Group(data.User.Name)"},
+		},
+		{
+			name:     "Conditional attributes",
+			template: `<input type="checkbox" {{if .Done}}checked{{end}} class="task {{if .Late}}late{{else}}ok{{end}}" disabled>`,
+			expected: []string{`h.INPUT(h.AttrType("checkbox"), h.AttrChecked(data.Done), h.AttrClass("task "+h.IfElse(data.Late, "late", "ok")), h.AttrDisabled(true))`},
+		},
+		{
+			name:     "Range",
+			template: `<ul>{{range .Users}}<li>{{.Name}}</li>{{end}}</ul>`,
+			expected: []string{`h.Range(data.Users, func(user User) h.HyperNode {`, `return h.LI()(user.Name)`},
+		},
+		{
+			name:     "Range with index and else",
+			template: `{{range $i, $tag := .Tags}}<span data-i="{{$i}}">{{$tag}} {{$.Title}}</span>{{else}}none{{end}}`,
+			expected: []string{"if len(data.Tags) == 0 {\n\t\t\treturn h.Group(\"none\")", `for i, tag := range data.Tags {`, `h.Attr("data-i", fmt.Sprint(i))`, `data.Title`},
+		},
+		{
+			name:     "With",
+			template: `{{with .Owner}}<p>{{.Name}}</p>{{end}}`,
+			expected: []string{`if owner := data.Owner; owner != nil {`, `h.P()(owner.Name)`},
+		},
+		{
+			name:     "Templates",
+			template: `{{template "user-card" .User}}{{define "user-card"}}<div>{{.Name}}</div>{{end}}`,
+			expected: []string{`UserCard(data.User)`, `func UserCard(data UserCardData) h.HyperNode {`, "define UserCardData, with the fields Name."},
+		},
+		{
+			name: "Raw text and foreign elements",
+			template: `<style>p > b { color: red }</style><svg viewBox="0 0 1 1"><path d="M0 0"/></svg><pre>  a
+  b</pre>`,
+			expected: []string{`h.STYLE()(h.RawText("p > b { color: red }"))`, `h.SVG(h.Attr("viewBox", "0 0 1 1"))(h.WithChildren(h.Element{Tag: "path", Attributes: []h.Attribute{h.Attr("d", "M0 0")}})())`, `h.PRE()("  a\n  b")`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, warnings := convertString(t, sourceFile{Name: "page.html", Text: tt.template})
+			for _, expected := range tt.expected {
+				if !strings.Contains(code, expected) {
+					t.Errorf("expected %q in:\n%s", expected, code)
+				}
+			}
+			if len(warnings) != 0 {
+				t.Errorf("unexpected warnings: %v", warnings)
+			}
+		})
+	}
+}
+
+func TestConvertInheritance(t *testing.T) {
+	code, warnings := convertString(t,
+		sourceFile{Name: "base.html", Text: `<title>{{block "title" .}}App{{end}}</title><main>{{template "content" .}}</main>`},
+		sourceFile{Name: "users.html", Text: `{{template "base.html" .}}{{define "title"}}Users{{end}}{{define "content"}}<p>{{.Count}}</p>{{end}}`},
+	)
+	for _, expected := range []string{
+		`h.TITLE()(h.Block("title", "App"))`,
+		`h.MAIN()(h.Block("content"))`,
+		"h.Extend(Base(data),\n\t\th.Define(\"title\", \"Users\"),\n\t\th.Define(\"content\", h.P()(data.Count)),",
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("expected %q in:\n%s", expected, code)
+		}
+	}
+	if strings.Contains(code, "func Content(") || strings.Contains(code, "func Title(") {
+		t.Errorf("expected blocks not to be converted to functions:\n%s", code)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("unexpected warnings: %v", warnings)
+	}
+}
+
+func TestConvertWarnings(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{name: "Unknown function", template: `{{upper .Name}}`, expected: "page.html:1: function upper isn't a builtin"},
+		{name: "Variable declaration", template: "<p>\n{{$x := .Name}}</p>", expected: "page.html:2: variable declarations aren't converted"},
+		{name: "Action in script", template: `<script>var x = {{.X}};</script>`, expected: "actions in <script> aren't escaped"},
+		{name: "Element crossing a condition", template: `{{if .A}}<div class="a">{{else}}<div>{{end}}x</div>`, expected: "</div> has no start tag in the same template block"},
+		{name: "Attribute with value in a condition", template: `<a {{if .New}}target="_blank"{{end}}>x</a>`, expected: "only converted for boolean attributes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, warnings := convertString(t, sourceFile{Name: "page.html", Text: tt.template})
+			for _, warning := range warnings {
+				if strings.Contains(warning.String(), tt.expected) {
+					return
+				}
+			}
+			t.Errorf("expected a warning containing %q, got %v", tt.expected, warnings)
+		})
+	}
+}
+
+// TestTables checks the tables against the constructors of package h.
+func TestTables(t *testing.T) {
+	declared := map[string]bool{}
+	for _, path := range []string{"../../elements.go", "../../attributes.go"} {
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				if d.Recv == nil {
+					declared[d.Name.Name] = true
+				}
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					if value, ok := spec.(*ast.ValueSpec); ok {
+						for _, name := range value.Names {
+							declared[name.Name] = true
+						}
+					}
+				}
+			}
+		}
+	}
+
+	for tag, constructor := range elements {
+		if !declared[strings.TrimSuffix(constructor, "*")] {
+			t.Errorf("element %s: no constructor %s", tag, constructor)
+		}
+	}
+	for attribute, constructor := range attributes {
+		if !declared[strings.TrimSuffix(constructor, "!")] {
+			t.Errorf("attribute %s: no constructor %s", attribute, constructor)
+		}
+	}
+}
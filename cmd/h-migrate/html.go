@@ -0,0 +1,498 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template/parse"
+)
+
+// Modes of the HTML scanner of a builder.
+const (
+	modeText    = iota
+	modeTag     // Inside a start tag
+	modeRaw     // Inside a <script> or a <style>
+	modeComment // Inside an HTML comment
+)
+
+// openElement is an element whose end tag hasn't been read yet.
+type openElement struct {
+	tag      string
+	attrs    []string
+	children []child
+}
+
+// tagPiece is a piece of a start tag: text, or an action within it.
+type tagPiece struct {
+	text string
+	node parse.Node
+}
+
+// optionalEnd are the elements whose end tag may be omitted.
+var optionalEnd = map[string]bool{
+	"html": true, "head": true, "body": true, "p": true, "li": true, "dt": true, "dd": true,
+	"option": true, "optgroup": true, "thead": true, "tbody": true, "tfoot": true,
+	"tr": true, "td": true, "th": true, "colgroup": true, "caption": true, "rt": true, "rp": true,
+}
+
+// text scans HTML text, which may stop in the middle of a tag.
+func (me *builder) text(s string) {
+	for s != "" {
+		switch me.mode {
+		case modeText:
+			i := strings.IndexByte(s, '<')
+			if i < 0 {
+				me.addText(s)
+				return
+			}
+			me.addText(s[:i])
+			s = s[i:]
+			switch {
+			case strings.HasPrefix(s, "<!--"):
+				me.mode = modeComment
+				s = s[len("<!--"):]
+			case strings.HasPrefix(s, "</"), strings.HasPrefix(s, "<!"):
+				end := strings.IndexByte(s, '>')
+				if end < 0 {
+					me.warn("tags containing actions aren't converted: %s", s)
+					return
+				}
+				if s[1] == '/' {
+					me.close(strings.TrimSpace(s[2:end]))
+				} else if strings.EqualFold(strings.TrimSpace(s[2:end]), "doctype html") {
+					me.add(child{code: "h.DOCTYPE()", node: true})
+				} else {
+					me.warn("%s isn't converted", s[:end+1])
+				}
+				s = s[end+1:]
+			case len(s) > 1 && isLetter(s[1]):
+				me.mode = modeTag
+				me.tag = nil
+				me.quote = 0
+				s = s[1:]
+			default:
+				me.addText("<")
+				s = s[1:]
+			}
+
+		case modeComment:
+			i := strings.Index(s, "-->")
+			if i < 0 {
+				return
+			}
+			me.mode = modeText
+			s = s[i+len("-->"):]
+
+		case modeTag:
+			i := me.tagEnd(s)
+			if i < 0 {
+				me.tag = append(me.tag, tagPiece{text: s})
+				return
+			}
+			me.tag = append(me.tag, tagPiece{text: s[:i]})
+			me.mode = modeText
+			s = s[i+1:]
+			me.openTag()
+
+		case modeRaw:
+			top := me.stack[len(me.stack)-1]
+			i := strings.Index(strings.ToLower(s), "</"+top.tag)
+			if i < 0 {
+				me.raw = append(me.raw, strconv.Quote(s))
+				return
+			}
+			me.raw = append(me.raw, strconv.Quote(s[:i]))
+			end := strings.IndexByte(s[i:], '>')
+			if end < 0 {
+				end = len(s) - i - 1
+			}
+			me.mode = modeText
+			s = s[i+end+1:]
+			me.closeRaw()
+		}
+	}
+}
+
+// tagEnd returns the index of the '>' ending a tag in s, or -1.
+func (me *builder) tagEnd(s string) int {
+	for i := 0; i < len(s); i++ {
+		switch {
+		case me.quote != 0:
+			if s[i] == me.quote {
+				me.quote = 0
+			}
+		case s[i] == '"' || s[i] == '\'':
+			me.quote = s[i]
+		case s[i] == '>':
+			return i
+		}
+	}
+	return -1
+}
+
+var whitespace = regexp.MustCompile(`\s+`)
+
+// addText adds text, with its whitespace collapsed outside of <pre>, and
+// dropped at the start and end of lines.
+func (me *builder) addText(s string) {
+	if !me.inPre() {
+		if trimmed := strings.TrimLeft(s, " \t\r\n"); strings.ContainsAny(s[:len(s)-len(trimmed)], "\r\n") {
+			s = trimmed
+		}
+		if trimmed := strings.TrimRight(s, " \t\r\n"); strings.ContainsAny(s[len(trimmed):], "\r\n") {
+			s = trimmed
+		}
+		s = whitespace.ReplaceAllString(s, " ")
+	}
+	if s != "" {
+		me.add(child{code: strconv.Quote(html.UnescapeString(s))})
+	}
+}
+
+func (me *builder) inPre() bool {
+	for _, element := range me.stack {
+		if element.tag == "pre" || element.tag == "textarea" {
+			return true
+		}
+	}
+	return me.pre
+}
+
+// tagItem is a byte of a tag, or an action within it.
+type tagItem struct {
+	ch   byte
+	node parse.Node
+}
+
+func (me tagItem) is(chars string) bool {
+	return me.node == nil && strings.IndexByte(chars, me.ch) >= 0
+}
+
+// openTag converts the start tag read.
+func (me *builder) openTag() {
+	var items []tagItem
+	for _, piece := range me.tag {
+		if piece.node != nil {
+			items = append(items, tagItem{node: piece.node})
+			continue
+		}
+		for i := 0; i < len(piece.text); i++ {
+			items = append(items, tagItem{ch: piece.text[i]})
+		}
+	}
+	me.tag = nil
+
+	for len(items) != 0 && items[len(items)-1].is(" \t\r\n") {
+		items = items[:len(items)-1]
+	}
+	selfClosing := len(items) != 0 && items[len(items)-1].is("/")
+	if selfClosing {
+		items = items[:len(items)-1]
+	}
+	i := 0
+	for i < len(items) && !items[i].is(" \t\r\n/") && items[i].node == nil {
+		i++
+	}
+	name := make([]byte, i)
+	for j := range name {
+		name[j] = items[j].ch
+	}
+
+	element := &openElement{tag: strings.ToLower(string(name)), attrs: me.attributes(items[i:])}
+	switch {
+	case strings.HasSuffix(elements[element.tag], "*") || selfClosing:
+		me.add(me.element(element))
+	case element.tag == "script" || element.tag == "style":
+		me.stack = append(me.stack, element)
+		me.mode = modeRaw
+		me.raw = nil
+	default:
+		me.stack = append(me.stack, element)
+	}
+}
+
+// attributes converts the attributes of a tag.
+func (me *builder) attributes(items []tagItem) []string {
+	var attrs []string
+	skipSpace := func(i int) int {
+		for i < len(items) && items[i].is(" \t\r\n/") {
+			i++
+		}
+		return i
+	}
+	for i := skipSpace(0); i < len(items); i = skipSpace(i) {
+		if items[i].node != nil {
+			attrs = append(attrs, me.attrAction(items[i].node)...)
+			i++
+			continue
+		}
+
+		start := i
+		for i < len(items) && items[i].node == nil && !items[i].is(" \t\r\n=/") {
+			i++
+		}
+		var name []byte
+		for _, item := range items[start:i] {
+			name = append(name, item.ch)
+		}
+
+		j := i
+		for j < len(items) && items[j].is(" \t\r\n") {
+			j++
+		}
+		if j == len(items) || !items[j].is("=") {
+			attrs = append(attrs, attribute(string(name), "", false))
+			continue
+		}
+		for i = j + 1; i < len(items) && items[i].is(" \t\r\n"); i++ {
+		}
+		var value []tagItem
+		if i < len(items) && items[i].is(`"'`) {
+			quote := items[i].ch
+			for i++; i < len(items) && !(items[i].node == nil && items[i].ch == quote); i++ {
+				value = append(value, items[i])
+			}
+			i++
+		} else {
+			for ; i < len(items) && !items[i].is(" \t\r\n"); i++ {
+				value = append(value, items[i])
+			}
+		}
+		attrs = append(attrs, attribute(string(name), me.attrValue(value), true))
+	}
+	return attrs
+}
+
+// attrValue returns the expression of an attribute value.
+func (me *builder) attrValue(items []tagItem) string {
+	var parts []string
+	var text []byte
+	flush := func() {
+		if len(text) != 0 {
+			parts = append(parts, strconv.Quote(html.UnescapeString(string(text))))
+			text = nil
+		}
+	}
+	for _, item := range items {
+		if item.node == nil {
+			text = append(text, item.ch)
+			continue
+		}
+		flush()
+		me.pos = item.node.Position()
+		switch n := item.node.(type) {
+		case *parse.ActionNode:
+			parts = append(parts, me.stringExpr(n.Pipe))
+		case *parse.IfNode:
+			if code, ok := me.textIf(n); ok {
+				parts = append(parts, code)
+				continue
+			}
+			me.warn("{{if}} in attribute values is only converted with text content: %s", n)
+		default:
+			me.warn("%s isn't converted in attribute values", n)
+		}
+	}
+	flush()
+	if len(parts) == 0 {
+		return `""`
+	}
+	return strings.Join(parts, " + ")
+}
+
+// textIf converts an {{if}} whose branches are text to h.IfElse.
+func (me *builder) textIf(n *parse.IfNode) (string, bool) {
+	then, ok := textOf(n.List)
+	if !ok {
+		return "", false
+	}
+	otherwise := `""`
+	if n.ElseList != nil {
+		if len(n.ElseList.Nodes) == 1 {
+			if elseIf, ok := n.ElseList.Nodes[0].(*parse.IfNode); ok {
+				code, ok := me.textIf(elseIf)
+				if !ok {
+					return "", false
+				}
+				otherwise = code
+			}
+		}
+		if otherwise == `""` {
+			text, ok := textOf(n.ElseList)
+			if !ok {
+				return "", false
+			}
+			otherwise = strconv.Quote(text)
+		}
+	}
+	return fmt.Sprintf("h.IfElse(%s, %q, %s)", me.condition(n.Pipe), then, otherwise), true
+}
+
+// textOf returns the text of a list made only of text.
+func textOf(list *parse.ListNode) (string, bool) {
+	var text strings.Builder
+	for _, node := range list.Nodes {
+		n, ok := node.(*parse.TextNode)
+		if !ok {
+			return "", false
+		}
+		text.Write(n.Text)
+	}
+	return html.UnescapeString(text.String()), true
+}
+
+// attrAction converts an action among the attributes of a tag, which is
+// only supported for boolean attributes, e.g. {{if .Done}}checked{{end}}.
+func (me *builder) attrAction(node parse.Node) []string {
+	me.pos = node.Position()
+	n, ok := node.(*parse.IfNode)
+	if !ok {
+		me.warn("%s isn't converted among attributes", node)
+		return nil
+	}
+	then, ok := textOf(n.List)
+	otherwise := ""
+	if ok && n.ElseList != nil {
+		otherwise, ok = textOf(n.ElseList)
+	}
+	if !ok || strings.Contains(then+otherwise, "=") {
+		me.warn("{{if}} among attributes is only converted for boolean attributes: %s", n)
+		return nil
+	}
+
+	cond := me.condition(n.Pipe)
+	var attrs []string
+	for _, name := range strings.Fields(then) {
+		attrs = append(attrs, boolAttribute(name, cond))
+	}
+	for _, name := range strings.Fields(otherwise) {
+		attrs = append(attrs, boolAttribute(name, "!("+cond+")"))
+	}
+	return attrs
+}
+
+// attribute returns the expression of an attribute.
+func attribute(name, value string, hasValue bool) string {
+	constructor, known := attributes[strings.ToLower(name)]
+	switch {
+	case strings.HasSuffix(constructor, "!"):
+		return "h." + strings.TrimSuffix(constructor, "!") + "(true)"
+	case known && hasValue:
+		return "h." + constructor + "(" + value + ")"
+	case known:
+		return "h." + constructor + `("")`
+	case hasValue:
+		return fmt.Sprintf("h.Attr(%q, %s)", name, value)
+	default:
+		return fmt.Sprintf("h.Attr(%q, true)", name)
+	}
+}
+
+// boolAttribute returns the expression of a boolean attribute set when
+// cond is true.
+func boolAttribute(name, cond string) string {
+	if constructor := attributes[strings.ToLower(name)]; strings.HasSuffix(constructor, "!") {
+		return "h." + strings.TrimSuffix(constructor, "!") + "(" + cond + ")"
+	}
+	return fmt.Sprintf("h.Attr(%q, %s)", name, cond)
+}
+
+// close converts the element ended by an end tag.
+func (me *builder) close(tag string) {
+	tag = strings.ToLower(tag)
+	for i := len(me.stack) - 1; i >= 0; i-- {
+		if me.stack[i].tag != tag {
+			continue
+		}
+		for len(me.stack) > i+1 {
+			if top := me.stack[len(me.stack)-1]; !optionalEnd[top.tag] {
+				me.warn("<%s> isn't closed before </%s>", top.tag, tag)
+			}
+			me.pop()
+		}
+		me.pop()
+		return
+	}
+	if !strings.HasSuffix(elements[tag], "*") {
+		me.warn("</%s> has no start tag in the same template block: elements must start and end within each {{if}}, {{range}}, {{with}} and {{define}}", tag)
+	}
+}
+
+func (me *builder) pop() {
+	element := me.stack[len(me.stack)-1]
+	me.stack = me.stack[:len(me.stack)-1]
+	me.add(me.element(element))
+}
+
+// rawAction converts an action in a <script> or a <style>.
+func (me *builder) rawAction(node parse.Node) {
+	n, ok := node.(*parse.ActionNode)
+	if !ok {
+		me.warn("%s isn't converted in <%s>", node, me.stack[len(me.stack)-1].tag)
+		return
+	}
+	me.warn("actions in <%s> aren't escaped by h.RawText: check %s", me.stack[len(me.stack)-1].tag, n)
+	me.raw = append(me.raw, me.stringExpr(n.Pipe))
+}
+
+// closeRaw converts the <script> or <style> read.
+func (me *builder) closeRaw() {
+	var parts []string
+	for _, part := range me.raw {
+		if part != `""` {
+			parts = append(parts, part)
+		}
+	}
+	me.raw = nil
+	element := me.stack[len(me.stack)-1]
+	if len(parts) != 0 {
+		element.children = append(element.children, child{code: "h.RawText(" + strings.Join(parts, " + ") + ")", node: true})
+	}
+	me.pop()
+}
+
+// finish returns the children converted, closing the elements still open.
+func (me *builder) finish() []child {
+	switch me.mode {
+	case modeTag:
+		me.warn("a tag isn't closed within the same template block")
+		me.mode = modeText
+		me.openTag()
+	case modeRaw:
+		me.closeRaw()
+	}
+	me.mode = modeText
+	for len(me.stack) != 0 {
+		if top := me.stack[len(me.stack)-1]; !optionalEnd[top.tag] {
+			me.warn("<%s> isn't closed within the same template block", top.tag)
+		}
+		me.pop()
+	}
+	return me.children
+}
+
+// element returns the expression of an element.
+func (me *builder) element(element *openElement) child {
+	attrs := strings.Join(element.attrs, ", ")
+	constructor, known := elements[element.tag]
+	switch {
+	case strings.HasSuffix(constructor, "*"):
+		return child{code: "h." + strings.TrimSuffix(constructor, "*") + "(" + attrs + ")", node: true}
+	case known:
+		return child{code: "h." + constructor + "(" + attrs + ")(" + list(element.children) + ")", node: true}
+	}
+
+	code := fmt.Sprintf("h.Element{Tag: %q", element.tag)
+	if attrs != "" {
+		code += ", Attributes: []h.Attribute{" + attrs + "}"
+	}
+	// Self-closing foreign elements, such as SVG ones, keep their end tag.
+	code += "}"
+	return child{code: "h.WithChildren(" + code + ")(" + list(element.children) + ")", node: true}
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
@@ -0,0 +1,72 @@
+// Command h-migrate converts html/template files to Go code building the
+// same HTML with package h, as a starting point for migrating an app.
+//
+// Usage:
+//
+//	h-migrate [-pkg views] [-o views/pages.go] [-left "{{" -right "}}"] templates/*.html
+//
+// Each file and each {{define}} becomes a function taking the template
+// data and returning an h.HyperNode: {{if}} converts to h.If and
+// h.IfElse, {{range}} to h.Range, {{template}} to a call of the function
+// of the template. Template inheritance converts to blocks: {{block}} and
+// the {{template}} of a template the pages define become h.Block, and
+// pages invoking a base template while defining its blocks become
+// h.Extend with h.Define.
+//
+// The output is a starting point rather than finished code: templates
+// aren't typed, so the data types of the functions must be defined (a
+// comment lists the fields each uses), and conditions testing something
+// else than a bool must be adjusted. Constructs that aren't converted,
+// such as template functions, {{break}} or actions inside <script>, are
+// reported on stderr and marked with TODO(h-migrate) comments.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	pkg := flag.String("pkg", "views", "package of the generated file")
+	output := flag.String("o", "", "file to write, instead of stdout")
+	left := flag.String("left", "{{", "left action delimiter of the templates")
+	right := flag.String("right", "}}", "right action delimiter of the templates")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: h-migrate [flags] template-files...")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var files []sourceFile
+	for _, name := range flag.Args() {
+		text, err := os.ReadFile(name)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "h-migrate:", err)
+			os.Exit(1)
+		}
+		files = append(files, sourceFile{Name: name, Text: string(text)})
+	}
+
+	code, warnings, err := convert(files, options{Package: *pkg, Left: *left, Right: *right})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "h-migrate:", err)
+		os.Exit(1)
+	}
+	for _, warning := range warnings {
+		fmt.Fprintln(os.Stderr, warning)
+	}
+
+	if *output == "" {
+		os.Stdout.Write(code)
+		return
+	}
+	if err := os.WriteFile(*output, code, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "h-migrate:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,288 @@
+package main
+
+// elements maps the tags to their constructor in package h, with a "*"
+// suffix for void elements.
+var elements = map[string]string{
+	"html":            "HTML",
+	"head":            "HEAD",
+	"title":           "TITLE",
+	"link":            "LINK*",
+	"meta":            "META*",
+	"style":           "STYLE",
+	"body":            "BODY",
+	"h1":              "H1",
+	"h2":              "H2",
+	"h3":              "H3",
+	"h4":              "H4",
+	"h5":              "H5",
+	"h6":              "H6",
+	"header":          "HEADER",
+	"footer":          "FOOTER",
+	"nav":             "NAV",
+	"main":            "MAIN",
+	"section":         "SECTION",
+	"article":         "ARTICLE",
+	"aside":           "ASIDE",
+	"hr":              "HR*",
+	"pre":             "PRE",
+	"blockquote":      "BLOCKQUOTE",
+	"ol":              "OL",
+	"ul":              "UL",
+	"li":              "LI",
+	"a":               "A",
+	"em":              "EM",
+	"strong":          "STRONG",
+	"code":            "CODE",
+	"var":             "VAR",
+	"samp":            "SAMP",
+	"kbd":             "KBD",
+	"sub":             "SUB",
+	"sup":             "SUP",
+	"i":               "I",
+	"b":               "B",
+	"u":               "U",
+	"mark":            "MARK",
+	"bdi":             "BDI",
+	"bdo":             "BDO",
+	"br":              "BR*",
+	"wbr":             "WBR*",
+	"img":             "IMG*",
+	"iframe":          "IFRAME",
+	"embed":           "EMBED*",
+	"object":          "OBJECT",
+	"picture":         "PICTURE",
+	"source":          "SOURCE*",
+	"track":           "TRACK*",
+	"video":           "VIDEO",
+	"audio":           "AUDIO",
+	"canvas":          "CANVAS",
+	"map":             "MAP",
+	"area":            "AREA*",
+	"svg":             "SVG",
+	"math":            "MATH",
+	"script":          "SCRIPT",
+	"noscript":        "NOSCRIPT",
+	"del":             "DEL",
+	"ins":             "INS",
+	"table":           "TABLE",
+	"caption":         "CAPTION",
+	"colgroup":        "COLGROUP",
+	"col":             "COL*",
+	"thead":           "THEAD",
+	"tbody":           "TBODY",
+	"tfoot":           "TFOOT",
+	"tr":              "TR",
+	"th":              "TH",
+	"td":              "TD",
+	"form":            "FORM",
+	"fieldset":        "FIELDSET",
+	"legend":          "LEGEND",
+	"label":           "LABEL",
+	"input":           "INPUT*",
+	"button":          "BUTTON",
+	"select":          "SELECT",
+	"datalist":        "DATALIST",
+	"optgroup":        "OPTGROUP",
+	"option":          "OPTION",
+	"textarea":        "TEXTAREA",
+	"output":          "OUTPUT",
+	"progress":        "PROGRESS",
+	"meter":           "METER",
+	"details":         "DETAILS",
+	"summary":         "SUMMARY",
+	"dialog":          "DIALOG",
+	"slot":            "SLOT",
+	"template":        "TEMPLATE",
+	"fencedframe":     "FENCEDFRAME",
+	"selectedcontent": "SELECTEDCONTENT",
+	"base":            "BASE*",
+	"hgroup":          "HGROUP",
+	"address":         "ADDRESS",
+	"search":          "SEARCH",
+	"div":             "DIV",
+	"span":            "SPAN",
+	"p":               "P",
+	"dl":              "DL",
+	"dt":              "DT",
+	"dd":              "DD",
+	"figure":          "FIGURE",
+	"figcaption":      "FIGCAPTION",
+	"menu":            "MENU",
+	"small":           "SMALL",
+	"s":               "S",
+	"cite":            "CITE",
+	"q":               "Q",
+	"dfn":             "DFN",
+	"abbr":            "ABBR",
+	"ruby":            "RUBY",
+	"rt":              "RT",
+	"rp":              "RP",
+	"data":            "DATA",
+	"time":            "TIME",
+}
+
+// attributes maps the attributes to their constructor in package h, with
+// a "!" suffix for boolean attributes. Event handlers are left out: they
+// convert to h.Attr.
+var attributes = map[string]string{
+	"accept":                "AttrAccept",
+	"accept-charset":        "AttrAcceptCharset",
+	"accesskey":             "AttrAccessKey",
+	"action":                "AttrAction",
+	"align":                 "AttrAlign",
+	"allow":                 "AttrAllow",
+	"alpha":                 "AttrAlpha",
+	"alt":                   "AttrAlt",
+	"aria-activedescendant": "AttrAriaActiveDescendant",
+	"aria-atomic":           "AttrAriaAtomic",
+	"aria-autocomplete":     "AttrAriaAutocomplete",
+	"aria-busy":             "AttrAriaBusy",
+	"aria-checked":          "AttrAriaChecked",
+	"aria-controls":         "AttrAriaControls",
+	"aria-current":          "AttrAriaCurrent",
+	"aria-describedby":      "AttrAriaDescribedBy",
+	"aria-disabled":         "AttrAriaDisabled",
+	"aria-errormessage":     "AttrAriaErrorMessage",
+	"aria-expanded":         "AttrAriaExpanded",
+	"aria-haspopup":         "AttrAriaHasPopup",
+	"aria-hidden":           "AttrAriaHidden",
+	"aria-invalid":          "AttrAriaInvalid",
+	"aria-keyshortcuts":     "AttrAriaKeyShortcuts",
+	"aria-label":            "AttrAriaLabel",
+	"aria-labelledby":       "AttrAriaLabelledBy",
+	"aria-level":            "AttrAriaLevel",
+	"aria-live":             "AttrAriaLive",
+	"aria-modal":            "AttrAriaModal",
+	"aria-multiselectable":  "AttrAriaMultiSelectable",
+	"aria-orientation":      "AttrAriaOrientation",
+	"aria-pressed":          "AttrAriaPressed",
+	"aria-readonly":         "AttrAriaReadOnly",
+	"aria-required":         "AttrAriaRequired",
+	"aria-selected":         "AttrAriaSelected",
+	"aria-sort":             "AttrAriaSort",
+	"aria-valuemax":         "AttrAriaValueMax",
+	"aria-valuemin":         "AttrAriaValueMin",
+	"aria-valuenow":         "AttrAriaValueNow",
+	"aria-valuetext":        "AttrAriaValueText",
+	"as":                    "AttrAs",
+	"async":                 "AttrAsync!",
+	"autocapitalize":        "AttrAutocapitalize",
+	"autocomplete":          "AttrAutocomplete",
+	"autofocus":             "AttrAutofocus!",
+	"autoplay":              "AttrAutoplay!",
+	"background":            "AttrBackground",
+	"bgcolor":               "AttrBGColor",
+	"border":                "AttrBorder",
+	"capture":               "AttrCapture",
+	"charset":               "AttrCharset",
+	"checked":               "AttrChecked!",
+	"cite":                  "AttrCite",
+	"class":                 "AttrClass",
+	"color":                 "AttrColor",
+	"colorspace":            "AttrColorSpace",
+	"cols":                  "AttrCols",
+	"colspan":               "AttrColSpan",
+	"content":               "AttrContent",
+	"contenteditable":       "AttrContentEditable",
+	"controls":              "AttrControls!",
+	"coords":                "AttrCoords",
+	"crossorigin":           "AttrCrossOrigin",
+	"csp":                   "AttrCsp",
+	"data":                  "AttrData",
+	"datetime":              "AttrDateTime",
+	"decoding":              "AttrDecoding",
+	"default":               "AttrDefault!",
+	"defer":                 "AttrDefer!",
+	"dir":                   "AttrDir",
+	"dirname":               "AttrDirName",
+	"disabled":              "AttrDisabled!",
+	"download":              "AttrDownload",
+	"draggable":             "AttrDraggable",
+	"enctype":               "AttrEncType",
+	"enterkeyhint":          "AttrEnterKeyHint",
+	"elementtiming":         "AttrElementTiming",
+	"for":                   "AttrFor",
+	"form":                  "AttrForm",
+	"formaction":            "AttrFormAction",
+	"formenctype":           "AttrFormEncType",
+	"formmethod":            "AttrFormMethod",
+	"formnovalidate":        "AttrFormNoValidate!",
+	"formtarget":            "AttrFormTarget",
+	"fetchpriority":         "AttrFetchPriority",
+	"headers":               "AttrHeaders",
+	"height":                "AttrHeight",
+	"hidden":                "AttrHidden!",
+	"high":                  "AttrHigh",
+	"href":                  "AttrHref",
+	"hreflang":              "AttrHrefLang",
+	"http-equiv":            "AttrHttpEquiv",
+	"id":                    "AttrID",
+	"integrity":             "AttrIntegrity",
+	"inputmode":             "AttrInputMode",
+	"ismap":                 "AttrIsMap!",
+	"itemprop":              "AttrItemProp",
+	"kind":                  "AttrKind",
+	"label":                 "AttrLabel",
+	"lang":                  "AttrLang",
+	"language":              "AttrLanguage",
+	"loading":               "AttrLoading",
+	"list":                  "AttrList",
+	"loop":                  "AttrLoop!",
+	"low":                   "AttrLow",
+	"max":                   "AttrMax",
+	"maxlength":             "AttrMaxLength",
+	"minlength":             "AttrMinLength",
+	"media":                 "AttrMedia",
+	"method":                "AttrMethod",
+	"min":                   "AttrMin",
+	"multiple":              "AttrMultiple!",
+	"muted":                 "AttrMuted!",
+	"name":                  "AttrName",
+	"nonce":                 "AttrNonce",
+	"novalidate":            "AttrNoValidate!",
+	"open":                  "AttrOpen!",
+	"optimum":               "AttrOptimum",
+	"pattern":               "AttrPattern",
+	"ping":                  "AttrPing",
+	"placeholder":           "AttrPlaceholder",
+	"playsinline":           "AttrPlaysInline!",
+	"poster":                "AttrPoster",
+	"popover":               "AttrPopover",
+	"popovertarget":         "AttrPopoverTarget",
+	"popovertargetaction":   "AttrPopoverTargetAction",
+	"preload":               "AttrPreload",
+	"readonly":              "AttrReadOnly!",
+	"referrerpolicy":        "AttrReferrerPolicy",
+	"rel":                   "AttrRel",
+	"required":              "AttrRequired!",
+	"reversed":              "AttrReversed!",
+	"role":                  "AttrRole",
+	"rows":                  "AttrRows",
+	"rowspan":               "AttrRowSpan",
+	"sandbox":               "AttrSandbox",
+	"scope":                 "AttrScope",
+	"selected":              "AttrSelected!",
+	"shape":                 "AttrShape",
+	"size":                  "AttrSize",
+	"sizes":                 "AttrSizes",
+	"slot":                  "AttrSlot",
+	"span":                  "AttrSpan",
+	"spellcheck":            "AttrSpellCheck",
+	"src":                   "AttrSrc",
+	"srcdoc":                "AttrSrcDoc",
+	"srclang":               "AttrSrcLang",
+	"srcset":                "AttrSrcSet",
+	"start":                 "AttrStart",
+	"step":                  "AttrStep",
+	"style":                 "AttrStyle",
+	"summary":               "AttrSummary",
+	"tabindex":              "AttrTabIndex",
+	"target":                "AttrTarget",
+	"title":                 "AttrTitle",
+	"translate":             "AttrTranslate",
+	"type":                  "AttrType",
+	"usemap":                "AttrUseMap",
+	"value":                 "AttrValue",
+	"width":                 "AttrWidth",
+	"wrap":                  "AttrWrap",
+}
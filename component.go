@@ -0,0 +1,111 @@
+package h
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Component is a reusable piece of UI configured by the fields of a struct,
+// its props. Components can be passed as children like any node.
+//
+// Props tagged `hyper:"required"` must be set (non-zero) for the component
+// to render, and components implementing [Validator] can check their props
+// further; see [ValidateProps].
+//
+// Example:
+//
+//	type Card struct {
+//		Title string `hyper:"required"`
+//		Body  HyperNode
+//	}
+//
+//	func (me Card) Node() HyperNode {
+//		return DIV(AttrClass("card"))(H2()(me.Title), me.Body)
+//	}
+//
+//	DIV()(Card{Title: "Welcome", Body: P()("Hello")})
+type Component interface {
+	Node() HyperNode
+}
+
+// Validator can be implemented by components to validate their props
+// beyond required ones, e.g. mutually exclusive or out-of-range values.
+type Validator interface {
+	Validate() error
+}
+
+// MissingPropsError is returned when required props of a component are not set.
+type MissingPropsError struct {
+	Component string
+	Props     []string
+}
+
+func (me MissingPropsError) Error() string {
+	return fmt.Sprintf("h: %s: missing required props: %s", me.Component, strings.Join(me.Props, ", "))
+}
+
+// ValidateProps checks that the required props of component are set, then
+// calls its Validate method if it implements [Validator].
+func ValidateProps(component Component) error {
+	value := reflect.ValueOf(component)
+	for value.Kind() == reflect.Pointer && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() == reflect.Struct {
+		var missing []string
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			if field.Tag.Get("hyper") == "required" && value.Field(i).IsZero() {
+				missing = append(missing, field.Name)
+			}
+		}
+		if len(missing) != 0 {
+			return MissingPropsError{Component: value.Type().String(), Props: missing}
+		}
+	}
+
+	if validator, ok := component.(Validator); ok {
+		return validator.Validate()
+	}
+	return nil
+}
+
+// RenderComponent returns the node of component, or a node failing to render
+// with the validation error when its props are invalid. Components passed as
+// children are rendered with it.
+func RenderComponent(component Component) HyperNode {
+	if err := ValidateProps(component); err != nil {
+		return errorNode{err: err}
+	}
+	return component.Node()
+}
+
+var components = struct {
+	sync.Mutex
+	types []reflect.Type
+}{}
+
+// RegisterComponent records the types of components, so test helpers such as
+// hypertest.CheckComponents can check them all. Register them from an init
+// function of the package defining them. Types already registered are
+// skipped.
+func RegisterComponent(prototypes ...Component) {
+	components.Lock()
+	defer components.Unlock()
+	for _, prototype := range prototypes {
+		if componentType := reflect.TypeOf(prototype); !slices.Contains(components.types, componentType) {
+			components.types = append(components.types, componentType)
+		}
+	}
+}
+
+// RegisteredComponents returns the types of the components registered with
+// [RegisterComponent].
+func RegisteredComponents() []reflect.Type {
+	components.Lock()
+	defer components.Unlock()
+	return append([]reflect.Type{}, components.types...)
+}
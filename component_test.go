@@ -0,0 +1,57 @@
+package h
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type testCard struct {
+	Title string `hyper:"required"`
+	Level int
+}
+
+func (me testCard) Node() HyperNode {
+	return DIV(AttrClass("card"))(H2()(me.Title))
+}
+
+func (me testCard) Validate() error {
+	if me.Level < 0 {
+		return errors.New("level must not be negative")
+	}
+	return nil
+}
+
+func TestComponent(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, SECTION()(testCard{Title: "Hello"})); err != nil {
+		t.Fatal(err)
+	}
+	if expected := `<section><div class="card"><h2>Hello</h2></div></section>`; buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+
+	var missing MissingPropsError
+	if err := Render(&buf, SECTION()(testCard{})); !errors.As(err, &missing) || missing.Props[0] != "Title" {
+		t.Errorf("expected a missing Title error, got %v", err)
+	}
+	if err := ValidateProps(testCard{Title: "x", Level: -1}); err == nil {
+		t.Error("expected Validate to be called")
+	}
+}
+
+func TestRegisterComponent(t *testing.T) {
+	RegisterComponent(testCard{}, testCard{Title: "x"})
+	RegisterComponent(testCard{})
+
+	count := 0
+	for _, componentType := range RegisteredComponents() {
+		if componentType == reflect.TypeOf(testCard{}) {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Errorf("expected testCard to be registered once, got %d", count)
+	}
+}
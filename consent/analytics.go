@@ -0,0 +1,57 @@
+package consent
+
+import (
+	"context"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+// IfAllowed returns node when the visitor accepted category, and an empty
+// node otherwise, for custom trackers and embeds.
+//
+// Example:
+//
+//	consent.IfAllowed(ctx, consent.Marketing, facebookPixel)
+func IfAllowed(ctx context.Context, category string, node h.HyperNode) h.HyperNode {
+	return h.If(FromContext(ctx).Allows(category), node)
+}
+
+func scriptAttrs(ctx context.Context, attrs ...h.Attribute) []h.Attribute {
+	if nonce := h.Nonce(ctx); nonce != "" {
+		attrs = append(attrs, h.AttrNonce(nonce))
+	}
+	return attrs
+}
+
+// Plausible returns the Plausible Analytics script for domain, when the
+// visitor accepted analytics.
+func Plausible(ctx context.Context, domain string) h.HyperNode {
+	return IfAllowed(ctx, Analytics, h.SCRIPT(scriptAttrs(ctx,
+		h.AttrDefer(true),
+		h.Attr("data-domain", domain),
+		h.AttrSrc("https://plausible.io/js/script.js"),
+	)...)())
+}
+
+// GA4 returns the Google Analytics 4 snippet for measurementID ("G-..."),
+// when the visitor accepted analytics.
+func GA4(ctx context.Context, measurementID string) h.HyperNode {
+	return IfAllowed(ctx, Analytics, h.Group(
+		h.SCRIPT(scriptAttrs(ctx, h.AttrAsync(true), h.AttrSrc("https://www.googletagmanager.com/gtag/js?id="+measurementID))...)(),
+		h.SCRIPT(scriptAttrs(ctx)...)(h.RawText(
+			"window.dataLayer=window.dataLayer||[];function gtag(){dataLayer.push(arguments)}"+
+				"gtag('js',new Date());gtag('config',"+jsString(measurementID)+")",
+		)),
+	))
+}
+
+// Umami returns the Umami script for websiteID, served from src (the
+// script URL of a self-hosted instance, or "https://cloud.umami.is/script.js"),
+// when the visitor accepted analytics.
+func Umami(ctx context.Context, websiteID, src string) h.HyperNode {
+	return IfAllowed(ctx, Analytics, h.SCRIPT(scriptAttrs(ctx,
+		h.AttrDefer(true),
+		h.AttrSrc(src),
+		h.Attr("data-website-id", websiteID),
+	)...)())
+}
@@ -0,0 +1,143 @@
+// Package consent implements a cookie consent banner and analytics snippets
+// that only load once the visitor agreed to them.
+//
+// The visitor's choice is kept in a cookie, read by [Middleware] into the
+// request context:
+//
+//	mux.Handle("/", consent.Middleware(app))
+//
+//	func layout(ctx context.Context, content h.HyperNode) h.HyperNode {
+//		return HTML()(
+//			HEAD()(consent.Plausible(ctx, "example.com")),
+//			BODY()(content, consent.Banner(ctx)),
+//		)
+//	}
+package consent
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+// CookieName is the name of the cookie holding the visitor's choice: the
+// accepted categories, comma-separated, or "none".
+var CookieName = "consent"
+
+// Categories of cookies and trackers the visitor can accept.
+const (
+	Analytics = "analytics"
+	Marketing = "marketing"
+)
+
+// Consent is the choice of a visitor.
+type Consent struct {
+	Decided  bool     // Whether the visitor made a choice; the banner is shown until then
+	Accepted []string // Accepted categories
+}
+
+// Allows reports whether the visitor accepted category.
+func (me Consent) Allows(category string) bool {
+	return slices.Contains(me.Accepted, category)
+}
+
+// FromRequest reads the choice of the visitor from the consent cookie of r.
+func FromRequest(r *http.Request) Consent {
+	cookie, err := r.Cookie(CookieName)
+	if err != nil || cookie.Value == "" {
+		return Consent{}
+	}
+	c := Consent{Decided: true}
+	for _, category := range strings.Split(cookie.Value, ",") {
+		if category != "" && category != "none" {
+			c.Accepted = append(c.Accepted, category)
+		}
+	}
+	return c
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying c.
+func NewContext(ctx context.Context, c Consent) context.Context {
+	return context.WithValue(ctx, contextKey{}, c)
+}
+
+// FromContext returns the choice carried by ctx; undecided when none.
+func FromContext(ctx context.Context) Consent {
+	c, _ := ctx.Value(contextKey{}).(Consent)
+	return c
+}
+
+// Middleware puts the choice of the visitor in the request context, where
+// [Banner], the analytics snippets and [FromContext] find it.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), FromRequest(r))))
+	})
+}
+
+// BannerParams configures [Banner]. All fields are optional.
+type BannerParams struct {
+	Message    any      // Defaults to a short notice about analytics cookies
+	PolicyURL  string   // Shows a link to the privacy policy when set
+	Categories []string // Categories accepted by "Accept"; defaults to [Analytics]
+	MaxAge     int      // Lifetime of the choice in days; defaults to 180
+	Attributes []h.Attribute
+}
+
+// Banner renders the consent banner, unless the visitor already made a
+// choice. Its buttons store the choice in the consent cookie and reload
+// the page, so the snippets render on the server with the new choice; the
+// script carries the CSP nonce of the context (see h.NonceKey).
+//
+// Example:
+//
+//	consent.Banner(ctx, consent.BannerParams{PolicyURL: "/privacy"})
+func Banner(ctx context.Context, params ...BannerParams) h.HyperNode {
+	if FromContext(ctx).Decided {
+		return h.Group()
+	}
+	var p BannerParams
+	if len(params) != 0 {
+		p = params[0]
+	}
+	message := p.Message
+	if message == nil {
+		message = "We use cookies to understand how our site is used. You can accept or decline them."
+	}
+	categories := h.IfElse(len(p.Categories) != 0, p.Categories, []string{Analytics})
+	maxAge := h.IfElse(p.MaxAge > 0, p.MaxAge, 180)
+
+	var scriptAttrs []h.Attribute
+	if nonce := h.Nonce(ctx); nonce != "" {
+		scriptAttrs = append(scriptAttrs, h.AttrNonce(nonce))
+	}
+
+	attrs := append([]h.Attribute{
+		h.AttrID("consent-banner"),
+		h.AttrClass("consent-banner"),
+		h.AttrRole("region"),
+		h.AttrAriaLabel("Cookie consent"),
+	}, p.Attributes...)
+	return h.DIV(attrs...)(
+		h.P()(message, h.If(p.PolicyURL != "", h.Group(" ", h.A(h.AttrHref(p.PolicyURL))("Privacy policy")))),
+		h.BUTTON(h.AttrType(h.TypeButton), h.Attr("data-consent", "none"))("Decline"),
+		h.BUTTON(h.AttrType(h.TypeButton), h.Attr("data-consent", strings.Join(categories, ",")))("Accept"),
+		h.SCRIPT(scriptAttrs...)(h.RawText(
+			`document.querySelectorAll("#consent-banner [data-consent]").forEach(function(b){b.addEventListener("click",function(){`+
+				`document.cookie=`+jsString(CookieName+"=")+`+b.dataset.consent+";path=/;max-age=`+strconv.Itoa(maxAge*86400)+`;samesite=lax";location.reload()})})`,
+		)),
+	)
+}
+
+// jsString returns s as a JavaScript string literal, safe inside <script>.
+func jsString(s string) string {
+	quoted, _ := json.Marshal(s)
+	return string(quoted)
+}
@@ -0,0 +1,88 @@
+package consent
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+func render(t *testing.T, node h.HyperNode) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := h.Render(&buf, node); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func contextWithCookie(value string) context.Context {
+	r := httptest.NewRequest("GET", "/", nil)
+	if value != "" {
+		r.AddCookie(&http.Cookie{Name: CookieName, Value: value})
+	}
+	var ctx context.Context
+	Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx = r.Context()
+	})).ServeHTTP(httptest.NewRecorder(), r)
+	return ctx
+}
+
+func TestBanner(t *testing.T) {
+	ctx := h.WithValue(contextWithCookie(""), h.NonceKey, "n0nce")
+	html := render(t, Banner(ctx, BannerParams{PolicyURL: "/privacy"}))
+	for _, expected := range []string{
+		`<div id="consent-banner" class="consent-banner" role="region" aria-label="Cookie consent">`,
+		`<a href="/privacy">Privacy policy</a>`,
+		`data-consent="none">Decline</button>`,
+		`data-consent="analytics">Accept</button>`,
+		`<script nonce="n0nce">`,
+		`"consent="+b.dataset.consent+";path=/;max-age=15552000;samesite=lax"`,
+	} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("expected %q in %q", expected, html)
+		}
+	}
+
+	if html := render(t, Banner(contextWithCookie("none"))); html != "" {
+		t.Errorf("expected no banner once decided, got %q", html)
+	}
+}
+
+func TestAnalytics(t *testing.T) {
+	tests := []struct {
+		cookie  string
+		allowed bool
+	}{
+		{cookie: "", allowed: false},
+		{cookie: "none", allowed: false},
+		{cookie: "marketing", allowed: false},
+		{cookie: "analytics,marketing", allowed: true},
+	}
+	for _, tt := range tests {
+		ctx := contextWithCookie(tt.cookie)
+		html := render(t, h.Group(
+			Plausible(ctx, "example.com"),
+			GA4(ctx, "G-TEST"),
+			Umami(ctx, "abc", "https://cloud.umami.is/script.js"),
+		))
+		if got := html != ""; got != tt.allowed {
+			t.Errorf("%q: expected allowed=%v, got %q", tt.cookie, tt.allowed, html)
+		}
+		if tt.allowed {
+			for _, expected := range []string{
+				`<script defer data-domain="example.com" src="https://plausible.io/js/script.js"></script>`,
+				`gtag('config',"G-TEST")`,
+				`data-website-id="abc"`,
+			} {
+				if !strings.Contains(html, expected) {
+					t.Errorf("expected %q in %q", expected, html)
+				}
+			}
+		}
+	}
+}
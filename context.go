@@ -0,0 +1,108 @@
+package h
+
+import (
+	"context"
+	"net/url"
+)
+
+// ContextKey is a typed key for request-scoped values, so deep components
+// can read request data (locale, nonce, current user...) from a
+// context.Context without prop drilling or type assertions. Keys are
+// compared by identity: create each one once with [NewContextKey].
+type ContextKey[T any] struct {
+	name string
+}
+
+// NewContextKey creates a key for values of type T. The name is only used
+// for debugging.
+//
+// Example:
+//
+//	var TenantKey = NewContextKey[*Tenant]("tenant")
+func NewContextKey[T any](name string) *ContextKey[T] {
+	return &ContextKey[T]{name: name}
+}
+
+func (me *ContextKey[T]) String() string {
+	return "h.ContextKey(" + me.name + ")"
+}
+
+// WithValue returns a copy of ctx carrying value for key.
+func WithValue[T any](ctx context.Context, key *ContextKey[T], value T) context.Context {
+	return context.WithValue(ctx, key, value)
+}
+
+// Value returns the value carried by ctx for key, and whether there is one.
+func Value[T any](ctx context.Context, key *ContextKey[T]) (T, bool) {
+	value, ok := ctx.Value(key).(T)
+	return value, ok
+}
+
+// Keys of the request-scoped values used across components. Set them in a
+// middleware, e.g.:
+//
+//	ctx := WithValue(r.Context(), LocaleKey, "fr")
+//	ctx = WithValue(ctx, NonceKey, nonce)
+//	ctx = WithValue(ctx, URLKey, r.URL)
+//	next.ServeHTTP(w, r.WithContext(ctx))
+var (
+	// LocaleKey holds the BCP 47 language tag of the request, e.g. "fr-CA".
+	LocaleKey = NewContextKey[string]("locale")
+	// NonceKey holds the Content-Security-Policy nonce of the response, for
+	// inline <script> and <style> elements (see [AttrNonce]).
+	NonceKey = NewContextKey[string]("nonce")
+	// ThemeKey holds the name of the theme of the request, e.g. "dark".
+	ThemeKey = NewContextKey[string]("theme")
+	// UserKey holds the current user, in the application's own type; read it
+	// with [CurrentUser].
+	UserKey = NewContextKey[any]("user")
+	// URLKey holds the URL of the current request, e.g. to highlight the
+	// current navigation link.
+	URLKey = NewContextKey[*url.URL]("url")
+	// CSRFKey holds the CSRF token of the request, added to forms by [FormTo].
+	CSRFKey = NewContextKey[string]("csrf")
+)
+
+// Locale returns the locale carried by ctx, or "" when there is none.
+func Locale(ctx context.Context) string {
+	locale, _ := Value(ctx, LocaleKey)
+	return locale
+}
+
+// Nonce returns the CSP nonce carried by ctx, or "" when there is none.
+func Nonce(ctx context.Context) string {
+	nonce, _ := Value(ctx, NonceKey)
+	return nonce
+}
+
+// Theme returns the theme carried by ctx, or "" when there is none.
+func Theme(ctx context.Context) string {
+	theme, _ := Value(ctx, ThemeKey)
+	return theme
+}
+
+// CSRFToken returns the CSRF token carried by ctx, or "" when there is none.
+func CSRFToken(ctx context.Context) string {
+	token, _ := Value(ctx, CSRFKey)
+	return token
+}
+
+// CurrentURL returns the request URL carried by ctx, or nil when there is none.
+func CurrentURL(ctx context.Context) *url.URL {
+	u, _ := Value(ctx, URLKey)
+	return u
+}
+
+// CurrentUser returns the current user carried by ctx, when there is one of
+// type T.
+//
+// Example:
+//
+//	if user, ok := CurrentUser[*User](ctx); ok {
+//		return SPAN()("Signed in as ", user.Name)
+//	}
+func CurrentUser[T any](ctx context.Context) (T, bool) {
+	value, _ := Value(ctx, UserKey)
+	user, ok := value.(T)
+	return user, ok
+}
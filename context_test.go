@@ -0,0 +1,41 @@
+package h
+
+import (
+	"context"
+	"net/url"
+	"testing"
+)
+
+func TestContextValues(t *testing.T) {
+	type user struct{ Name string }
+	tenantKey := NewContextKey[string]("tenant")
+	otherKey := NewContextKey[string]("tenant")
+
+	ctx := context.Background()
+	if Locale(ctx) != "" || Nonce(ctx) != "" || CurrentURL(ctx) != nil {
+		t.Error("expected empty values without a context value")
+	}
+
+	u, _ := url.Parse("/settings")
+	ctx = WithValue(ctx, LocaleKey, "fr")
+	ctx = WithValue(ctx, NonceKey, "abc")
+	ctx = WithValue(ctx, URLKey, u)
+	ctx = WithValue[any](ctx, UserKey, &user{Name: "Ada"})
+	ctx = WithValue(ctx, tenantKey, "acme")
+
+	if Locale(ctx) != "fr" || Nonce(ctx) != "abc" || CurrentURL(ctx) != u {
+		t.Error("unexpected request values")
+	}
+	if current, ok := CurrentUser[*user](ctx); !ok || current.Name != "Ada" {
+		t.Errorf("unexpected user %v", current)
+	}
+	if _, ok := CurrentUser[string](ctx); ok {
+		t.Error("expected no user of another type")
+	}
+	if tenant, ok := Value(ctx, tenantKey); !ok || tenant != "acme" {
+		t.Errorf("unexpected tenant %q", tenant)
+	}
+	if _, ok := Value(ctx, otherKey); ok {
+		t.Error("keys with the same name must be distinct")
+	}
+}
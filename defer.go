@@ -0,0 +1,249 @@
+package h
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// deferSwapScript moves a streamed region from its <template> into the place
+// of its placeholder. It is inlined after each region so it runs without
+// any client library.
+const deferSwapScript = `(function(){var t=document.getElementById(%q),p=document.getElementById(%q);if(t&&p)p.replaceWith(t.content);if(t)t.remove()})()`
+
+// deferQueue collects the regions deferred while building a streamed page.
+type deferQueue struct {
+	ctx     context.Context
+	mu      sync.Mutex
+	next    int
+	pending int
+	results chan deferResult
+}
+
+type deferResult struct {
+	id   string
+	node HyperNode
+	err  error
+}
+
+type deferQueueKey struct{}
+
+// DeferredNode is a region of a page loaded from a slow data source, created
+// with [Defer].
+type DeferredNode struct {
+	ctx      context.Context
+	id       string // Set when the page is streamed
+	fallback HyperNode
+	load     func(ctx context.Context) (HyperNode, error)
+}
+
+// Defer returns a region of the page whose content is produced by load, for
+// slow data sources that shouldn't hold back the rest of the page.
+//
+// In a page built by [Stream], load starts right away in its own goroutine
+// and the region renders as fallback (e.g. a spinner) until its content is
+// streamed in. Anywhere else, load runs when the region is rendered and its
+// content replaces the fallback in place, so components using Defer also
+// render normally.
+//
+// Example:
+//
+//	Defer(ctx, P()("Loading invoices…"), func(ctx context.Context) (HyperNode, error) {
+//		invoices, err := store.Invoices(ctx)
+//		if err != nil {
+//			return nil, err
+//		}
+//		return InvoiceTable(invoices), nil
+//	})
+func Defer(ctx context.Context, fallback HyperNode, load func(ctx context.Context) (HyperNode, error)) DeferredNode {
+	node := DeferredNode{ctx: ctx, fallback: fallback, load: load}
+	queue, _ := ctx.Value(deferQueueKey{}).(*deferQueue)
+	if queue == nil {
+		return node
+	}
+
+	queue.mu.Lock()
+	queue.next++
+	queue.pending++
+	node.id = fmt.Sprintf("h-defer-%d", queue.next)
+	queue.mu.Unlock()
+
+	go func() {
+		result := deferResult{id: node.id}
+		func() {
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					result.err = fmt.Errorf("h: deferred region panic: %v", recovered)
+				}
+			}()
+			result.node, result.err = load(queue.ctx)
+		}()
+		select {
+		case queue.results <- result:
+		case <-queue.ctx.Done():
+		}
+	}()
+	return node
+}
+
+func (me DeferredNode) Render(w io.Writer) error {
+	var buf bytes.Buffer
+	if err := me.RenderToBuffer(&buf); err != nil {
+		return err
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// RenderToBuffer implements [BufferRenderer].
+func (me DeferredNode) RenderToBuffer(buf *bytes.Buffer) error {
+	if me.id == "" {
+		node, err := me.load(me.ctx)
+		if err != nil {
+			return err
+		}
+		if node == nil {
+			return nil
+		}
+		return Element{Children: []HyperNode{node}}.render(buf)
+	}
+	buf.WriteString(`<h-defer id="`)
+	buf.WriteString(me.id)
+	buf.WriteString(`">`)
+	if me.fallback != nil {
+		if err := (Element{Children: []HyperNode{me.fallback}}).render(buf); err != nil {
+			return err
+		}
+	}
+	buf.WriteString("</h-defer>")
+	return nil
+}
+
+// Unwrap implements [Wrapper], returning the fallback: the content of the
+// region is only known once loaded.
+func (me DeferredNode) Unwrap() []HyperNode {
+	if me.fallback == nil {
+		return nil
+	}
+	return []HyperNode{me.fallback}
+}
+
+// mapNodes maps the fallback, and the content once loaded when the region
+// isn't streamed.
+func (me DeferredNode) mapNodes(fn func(HyperNode) HyperNode) HyperNode {
+	if me.fallback != nil {
+		me.fallback = fn(me.fallback)
+	}
+	load := me.load
+	me.load = func(ctx context.Context) (HyperNode, error) {
+		node, err := load(ctx)
+		if err != nil || node == nil {
+			return node, err
+		}
+		return fn(node), nil
+	}
+	return me
+}
+
+// Stream renders the page built by page progressively: the page is sent
+// first, with the regions created by [Defer] showing their fallback, then
+// each region is sent as soon as its content is ready, in a chunked
+// response that a small inline script swaps into place. Regions load
+// concurrently, and may themselves contain deferred regions.
+//
+// Chunked HTML works in every browser without htmx, unlike
+// multipart/x-mixed-replace, which browsers only honor for images. The
+// swap scripts carry the CSP nonce of the request context (see [NonceKey]).
+//
+// A region whose load fails keeps its fallback; Stream returns the errors
+// of all failed regions once the response is complete, as the status has
+// already been sent. It returns early with the context's error when the
+// client goes away.
+//
+// Example:
+//
+//	mux.HandleFunc("GET /dashboard", func(w http.ResponseWriter, r *http.Request) {
+//		err := Stream(w, r, func(ctx context.Context) HyperNode {
+//			return Layout(
+//				Header(),
+//				Defer(ctx, Spinner(), func(ctx context.Context) (HyperNode, error) {
+//					return SlowReport(ctx)
+//				}),
+//			)
+//		})
+//		if err != nil {
+//			slog.ErrorContext(r.Context(), "stream failed", "error", err)
+//		}
+//	})
+func Stream(w http.ResponseWriter, r *http.Request, page func(ctx context.Context) HyperNode) error {
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	queue := &deferQueue{results: make(chan deferResult)}
+	ctx = context.WithValue(ctx, deferQueueKey{}, queue)
+	queue.ctx = ctx
+
+	header := w.Header()
+	if header.Get("Content-Type") == "" {
+		header.Set("Content-Type", "text/html; charset=utf-8")
+	}
+	// Keep proxies such as nginx from buffering the response.
+	header.Set("X-Accel-Buffering", "no")
+
+	flusher := http.NewResponseController(w)
+	if err := page(ctx).Render(w); err != nil {
+		return err
+	}
+	flusher.Flush()
+
+	buf := getBuffer(mediumBufferSize)
+	defer putBuffer(buf)
+
+	var scriptAttrs []Attribute
+	if nonce := Nonce(ctx); nonce != "" {
+		scriptAttrs = append(scriptAttrs, AttrNonce(nonce))
+	}
+
+	var errs []error
+	for {
+		queue.mu.Lock()
+		pending := queue.pending
+		queue.mu.Unlock()
+		if pending == 0 {
+			break
+		}
+
+		var result deferResult
+		select {
+		case result = <-queue.results:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		queue.mu.Lock()
+		queue.pending--
+		queue.mu.Unlock()
+
+		if result.err != nil {
+			errs = append(errs, fmt.Errorf("h: deferred region %s: %w", result.id, result.err))
+			continue
+		}
+
+		buf.Reset()
+		err := Group(
+			TEMPLATE(AttrID(result.id+"-content"))(result.node),
+			SCRIPT(scriptAttrs...)(RawText(fmt.Sprintf(deferSwapScript, result.id+"-content", result.id))),
+		).Render(buf)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("h: deferred region %s: %w", result.id, err))
+			continue
+		}
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return err
+		}
+		flusher.Flush()
+	}
+	return errors.Join(errs...)
+}
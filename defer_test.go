@@ -0,0 +1,80 @@
+package h
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDeferWithoutStream(t *testing.T) {
+	node := DIV()(Defer(context.Background(), Text("loading"), func(context.Context) (HyperNode, error) {
+		return P()("loaded"), nil
+	}))
+	var buf bytes.Buffer
+	if err := Render(&buf, node); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "<div><p>loaded</p></div>"; buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
+
+func TestStream(t *testing.T) {
+	release := make(chan struct{})
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r = r.WithContext(WithValue(r.Context(), NonceKey, "abc"))
+	w := httptest.NewRecorder()
+
+	err := Stream(w, r, func(ctx context.Context) HyperNode {
+		return MAIN()(
+			H1()("Dashboard"),
+			Defer(ctx, Text("loading report"), func(ctx context.Context) (HyperNode, error) {
+				<-release
+				return SECTION()(
+					"report",
+					Defer(ctx, Text("loading chart"), func(context.Context) (HyperNode, error) {
+						return Text("chart"), nil
+					}),
+				), nil
+			}),
+			Defer(ctx, Text("loading stats"), func(context.Context) (HyperNode, error) {
+				defer close(release)
+				return Text("stats"), nil
+			}),
+			Defer(ctx, Text("unavailable"), func(context.Context) (HyperNode, error) {
+				return nil, errors.New("timeout")
+			}),
+		)
+	})
+	if err == nil || !strings.Contains(err.Error(), "h-defer-3: timeout") {
+		t.Errorf("expected the failed region's error, got %v", err)
+	}
+
+	body := w.Body.String()
+	shell := `<main><h1>Dashboard</h1><h-defer id="h-defer-1">loading report</h-defer><h-defer id="h-defer-2">loading stats</h-defer><h-defer id="h-defer-3">unavailable</h-defer></main>`
+	if !strings.HasPrefix(body, shell) {
+		t.Fatalf("expected the page with fallbacks first, got %q", body)
+	}
+
+	// Regions are streamed as they complete: stats unblocks the report,
+	// whose nested chart comes last.
+	stats := strings.Index(body, `<template id="h-defer-2-content">stats</template>`)
+	report := strings.Index(body, `<template id="h-defer-1-content"><section>report<h-defer id="h-defer-4">loading chart</h-defer></section></template>`)
+	chart := strings.Index(body, `<template id="h-defer-4-content">chart</template>`)
+	if stats < 0 || report < stats || chart < report {
+		t.Errorf("unexpected regions (stats %d, report %d, chart %d): %q", stats, report, chart, body)
+	}
+	if strings.Contains(body, "h-defer-3-content") {
+		t.Errorf("expected the failed region to keep its fallback, got %q", body)
+	}
+	if strings.Count(body, `<script nonce="abc">`) != 3 {
+		t.Errorf("expected a swap script with the nonce per region, got %q", body)
+	}
+	if !w.Flushed {
+		t.Error("expected the response to be flushed")
+	}
+}
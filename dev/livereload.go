@@ -0,0 +1,98 @@
+package dev
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+// ErrDisabled is returned by registry operations without the hyperdev build tag.
+var ErrDisabled = errors.New("dev: built without the hyperdev tag")
+
+// DefaultLiveReloadPath is where [LiveReloadScript] expects [LiveReload] by default.
+const DefaultLiveReloadPath = "/_hyper/livereload"
+
+// buildID identifies the running process. A page seeing a different id
+// after reconnecting knows the server was recompiled and restarted.
+var buildID = strconv.FormatInt(time.Now().UnixNano(), 36)
+
+var reload = struct {
+	sync.Mutex
+	version     int
+	subscribers map[chan int]struct{}
+}{subscribers: map[chan int]struct{}{}}
+
+// notifyReload asks the connected pages to reload.
+func notifyReload() {
+	reload.Lock()
+	defer reload.Unlock()
+	reload.version++
+	for subscriber := range reload.subscribers {
+		select {
+		case subscriber <- reload.version:
+		default:
+		}
+	}
+}
+
+// LiveReload returns the server-sent events endpoint used by
+// [LiveReloadScript]. It sends the build id on connect, so pages reload once
+// a recompiled server is back up, and again whenever a component is replaced.
+//
+// Example:
+//
+//	mux.Handle(dev.DefaultLiveReloadPath, dev.LiveReload())
+func LiveReload() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+
+		updates := make(chan int, 1)
+		reload.Lock()
+		reload.subscribers[updates] = struct{}{}
+		version := reload.version
+		reload.Unlock()
+		defer func() {
+			reload.Lock()
+			delete(reload.subscribers, updates)
+			reload.Unlock()
+		}()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		send := func(version int) {
+			fmt.Fprintf(w, "data: %s.%d\n\n", buildID, version)
+			flusher.Flush()
+		}
+		send(version)
+
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case version := <-updates:
+				send(version)
+			}
+		}
+	})
+}
+
+// LiveReloadScript returns the script connecting a page to the [LiveReload]
+// endpoint at path ([DefaultLiveReloadPath] when empty). Include it in the
+// layout of development builds only.
+func LiveReloadScript(path string) h.HyperNode {
+	if path == "" {
+		path = DefaultLiveReloadPath
+	}
+	quoted, _ := json.Marshal(path)
+	return h.SCRIPT()(h.RawText(`(function(){var seen;new EventSource(` + string(quoted) + `).onmessage=function(e){if(seen&&seen!==e.data){location.reload()}seen=e.data}})();`))
+}
@@ -0,0 +1,217 @@
+// Package dev provides development-time tooling. Nothing in it is meant to
+// be enabled in production.
+package dev
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+// QueryParam is the query parameter that opens the debug overlay on load,
+// e.g. /projects?_debug. The overlay can also be toggled with Ctrl+Shift+D.
+const QueryParam = "_debug"
+
+// cacheHits counts cache hits process-wide; see [RecordCacheHit].
+var cacheHits atomic.Int64
+
+// memoHook installs the hook counting the cache hits of [h.Memo] once.
+var memoHook sync.Once
+
+// RecordCacheHit counts a render cache hit, shown by the debug overlay.
+// The fragments served from cache by [h.Memo] are counted once a [Handler]
+// has been created; other caches call it when they serve a node from
+// cache. Hits are counted process wide, so concurrent requests may be
+// attributed to each other's overlays.
+func RecordCacheHit() {
+	cacheHits.Add(1)
+}
+
+// countMemoHits sets [h.OnMemoHit] to record cache hits, calling the hook
+// set before, if any.
+func countMemoHits() {
+	memoHook.Do(func() {
+		next := h.OnMemoHit
+		h.OnMemoHit = func(ctx context.Context, key string) {
+			RecordCacheHit()
+			if next != nil {
+				next(ctx, key)
+			}
+		}
+	})
+}
+
+// Component is an entry of the component tree, built from [h.NamedNode] wrappers.
+type Component struct {
+	Name     string
+	Children []Component
+}
+
+// Stats describes a render.
+type Stats struct {
+	RenderTime time.Duration
+	Nodes      int // Number of nodes in the tree
+	CacheHits  int64
+	Components []Component
+}
+
+// Inspect counts the nodes of node and collects its component tree. Timings
+// are left empty.
+func Inspect(node h.HyperNode) Stats {
+	var stats Stats
+	stats.Components = inspect(node, &stats.Nodes)
+	return stats
+}
+
+func inspect(node h.HyperNode, count *int) []Component {
+	switch n := node.(type) {
+	case h.NamedNode:
+		return []Component{{Name: n.Name, Children: inspect(n.Node, count)}}
+	case h.Wrapper:
+		var components []Component
+		for _, child := range n.Unwrap() {
+			components = append(components, inspect(child, count)...)
+		}
+		return components
+	case h.Element:
+		if n.Tag != "" {
+			*count++
+		}
+		var components []Component
+		for _, child := range n.Children {
+			components = append(components, inspect(child, count)...)
+		}
+		return components
+	case nil:
+		return nil
+	default:
+		*count++
+		return nil
+	}
+}
+
+// Handler is [h.Handler] with the debug overlay injected into every page.
+// Use it in place of h.Handler in development builds.
+//
+// Example:
+//
+//	handle := h.Handler
+//	if os.Getenv("APP_ENV") == "development" {
+//		handle = dev.Handler
+//	}
+//	mux.Handle("GET /", handle(homePage))
+func Handler(fn h.HandlerFunc, options ...h.HandlerOptions) http.Handler {
+	countMemoHits()
+	return h.Handler(func(r *http.Request) (h.HyperNode, error) {
+		node, err := fn(r)
+		if err != nil || node == nil {
+			return node, err
+		}
+		return Instrument(node, r.URL.Query().Has(QueryParam)), nil
+	}, options...)
+}
+
+// Instrument returns a node rendering node followed by the debug overlay,
+// injected before the closing </body> tag when there is one. The overlay
+// starts open when open is true.
+func Instrument(node h.HyperNode, open bool) h.HyperNode {
+	return instrumented{node: node, open: open}
+}
+
+type instrumented struct {
+	node h.HyperNode
+	open bool
+}
+
+func (me instrumented) Render(w io.Writer) error {
+	return me.RenderCtx(context.Background(), w)
+}
+
+// RenderCtx implements [h.NodeCtx], passing ctx on to the page so it
+// renders as it does without the overlay.
+func (me instrumented) RenderCtx(ctx context.Context, w io.Writer) error {
+	var page bytes.Buffer
+	hits := cacheHits.Load()
+	start := time.Now()
+	if err := h.RenderCtx(ctx, &page, me.node); err != nil {
+		return err
+	}
+	stats := Inspect(me.node)
+	stats.RenderTime = time.Since(start)
+	stats.CacheHits = cacheHits.Load() - hits
+
+	var overlay bytes.Buffer
+	if err := h.RenderCtx(ctx, &overlay, Overlay(stats, me.open)); err != nil {
+		return err
+	}
+
+	output := page.Bytes()
+	at := bytes.LastIndex(output, []byte("</body>"))
+	if at < 0 {
+		at = len(output)
+	}
+	for _, part := range [][]byte{output[:at], overlay.Bytes(), output[at:]} {
+		if _, err := w.Write(part); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Unwrap implements [h.Wrapper].
+func (me instrumented) Unwrap() []h.HyperNode {
+	return []h.HyperNode{me.node}
+}
+
+const overlayCSS = `#hyper-debug{position:fixed;right:12px;bottom:12px;z-index:2147483647;max-width:360px;max-height:60vh;overflow:auto;padding:12px;border-radius:8px;background:#111827;color:#f9fafb;font:12px/1.5 ui-monospace,monospace;box-shadow:0 4px 16px rgba(0,0,0,.3)}` +
+	`#hyper-debug dl{display:grid;grid-template-columns:auto 1fr;gap:0 12px;margin:0 0 8px}#hyper-debug dd{margin:0}` +
+	`#hyper-debug ul{margin:0;padding-left:14px}`
+
+const overlayScript = `document.addEventListener("keydown",function(e){if(e.ctrlKey&&e.shiftKey&&(e.key==="D"||e.key==="d")){var o=document.getElementById("hyper-debug");if(o){e.preventDefault();o.hidden=!o.hidden}}});`
+
+// Overlay renders the debug panel for stats. It is hidden unless open is
+// true, and toggled with Ctrl+Shift+D. Its inline style and script carry
+// the nonce of the render context (see [h.Nonce]), so the policy of
+// [h.SecurityHeaders] allows them when it is rendered with [h.RenderCtx].
+func Overlay(stats Stats, open bool) h.HyperNode {
+	return h.Group(
+		h.DIV(h.AttrID("hyper-debug"), h.AttrHidden(!open), h.AttrRole("complementary"), h.AttrAriaLabel("Debug overlay"))(
+			h.CtxFunc(func(ctx context.Context) h.HyperNode {
+				return h.STYLE(nonceAttrs(ctx)...)(h.RawText(overlayCSS))
+			}),
+			h.DL()(
+				h.DT()("Render time"), h.DD()(stats.RenderTime.Round(time.Microsecond).String()),
+				h.DT()("Nodes"), h.DD()(strconv.Itoa(stats.Nodes)),
+				h.DT()("Cache hits"), h.DD()(strconv.FormatInt(stats.CacheHits, 10)),
+			),
+			h.If(len(stats.Components) != 0, h.DETAILS(h.AttrOpen(true))(
+				h.SUMMARY()("Components"),
+				componentTree(stats.Components),
+			)),
+		),
+		h.CtxFunc(func(ctx context.Context) h.HyperNode {
+			return h.SCRIPT(nonceAttrs(ctx)...)(h.RawText(overlayScript))
+		}),
+	)
+}
+
+// nonceAttrs returns the nonce attribute of the request of ctx, if any.
+func nonceAttrs(ctx context.Context) []h.Attribute {
+	if nonce := h.Nonce(ctx); nonce != "" {
+		return []h.Attribute{h.AttrNonce(nonce)}
+	}
+	return nil
+}
+
+func componentTree(components []Component) h.HyperNode {
+	return h.UL()(h.Range(components, func(c Component) h.HyperNode {
+		return h.LI()(c.Name, h.If(len(c.Children) != 0, componentTree(c.Children)))
+	}))
+}
@@ -0,0 +1,117 @@
+package dev
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+func TestInspect(t *testing.T) {
+	card := func(name string) h.HyperNode {
+		return h.Named("Card", h.DIV()(h.Named("Avatar", h.IMG()), name))
+	}
+	page := h.Named("Page", h.BODY()(card("a"), card("b")))
+
+	stats := Inspect(page)
+	// body + 2 * (div + img + text)
+	if stats.Nodes != 7 {
+		t.Errorf("expected 7 nodes, got %d", stats.Nodes)
+	}
+	expected := []Component{{Name: "Page", Children: []Component{
+		{Name: "Card", Children: []Component{{Name: "Avatar"}}},
+		{Name: "Card", Children: []Component{{Name: "Avatar"}}},
+	}}}
+	if !equalComponents(stats.Components, expected) {
+		t.Errorf("expected components %+v, got %+v", expected, stats.Components)
+	}
+}
+
+func equalComponents(a, b []Component) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Name != b[i].Name || !equalComponents(a[i].Children, b[i].Children) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestInstrument(t *testing.T) {
+	page := h.HTML()(h.BODY()(h.Named("Hello", h.P()("hello"))))
+
+	var buf bytes.Buffer
+	if err := Instrument(page, false).Render(&buf); err != nil {
+		t.Fatal(err)
+	}
+	output := buf.String()
+	if !strings.Contains(output, `<p>hello</p><div id="hyper-debug" hidden`) || !strings.HasSuffix(output, "</script></body></html>") {
+		t.Errorf("overlay not injected before </body>: %q", output)
+	}
+	if !strings.Contains(output, "<li>Hello</li>") {
+		t.Errorf("component tree missing: %q", output)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	handler := Handler(func(*http.Request) (h.HyperNode, error) { return h.P()("hi"), nil })
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/?_debug", nil))
+	if body := w.Body.String(); !strings.HasPrefix(body, `<p>hi</p><div id="hyper-debug" role=`) {
+		t.Errorf("expected an open overlay, got %q", body)
+	}
+}
+
+func TestHandlerRenderContext(t *testing.T) {
+	// The page renders with the request context under the overlay, as it
+	// does without it.
+	handler := Handler(func(*http.Request) (h.HyperNode, error) {
+		return h.BODY()(h.CtxFunc(func(ctx context.Context) h.HyperNode {
+			return h.P()(h.Locale(ctx))
+		})), nil
+	})
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/", nil)
+	handler.ServeHTTP(w, r.WithContext(h.WithValue(r.Context(), h.LocaleKey, "fr")))
+	if body := w.Body.String(); !strings.HasPrefix(body, "<body><p>fr</p>") {
+		t.Errorf("expected the page rendered with the request context, got %q", body)
+	}
+}
+
+func TestHandlerCacheHitsAndNonce(t *testing.T) {
+	defer h.PurgeMemo()
+	// The hook set by the application is kept.
+	defer func(hook func(context.Context, string)) { h.OnMemoHit, memoHook = hook, sync.Once{} }(h.OnMemoHit)
+	memoHook = sync.Once{}
+	appHits := 0
+	h.OnMemoHit = func(context.Context, string) { appHits++ }
+
+	handler := Handler(func(r *http.Request) (h.HyperNode, error) {
+		return h.BODY()(h.Memo(r.Context(), "dev-overlay-hits", func() h.HyperNode { return h.P()("cached") })), nil
+	}, h.HandlerOptions{SecurityHeaders: &h.SecurityHeaders{}})
+
+	var body string
+	for range 2 {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+		body = w.Body.String()
+	}
+	if !strings.Contains(body, "<dt>Cache hits</dt><dd>1</dd>") {
+		t.Errorf("expected the cache hit of the second render, got %q", body)
+	}
+	if appHits != 1 {
+		t.Errorf("expected the hook of the application to be called once, got %d", appHits)
+	}
+	if n := strings.Count(body, ` nonce="`); n != 2 {
+		t.Errorf("expected the style and the script of the overlay to carry the nonce, got %q", body)
+	}
+}
@@ -0,0 +1,38 @@
+//go:build !hyperdev
+
+package dev
+
+import h "github.com/assaidy/hyper/v2"
+
+// Enabled reports whether the package was built with the hyperdev build tag.
+const Enabled = false
+
+// Register registers fn as the component called name and returns the
+// function to call it with. Without the hyperdev build tag it returns fn
+// itself, so registration costs nothing in production builds.
+//
+// With the tag, the returned function looks the component up by name on
+// every call and wraps its output in [h.Named], so [Replace] takes effect on
+// the next render and the debug overlay shows the component tree.
+//
+// Example:
+//
+//	var UserCard = dev.Register("UserCard", func(user User) h.HyperNode {
+//		return DIV(AttrClass("card"))(user.Name)
+//	})
+func Register[P any](name string, fn func(P) h.HyperNode) func(P) h.HyperNode {
+	return fn
+}
+
+// Replace swaps the implementation of a registered component and asks the
+// pages connected to [LiveReload] to reload. Without the hyperdev build tag
+// it does nothing and returns [ErrDisabled].
+func Replace[P any](name string, fn func(P) h.HyperNode) error {
+	return ErrDisabled
+}
+
+// Components returns the names of the registered components. It is empty
+// without the hyperdev build tag.
+func Components() []string {
+	return nil
+}
@@ -0,0 +1,67 @@
+//go:build hyperdev
+
+package dev
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+const Enabled = true
+
+var registry = struct {
+	sync.RWMutex
+	components map[string]any
+}{components: map[string]any{}}
+
+func Register[P any](name string, fn func(P) h.HyperNode) func(P) h.HyperNode {
+	registry.Lock()
+	if _, ok := registry.components[name]; ok {
+		registry.Unlock()
+		panic("dev: component " + name + " registered twice")
+	}
+	registry.components[name] = fn
+	registry.Unlock()
+
+	return func(props P) h.HyperNode {
+		return h.Named(name, lookup[P](name)(props))
+	}
+}
+
+func Replace[P any](name string, fn func(P) h.HyperNode) error {
+	registry.Lock()
+	existing, ok := registry.components[name]
+	if !ok {
+		registry.Unlock()
+		return fmt.Errorf("dev: unknown component %q", name)
+	}
+	if _, ok := existing.(func(P) h.HyperNode); !ok {
+		registry.Unlock()
+		return fmt.Errorf("dev: component %q registered with a different signature", name)
+	}
+	registry.components[name] = fn
+	registry.Unlock()
+
+	notifyReload()
+	return nil
+}
+
+func Components() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+	names := make([]string, 0, len(registry.components))
+	for name := range registry.components {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func lookup[P any](name string) func(P) h.HyperNode {
+	registry.RLock()
+	defer registry.RUnlock()
+	return registry.components[name].(func(P) h.HyperNode)
+}
@@ -0,0 +1,52 @@
+//go:build hyperdev
+
+package dev
+
+import (
+	"bytes"
+	"testing"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+func TestRegistry(t *testing.T) {
+	greeting := Register("Greeting", func(name string) h.HyperNode {
+		return h.P()("Hello, ", name)
+	})
+
+	render := func() string {
+		var buf bytes.Buffer
+		h.Render(&buf, greeting("Ada"))
+		return buf.String()
+	}
+	if output := render(); output != "<p>Hello, Ada</p>" {
+		t.Errorf("unexpected output %q", output)
+	}
+
+	updates := make(chan int, 1)
+	reload.Lock()
+	reload.subscribers[updates] = struct{}{}
+	reload.Unlock()
+
+	if err := Replace("Greeting", func(name string) h.HyperNode { return h.P()("Hi, ", name) }); err != nil {
+		t.Fatal(err)
+	}
+	if output := render(); output != "<p>Hi, Ada</p>" {
+		t.Errorf("replacement not used: %q", output)
+	}
+	select {
+	case <-updates:
+	default:
+		t.Error("expected a reload notification")
+	}
+
+	if err := Replace("Greeting", func(n int) h.HyperNode { return nil }); err == nil {
+		t.Error("expected an error for a different signature")
+	}
+	if err := Replace("Missing", func(string) h.HyperNode { return nil }); err == nil {
+		t.Error("expected an error for an unknown component")
+	}
+	if _, ok := greeting("x").(h.NamedNode); !ok {
+		t.Error("expected registered components to be wrapped in h.Named")
+	}
+}
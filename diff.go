@@ -0,0 +1,280 @@
+package h
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"strings"
+)
+
+// ErrNoDiffTarget is returned by [Diff] when content changed outside of any
+// element with an id, so there is no element for an update to target.
+var ErrNoDiffTarget = errors.New("h: changed content has no element with an id to target")
+
+// Diff compares two versions of a tree and returns the elements to send to
+// the browser to turn the old version into the new one, as htmx
+// out-of-band swaps (hx-swap-oob="true"). Each returned element is the
+// smallest element with an id enclosing a change, so live views can push
+// minimal updates over SSE or WebSocket instead of whole sections. No
+// elements are returned when both versions are the same. Each node is
+// rendered or hashed once, so the cost is linear in the size of the trees.
+//
+// Children are matched by position, so an element inserted in a list shifts
+// all the following ones and the whole list is replaced. Wrap list items in
+// [Key] (and give them ids) to match them by key instead: removed items are
+// then deleted, inserted ones placed after their previous sibling, and only
+// changed ones replaced. Reordering a keyed list still replaces it whole.
+//
+// Example:
+//
+//	fragments, err := Diff(previous, current)
+//	if err != nil {
+//		return err
+//	}
+//	for _, fragment := range fragments {
+//		fragment.Render(sse)
+//	}
+func Diff(old, new HyperNode) ([]Element, error) {
+	var d differ
+	oldSum, err := d.sum(old)
+	if err != nil {
+		return nil, err
+	}
+	newSum, err := d.sum(new)
+	if err != nil {
+		return nil, err
+	}
+	fragments, dirty := d.diff(old, new, oldSum, newSum)
+	if dirty {
+		return nil, ErrNoDiffTarget
+	}
+	return fragments, nil
+}
+
+type differ struct {
+	buf bytes.Buffer
+}
+
+// nodeSum is the hash of a node, computed once per node of a tree so that
+// comparing subtrees doesn't render them again at each level. Elements are
+// hashed from their tag, attributes and children, mirroring how they are
+// matched by [differ.diff]; other nodes from their output.
+type nodeSum struct {
+	sum      [sha256.Size]byte
+	attrs    [sha256.Size]byte // Hash of the rendered attributes of elements
+	children []*nodeSum        // Sums of the flattened children of elements
+}
+
+// sum hashes node and its subtree.
+func (me *differ) sum(node HyperNode) (*nodeSum, error) {
+	node = unwrap(node)
+	element, ok := node.(Element)
+	if !ok {
+		me.buf.Reset()
+		if err := Render(&me.buf, node); err != nil {
+			return nil, err
+		}
+		return &nodeSum{sum: sha256.Sum256(me.buf.Bytes())}, nil
+	}
+
+	me.buf.Reset()
+	if err := Render(&me.buf, Element{Tag: "x", Attributes: element.Attributes}); err != nil {
+		return nil, err
+	}
+	sum := &nodeSum{attrs: sha256.Sum256(me.buf.Bytes())}
+	digest := sha256.New()
+	digest.Write([]byte(element.Tag + "\x00"))
+	if element.IsVoid {
+		digest.Write([]byte{1})
+	}
+	digest.Write(sum.attrs[:])
+	for _, child := range flattenGroups(element.Children) {
+		childSum, err := me.sum(child)
+		if err != nil {
+			return nil, err
+		}
+		sum.children = append(sum.children, childSum)
+		digest.Write(childSum.sum[:])
+	}
+	digest.Sum(sum.sum[:0])
+	return sum, nil
+}
+
+// diff returns the fragments updating old into new, whose hashes are
+// oldSum and newSum, and whether the change could not be targeted at this
+// level and must be handled by an ancestor.
+func (me *differ) diff(old, new HyperNode, oldSum, newSum *nodeSum) ([]Element, bool) {
+	if oldSum.sum == newSum.sum {
+		return nil, false
+	}
+	old, new = unwrap(old), unwrap(new)
+
+	oldElement, ok1 := old.(Element)
+	newElement, ok2 := new.(Element)
+	if !ok1 || !ok2 || oldElement.Tag == "" || newElement.Tag == "" {
+		return nil, true
+	}
+
+	oldID, _ := oldElement.Attribute("id")
+	newID, _ := newElement.Attribute("id")
+	targetable := newID != "" && oldID == newID
+
+	if oldElement.Tag != newElement.Tag || oldSum.attrs != newSum.attrs {
+		return me.target(newElement, targetable)
+	}
+
+	oldChildren, newChildren := flattenGroups(oldElement.Children), flattenGroups(newElement.Children)
+	var fragments []Element
+	var dirty bool
+	if isKeyed(oldChildren) && isKeyed(newChildren) {
+		fragments, dirty = me.diffKeyed(newID, oldChildren, newChildren, oldSum.children, newSum.children)
+	} else {
+		fragments, dirty = me.diffPositional(oldChildren, newChildren, oldSum.children, newSum.children)
+	}
+	if dirty {
+		return me.target(newElement, targetable)
+	}
+	return fragments, false
+}
+
+// diffPositional diffs children matched by position.
+func (me *differ) diffPositional(oldChildren, newChildren []HyperNode, oldSums, newSums []*nodeSum) ([]Element, bool) {
+	if len(oldChildren) != len(newChildren) {
+		return nil, true
+	}
+	var fragments []Element
+	for i := range newChildren {
+		childFragments, dirty := me.diff(oldChildren[i], newChildren[i], oldSums[i], newSums[i])
+		if dirty {
+			return nil, true
+		}
+		fragments = append(fragments, childFragments...)
+	}
+	return fragments, false
+}
+
+// diffKeyed diffs children matched by key. Removed children are deleted and
+// inserted ones are placed after their previous sibling (or at the start of
+// the parent), which requires them to have ids. Reordered children are left
+// to the parent to replace.
+func (me *differ) diffKeyed(parentID string, oldChildren, newChildren []HyperNode, oldSums, newSums []*nodeSum) ([]Element, bool) {
+	oldIndexes := make(map[string]int, len(oldChildren))
+	for i, child := range oldChildren {
+		key := child.(KeyedNode).Key
+		if _, ok := oldIndexes[key]; ok {
+			return nil, true
+		}
+		oldIndexes[key] = i
+	}
+	newKeys := make(map[string]bool, len(newChildren))
+	lastIndex := -1
+	for _, child := range newChildren {
+		key := child.(KeyedNode).Key
+		if newKeys[key] {
+			return nil, true
+		}
+		newKeys[key] = true
+		if i, ok := oldIndexes[key]; ok {
+			if i < lastIndex {
+				return nil, true // reordered
+			}
+			lastIndex = i
+		}
+	}
+
+	var fragments []Element
+	for _, child := range oldChildren {
+		if newKeys[child.(KeyedNode).Key] {
+			continue
+		}
+		element, id := elementID(child)
+		if id == "" {
+			return nil, true
+		}
+		fragments = append(fragments, Element{Tag: element.Tag, Attributes: []Attribute{AttrID(id), Attr("hx-swap-oob", "delete")}})
+	}
+
+	previousID := ""
+	for i, child := range newChildren {
+		_, id := elementID(child)
+		if oldIndex, ok := oldIndexes[child.(KeyedNode).Key]; ok {
+			childFragments, dirty := me.diff(oldChildren[oldIndex], child, oldSums[oldIndex], newSums[i])
+			if dirty {
+				return nil, true
+			}
+			fragments = append(fragments, childFragments...)
+		} else {
+			swap := "afterend:#" + previousID
+			if i == 0 {
+				swap = "afterbegin:#" + parentID
+			}
+			if id == "" || strings.HasSuffix(swap, "#") {
+				return nil, true
+			}
+			fragments = append(fragments, Element{
+				Tag:        "template",
+				Attributes: []Attribute{Attr("hx-swap-oob", swap)},
+				Children:   []HyperNode{child},
+			})
+		}
+		previousID = id
+	}
+	return fragments, false
+}
+
+// target replaces element as a whole when it can be targeted, and otherwise
+// leaves the change to an ancestor.
+func (me *differ) target(element Element, targetable bool) ([]Element, bool) {
+	if !targetable {
+		return nil, true
+	}
+	element.Attributes = append(append([]Attribute{}, element.Attributes...), Attr("hx-swap-oob", "true"))
+	return []Element{element}, false
+}
+
+// unwrap returns the node wrapped by [NamedNode] and [KeyedNode] wrappers.
+func unwrap(node HyperNode) HyperNode {
+	for {
+		switch n := node.(type) {
+		case NamedNode:
+			node = n.Node
+		case KeyedNode:
+			node = n.Node
+		default:
+			return node
+		}
+	}
+}
+
+// flattenGroups replaces groups (elements without a tag) by their children.
+func flattenGroups(children []HyperNode) []HyperNode {
+	var flattened []HyperNode
+	for _, child := range children {
+		if group, ok := child.(Element); ok && group.Tag == "" {
+			flattened = append(flattened, flattenGroups(group.Children)...)
+			continue
+		}
+		flattened = append(flattened, child)
+	}
+	return flattened
+}
+
+// isKeyed reports whether all children are keyed.
+func isKeyed(children []HyperNode) bool {
+	for _, child := range children {
+		if _, ok := child.(KeyedNode); !ok {
+			return false
+		}
+	}
+	return len(children) != 0
+}
+
+// elementID returns the element wrapped by node and its id, if any.
+func elementID(node HyperNode) (Element, string) {
+	element, ok := unwrap(node).(Element)
+	if !ok {
+		return Element{}, ""
+	}
+	id, _ := element.Attribute("id")
+	return element, id
+}
@@ -0,0 +1,105 @@
+package h
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	page := func(count, status string, items ...string) HyperNode {
+		return MAIN()(
+			H1()("Dashboard"),
+			DIV(AttrID("stats"))(SPAN(AttrClass("count"))(count), " visitors"),
+			P(AttrID("status"))(status),
+			UL(AttrID("items"))(Range(items, func(item string) HyperNode { return LI()(item) })),
+		)
+	}
+
+	tests := []struct {
+		name     string
+		old, new HyperNode
+		expected string
+		err      error
+	}{
+		{
+			name: "Unchanged",
+			old:  page("1", "ok", "a"),
+			new:  page("1", "ok", "a"),
+		},
+		{
+			name:     "Nested change targets the nearest id",
+			old:      page("1", "ok", "a"),
+			new:      page("2", "ok", "a"),
+			expected: `<div id="stats" hx-swap-oob="true"><span class="count">2</span> visitors</div>`,
+		},
+		{
+			name:     "Several changes",
+			old:      page("1", "ok", "a"),
+			new:      page("2", "down", "a"),
+			expected: `<div id="stats" hx-swap-oob="true"><span class="count">2</span> visitors</div><p id="status" hx-swap-oob="true">down</p>`,
+		},
+		{
+			name:     "List change",
+			old:      page("1", "ok", "a"),
+			new:      page("1", "ok", "a", "b"),
+			expected: `<ul id="items" hx-swap-oob="true"><li>a</li><li>b</li></ul>`,
+		},
+		{
+			name: "Untargetable change",
+			old:  page("1", "ok", "a"),
+			new:  MAIN()(H1()("Other")),
+			err:  ErrNoDiffTarget,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fragments, err := Diff(tt.old, tt.new)
+			if err != tt.err {
+				t.Fatalf("expected error %v, got %v", tt.err, err)
+			}
+			var buf bytes.Buffer
+			for _, fragment := range fragments {
+				fragment.Render(&buf)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, buf.String())
+			}
+		})
+	}
+}
+
+// countedNode counts its renders.
+type countedNode struct {
+	text    string
+	renders *int
+}
+
+func (me countedNode) Render(w io.Writer) error {
+	*me.renders++
+	_, err := io.WriteString(w, me.text)
+	return err
+}
+
+func TestDiffRendersNodesOnce(t *testing.T) {
+	renders := 0
+	tree := func(text string) HyperNode {
+		var node HyperNode = countedNode{text: text, renders: &renders}
+		for range 10 {
+			node = DIV()(node)
+		}
+		return DIV(AttrID("root"))(node)
+	}
+
+	fragments, err := Diff(tree("a"), tree("b"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fragments) != 1 {
+		t.Errorf("expected the root to be replaced, got %v", fragments)
+	}
+	if renders != 2 {
+		t.Errorf("expected the leaf of each tree to render once, got %d renders", renders)
+	}
+}
@@ -0,0 +1,123 @@
+package h
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Pair is a term and its description, rendered as a <dt>/<dd> pair by
+// [DlFrom]. Both accept what element builders accept: strings, nodes...
+type Pair struct {
+	Term        any
+	Description any
+}
+
+// DlFrom creates a <dl> element listing pairs, in order.
+//
+// Example:
+//
+//	DlFrom([]Pair{
+//		{"Name", user.Name},
+//		{"Email", A(AttrHref("mailto:" + user.Email))(user.Email)},
+//	}, AttrClass("details"))
+func DlFrom(pairs []Pair, attrs ...Attribute) Element {
+	children := make([]any, 0, 2*len(pairs))
+	for _, pair := range pairs {
+		children = append(children, DT()(pair.Term), DD()(pair.Description))
+	}
+	return DL(attrs...)(children...)
+}
+
+// DlFromMap creates a <dl> element listing the entries of m, sorted by key.
+func DlFromMap[V any](m map[string]V, attrs ...Attribute) Element {
+	pairs := make([]Pair, 0, len(m))
+	for _, key := range slices.Sorted(maps.Keys(m)) {
+		pairs = append(pairs, Pair{Term: key, Description: m[key]})
+	}
+	return DlFrom(pairs, attrs...)
+}
+
+// DetailsFrom returns the exported fields of the struct v (or pointer to
+// struct) as pairs for [DlFrom], for object detail pages. The `detail`
+// struct tag sets the term of a field, or hides it with "-"; by default
+// the term is the field name in words ("CreatedAt" reads "Created at").
+// Times are formatted as dates and times, and nil values as "—".
+//
+// Example:
+//
+//	type Order struct {
+//		ID        int       `detail:"Order #"`
+//		CreatedAt time.Time
+//		Secret    string    `detail:"-"`
+//	}
+//
+//	DlFrom(DetailsFrom(order))
+func DetailsFrom(v any) []Pair {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var pairs []Pair
+	for i := range value.NumField() {
+		field := value.Type().Field(i)
+		term := field.Tag.Get("detail")
+		if !field.IsExported() || term == "-" {
+			continue
+		}
+		if term == "" {
+			term = fieldWords(field.Name)
+		}
+		pairs = append(pairs, Pair{Term: term, Description: detailValue(value.Field(i))})
+	}
+	return pairs
+}
+
+func detailValue(value reflect.Value) any {
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice:
+		if value.IsNil() {
+			return "—"
+		}
+	}
+	switch v := value.Interface().(type) {
+	case time.Time:
+		if v.IsZero() {
+			return "—"
+		}
+		return TIME(AttrDateTime(v.Format(time.RFC3339)))(v.Format("2006-01-02 15:04"))
+	case *time.Time:
+		return detailValue(reflect.ValueOf(*v))
+	default:
+		return v
+	}
+}
+
+// fieldWords splits a Go field name into words: "CreatedAt" becomes
+// "Created at", and acronyms are kept, "UserID" becoming "User ID".
+func fieldWords(name string) string {
+	runes := []rune(name)
+	var words strings.Builder
+	for i, r := range runes {
+		startsWord := i != 0 && unicode.IsUpper(r) &&
+			(unicode.IsLower(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1]))
+		if startsWord {
+			words.WriteByte(' ')
+		}
+		if startsWord && !(i+1 < len(runes) && unicode.IsUpper(runes[i+1])) {
+			r = unicode.ToLower(r)
+		}
+		words.WriteRune(r)
+	}
+	return words.String()
+}
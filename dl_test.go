@@ -0,0 +1,74 @@
+package h
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestDlFrom(t *testing.T) {
+	tests := []struct {
+		node     HyperNode
+		expected string
+	}{
+		{
+			node:     DlFrom([]Pair{{"Name", "Ada"}, {"Site", A(AttrHref("/ada"))("ada")}}, AttrClass("details")),
+			expected: `<dl class="details"><dt>Name</dt><dd>Ada</dd><dt>Site</dt><dd><a href="/ada">ada</a></dd></dl>`,
+		},
+		{
+			node:     DlFromMap(map[string]int{"b": 2, "a": 1}),
+			expected: `<dl><dt>a</dt><dd>1</dd><dt>b</dt><dd>2</dd></dl>`,
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := Render(&buf, tt.node); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != tt.expected {
+			t.Errorf("expected %q, got %q", tt.expected, buf.String())
+		}
+	}
+}
+
+func TestDetailsFrom(t *testing.T) {
+	type order struct {
+		ID        int `detail:"Order #"`
+		UserID    int
+		CreatedAt time.Time
+		ShippedAt *time.Time
+		Notes     []string
+		Secret    string `detail:"-"`
+		internal  string
+	}
+	created := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+
+	var buf bytes.Buffer
+	if err := Render(&buf, DlFrom(DetailsFrom(&order{ID: 7, UserID: 3, CreatedAt: created, Secret: "x", internal: "y"}))); err != nil {
+		t.Fatal(err)
+	}
+	expected := `<dl><dt>Order #</dt><dd>7</dd><dt>User ID</dt><dd>3</dd>` +
+		`<dt>Created at</dt><dd><time datetime="2024-03-01T09:30:00Z">2024-03-01 09:30</time></dd>` +
+		`<dt>Shipped at</dt><dd>—</dd><dt>Notes</dt><dd>—</dd></dl>`
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+
+	if pairs := DetailsFrom(42); pairs != nil {
+		t.Errorf("expected no pairs for a non-struct, got %v", pairs)
+	}
+}
+
+func TestFieldWords(t *testing.T) {
+	for name, expected := range map[string]string{
+		"Name":       "Name",
+		"CreatedAt":  "Created at",
+		"UserID":     "User ID",
+		"HTTPServer": "HTTP server",
+		"ID":         "ID",
+	} {
+		if got := fieldWords(name); got != expected {
+			t.Errorf("fieldWords(%q) = %q, want %q", name, got, expected)
+		}
+	}
+}
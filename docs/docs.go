@@ -0,0 +1,231 @@
+// Package docs is a kit for documentation sites: a sidebar tree and
+// previous/next links built from a registry of pages, and an on-page table
+// of contents generated from the headings of the content.
+//
+// Pages are registered once, in reading order:
+//
+//	var site = docs.NewSite(
+//		docs.Page{Title: "Introduction", URL: "/docs"},
+//		docs.Page{Title: "Guides", Pages: []docs.Page{
+//			{Title: "Installation", URL: "/docs/install"},
+//			{Title: "Routing", URL: "/docs/routing"},
+//		}},
+//	)
+//
+//	func docPage(r *http.Request, content h.HyperNode) h.HyperNode {
+//		content = docs.AnchorHeadings(content)
+//		return h.DIV()(
+//			site.Sidebar(r.URL.Path),
+//			h.MAIN()(content, site.PrevNext(r.URL.Path)),
+//			docs.TableOfContents(content),
+//		)
+//	}
+package docs
+
+import (
+	"bytes"
+	"html"
+	"strings"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+// Page is a page of a documentation [Site], or a section grouping pages
+// when it has no URL.
+type Page struct {
+	Title string
+	URL   string
+	Pages []Page // Child pages, shown nested in the sidebar
+}
+
+// contains reports whether url is the URL of the page or of one of its
+// descendants.
+func (me Page) contains(url string) bool {
+	if me.URL == url {
+		return true
+	}
+	for _, child := range me.Pages {
+		if child.contains(url) {
+			return true
+		}
+	}
+	return false
+}
+
+// Site is the registry of the pages of a documentation site.
+type Site struct {
+	pages []Page
+	order []Page // Pages with a URL, in reading order
+}
+
+// NewSite creates a site of pages, in reading order: a page is followed by
+// its child pages, then by its next sibling.
+func NewSite(pages ...Page) *Site {
+	site := &Site{pages: pages}
+	var flatten func(pages []Page)
+	flatten = func(pages []Page) {
+		for _, page := range pages {
+			if page.URL != "" {
+				site.order = append(site.order, page)
+			}
+			flatten(page.Pages)
+		}
+	}
+	flatten(pages)
+	return site
+}
+
+// Find returns the page at url, and whether there is one.
+func (me *Site) Find(url string) (Page, bool) {
+	for _, page := range me.order {
+		if page.URL == url {
+			return page, true
+		}
+	}
+	return Page{}, false
+}
+
+// Sidebar returns the navigation tree of the site. The page at current is
+// marked with aria-current="page", and the sections containing it are
+// expanded.
+func (me *Site) Sidebar(current string) h.Element {
+	return h.NAV(h.AttrClass("docs-sidebar"), h.AttrAriaLabel("Documentation"))(sidebarList(me.pages, current))
+}
+
+func sidebarList(pages []Page, current string) h.Element {
+	return h.UL()(h.Range(pages, func(page Page) h.HyperNode {
+		var label h.HyperNode = h.Text(page.Title)
+		if page.URL != "" {
+			attrs := []h.Attribute{h.AttrHref(page.URL)}
+			if page.URL == current {
+				attrs = append(attrs, h.AttrAriaCurrent("page"))
+			}
+			label = h.A(attrs...)(page.Title)
+		}
+		if len(page.Pages) == 0 {
+			return h.LI()(label)
+		}
+		return h.LI()(
+			h.DETAILS(h.AttrOpen(page.contains(current)))(
+				h.SUMMARY()(label),
+				sidebarList(page.Pages, current),
+			),
+		)
+	}))
+}
+
+// PrevNext returns links to the pages before and after the page at current
+// in reading order. It renders nothing for pages not in the site.
+func (me *Site) PrevNext(current string) h.HyperNode {
+	i := -1
+	for j, page := range me.order {
+		if page.URL == current {
+			i = j
+		}
+	}
+	if i < 0 {
+		return h.Group()
+	}
+
+	var links []any
+	if i > 0 {
+		prev := me.order[i-1]
+		links = append(links, h.A(h.AttrHref(prev.URL), h.AttrRel("prev"), h.AttrClass("docs-prev"))(
+			h.SPAN()("Previous"), " ", h.STRONG()(prev.Title),
+		))
+	}
+	if i < len(me.order)-1 {
+		next := me.order[i+1]
+		links = append(links, h.A(h.AttrHref(next.URL), h.AttrRel("next"), h.AttrClass("docs-next"))(
+			h.SPAN()("Next"), " ", h.STRONG()(next.Title),
+		))
+	}
+	return h.NAV(h.AttrClass("docs-prev-next"), h.AttrAriaLabel("Previous and next pages"))(links...)
+}
+
+// headingSelector selects the headings listed in tables of contents and
+// given anchor links.
+const headingSelector = "h2, h3"
+
+// AnchorHeadings returns content with its h2 and h3 headings linkable:
+// headings without id get one derived from their text ("Getting started"
+// becomes "getting-started"), and a link to it is appended to them, shown
+// as "#" (see h.HeadingAnchors).
+func AnchorHeadings(content h.HyperNode) h.HyperNode {
+	return h.HeadingAnchors(content, h.HeadingAnchorOptions{
+		Selector: headingSelector,
+		Label:    "Link to this section",
+		Class:    "docs-anchor",
+	})
+}
+
+// TableOfContents returns the list of the h2 and h3 headings of content,
+// h3 nested under h2, linking to them. Headings need ids: pass content
+// through AnchorHeadings first. It renders nothing when content has no
+// headings.
+func TableOfContents(content h.HyperNode) h.HyperNode {
+	headings, _ := h.Query(content, headingSelector) // The selector is valid
+	var items []any
+	var sub []any // The h3 of the last h2
+	flush := func() {
+		if len(sub) == 0 {
+			return
+		}
+		if len(items) == 0 {
+			items = append(items, sub...)
+		} else {
+			last := items[len(items)-1].(h.Element)
+			last.Children = append(last.Children, h.UL()(sub...))
+			items[len(items)-1] = last
+		}
+		sub = nil
+	}
+	for _, heading := range headings {
+		id, ok := heading.Attribute("id")
+		if !ok {
+			continue
+		}
+		item := h.LI()(h.A(h.AttrHref("#" + id))(text(heading)))
+		if heading.Tag == "h3" {
+			sub = append(sub, item)
+			continue
+		}
+		flush()
+		items = append(items, item)
+	}
+	flush()
+	if len(items) == 0 {
+		return h.Group()
+	}
+	return h.NAV(h.AttrClass("docs-toc"), h.AttrAriaLabel("On this page"))(h.UL()(items...))
+}
+
+// text returns the text of element, without the anchor link added by
+// AnchorHeadings.
+func text(element h.Element) string {
+	var buf bytes.Buffer
+	for _, child := range element.Children {
+		if e, ok := child.(h.Element); ok && e.HasClass("docs-anchor") {
+			continue
+		}
+		if child.Render(&buf) != nil {
+			return ""
+		}
+	}
+	markup := buf.String()
+	var out strings.Builder
+	for markup != "" {
+		start := strings.IndexByte(markup, '<')
+		if start < 0 {
+			out.WriteString(markup)
+			break
+		}
+		out.WriteString(markup[:start])
+		end := strings.IndexByte(markup[start:], '>')
+		if end < 0 {
+			break
+		}
+		markup = markup[start+end+1:]
+	}
+	return strings.TrimSpace(html.UnescapeString(out.String()))
+}
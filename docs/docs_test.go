@@ -0,0 +1,81 @@
+package docs
+
+import (
+	"bytes"
+	"testing"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+var site = NewSite(
+	Page{Title: "Introduction", URL: "/docs"},
+	Page{Title: "Guides", Pages: []Page{
+		{Title: "Installation", URL: "/docs/install"},
+		{Title: "Routing", URL: "/docs/routing"},
+	}},
+	Page{Title: "FAQ", URL: "/docs/faq"},
+)
+
+func render(t *testing.T, node h.HyperNode) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := h.Render(&buf, node); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestSidebar(t *testing.T) {
+	expected := `<nav class="docs-sidebar" aria-label="Documentation"><ul>` +
+		`<li><a href="/docs">Introduction</a></li>` +
+		`<li><details open><summary>Guides</summary><ul>` +
+		`<li><a href="/docs/install">Installation</a></li>` +
+		`<li><a href="/docs/routing" aria-current="page">Routing</a></li>` +
+		`</ul></details></li>` +
+		`<li><a href="/docs/faq">FAQ</a></li></ul></nav>`
+	if got := render(t, site.Sidebar("/docs/routing")); got != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, got)
+	}
+}
+
+func TestPrevNext(t *testing.T) {
+	expected := `<nav class="docs-prev-next" aria-label="Previous and next pages">` +
+		`<a href="/docs" rel="prev" class="docs-prev"><span>Previous</span> <strong>Introduction</strong></a>` +
+		`<a href="/docs/routing" rel="next" class="docs-next"><span>Next</span> <strong>Routing</strong></a></nav>`
+	if got := render(t, site.PrevNext("/docs/install")); got != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, got)
+	}
+	if got := render(t, site.PrevNext("/docs/faq")); got != `<nav class="docs-prev-next" aria-label="Previous and next pages"><a href="/docs/routing" rel="prev" class="docs-prev"><span>Previous</span> <strong>Routing</strong></a></nav>` {
+		t.Errorf("expected only a previous link on the last page, got %s", got)
+	}
+	if got := render(t, site.PrevNext("/elsewhere")); got != "" {
+		t.Errorf("expected nothing for pages not in the site, got %s", got)
+	}
+}
+
+func TestTableOfContents(t *testing.T) {
+	content := AnchorHeadings(h.ARTICLE()(
+		h.H1()("Routing"),
+		h.H2()("Getting started"),
+		h.H3()("Patterns & ", h.CODE()("{wildcards}")),
+		h.H2(h.AttrID("custom"))("Middleware"),
+		h.H2()("Getting started"),
+	))
+
+	expected := `<article><h1>Routing</h1>` +
+		`<h2 id="getting-started">Getting started <a href="#getting-started" class="docs-anchor" aria-label="Link to this section">#</a></h2>` +
+		`<h3 id="patterns-wildcards">Patterns &amp; <code>{wildcards}</code> <a href="#patterns-wildcards" class="docs-anchor" aria-label="Link to this section">#</a></h3>` +
+		`<h2 id="custom">Middleware <a href="#custom" class="docs-anchor" aria-label="Link to this section">#</a></h2>` +
+		`<h2 id="getting-started-2">Getting started <a href="#getting-started-2" class="docs-anchor" aria-label="Link to this section">#</a></h2></article>`
+	if got := render(t, content); got != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, got)
+	}
+
+	expected = `<nav class="docs-toc" aria-label="On this page"><ul>` +
+		`<li><a href="#getting-started">Getting started</a><ul><li><a href="#patterns-wildcards">Patterns &amp; {wildcards}</a></li></ul></li>` +
+		`<li><a href="#custom">Middleware</a></li>` +
+		`<li><a href="#getting-started-2">Getting started</a></li></ul></nav>`
+	if got := render(t, TableOfContents(content)); got != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, got)
+	}
+}
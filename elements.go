@@ -5,7 +5,8 @@ import (
 	"fmt"
 	"html"
 	"io"
-	"sync"
+	"slices"
+	"strings"
 )
 
 // Text represents a plain text node that renders HTML-escaped content.
@@ -37,33 +38,67 @@ type Element struct {
 
 // Render generates the HTML for the element and its children to the provided writer.
 func (me Element) Render(w io.Writer) error {
-	buf := bufferPool.Get().(*bytes.Buffer)
-	defer func() {
-		buf.Reset()
-		bufferPool.Put(buf)
-	}()
+	hint := sizeHint(me.Tag)
+	buf := getBuffer(int(hint.Load()))
+	defer putBuffer(buf)
 
 	if err := me.render(buf); err != nil {
 		return err
 	}
+	hint.Store(int64(buf.Len()))
 
 	_, err := w.Write(buf.Bytes())
 	return err
 }
 
-// bufferPool is a sync pool for reusing byte buffers during HTML rendering.
-// This reduces allocations when rendering many elements by recycling buffers
-// with a pre-allocated capacity of 1KB.
-var bufferPool = sync.Pool{
-	New: func() any {
-		var buf bytes.Buffer
-		buf.Grow(1024)
-		return &buf
-	},
+// RenderToBuffer renders the element into buf. It implements [BufferRenderer],
+// letting custom nodes built on elements render without an extra copy.
+func (me Element) RenderToBuffer(buf *bytes.Buffer) error {
+	return me.render(buf)
+}
+
+// Attribute returns the value of the attribute with the given key and whether
+// it is set. When the key appears more than once, the first one wins, as it
+// does in browsers. An active [BooleanAttribute] is reported as set with an
+// empty value. Grouped [Attributes] are searched too.
+func (me Element) Attribute(key string) (string, bool) {
+	return lookupAttribute(me.Attributes, key)
+}
+
+func lookupAttribute(attrs []Attribute, key string) (string, bool) {
+	for _, attr := range attrs {
+		switch a := attr.(type) {
+		case Attributes:
+			if value, ok := lookupAttribute(a, key); ok {
+				return value, true
+			}
+		case PairAttribute:
+			if a.Key == key {
+				return a.Value, true
+			}
+		case BooleanAttribute:
+			if a.Key == key && a.IsActive {
+				return "", true
+			}
+		}
+	}
+	return "", false
+}
+
+// HasClass reports whether class is one of the element's space-separated classes.
+func (me Element) HasClass(class string) bool {
+	classes, _ := me.Attribute("class")
+	return slices.Contains(strings.Fields(classes), class)
 }
 
 // render renders the element to the provided buffer.
 func (me Element) render(buf *bytes.Buffer) error {
+	if l := limits.Load(); l != nil {
+		if err := checkElementLimits(l, me); err != nil {
+			return err
+		}
+	}
+
 	if me.Tag == "" {
 		return me.renderChildren(buf)
 	}
@@ -91,6 +126,7 @@ func (me Element) render(buf *bytes.Buffer) error {
 
 // renderChildren renders all child nodes to the provided buffer.
 func (me Element) renderChildren(buf *bytes.Buffer) error {
+	l := limits.Load()
 	for _, child := range me.Children {
 		switch c := child.(type) {
 		// I'm tring to pass the concrete type [bytes.Buffer] as possible.
@@ -104,11 +140,21 @@ func (me Element) renderChildren(buf *bytes.Buffer) error {
 			buf.WriteString(html.EscapeString(string(c)))
 		case RawText:
 			buf.WriteString(string(c))
+		case BufferRenderer:
+			if err := c.RenderToBuffer(buf); err != nil {
+				return err
+			}
 		default:
 			if err := c.Render(buf); err != nil {
 				return err
 			}
 		}
+
+		if l != nil {
+			if err := checkOutputLimit(l, me.Tag, buf); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -142,12 +188,15 @@ func WithChildren(element Element) ElementBuilder {
 }
 
 // InsertChildren adds child nodes to an [Element]. It accepts [HyperNode] values,
-// strings (converted to [Text]), and other values (converted to [Text] via fmt.Sprint).
+// [Component] values (rendered with [RenderComponent]), strings (converted to
+// [Text]), and other values (converted to [Text] via fmt.Sprint).
 func InsertChildren(element *Element, children ...any) {
 	for _, child := range children {
 		switch value := child.(type) {
 		case HyperNode:
 			element.Children = append(element.Children, value)
+		case Component:
+			element.Children = append(element.Children, RenderComponent(value))
 		// Explicit string and fmt.Stringer cases for performance:
 		// fmt.Sprint() would handle these, but with overhead from type inspection and buffer allocation.
 		case string:
@@ -0,0 +1,104 @@
+package h
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// ErrorPageParams configures the error pages. All fields are optional; the
+// page constructors fill in sensible defaults for their status.
+type ErrorPageParams struct {
+	Status    int    // HTTP status shown on the page
+	Title     string // Page heading and <title>
+	Message   string // Explanation shown below the heading
+	RequestID string // Shown when set, so users can quote it when reporting the problem
+	// Nonce is the CSP nonce of the request (see [Nonce]), carried by the
+	// inline <style> of the default layout. [Handler] and [MaintenanceMode]
+	// set it from the request context.
+	Nonce string
+	// Layout wraps the page content in the app's own document (head, navigation,
+	// styles...). The default renders a minimal standalone document.
+	Layout func(title string, content HyperNode) HyperNode
+}
+
+// ErrorPage renders an error page from params. The status defaults to 500.
+//
+// Example:
+//
+//	ErrorPage(ErrorPageParams{
+//		Status:  http.StatusForbidden,
+//		Message: "You don't have access to this project.",
+//		Layout:  appLayout,
+//	})
+func ErrorPage(params ErrorPageParams) HyperNode {
+	if params.Status == 0 {
+		params.Status = http.StatusInternalServerError
+	}
+	if params.Title == "" {
+		params.Title = http.StatusText(params.Status)
+	}
+	layout := params.Layout
+	if layout == nil {
+		layout = func(title string, content HyperNode) HyperNode {
+			return defaultErrorLayout(title, params.Nonce, content)
+		}
+	}
+
+	content := MAIN(AttrClass("error-page"))(
+		P(AttrClass("error-page-status"))(strconv.Itoa(params.Status)),
+		H1()(params.Title),
+		If(params.Message != "", P(AttrClass("error-page-message"))(params.Message)),
+		If(params.RequestID != "", P(AttrClass("error-page-request-id"))(
+			"Request ID: ", CODE()(params.RequestID),
+		)),
+	)
+	return layout(params.Title, content)
+}
+
+// NotFoundPage renders a 404 page.
+func NotFoundPage(params ...ErrorPageParams) HyperNode {
+	return statusPage(http.StatusNotFound, "Page not found",
+		"The page you are looking for doesn't exist or has been moved.", params)
+}
+
+// InternalErrorPage renders a 500 page.
+func InternalErrorPage(params ...ErrorPageParams) HyperNode {
+	return statusPage(http.StatusInternalServerError, "Something went wrong",
+		"An unexpected error occurred. Please try again later.", params)
+}
+
+// MaintenancePage renders a 503 page for planned downtime.
+func MaintenancePage(params ...ErrorPageParams) HyperNode {
+	return statusPage(http.StatusServiceUnavailable, "Down for maintenance",
+		"We're performing scheduled maintenance and will be back shortly.", params)
+}
+
+func statusPage(status int, title, message string, params []ErrorPageParams) HyperNode {
+	var p ErrorPageParams
+	if len(params) != 0 {
+		p = params[0]
+	}
+	p.Status = status
+	p.Title = IfElse(p.Title != "", p.Title, title)
+	p.Message = IfElse(p.Message != "", p.Message, message)
+	return ErrorPage(p)
+}
+
+func defaultErrorLayout(title, nonce string, content HyperNode) HyperNode {
+	var styleAttrs []Attribute
+	if nonce != "" {
+		styleAttrs = append(styleAttrs, AttrNonce(nonce))
+	}
+	return Group(
+		DOCTYPE(),
+		HTML(AttrLang("en"))(
+			HEAD()(
+				META(AttrCharset("utf-8")),
+				META(AttrName("viewport"), AttrContent("width=device-width, initial-scale=1")),
+				TITLE()(title),
+				STYLE(styleAttrs...)(RawText("body{font-family:system-ui,sans-serif;margin:0;display:grid;place-items:center;min-height:100vh;text-align:center;color:#111827}.error-page-status{font-size:3rem;font-weight:700;color:#6b7280;margin:0}")),
+			),
+			BODY()(content),
+		),
+	)
+}
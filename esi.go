@@ -0,0 +1,121 @@
+package h
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ESIIncludeNode is an Edge Side Include, created with [ESIInclude].
+type ESIIncludeNode struct {
+	Src      string
+	Fallback HyperNode
+}
+
+// ESIInclude returns an Edge Side Include of src, for pages cached at a CDN
+// (Akamai, Fastly, Varnish...) with fragments cached separately or not at
+// all, such as the user menu of an otherwise public page.
+//
+// It renders as <esi:include src="..." onerror="continue"/> followed by the
+// fallback inside <esi:remove>: an edge processing ESI replaces the include
+// and drops the fallback, while browsers reached without an edge ignore the
+// unknown include tag and show the fallback. Use [ResolveESI] to render the
+// includes on the server instead when no edge is present.
+//
+// Example:
+//
+//	HEADER()(
+//		Logo(),
+//		ESIInclude("/fragments/user-menu", A(AttrHref("/login"))("Sign in")),
+//	)
+func ESIInclude(src string, fallback HyperNode) ESIIncludeNode {
+	return ESIIncludeNode{Src: src, Fallback: fallback}
+}
+
+func (me ESIIncludeNode) Render(w io.Writer) error {
+	var buf bytes.Buffer
+	if err := me.RenderToBuffer(&buf); err != nil {
+		return err
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// RenderToBuffer implements [BufferRenderer].
+func (me ESIIncludeNode) RenderToBuffer(buf *bytes.Buffer) error {
+	// ESI processors expect the XML empty-element syntax, which void
+	// elements don't render.
+	buf.WriteString("<esi:include")
+	AttrSrc(me.Src).Render(buf)
+	buf.WriteString(` onerror="continue"/>`)
+	if me.Fallback == nil {
+		return nil
+	}
+	return Element{Tag: "esi:remove", Children: []HyperNode{me.Fallback}}.render(buf)
+}
+
+// Unwrap implements [Wrapper], returning the fallback.
+func (me ESIIncludeNode) Unwrap() []HyperNode {
+	if me.Fallback == nil {
+		return nil
+	}
+	return []HyperNode{me.Fallback}
+}
+
+func (me ESIIncludeNode) mapNodes(fn func(HyperNode) HyperNode) HyperNode {
+	if me.Fallback != nil {
+		me.Fallback = fn(me.Fallback)
+	}
+	return me
+}
+
+// ResolveESI returns node with its ESI includes resolved on the server, for
+// requests not going through an edge processing them (see [EdgeSupportsESI]).
+// Each include is replaced by the node resolve returns for its src, or by
+// its fallback when resolve returns nil.
+//
+// Example:
+//
+//	if !EdgeSupportsESI(r) {
+//		page = ResolveESI(page, func(src string) HyperNode {
+//			if src == "/fragments/user-menu" {
+//				return UserMenu(r.Context())
+//			}
+//			return nil
+//		})
+//	}
+func ResolveESI(node HyperNode, resolve func(src string) HyperNode) HyperNode {
+	switch n := node.(type) {
+	case ESIIncludeNode:
+		if resolved := resolve(n.Src); resolved != nil {
+			return resolved
+		}
+		return IfElse(n.Fallback != nil, n.Fallback, Group())
+	case Element:
+		children := make([]HyperNode, len(n.Children))
+		for i, child := range n.Children {
+			children[i] = ResolveESI(child, resolve)
+		}
+		n.Children = children
+		return n
+	case Wrapper:
+		return mapWrapped(n, func(child HyperNode) HyperNode {
+			return ResolveESI(child, resolve)
+		})
+	default:
+		return node
+	}
+}
+
+// EdgeSupportsESI reports whether r went through an edge announcing ESI
+// support with the Surrogate-Capability header (Edge Architecture
+// Specification), e.g. `Surrogate-Capability: cdn="ESI/1.0"`.
+func EdgeSupportsESI(r *http.Request) bool {
+	for _, capability := range r.Header.Values("Surrogate-Capability") {
+		if strings.Contains(capability, "ESI/1.0") {
+			return true
+		}
+	}
+	return false
+}
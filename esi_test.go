@@ -0,0 +1,61 @@
+package h
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestESIInclude(t *testing.T) {
+	page := HEADER()(ESIInclude("/fragments/menu?x=1&y=2", A(AttrHref("/login"))("Sign in")), ESIInclude("/fragments/ad", nil))
+
+	tests := []struct {
+		name     string
+		node     HyperNode
+		expected string
+	}{
+		{
+			name:     "Edge",
+			node:     page,
+			expected: `<header><esi:include src="/fragments/menu?x=1&y=2" onerror="continue"/><esi:remove><a href="/login">Sign in</a></esi:remove><esi:include src="/fragments/ad" onerror="continue"/></header>`,
+		},
+		{
+			name: "Resolved",
+			node: ResolveESI(page, func(src string) HyperNode {
+				if src == "/fragments/menu?x=1&y=2" {
+					return SPAN()("Ada")
+				}
+				return nil
+			}),
+			expected: `<header><span>Ada</span></header>`,
+		},
+		{
+			name:     "Unresolved falls back",
+			node:     ResolveESI(page, func(string) HyperNode { return nil }),
+			expected: `<header><a href="/login">Sign in</a></header>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Render(&buf, tt.node); err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, buf.String())
+			}
+		})
+	}
+}
+
+func TestEdgeSupportsESI(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	if EdgeSupportsESI(r) {
+		t.Error("expected no ESI support without header")
+	}
+	r.Header.Set("Surrogate-Capability", `cdn="ESI/1.0"`)
+	if !EdgeSupportsESI(r) {
+		t.Error("expected ESI support")
+	}
+}
@@ -0,0 +1,82 @@
+package h
+
+import (
+	"context"
+	"hash/fnv"
+	"slices"
+)
+
+// ExperimentSubjectKey holds the key [Experiment] buckets visitors by,
+// typically a user or session ID. Set it in a middleware, like the keys of
+// [LocaleKey].
+var ExperimentSubjectKey = NewContextKey[string]("experiment-subject")
+
+// ControlVariant is the variant [Experiment] renders when the context has
+// no subject to bucket.
+const ControlVariant = "control"
+
+// OnExperiment, when set, is called each time [Experiment] assigns a
+// variant, e.g. to log exposures to an analytics backend.
+var OnExperiment func(ctx context.Context, name, variant string)
+
+// ExperimentVariant returns the variant of the experiment called name
+// assigned to the subject of ctx (see [ExperimentSubjectKey]). Assignments
+// are deterministic: a subject always sees the same variant of an
+// experiment, and variants are picked with equal weight.
+//
+// Without a subject, the [ControlVariant] is returned if it is one of the
+// variants, or else the first variant in lexical order.
+func ExperimentVariant(ctx context.Context, name string, variants []string) string {
+	if len(variants) == 0 {
+		return ""
+	}
+	variants = slices.Sorted(slices.Values(variants))
+	subject, _ := Value(ctx, ExperimentSubjectKey)
+	if subject == "" {
+		if slices.Contains(variants, ControlVariant) {
+			return ControlVariant
+		}
+		return variants[0]
+	}
+	hash := fnv.New32a()
+	hash.Write([]byte(name))
+	hash.Write([]byte{0})
+	hash.Write([]byte(subject))
+	return variants[hash.Sum32()%uint32(len(variants))]
+}
+
+// Experiment renders the variant of the A/B test called name assigned to
+// the subject of ctx (see [ExperimentVariant]). When the variant is an
+// element, data-experiment and data-variant attributes record the
+// assignment for client-side analytics; [OnExperiment] is called either way.
+//
+// Example:
+//
+//	Experiment(ctx, "signup-cta", map[string]HyperNode{
+//		"control": BUTTON()("Sign up"),
+//		"free":    BUTTON()("Start for free"),
+//	})
+//	// <button data-experiment="signup-cta" data-variant="free">Start for free</button>
+func Experiment(ctx context.Context, name string, variants map[string]HyperNode) HyperNode {
+	names := make([]string, 0, len(variants))
+	for variant := range variants {
+		names = append(names, variant)
+	}
+	variant := ExperimentVariant(ctx, name, names)
+	if variant == "" {
+		return Group()
+	}
+	if OnExperiment != nil {
+		OnExperiment(ctx, name, variant)
+	}
+
+	node := variants[variant]
+	if element, ok := node.(Element); ok {
+		element.Attributes = append(slices.Clip(element.Attributes),
+			Attr("data-experiment", name),
+			Attr("data-variant", variant),
+		)
+		return element
+	}
+	return node
+}
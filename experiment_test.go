@@ -0,0 +1,58 @@
+package h
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestExperiment(t *testing.T) {
+	variants := map[string]HyperNode{
+		"control": BUTTON()("Sign up"),
+		"free":    BUTTON()("Start for free"),
+		"trial":   Text("Try it"),
+	}
+
+	var exposures []string
+	defer func() { OnExperiment = nil }()
+	OnExperiment = func(ctx context.Context, name, variant string) {
+		exposures = append(exposures, name+"="+variant)
+	}
+
+	render := func(ctx context.Context) string {
+		var buf bytes.Buffer
+		if err := Render(&buf, Experiment(ctx, "signup-cta", variants)); err != nil {
+			t.Fatal(err)
+		}
+		return buf.String()
+	}
+
+	expected := `<button data-experiment="signup-cta" data-variant="control">Sign up</button>`
+	if got := render(context.Background()); got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+
+	seen := map[string]bool{}
+	for _, subject := range []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"} {
+		ctx := WithValue(context.Background(), ExperimentSubjectKey, subject)
+		first := render(ctx)
+		if again := render(ctx); again != first {
+			t.Errorf("subject %q: expected a stable assignment, got %q then %q", subject, first, again)
+		}
+		seen[first] = true
+	}
+	if len(seen) < 2 {
+		t.Errorf("expected subjects to be spread over variants, got %v", seen)
+	}
+	if !strings.HasPrefix(exposures[0], "signup-cta=") || len(exposures) != 21 {
+		t.Errorf("unexpected exposures: %v", exposures)
+	}
+
+	// the original element is left untouched
+	var buf bytes.Buffer
+	Render(&buf, variants["control"])
+	if buf.String() != "<button>Sign up</button>" {
+		t.Errorf("variant was modified: %q", buf.String())
+	}
+}
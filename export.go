@@ -0,0 +1,181 @@
+package h
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"html"
+	"io"
+	"iter"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// TableFormat is a file format tables are exported to by [ExportTable].
+// [CSV] is built in; implement it to export to other formats, such as
+// XLSX with a spreadsheet library.
+type TableFormat interface {
+	ContentType() string
+	Extension() string // File extension, with its dot: ".csv"
+	NewWriter(w io.Writer) TableWriter
+}
+
+// TableWriter writes the rows of an exported table, the header first.
+type TableWriter interface {
+	WriteRow(cells []string) error
+	// Close flushes what is buffered; the underlying writer stays open.
+	Close() error
+}
+
+// CSV is the CSV [TableFormat]. Cells that spreadsheets would evaluate as
+// formulas ("=SUM(A1:A9)", "@cmd"...) are prefixed with a quote, since
+// exports often hold user input.
+var CSV TableFormat = csvFormat{}
+
+type csvFormat struct{}
+
+func (csvFormat) ContentType() string { return "text/csv; charset=utf-8" }
+func (csvFormat) Extension() string   { return ".csv" }
+
+func (csvFormat) NewWriter(w io.Writer) TableWriter {
+	return csvWriter{csv.NewWriter(w)}
+}
+
+type csvWriter struct {
+	*csv.Writer
+}
+
+func (me csvWriter) WriteRow(cells []string) error {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		escaped[i] = escapeFormula(cell)
+	}
+	return me.Write(escaped)
+}
+
+func (me csvWriter) Close() error {
+	me.Flush()
+	return me.Error()
+}
+
+// escapeFormula neutralizes cells that spreadsheets would run as formulas,
+// leaving numbers such as "-5" alone.
+func escapeFormula(cell string) string {
+	if cell == "" || !strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return cell
+	}
+	if _, err := strconv.ParseFloat(cell, 64); err == nil {
+		return cell
+	}
+	return "'" + cell
+}
+
+// ExportTable writes the rows as a table in format, with the columns used
+// by [TableFrom] for the page, so the page and its exports can't drift
+// apart. Rows are written as they are produced, so large datasets are
+// streamed rather than loaded in memory.
+func ExportTable[T any](w io.Writer, format TableFormat, columns []Column[T], rows iter.Seq2[T, error]) error {
+	writer := format.NewWriter(w)
+	cells := make([]string, len(columns))
+	for i, column := range columns {
+		cells[i] = plainText(column.Header)
+	}
+	if err := writer.WriteRow(cells); err != nil {
+		return err
+	}
+
+	for row, err := range rows {
+		if err != nil {
+			return err
+		}
+		for i, column := range columns {
+			if column.Export != nil {
+				cells[i] = column.Export(row)
+			} else {
+				cells[i] = plainText(column.Cell(row))
+			}
+		}
+		if err := writer.WriteRow(cells); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+// ExportHandler returns a handler downloading the rows returned by rows
+// for the request as filename (without extension) in format, to serve next
+// to the page showing them.
+//
+// Example:
+//
+//	mux.Handle("GET /users.csv", ExportHandler("users", CSV, userColumns, func(r *http.Request) iter.Seq2[User, error] {
+//		return db.StreamUsers(r.Context(), ParseTableQuery(r))
+//	}))
+func ExportHandler[T any](filename string, format TableFormat, columns []Column[T], rows func(r *http.Request) iter.Seq2[T, error]) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", format.ContentType())
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename+format.Extension()))
+
+		counter := &countingWriter{w: w}
+		if err := ExportTable(counter, format, columns, rows(r)); err != nil {
+			if counter.n == 0 {
+				w.Header().Del("Content-Disposition")
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+			// The download started: abort it so it isn't mistaken for a
+			// complete one.
+			panic(http.ErrAbortHandler)
+		}
+	})
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (me *countingWriter) Write(p []byte) (int, error) {
+	n, err := me.w.Write(p)
+	me.n += int64(n)
+	return n, err
+}
+
+// plainText returns the text of a header or cell content: strings as they
+// are, and nodes rendered without their tags.
+func plainText(content any) string {
+	switch v := content.(type) {
+	case string:
+		return v
+	case fmt.Stringer:
+		return v.String()
+	case HyperNode:
+		var buf bytes.Buffer
+		if err := v.Render(&buf); err != nil {
+			return ""
+		}
+		return stripTags(buf.String())
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// stripTags returns the text of HTML markup.
+func stripTags(markup string) string {
+	var text strings.Builder
+	for markup != "" {
+		start := strings.IndexByte(markup, '<')
+		if start < 0 {
+			text.WriteString(markup)
+			break
+		}
+		text.WriteString(markup[:start])
+		end := strings.IndexByte(markup[start:], '>')
+		if end < 0 {
+			break
+		}
+		markup = markup[start+end+1:]
+	}
+	return html.UnescapeString(text.String())
+}
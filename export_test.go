@@ -0,0 +1,61 @@
+package h
+
+import (
+	"bytes"
+	"errors"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func tableUserRows(users ...tableUser) iter.Seq2[tableUser, error] {
+	return func(yield func(tableUser, error) bool) {
+		for _, u := range users {
+			if !yield(u, nil) {
+				return
+			}
+		}
+	}
+}
+
+func TestExportTable(t *testing.T) {
+	columns := append(slices.Clone(tableUserColumns), Column[tableUser]{
+		Header: STRONG()("Note"),
+		Cell:   func(u tableUser) any { return EM()("x") },
+		Export: func(u tableUser) string { return "=1+1" },
+	})
+
+	var buf bytes.Buffer
+	if err := ExportTable(&buf, CSV, columns, tableUserRows(tableUser{"Ada, Countess", "ada@example.com"}, tableUser{"-5", "b&o@example.com"})); err != nil {
+		t.Fatal(err)
+	}
+	expected := "Name,Email,Note\n\"Ada, Countess\",ada@example.com,'=1+1\n-5,b&o@example.com,'=1+1\n"
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
+
+func TestExportHandler(t *testing.T) {
+	handler := ExportHandler("users", CSV, tableUserColumns, func(r *http.Request) iter.Seq2[tableUser, error] {
+		return tableUserRows(tableUser{"Ada", "ada@example.com"})
+	})
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/users.csv", nil))
+	if got := w.Header().Get("Content-Disposition"); got != `attachment; filename="users.csv"` {
+		t.Errorf("unexpected Content-Disposition %q", got)
+	}
+	if w.Body.String() != "Name,Email\nAda,ada@example.com\n" {
+		t.Errorf("unexpected body %q", w.Body.String())
+	}
+
+	failing := ExportHandler("users", CSV, tableUserColumns, func(r *http.Request) iter.Seq2[tableUser, error] {
+		return func(yield func(tableUser, error) bool) { yield(tableUser{}, errors.New("db down")) }
+	})
+	w = httptest.NewRecorder()
+	failing.ServeHTTP(w, httptest.NewRequest("GET", "/users.csv", nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected status 500, got %d", w.Code)
+	}
+}
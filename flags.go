@@ -0,0 +1,68 @@
+package h
+
+import "context"
+
+// FlagProvider evaluates feature flags, for [IfFlag].
+//
+// To use OpenFeature, wrap a client with [FlagFunc]:
+//
+//	client := openfeature.NewClient("web")
+//	h.DefaultFlagProvider = h.FlagFunc(func(ctx context.Context, flag string) bool {
+//		return client.Boolean(ctx, flag, false, openfeature.TransactionContext(ctx))
+//	})
+type FlagProvider interface {
+	// Enabled reports whether flag is on for the request of ctx. Providers
+	// report unknown flags, and evaluation errors, as off.
+	Enabled(ctx context.Context, flag string) bool
+}
+
+// FlagFunc adapts a function to a [FlagProvider].
+type FlagFunc func(ctx context.Context, flag string) bool
+
+func (me FlagFunc) Enabled(ctx context.Context, flag string) bool {
+	return me(ctx, flag)
+}
+
+// StaticFlags is a [FlagProvider] with fixed values, for tests and local
+// development.
+type StaticFlags map[string]bool
+
+func (me StaticFlags) Enabled(ctx context.Context, flag string) bool {
+	return me[flag]
+}
+
+// DefaultFlagProvider evaluates the flags of [IfFlag] when the context
+// carries no provider (see [FlagProviderKey]). All flags are off by default.
+var DefaultFlagProvider FlagProvider = StaticFlags{}
+
+// FlagProviderKey holds a [FlagProvider] overriding [DefaultFlagProvider]
+// for a request, e.g. one forcing flags on for internal users.
+var FlagProviderKey = NewContextKey[FlagProvider]("flags")
+
+// FlagEnabled reports whether flag is on for the request of ctx.
+func FlagEnabled(ctx context.Context, flag string) bool {
+	if provider, ok := Value(ctx, FlagProviderKey); ok && provider != nil {
+		return provider.Enabled(ctx, flag)
+	}
+	return DefaultFlagProvider.Enabled(ctx, flag)
+}
+
+// IfFlag returns node when flag is on for the request of ctx, and an empty
+// node otherwise, so UI changes can be rolled out gradually without
+// handlers passing flags down to components.
+//
+// Example:
+//
+//	NAV()(
+//		A(AttrHref("/"))("Home"),
+//		IfFlag(ctx, "new-billing", A(AttrHref("/billing"))("Billing")),
+//	)
+func IfFlag(ctx context.Context, flag string, node HyperNode) HyperNode {
+	return If(FlagEnabled(ctx, flag), node)
+}
+
+// IfFlagElse returns node when flag is on for the request of ctx, and
+// alternative otherwise.
+func IfFlagElse(ctx context.Context, flag string, node, alternative HyperNode) HyperNode {
+	return IfElse(FlagEnabled(ctx, flag), node, alternative)
+}
@@ -0,0 +1,39 @@
+package h
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestIfFlag(t *testing.T) {
+	defer func(provider FlagProvider) { DefaultFlagProvider = provider }(DefaultFlagProvider)
+	DefaultFlagProvider = StaticFlags{"new-nav": true}
+
+	render := func(ctx context.Context) string {
+		var buf bytes.Buffer
+		if err := Render(&buf, Group(
+			IfFlag(ctx, "new-nav", Text("new")),
+			IfFlagElse(ctx, "beta", Text("beta"), Text("stable")),
+		)); err != nil {
+			t.Fatal(err)
+		}
+		return buf.String()
+	}
+
+	if got := render(context.Background()); got != "newstable" {
+		t.Errorf("expected %q, got %q", "newstable", got)
+	}
+
+	var asked []string
+	override := FlagFunc(func(ctx context.Context, flag string) bool {
+		asked = append(asked, flag)
+		return flag == "beta"
+	})
+	if got := render(WithValue(context.Background(), FlagProviderKey, FlagProvider(override))); got != "beta" {
+		t.Errorf("expected %q, got %q", "beta", got)
+	}
+	if len(asked) != 2 {
+		t.Errorf("expected the context provider to be asked twice, got %v", asked)
+	}
+}
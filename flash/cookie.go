@@ -0,0 +1,131 @@
+package flash
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+// ErrInvalidCookie is returned by [CookieStore.Pop] when the cookie was tampered with.
+var ErrInvalidCookie = errors.New("flash: invalid cookie")
+
+// CookieStore is a [Store] keeping messages in a signed cookie, so no
+// server-side session storage is needed. Cookies are limited to about 4KB,
+// which is plenty for a few short messages.
+type CookieStore struct {
+	Secret []byte // Key used to sign the cookie
+	Name   string // Cookie name; defaults to "flash"
+	Path   string // Cookie path; defaults to "/"
+	Secure bool   // Whether the cookie is only sent over HTTPS
+}
+
+// NewCookieStore creates a [CookieStore] signing its cookie with secret.
+func NewCookieStore(secret []byte) *CookieStore {
+	return &CookieStore{Secret: secret}
+}
+
+func (me *CookieStore) name() string {
+	if me.Name == "" {
+		return "flash"
+	}
+	return me.Name
+}
+
+// Add queues message, keeping the messages already queued by the request's
+// cookie or by earlier calls during the same response.
+func (me *CookieStore) Add(w http.ResponseWriter, r *http.Request, message Message) error {
+	messages, err := me.pending(w, r)
+	if err != nil {
+		return err
+	}
+	messages = append(messages, message)
+
+	data, err := json.Marshal(messages)
+	if err != nil {
+		return err
+	}
+	payload := base64.RawURLEncoding.EncodeToString(data)
+	me.setCookie(w, payload+"."+me.sign(payload), 0)
+	return nil
+}
+
+// Pop returns the queued messages and clears the cookie.
+func (me *CookieStore) Pop(w http.ResponseWriter, r *http.Request) ([]Message, error) {
+	cookie, err := r.Cookie(me.name())
+	if err != nil {
+		return nil, nil
+	}
+	me.setCookie(w, "", -1)
+	return me.decode(cookie.Value)
+}
+
+// pending returns the messages queued so far: the ones set on the response
+// by an earlier Add, or else the ones in the request cookie.
+func (me *CookieStore) pending(w http.ResponseWriter, r *http.Request) ([]Message, error) {
+	response := http.Response{Header: w.Header()}
+	for _, cookie := range response.Cookies() {
+		if cookie.Name == me.name() {
+			return me.decode(cookie.Value)
+		}
+	}
+	if cookie, err := r.Cookie(me.name()); err == nil {
+		return me.decode(cookie.Value)
+	}
+	return nil, nil
+}
+
+func (me *CookieStore) decode(value string) ([]Message, error) {
+	if value == "" {
+		return nil, nil
+	}
+	payload, signature, ok := strings.Cut(value, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(me.sign(payload))) {
+		return nil, ErrInvalidCookie
+	}
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, ErrInvalidCookie
+	}
+	var messages []Message
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return nil, ErrInvalidCookie
+	}
+	return messages, nil
+}
+
+// setCookie replaces any cookie with the store's name already set on the response.
+func (me *CookieStore) setCookie(w http.ResponseWriter, value string, maxAge int) {
+	header := w.Header()
+	cookies := header.Values("Set-Cookie")
+	header.Del("Set-Cookie")
+	for _, cookie := range cookies {
+		if !strings.HasPrefix(cookie, me.name()+"=") {
+			header.Add("Set-Cookie", cookie)
+		}
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     me.name(),
+		Value:    value,
+		Path:     h.IfElse(me.Path != "", me.Path, "/"),
+		MaxAge:   maxAge,
+		Secure:   me.Secure,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func (me *CookieStore) sign(payload string) string {
+	if len(me.Secret) == 0 {
+		panic("flash: CookieStore.Secret must be set")
+	}
+	mac := hmac.New(sha256.New, me.Secret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
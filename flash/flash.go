@@ -0,0 +1,119 @@
+// Package flash implements one-time "flash" messages shown on the next page
+// a user sees, typically after a POST/redirect/GET cycle ("Profile saved.").
+//
+// Messages are queued with a [Store] and shown with [Flashes]:
+//
+//	store := flash.NewCookieStore([]byte(os.Getenv("FLASH_SECRET")))
+//	mux.Handle("/", flash.Middleware(store)(app))
+//
+//	func saveProfile(w http.ResponseWriter, r *http.Request) {
+//		...
+//		store.Add(w, r, flash.Message{Level: flash.Success, Text: "Profile saved."})
+//		http.Redirect(w, r, "/profile", http.StatusSeeOther)
+//	}
+//
+//	func profilePage(r *http.Request) h.HyperNode {
+//		return BODY()(flash.Flashes(r.Context()), ...)
+//	}
+package flash
+
+import (
+	"context"
+	"net/http"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+// Level is the severity of a message, used for styling.
+type Level string
+
+const (
+	Info    Level = "info"
+	Success Level = "success"
+	Warning Level = "warning"
+	Error   Level = "error"
+)
+
+// Message is a flash message.
+type Message struct {
+	Level Level  `json:"l"`
+	Text  string `json:"t"`
+}
+
+// Store persists flash messages between requests.
+type Store interface {
+	// Add queues a message to be shown on a later request.
+	Add(w http.ResponseWriter, r *http.Request, message Message) error
+	// Pop returns the queued messages and removes them from the store.
+	Pop(w http.ResponseWriter, r *http.Request) ([]Message, error)
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying messages, for [Flashes] to render.
+func NewContext(ctx context.Context, messages []Message) context.Context {
+	return context.WithValue(ctx, contextKey{}, messages)
+}
+
+// FromContext returns the messages carried by ctx, if any.
+func FromContext(ctx context.Context) []Message {
+	messages, _ := ctx.Value(contextKey{}).([]Message)
+	return messages
+}
+
+// Middleware pops the queued messages of every request from store and puts
+// them in the request context, where [Flashes] and [FromContext] find them.
+// Requests made by htmx for partial updates are left alone, so the
+// messages are not consumed by a fragment that does not show them.
+func Middleware(store Store) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("HX-Request") == "true" && r.Header.Get("HX-Boosted") != "true" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			messages, err := store.Pop(w, r)
+			if err == nil && len(messages) != 0 {
+				r = r.WithContext(NewContext(r.Context(), messages))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Params customizes how [Flashes] renders messages. All fields are optional.
+type Params struct {
+	// Render renders a single message. The default renders a
+	// <div class="flash flash-{level}">; use it to plug in a toast component,
+	// e.g. hyperui.Toast.
+	Render func(Message) h.HyperNode
+	// Attributes are added to the container element.
+	Attributes []h.Attribute
+}
+
+// Flashes renders the messages carried by ctx inside a polite live region,
+// so they are also announced by screen readers. Nothing is rendered when
+// there are no messages.
+func Flashes(ctx context.Context, params ...Params) h.HyperNode {
+	var p Params
+	if len(params) != 0 {
+		p = params[0]
+	}
+
+	messages := FromContext(ctx)
+	if len(messages) == 0 {
+		return h.Group()
+	}
+
+	render := p.Render
+	if render == nil {
+		render = defaultRender
+	}
+
+	attrs := append([]h.Attribute{h.AttrClass("flashes"), h.AttrRole("status"), h.AttrAriaLive("polite")}, p.Attributes...)
+	return h.DIV(attrs...)(h.Range(messages, render))
+}
+
+func defaultRender(message Message) h.HyperNode {
+	return h.DIV(h.AttrClass("flash flash-" + string(message.Level)))(message.Text)
+}
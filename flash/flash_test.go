@@ -0,0 +1,88 @@
+package flash
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+func TestCookieStore(t *testing.T) {
+	store := NewCookieStore([]byte("secret"))
+
+	// First request queues two messages.
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/profile", nil)
+	store.Add(w, r, Message{Level: Success, Text: "Saved."})
+	store.Add(w, r, Message{Level: Info, Text: "Check your email."})
+
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 {
+		t.Fatalf("expected one cookie, got %d", len(cookies))
+	}
+
+	// Next request pops them and clears the cookie.
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest("GET", "/profile", nil)
+	r.AddCookie(cookies[0])
+	messages, err := store.Pop(w, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Message{{Level: Success, Text: "Saved."}, {Level: Info, Text: "Check your email."}}
+	if !slices.Equal(messages, expected) {
+		t.Errorf("Pop() = %v, want %v", messages, expected)
+	}
+	if cleared := w.Result().Cookies(); len(cleared) != 1 || cleared[0].MaxAge >= 0 {
+		t.Errorf("expected the cookie to be cleared, got %v", cleared)
+	}
+
+	// A tampered cookie is rejected.
+	r = httptest.NewRequest("GET", "/profile", nil)
+	r.AddCookie(&http.Cookie{Name: "flash", Value: "x" + cookies[0].Value})
+	if _, err := store.Pop(httptest.NewRecorder(), r); err != ErrInvalidCookie {
+		t.Errorf("Pop() error = %v, want %v", err, ErrInvalidCookie)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	store := NewCookieStore([]byte("secret"))
+	w := httptest.NewRecorder()
+	store.Add(w, httptest.NewRequest("POST", "/", nil), Message{Level: Error, Text: "Oops <3"})
+	cookie := w.Result().Cookies()[0]
+
+	var buf bytes.Buffer
+	handler := Middleware(store)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.Render(&buf, Flashes(r.Context()))
+	}))
+	r := httptest.NewRequest("GET", "/", nil)
+	r.AddCookie(cookie)
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+
+	expected := `<div class="flashes" role="status" aria-live="polite"><div class="flash flash-error">Oops &lt;3</div></div>`
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
+
+func TestFlashes(t *testing.T) {
+	var buf bytes.Buffer
+	h.Render(&buf, Flashes(context.Background()))
+	if buf.Len() != 0 {
+		t.Errorf("expected nothing to be rendered, got %q", buf.String())
+	}
+
+	ctx := NewContext(context.Background(), []Message{{Level: Warning, Text: "Careful"}})
+	buf.Reset()
+	h.Render(&buf, Flashes(ctx, Params{
+		Render: func(m Message) h.HyperNode { return h.P()(m.Text) },
+	}))
+	if !strings.Contains(buf.String(), "<p>Careful</p>") {
+		t.Errorf("custom renderer not used: %q", buf.String())
+	}
+}
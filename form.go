@@ -0,0 +1,152 @@
+package h
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+var (
+	// MethodOverrideField is the name of the hidden field carrying the
+	// method of forms submitted with a method HTML forms don't support.
+	MethodOverrideField = "_method"
+	// CSRFField is the name of the hidden field carrying the CSRF token.
+	CSRFField = "csrf_token"
+)
+
+// FormTo creates a <form> submitting to action with method. HTML forms only
+// support GET and POST, so forms using another method (PUT, PATCH, DELETE)
+// are posted with the method in a hidden [MethodOverrideField] field, for
+// [MethodOverride] to restore on the server. Forms not using GET also get
+// the CSRF token carried by ctx (see [CSRFKey]) in a hidden [CSRFField]
+// field.
+//
+// Example:
+//
+//	FormTo(ctx, "DELETE", "/posts/42")(
+//		BUTTON(AttrType(TypeSubmit))("Delete"),
+//	)
+//	// <form method="post" action="/posts/42">
+//	//   <input type="hidden" name="_method" value="DELETE">
+//	//   <input type="hidden" name="csrf_token" value="...">
+//	//   <button type="submit">Delete</button>
+//	// </form>
+func FormTo(ctx context.Context, method, action string, attrs ...Attribute) ElementBuilder {
+	method = strings.ToUpper(method)
+	formMethod := method
+	if method != http.MethodGet && method != http.MethodPost {
+		formMethod = http.MethodPost
+	}
+	attrs = append([]Attribute{AttrMethod(strings.ToLower(formMethod)), AttrAction(action)}, attrs...)
+
+	var hidden []any
+	if formMethod != method {
+		hidden = append(hidden, hiddenInput(MethodOverrideField, method))
+	}
+	if method != http.MethodGet {
+		if token := CSRFToken(ctx); token != "" {
+			hidden = append(hidden, hiddenInput(CSRFField, token))
+		}
+	}
+	return func(children ...any) Element {
+		return FORM(attrs...)(append(hidden[:len(hidden):len(hidden)], children...)...)
+	}
+}
+
+func hiddenInput(name, value string) HyperNode {
+	return INPUT(AttrType(TypeHidden), AttrName(name), AttrValue(value))
+}
+
+// MethodOverride is a middleware restoring the method of the POST requests
+// sent by forms created with [FormTo], from their [MethodOverrideField]
+// field. Only PUT, PATCH and DELETE are restored. Install it before the
+// router, so routes are matched with the restored method.
+//
+// Example:
+//
+//	http.ListenAndServe(":8080", MethodOverride(mux))
+func MethodOverride(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			switch method := strings.ToUpper(r.PostFormValue(MethodOverrideField)); method {
+			case http.MethodPut, http.MethodPatch, http.MethodDelete:
+				r.Method = method
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Class names set by [WithErrors], as styling hooks.
+const (
+	ClassInvalid    = "invalid"
+	ClassFieldError = "field-error"
+)
+
+// WithErrors returns form with the validation errors of errs, keyed by field
+// name, shown next to the matching controls (<input>, <select> and
+// <textarea> elements with that name), so re-rendering a rejected form is
+// one call. Each matching control gets aria-invalid="true" and the
+// [ClassInvalid] class, and is described (aria-describedby) by a
+// <span class="field-error"> holding the messages, inserted after the last
+// control with that name. Errors for fields without a control are ignored.
+//
+// Example:
+//
+//	if errs := validate(signup); len(errs) != 0 {
+//		return WithErrors(SignupForm(signup), errs), nil
+//	}
+func WithErrors(form HyperNode, errs map[string][]error) HyperNode {
+	isControl := func(element Element) (string, bool) {
+		switch element.Tag {
+		case "input", "select", "textarea":
+			name, _ := element.Attribute("name")
+			return name, len(errs[name]) != 0
+		}
+		return "", false
+	}
+
+	// Controls sharing a name, such as radio buttons, share a message
+	// placed after the last of them.
+	counts := map[string]int{}
+	errorIDs := map[string]string{}
+	walkElementsWithAncestors(form, nil, func(element Element, _ []Element) bool {
+		if name, ok := isControl(element); ok {
+			if counts[name] == 0 {
+				if id, _ := element.Attribute("id"); id != "" {
+					errorIDs[name] = id + "-error"
+				} else {
+					errorIDs[name] = UniqueID(ClassFieldError)
+				}
+			}
+			counts[name]++
+		}
+		return true
+	})
+
+	seen := map[string]int{}
+	return transform(form, nil, func(element Element, _ []Element) bool {
+		_, ok := isControl(element)
+		return ok
+	}, func(control Element) HyperNode {
+		name, _ := control.Attribute("name")
+		errorID := errorIDs[name]
+		control.Attributes = appendAttrToken(control.Attributes, "class", ClassInvalid)
+		control.Attributes = appendAttrToken(control.Attributes, "aria-describedby", errorID)
+		control.Attributes = append(removeAttr(control.Attributes, "aria-invalid"), Attr("aria-invalid", "true"))
+
+		seen[name]++
+		if seen[name] < counts[name] {
+			return control
+		}
+		message := SPAN(AttrID(errorID), AttrClass(ClassFieldError))
+		var messages []any
+		for i, err := range errs[name] {
+			if i != 0 {
+				messages = append(messages, BR())
+			}
+			messages = append(messages, err.Error())
+		}
+		return Group(control, message(messages...))
+	})
+}
@@ -0,0 +1,100 @@
+package h
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFormTo(t *testing.T) {
+	ctx := WithValue(context.Background(), CSRFKey, "tok")
+	tests := []struct {
+		method   string
+		expected string
+	}{
+		{
+			method:   "get",
+			expected: `<form method="get" action="/posts"><button>Go</button></form>`,
+		},
+		{
+			method:   "POST",
+			expected: `<form method="post" action="/posts"><input type="hidden" name="csrf_token" value="tok"><button>Go</button></form>`,
+		},
+		{
+			method:   "delete",
+			expected: `<form method="post" action="/posts"><input type="hidden" name="_method" value="DELETE"><input type="hidden" name="csrf_token" value="tok"><button>Go</button></form>`,
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := Render(&buf, FormTo(ctx, tt.method, "/posts")(BUTTON()("Go"))); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.method, tt.expected, buf.String())
+		}
+	}
+}
+
+func TestMethodOverride(t *testing.T) {
+	tests := []struct {
+		body     string
+		expected string
+	}{
+		{body: "_method=delete", expected: http.MethodDelete},
+		{body: "_method=PATCH", expected: http.MethodPatch},
+		{body: "_method=GET", expected: http.MethodPost},
+		{body: "name=x", expected: http.MethodPost},
+	}
+	for _, tt := range tests {
+		var method string
+		handler := MethodOverride(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			method = r.Method
+		}))
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		handler.ServeHTTP(httptest.NewRecorder(), r)
+		if method != tt.expected {
+			t.Errorf("%q: expected %s, got %s", tt.body, tt.expected, method)
+		}
+	}
+}
+
+func TestWithErrors(t *testing.T) {
+	form := FORM()(
+		INPUT(AttrID("email"), AttrName("email"), AttrClass("input"), Attr("aria-invalid", "false")),
+		INPUT(AttrType("radio"), AttrName("plan"), AttrValue("free")),
+		INPUT(AttrType("radio"), AttrName("plan"), AttrValue("pro")),
+		TEXTAREA(AttrName("bio"))(),
+	)
+	node := WithErrors(form, map[string][]error{
+		"email": {errors.New("is required"), errors.New("must be valid")},
+		"plan":  {errors.New("pick a plan")},
+		"other": {errors.New("ignored")},
+	})
+
+	var buf bytes.Buffer
+	if err := Render(&buf, node); err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		`<input id="email" name="email" class="input invalid" aria-describedby="email-error" aria-invalid="true"><span id="email-error" class="field-error">is required<br>must be valid</span>`,
+		`value="free" class="invalid" aria-describedby="field-error-`,
+		`value="pro" class="invalid" aria-describedby="field-error-`,
+		`<textarea name="bio"></textarea>`,
+	} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("expected %q in %q", expected, buf.String())
+		}
+	}
+	if got := strings.Count(buf.String(), `class="field-error"`); got != 2 {
+		t.Errorf("expected 2 messages, got %d", got)
+	}
+	if !strings.Contains(buf.String(), `value="pro" class="invalid"`) || strings.Index(buf.String(), "pick a plan") < strings.Index(buf.String(), `value="pro"`) {
+		t.Errorf("expected the plan message after the last radio button, got %q", buf.String())
+	}
+}
@@ -0,0 +1,255 @@
+package h
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FormatOptions configures the display helpers [Decimal], [Money],
+// [Percent], [FileSize] and [RelativeTime]. All fields are optional.
+type FormatOptions struct {
+	// Locale is the BCP 47 language tag the value is formatted for, such as
+	// the one carried by the request context (see [Locale]); defaults to
+	// English conventions.
+	Locale string
+	// Now is the time [RelativeTime] counts from; defaults to the current
+	// time.
+	Now        time.Time
+	Attributes []Attribute // Extra attributes for the <span>
+}
+
+// numberFormat holds the conventions of a locale for displaying numbers.
+type numberFormat struct {
+	group, decimal string
+	currencyFirst  bool   // "$1.00" rather than "1,00 $"
+	percentSpace   string // Between the number and the % sign
+	// Spaces are non-breaking, so values never wrap across lines.
+}
+
+var (
+	englishFormat = numberFormat{group: ",", decimal: ".", currencyFirst: true}
+	// numberFormats maps languages to their conventions; locales are
+	// matched on their language, so "fr-CA" is formatted as "fr".
+	numberFormats = map[string]numberFormat{
+		"en": englishFormat,
+		"ja": englishFormat,
+		"zh": englishFormat,
+		"ko": englishFormat,
+		"de": {group: ".", decimal: ",", percentSpace: "\u00a0"},
+		"es": {group: ".", decimal: ",", percentSpace: "\u00a0"},
+		"it": {group: ".", decimal: ","},
+		"nl": {group: ".", decimal: ","},
+		"pt": {group: ".", decimal: ","},
+		"fr": {group: "\u00a0", decimal: ",", percentSpace: "\u00a0"},
+		"ru": {group: "\u00a0", decimal: ",", percentSpace: "\u00a0"},
+		"pl": {group: "\u00a0", decimal: ",", percentSpace: "\u00a0"},
+		"sv": {group: "\u00a0", decimal: ",", percentSpace: "\u00a0"},
+	}
+)
+
+func formatFor(locale string) numberFormat {
+	language, _, _ := strings.Cut(strings.ToLower(locale), "-")
+	if format, ok := numberFormats[language]; ok {
+		return format
+	}
+	return englishFormat
+}
+
+// number formats n, rounded to decimals fractional digits, with the group
+// and decimal separators of the format. When trim is set, trailing zero
+// fractional digits are dropped.
+func (me numberFormat) number(n float64, decimals int, trim bool) string {
+	digits := strconv.FormatFloat(math.Abs(n), 'f', decimals, 64)
+	integer, fraction, _ := strings.Cut(digits, ".")
+	if trim {
+		fraction = strings.TrimRight(fraction, "0")
+	}
+
+	var s strings.Builder
+	if n < 0 && strings.Trim(digits, "0.") != "" {
+		s.WriteByte('-')
+	}
+	for i, digit := range integer {
+		if i != 0 && (len(integer)-i)%3 == 0 {
+			s.WriteString(me.group)
+		}
+		s.WriteRune(digit)
+	}
+	if fraction != "" {
+		s.WriteString(me.decimal)
+		s.WriteString(fraction)
+	}
+	return s.String()
+}
+
+// Decimal returns n with up to decimals fractional digits, formatted for
+// the locale, in a <span> whose data-raw attribute carries the exact value.
+//
+// Example:
+//
+//	Decimal(1234567.891, 2) // <span data-raw="1234567.891">1,234,567.89</span>
+//	Decimal(0.5, 2, FormatOptions{Locale: "de"}) // <span data-raw="0.5">0,5</span>
+func Decimal(n float64, decimals int, options ...FormatOptions) Element {
+	o := formatOptions(options)
+	text := formatFor(o.Locale).number(n, decimals, true)
+	attrs := append([]Attribute{Attr("data-raw", strconv.FormatFloat(n, 'f', -1, 64))}, o.Attributes...)
+	return SPAN(attrs...)(text)
+}
+
+// currencies maps ISO 4217 codes to their symbol and number of decimals.
+// Other currencies are displayed with their code and 2 decimals.
+var currencies = map[string]struct {
+	symbol   string
+	decimals int
+}{
+	"USD": {"$", 2},
+	"EUR": {"€", 2},
+	"GBP": {"£", 2},
+	"JPY": {"¥", 0},
+	"CNY": {"¥", 2},
+	"KRW": {"₩", 0},
+	"INR": {"₹", 2},
+	"BRL": {"R$", 2},
+	"RUB": {"₽", 2},
+	"EGP": {"E£", 2},
+}
+
+// Money returns amount in currency (an ISO 4217 code) formatted for the
+// locale, in a <span> whose data-raw attribute carries the amount and
+// data-currency the currency, for sorting and scripts.
+//
+// Example:
+//
+//	Money(1234.5, "EUR", FormatOptions{Locale: Locale(ctx)})
+//	// en: <span data-raw="1234.50" data-currency="EUR">€1,234.50</span>
+//	// fr: <span data-raw="1234.50" data-currency="EUR">1 234,50 €</span>
+func Money(amount float64, currency string, options ...FormatOptions) Element {
+	o := formatOptions(options)
+	currency = strings.ToUpper(currency)
+	symbol, decimals := currency, 2
+	if c, ok := currencies[currency]; ok {
+		symbol, decimals = c.symbol, c.decimals
+	}
+
+	format := formatFor(o.Locale)
+	number := format.number(amount, decimals, false)
+	var text string
+	switch {
+	case format.currencyFirst && symbol == currency:
+		text = signFirst(symbol+"\u00a0", number)
+	case format.currencyFirst:
+		text = signFirst(symbol, number)
+	default:
+		text = number + "\u00a0" + symbol
+	}
+
+	attrs := append([]Attribute{
+		Attr("data-raw", strconv.FormatFloat(amount, 'f', decimals, 64)),
+		Attr("data-currency", currency),
+	}, o.Attributes...)
+	return SPAN(attrs...)(text)
+}
+
+// signFirst prefixes number with prefix, keeping the minus sign first:
+// "-$5.00" rather than "$-5.00".
+func signFirst(prefix, number string) string {
+	if rest, ok := strings.CutPrefix(number, "-"); ok {
+		return "-" + prefix + rest
+	}
+	return prefix + number
+}
+
+// Percent returns the ratio v (0.125 for 12.5%) as a percentage formatted
+// for the locale, with up to one fractional digit, in a <span> whose
+// data-raw attribute carries v.
+//
+// Example:
+//
+//	Percent(0.125) // <span data-raw="0.125">12.5%</span>
+func Percent(v float64, options ...FormatOptions) Element {
+	o := formatOptions(options)
+	format := formatFor(o.Locale)
+	text := format.number(v*100, 1, true) + format.percentSpace + "%"
+	attrs := append([]Attribute{Attr("data-raw", strconv.FormatFloat(v, 'f', -1, 64))}, o.Attributes...)
+	return SPAN(attrs...)(text)
+}
+
+var fileSizeUnits = []string{"B", "KB", "MB", "GB", "TB", "PB", "EB"}
+
+// FileSize returns a size in bytes in the largest unit keeping it at least
+// 1, in multiples of 1024 and with up to one fractional digit, formatted for
+// the locale, in a <span> whose data-raw attribute carries the exact size.
+//
+// Example:
+//
+//	FileSize(1536) // <span data-raw="1536">1.5 KB</span>
+func FileSize(bytes int64, options ...FormatOptions) Element {
+	o := formatOptions(options)
+	size, unit := math.Abs(float64(bytes)), 0
+	for size >= 1024 && unit < len(fileSizeUnits)-1 {
+		size /= 1024
+		unit++
+	}
+	if bytes < 0 {
+		size = -size
+	}
+	text := formatFor(o.Locale).number(size, 1, true) + "\u00a0" + fileSizeUnits[unit]
+	attrs := append([]Attribute{Attr("data-raw", strconv.FormatInt(bytes, 10))}, o.Attributes...)
+	return SPAN(attrs...)(text)
+}
+
+var relativeTimeUnits = []struct {
+	name string
+	size time.Duration
+}{
+	{"year", 365 * 24 * time.Hour},
+	{"month", 30 * 24 * time.Hour},
+	{"week", 7 * 24 * time.Hour},
+	{"day", 24 * time.Hour},
+	{"hour", time.Hour},
+	{"minute", time.Minute},
+}
+
+// RelativeTime returns t relative to now, in the largest whole unit, in a
+// <time> element whose datetime attribute carries the exact time and title
+// the full date, shown on hover. Times within a minute are "just now".
+//
+// Example:
+//
+//	RelativeTime(comment.Created)
+//	// <time datetime="2025-03-01T09:30:00Z" title="Mar 1, 2025 09:30 UTC">3 hours ago</time>
+//	RelativeTime(invoice.Due) // <time ...>in 2 weeks</time>
+func RelativeTime(t time.Time, options ...FormatOptions) Element {
+	o := formatOptions(options)
+	now := o.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	elapsed := now.Sub(t)
+	text := "just now"
+	for _, unit := range relativeTimeUnits {
+		n := int64(elapsed.Abs() / unit.size)
+		if n == 0 {
+			continue
+		}
+		text = formatFor(o.Locale).number(float64(n), 0, true) + " " + unit.name + IfElse(n == 1, "", "s")
+		text = IfElse(elapsed < 0, "in "+text, text+" ago")
+		break
+	}
+
+	attrs := append([]Attribute{
+		AttrDateTime(t.Format(time.RFC3339)),
+		AttrTitle(t.Format("Jan 2, 2006 15:04 MST")),
+	}, o.Attributes...)
+	return TIME(attrs...)(text)
+}
+
+func formatOptions(options []FormatOptions) FormatOptions {
+	if len(options) != 0 {
+		return options[0]
+	}
+	return FormatOptions{}
+}
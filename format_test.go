@@ -0,0 +1,72 @@
+package h
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDisplayHelpers(t *testing.T) {
+	nbsp := "\u00a0"
+	fr := FormatOptions{Locale: "fr-CA"}
+	tests := []struct {
+		node     HyperNode
+		expected string
+	}{
+		{node: Decimal(1234567.891, 2), expected: `<span data-raw="1234567.891">1,234,567.89</span>`},
+		{node: Decimal(0.5, 2, FormatOptions{Locale: "de"}), expected: `<span data-raw="0.5">0,5</span>`},
+		{node: Money(1234.5, "usd"), expected: `<span data-raw="1234.50" data-currency="USD">$1,234.50</span>`},
+		{node: Money(-5, "EUR"), expected: `<span data-raw="-5.00" data-currency="EUR">-€5.00</span>`},
+		{node: Money(1234567.891, "EUR", fr), expected: `<span data-raw="1234567.89" data-currency="EUR">1 234 567,89 €</span>`},
+		{node: Money(1500, "JPY", FormatOptions{Locale: "de"}), expected: `<span data-raw="1500" data-currency="JPY">1.500 ¥</span>`},
+		{node: Money(10, "CHF"), expected: `<span data-raw="10.00" data-currency="CHF">CHF 10.00</span>`},
+		{node: Percent(0.125), expected: `<span data-raw="0.125">12.5%</span>`},
+		{node: Percent(0.5, fr), expected: `<span data-raw="0.5">50 %</span>`},
+		{node: Percent(-0.0001), expected: `<span data-raw="-0.0001">0%</span>`},
+		{node: FileSize(512), expected: `<span data-raw="512">512 B</span>`},
+		{node: FileSize(1536, fr), expected: `<span data-raw="1536">1,5 KB</span>`},
+		{node: FileSize(5<<30, FormatOptions{Attributes: []Attribute{AttrClass("size")}}), expected: `<span data-raw="5368709120" class="size">5 GB</span>`},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := Render(&buf, tt.node); err != nil {
+			t.Fatal(err)
+		}
+		// Separators are non-breaking spaces, written as plain spaces above.
+		got := strings.ReplaceAll(buf.String(), nbsp, " ")
+		if got != tt.expected {
+			t.Errorf("expected %q, got %q", tt.expected, got)
+		}
+	}
+}
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		t        time.Time
+		expected string
+	}{
+		{t: now.Add(-30 * time.Second), expected: "just now"},
+		{t: now.Add(-time.Minute), expected: "1 minute ago"},
+		{t: now.Add(-3*time.Hour - 20*time.Minute), expected: "3 hours ago"},
+		{t: now.Add(15 * 24 * time.Hour), expected: "in 2 weeks"},
+		{t: now.AddDate(-3, 0, 0), expected: "3 years ago"},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := Render(&buf, RelativeTime(tt.t, FormatOptions{Now: now})); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(buf.String(), ">"+tt.expected+"</time>") {
+			t.Errorf("expected %q, got %q", tt.expected, buf.String())
+		}
+	}
+
+	var buf bytes.Buffer
+	Render(&buf, RelativeTime(now.Add(-time.Hour), FormatOptions{Now: now}))
+	expected := `<time datetime="2025-03-01T11:00:00Z" title="Mar 1, 2025 11:00 UTC">1 hour ago</time>`
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
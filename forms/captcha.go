@@ -0,0 +1,160 @@
+package forms
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+// CaptchaProvider selects the captcha service used by [Captcha].
+type CaptchaProvider int
+
+const (
+	HCaptcha  CaptchaProvider = iota // hCaptcha (https://www.hcaptcha.com)
+	Turnstile                        // Cloudflare Turnstile (https://www.cloudflare.com/products/turnstile)
+	ReCaptcha                        // Google reCAPTCHA v2 (https://developers.google.com/recaptcha)
+)
+
+type captchaService struct {
+	scriptURL     string
+	widgetClass   string
+	responseField string
+	verifyURL     string
+}
+
+var captchaServices = map[CaptchaProvider]captchaService{
+	HCaptcha: {
+		scriptURL:     "https://js.hcaptcha.com/1/api.js",
+		widgetClass:   "h-captcha",
+		responseField: "h-captcha-response",
+		verifyURL:     "https://api.hcaptcha.com/siteverify",
+	},
+	Turnstile: {
+		scriptURL:     "https://challenges.cloudflare.com/turnstile/v0/api.js",
+		widgetClass:   "cf-turnstile",
+		responseField: "cf-turnstile-response",
+		verifyURL:     "https://challenges.cloudflare.com/turnstile/v0/siteverify",
+	},
+	ReCaptcha: {
+		scriptURL:     "https://www.google.com/recaptcha/api.js",
+		widgetClass:   "g-recaptcha",
+		responseField: "g-recaptcha-response",
+		verifyURL:     "https://www.google.com/recaptcha/api/siteverify",
+	},
+}
+
+// Errors returned by [Captcha.Verify].
+var (
+	ErrMissingCaptcha = errors.New("forms: missing captcha response")
+	ErrCaptchaFailed  = errors.New("forms: captcha verification failed")
+)
+
+// Captcha renders and verifies a captcha widget. The three supported
+// providers share the same integration model, so switching between them is a
+// matter of changing Provider and the keys.
+//
+// Example:
+//
+//	var captcha = forms.Captcha{
+//		Provider:  forms.Turnstile,
+//		SiteKey:   os.Getenv("TURNSTILE_SITE_KEY"),
+//		SecretKey: os.Getenv("TURNSTILE_SECRET_KEY"),
+//	}
+//
+//	HEAD()(captcha.Script())
+//	FORM(AttrMethod(MethodPost))(..., captcha.Widget(), BUTTON()("Send"))
+//
+//	if err := captcha.Verify(r.Context(), r); err != nil { ... }
+type Captcha struct {
+	Provider   CaptchaProvider
+	SiteKey    string       // Public key rendered in the widget
+	SecretKey  string       // Private key used for server-side verification
+	Theme      string       // Widget theme ("light", "dark" or "auto"); provider default when empty
+	HTTPClient *http.Client // Client used by Verify; defaults to http.DefaultClient
+	VerifyURL  string       // Overrides the provider's verification endpoint (e.g. for tests)
+}
+
+func (me Captcha) service() captchaService {
+	service, ok := captchaServices[me.Provider]
+	if !ok {
+		panic("invalid captcha provider")
+	}
+	return service
+}
+
+// Script returns the provider's <script> include. Place it once per page.
+func (me Captcha) Script(attrs ...h.Attribute) h.HyperNode {
+	attrs = append([]h.Attribute{h.AttrSrc(me.service().scriptURL), h.AttrAsync(true), h.AttrDefer(true)}, attrs...)
+	return h.SCRIPT(attrs...)()
+}
+
+// Widget returns the element the provider's script turns into the captcha.
+// It must be placed inside the form being protected.
+func (me Captcha) Widget(attrs ...h.Attribute) h.HyperNode {
+	element := h.Element{Tag: "div"}
+	element.Attributes = append(element.Attributes,
+		h.AttrClass(me.service().widgetClass),
+		h.Attr("data-sitekey", me.SiteKey),
+	)
+	if me.Theme != "" {
+		element.Attributes = append(element.Attributes, h.Attr("data-theme", me.Theme))
+	}
+	element.Attributes = append(element.Attributes, attrs...)
+	return element
+}
+
+// Verify checks the captcha response submitted with r against the provider's
+// API. It returns nil when the challenge was passed, [ErrMissingCaptcha] when
+// the form has no response, and an error wrapping [ErrCaptchaFailed] when the
+// provider rejects it.
+func (me Captcha) Verify(ctx context.Context, r *http.Request) error {
+	service := me.service()
+	if err := r.ParseForm(); err != nil {
+		return err
+	}
+	response := r.PostForm.Get(service.responseField)
+	if response == "" {
+		return ErrMissingCaptcha
+	}
+
+	form := url.Values{"secret": {me.SecretKey}, "response": {response}}
+	if ip, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		form.Set("remoteip", ip)
+	}
+
+	endpoint := h.IfElse(me.VerifyURL != "", me.VerifyURL, service.verifyURL)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	client := me.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(request)
+	if err != nil {
+		return fmt.Errorf("forms: verifying captcha: %w", err)
+	}
+	defer res.Body.Close()
+
+	var result struct {
+		Success    bool     `json:"success"`
+		ErrorCodes []string `json:"error-codes"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return fmt.Errorf("forms: decoding captcha verification: %w", err)
+	}
+	if !result.Success {
+		return fmt.Errorf("%w: %s", ErrCaptchaFailed, strings.Join(result.ErrorCodes, ", "))
+	}
+	return nil
+}
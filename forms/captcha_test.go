@@ -0,0 +1,60 @@
+package forms
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestCaptcha_Widget(t *testing.T) {
+	captcha := Captcha{Provider: Turnstile, SiteKey: "site", Theme: "dark"}
+
+	if got, want := render(t, captcha.Widget()), `<div class="cf-turnstile" data-sitekey="site" data-theme="dark"></div>`; got != want {
+		t.Errorf("Widget() = %q, want %q", got, want)
+	}
+	if got, want := render(t, captcha.Script()), `<script src="https://challenges.cloudflare.com/turnstile/v0/api.js" async defer></script>`; got != want {
+		t.Errorf("Script() = %q, want %q", got, want)
+	}
+}
+
+func TestCaptcha_Verify(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		form, _ := url.ParseQuery(string(body))
+		if form.Get("secret") == "secret" && form.Get("response") == "good" {
+			io.WriteString(w, `{"success":true}`)
+			return
+		}
+		io.WriteString(w, `{"success":false,"error-codes":["invalid-input-response"]}`)
+	}))
+	defer server.Close()
+
+	captcha := Captcha{Provider: HCaptcha, SecretKey: "secret", VerifyURL: server.URL}
+
+	tests := []struct {
+		name     string
+		response string
+		expected error
+	}{
+		{name: "Passed", response: "good"},
+		{name: "Rejected", response: "bad", expected: ErrCaptchaFailed},
+		{name: "Missing", response: "", expected: ErrMissingCaptcha},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := url.Values{"h-captcha-response": {tt.response}}
+			r := httptest.NewRequest("POST", "/signup", strings.NewReader(form.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			if err := captcha.Verify(context.Background(), r); !errors.Is(err, tt.expected) {
+				t.Errorf("Verify() = %v, want %v", err, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+// Package forms provides form building blocks that take care of the wiring
+// between labels, controls, help text and error messages.
+package forms
+
+import (
+	"strings"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+// Class names set on the elements rendered by [Field], as styling hooks.
+const (
+	ClassField      = "field"
+	ClassFieldLabel = "field-label"
+	ClassFieldHelp  = "field-help"
+	ClassFieldError = "field-error"
+)
+
+// fieldID returns the id of a control without one, derived from its form
+// and name so that renders are deterministic, e.g. "signup-email", or
+// "field-email" without a form. Controls without a name get a unique id,
+// e.g. "field-3".
+func fieldID(form, name string) string {
+	prefix := form
+	if form == "" {
+		prefix = "field"
+	}
+	var id strings.Builder
+	id.WriteString(prefix)
+	separate := true
+	for _, r := range name {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' {
+			if separate {
+				id.WriteByte('-')
+				separate = false
+			}
+			id.WriteRune(r)
+		} else {
+			separate = true
+		}
+	}
+	if id.Len() == len(prefix) {
+		return h.UniqueID(prefix)
+	}
+	return id.String()
+}
+
+// withoutID returns a copy of attrs without their id attributes, including
+// those of attribute groups.
+func withoutID(attrs []h.Attribute) []h.Attribute {
+	var kept []h.Attribute
+	for _, attr := range attrs {
+		switch a := attr.(type) {
+		case h.Attributes:
+			attr = h.Attributes(withoutID(a))
+		case h.PairAttribute:
+			if a.Key == "id" {
+				continue
+			}
+		}
+		kept = append(kept, attr)
+	}
+	return kept
+}
+
+// FieldParams configures a [Field]. Label is the only required field.
+type FieldParams struct {
+	ID         string        // Control id; defaults to the control's own id, or one derived from Form and the control's name
+	Form       string        // Form id, scoping the derived ids of forms sharing field names on a page
+	Label      any           // Label content (string or node)
+	Help       any           // Optional help text shown below the control
+	Errors     []string      // Validation errors; the control is marked invalid when non-empty
+	Attributes []h.Attribute // Extra attributes for the wrapping <div>
+}
+
+// Field wraps a form control with its label, help text and error messages,
+// and wires them together for assistive technology:
+//
+//   - the control gets an id (derived from its name when it has none, or
+//     unique when it has no name either) and the label's for= points at it,
+//   - the help and error elements get ids derived from the control id, listed
+//     in the control's aria-describedby,
+//   - the control gets aria-invalid="true" and aria-errormessage when there
+//     are errors.
+//
+// The control is typically an INPUT, SELECT or TEXTAREA element. Other node
+// types are rendered as they are, so they must carry params.ID themselves.
+//
+// Ids derived from names are the same on every render, so pages can be
+// cached and compared, but fields sharing a name on a page need their own
+// params.Form or params.ID to get different ids.
+//
+// Example:
+//
+//	forms.Field(forms.FieldParams{
+//		Label:  "Email",
+//		Help:   "We never share your email.",
+//		Errors: errs["email"],
+//	}, INPUT(AttrType(TypeEmail), AttrName("email"), AttrRequired(true)))
+func Field(params FieldParams, control h.HyperNode) h.HyperNode {
+	element, isElement := control.(h.Element)
+
+	id := params.ID
+	if isElement {
+		if existing, ok := element.Attribute("id"); ok && existing != "" {
+			id = existing
+		}
+	}
+	if id == "" {
+		name := ""
+		if isElement {
+			name, _ = element.Attribute("name")
+		}
+		id = fieldID(params.Form, name)
+	}
+	helpID, errorID := id+"-help", id+"-error"
+
+	if isElement {
+		element.Attributes = append([]h.Attribute{}, element.Attributes...)
+		if existing, _ := element.Attribute("id"); existing != id {
+			element.Attributes = append(withoutID(element.Attributes), h.AttrID(id))
+		}
+
+		var describedBy []string
+		if params.Help != nil {
+			describedBy = append(describedBy, helpID)
+		}
+		if len(params.Errors) != 0 {
+			describedBy = append(describedBy, errorID)
+			element.Attributes = append(element.Attributes,
+				h.AttrAriaInvalid("true"),
+				h.AttrAriaErrorMessage(errorID),
+			)
+		}
+		if len(describedBy) != 0 {
+			element.Attributes = append(element.Attributes, h.AttrAriaDescribedBy(strings.Join(describedBy, " ")))
+		}
+		control = element
+	}
+
+	wrapper := h.Element{Tag: "div", Attributes: append([]h.Attribute{h.AttrClass(ClassField)}, params.Attributes...)}
+	h.InsertChildren(&wrapper,
+		h.LABEL(h.AttrFor(id), h.AttrClass(ClassFieldLabel))(params.Label),
+		control,
+	)
+	if params.Help != nil {
+		h.InsertChildren(&wrapper, h.P(h.AttrID(helpID), h.AttrClass(ClassFieldHelp))(params.Help))
+	}
+	if len(params.Errors) != 0 {
+		errorsElement := h.Element{Tag: "div", Attributes: []h.Attribute{h.AttrID(errorID), h.AttrClass(ClassFieldError)}}
+		for _, message := range params.Errors {
+			h.InsertChildren(&errorsElement, h.P()(message))
+		}
+		h.InsertChildren(&wrapper, errorsElement)
+	}
+	return wrapper
+}
@@ -0,0 +1,112 @@
+package forms
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+func render(t *testing.T, node h.HyperNode) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := h.Render(&buf, node); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	return buf.String()
+}
+
+func TestField(t *testing.T) {
+	tests := []struct {
+		name     string
+		node     h.HyperNode
+		expected string
+	}{
+		{
+			name: "Explicit id",
+			node: Field(FieldParams{ID: "email", Label: "Email"}, h.INPUT(h.AttrName("email"))),
+			expected: `<div class="field"><label for="email" class="field-label">Email</label>` +
+				`<input name="email" id="email"></div>`,
+		},
+		{
+			name: "Control id wins",
+			node: Field(FieldParams{ID: "ignored", Label: "Bio", Help: "Markdown allowed"}, h.TEXTAREA(h.AttrID("bio"))()),
+			expected: `<div class="field"><label for="bio" class="field-label">Bio</label>` +
+				`<textarea id="bio" aria-describedby="bio-help"></textarea>` +
+				`<p id="bio-help" class="field-help">Markdown allowed</p></div>`,
+		},
+		{
+			name: "Errors",
+			node: Field(FieldParams{ID: "age", Label: "Age", Help: "Years", Errors: []string{"required", "too young"}}, h.INPUT()),
+			expected: `<div class="field"><label for="age" class="field-label">Age</label>` +
+				`<input id="age" aria-invalid="true" aria-errormessage="age-error" aria-describedby="age-help age-error">` +
+				`<p id="age-help" class="field-help">Years</p>` +
+				`<div id="age-error" class="field-error"><p>required</p><p>too young</p></div></div>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := render(t, tt.node); got != tt.expected {
+				t.Errorf("Field() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestField_GeneratedID(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   FieldParams
+		control  h.HyperNode
+		expected string
+	}{
+		{
+			name:     "From the name",
+			params:   FieldParams{Label: "Name"},
+			control:  h.INPUT(h.AttrName("user[name]")),
+			expected: `<label for="field-user-name" class="field-label">Name</label><input name="user[name]" id="field-user-name">`,
+		},
+		{
+			name:     "Scoped by the form",
+			params:   FieldParams{Form: "signup", Label: "Email"},
+			control:  h.INPUT(h.AttrName("email")),
+			expected: `<label for="signup-email" class="field-label">Email</label><input name="email" id="signup-email">`,
+		},
+		{
+			name:     "Empty id replaced",
+			params:   FieldParams{Label: "Email"},
+			control:  h.INPUT(h.AttrID(""), h.AttrName("email")),
+			expected: `<label for="field-email" class="field-label">Email</label><input name="email" id="field-email">`,
+		},
+		{
+			name:     "Grouped empty id replaced",
+			params:   FieldParams{Label: "Email"},
+			control:  h.INPUT(h.Attributes{h.AttrID(""), h.AttrName("email")}),
+			expected: `<label for="field-email" class="field-label">Email</label><input name="email" id="field-email">`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, second := render(t, Field(tt.params, tt.control)), render(t, Field(tt.params, tt.control))
+			if !strings.Contains(first, tt.expected) {
+				t.Errorf("Field() = %q, want it to contain %q", first, tt.expected)
+			}
+			if first != second {
+				t.Errorf("Field() should render the same ids every time, got %q and %q", first, second)
+			}
+		})
+	}
+}
+
+func TestField_UnnamedControls(t *testing.T) {
+	first := render(t, Field(FieldParams{Label: "A"}, h.INPUT()))
+	second := render(t, Field(FieldParams{Label: "B"}, h.INPUT()))
+	id := regexp.MustCompile(`id="([^"]+)"`)
+	if a, b := id.FindStringSubmatch(first), id.FindStringSubmatch(second); a == nil || b == nil || a[1] == b[1] {
+		t.Errorf("expected unnamed controls to get different ids, got %q and %q", first, second)
+	}
+}
@@ -0,0 +1,144 @@
+package forms
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+// Errors returned by [SpamGuard.Verify].
+var (
+	ErrHoneypotFilled   = errors.New("forms: honeypot field was filled")
+	ErrMissingToken     = errors.New("forms: missing form token")
+	ErrInvalidToken     = errors.New("forms: invalid form token")
+	ErrSubmittedTooFast = errors.New("forms: form submitted too fast")
+	ErrTokenExpired     = errors.New("forms: form token expired")
+)
+
+// SpamGuard adds basic bot protection to public forms (contact, signup...)
+// without captchas, using two signals:
+//
+//   - a honeypot field hidden from humans, that naive bots fill in,
+//   - a signed timestamp token, rejecting forms submitted faster than a
+//     human could (or replayed long after the page was rendered).
+//
+// Render [SpamGuard.Fields] inside the form and call [SpamGuard.Verify] in the
+// handler. Secret is required; the other fields have sensible defaults.
+//
+// Example:
+//
+//	var guard = forms.SpamGuard{Secret: []byte(os.Getenv("FORM_SECRET"))}
+//
+//	FORM(AttrMethod(MethodPost), AttrAction("/contact"))(
+//		guard.Fields(),
+//		...
+//	)
+//
+//	func contact(w http.ResponseWriter, r *http.Request) {
+//		if err := guard.Verify(r); err != nil {
+//			http.Error(w, "Please try again.", http.StatusBadRequest)
+//			return
+//		}
+//		...
+//	}
+type SpamGuard struct {
+	Secret       []byte           // Key used to sign the timestamp token
+	HoneypotName string           // Name of the honeypot field; defaults to "website"
+	TokenName    string           // Name of the token field; defaults to "_form_ts"
+	MinDelay     time.Duration    // Minimum time between rendering and submitting; defaults to 2 seconds
+	MaxAge       time.Duration    // Maximum time between rendering and submitting; defaults to 24 hours
+	Now          func() time.Time // Clock used for tokens; defaults to time.Now
+}
+
+func (me SpamGuard) honeypotName() string {
+	return h.IfElse(me.HoneypotName != "", me.HoneypotName, "website")
+}
+
+func (me SpamGuard) tokenName() string {
+	return h.IfElse(me.TokenName != "", me.TokenName, "_form_ts")
+}
+
+func (me SpamGuard) now() time.Time {
+	if me.Now != nil {
+		return me.Now()
+	}
+	return time.Now()
+}
+
+// Fields returns the hidden honeypot and token fields to place inside a form.
+// The honeypot is moved off-screen rather than hidden with display:none,
+// which some bots detect, and is skipped by keyboard navigation, autofill and
+// screen readers.
+func (me SpamGuard) Fields() h.HyperNode {
+	return h.Group(
+		h.DIV(h.AttrAriaHidden("true"), h.AttrStyle("position:absolute;left:-10000px;top:auto;width:1px;height:1px;overflow:hidden"))(
+			h.LABEL()(
+				"Leave this field empty",
+				h.INPUT(
+					h.AttrType(h.TypeText),
+					h.AttrName(me.honeypotName()),
+					h.AttrTabIndex("-1"),
+					h.AttrAutocomplete("off"),
+				),
+			),
+		),
+		h.INPUT(h.AttrType(h.TypeHidden), h.AttrName(me.tokenName()), h.AttrValue(me.Token())),
+	)
+}
+
+// Token returns a signed token holding the current time.
+func (me SpamGuard) Token() string {
+	timestamp := strconv.FormatInt(me.now().Unix(), 10)
+	return timestamp + "." + me.sign(timestamp)
+}
+
+// Verify checks the submitted form of r, parsing it if needed, and returns
+// nil when the submission looks human.
+func (me SpamGuard) Verify(r *http.Request) error {
+	if err := r.ParseForm(); err != nil {
+		return err
+	}
+	if r.PostForm.Get(me.honeypotName()) != "" {
+		return ErrHoneypotFilled
+	}
+
+	token := r.PostForm.Get(me.tokenName())
+	if token == "" {
+		return ErrMissingToken
+	}
+	timestamp, signature, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(me.sign(timestamp))) {
+		return ErrInvalidToken
+	}
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidToken
+	}
+
+	elapsed := me.now().Sub(time.Unix(seconds, 0))
+	minDelay := h.IfElse(me.MinDelay > 0, me.MinDelay, 2*time.Second)
+	maxAge := h.IfElse(me.MaxAge > 0, me.MaxAge, 24*time.Hour)
+	switch {
+	case elapsed < minDelay:
+		return ErrSubmittedTooFast
+	case elapsed > maxAge:
+		return ErrTokenExpired
+	}
+	return nil
+}
+
+func (me SpamGuard) sign(timestamp string) string {
+	if len(me.Secret) == 0 {
+		panic("forms: SpamGuard.Secret must be set")
+	}
+	mac := hmac.New(sha256.New, me.Secret)
+	mac.Write([]byte(timestamp))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,53 @@
+package forms
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSpamGuard(t *testing.T) {
+	rendered := time.Unix(1_700_000_000, 0)
+	guard := SpamGuard{Secret: []byte("secret"), Now: func() time.Time { return rendered }}
+	token := guard.Token()
+
+	tests := []struct {
+		name     string
+		form     url.Values
+		after    time.Duration
+		expected error
+	}{
+		{name: "Human", form: url.Values{"_form_ts": {token}}, after: 10 * time.Second},
+		{name: "Honeypot filled", form: url.Values{"_form_ts": {token}, "website": {"spam.example"}}, after: 10 * time.Second, expected: ErrHoneypotFilled},
+		{name: "Missing token", form: url.Values{}, after: 10 * time.Second, expected: ErrMissingToken},
+		{name: "Tampered token", form: url.Values{"_form_ts": {"1" + token}}, after: 10 * time.Second, expected: ErrInvalidToken},
+		{name: "Too fast", form: url.Values{"_form_ts": {token}}, after: time.Second, expected: ErrSubmittedTooFast},
+		{name: "Expired", form: url.Values{"_form_ts": {token}}, after: 25 * time.Hour, expected: ErrTokenExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			submitter := guard
+			submitter.Now = func() time.Time { return rendered.Add(tt.after) }
+
+			r := httptest.NewRequest("POST", "/contact", strings.NewReader(tt.form.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if err := submitter.Verify(r); err != tt.expected {
+				t.Errorf("Verify() = %v, want %v", err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSpamGuard_Fields(t *testing.T) {
+	guard := SpamGuard{Secret: []byte("secret"), HoneypotName: "url"}
+	got := render(t, guard.Fields())
+
+	for _, want := range []string{`name="url" tabindex="-1" autocomplete="off"`, `<input type="hidden" name="_form_ts" value="`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Fields() = %q, want it to contain %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package forms
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+// ErrInvalidWizardState is returned by [Wizard.Completed] when the state
+// field was tampered with.
+var ErrInvalidWizardState = errors.New("forms: invalid wizard state")
+
+// Wizard tracks the completed steps of a multi-step form between requests,
+// in a signed hidden field, so handlers can refuse to skip ahead without
+// keeping server-side sessions. The data entered in the steps is left to
+// the application. Render [Wizard.Field] inside each step's form and read
+// the steps back with [Wizard.Completed]. Secret and Steps are required.
+//
+// Example:
+//
+//	var signup = forms.Wizard{Secret: secret, Steps: []string{"account", "profile", "confirm"}}
+//
+//	func submitStep(w http.ResponseWriter, r *http.Request) {
+//		completed, err := signup.Completed(r)
+//		step := r.PathValue("step")
+//		if err != nil || !signup.CanVisit(step, completed) {
+//			http.Error(w, "Invalid step.", http.StatusBadRequest)
+//			return
+//		}
+//		... // validate and save the step
+//		completed = signup.Complete(step, completed)
+//		next := signup.Next(completed)
+//		... // render the form of next with signup.Field(completed)
+//	}
+type Wizard struct {
+	Secret    []byte   // Key used to sign the state
+	Steps     []string // Step names, in order
+	FieldName string   // Name of the state field; defaults to "_wizard"
+}
+
+func (me Wizard) fieldName() string {
+	return h.IfElse(me.FieldName != "", me.FieldName, "_wizard")
+}
+
+// Field returns the hidden field carrying the completed steps, to place
+// inside the form of a step.
+func (me Wizard) Field(completed []string) h.HyperNode {
+	state := strings.Join(completed, ",")
+	return h.INPUT(h.AttrType(h.TypeHidden), h.AttrName(me.fieldName()), h.AttrValue(state+"."+me.sign(state)))
+}
+
+// Completed returns the completed steps carried by the submitted form of
+// r, parsing it if needed; none when the form has no state field.
+func (me Wizard) Completed(r *http.Request) ([]string, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+	value := r.Form.Get(me.fieldName())
+	if value == "" {
+		return nil, nil
+	}
+	state, signature, ok := strings.Cut(value, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(me.sign(state))) {
+		return nil, ErrInvalidWizardState
+	}
+	if state == "" {
+		return nil, nil
+	}
+	return strings.Split(state, ","), nil
+}
+
+// Complete returns completed with step added, in the order of the steps.
+func (me Wizard) Complete(step string, completed []string) []string {
+	var result []string
+	for _, s := range me.Steps {
+		if s == step || slices.Contains(completed, s) {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// Next returns the first step not completed, or "" when all are.
+func (me Wizard) Next(completed []string) string {
+	for _, step := range me.Steps {
+		if !slices.Contains(completed, step) {
+			return step
+		}
+	}
+	return ""
+}
+
+// CanVisit reports whether step can be shown or submitted: it is a step of
+// the wizard and all the steps before it are completed.
+func (me Wizard) CanVisit(step string, completed []string) bool {
+	for _, s := range me.Steps {
+		if s == step {
+			return true
+		}
+		if !slices.Contains(completed, s) {
+			return false
+		}
+	}
+	return false
+}
+
+func (me Wizard) sign(state string) string {
+	if len(me.Secret) == 0 {
+		panic("forms: Wizard.Secret must be set")
+	}
+	mac := hmac.New(sha256.New, me.Secret)
+	mac.Write([]byte(state))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,50 @@
+package forms
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+func TestWizard(t *testing.T) {
+	wizard := Wizard{Secret: []byte("secret"), Steps: []string{"account", "profile", "confirm"}}
+
+	completed := wizard.Complete("account", nil)
+	if next := wizard.Next(completed); next != "profile" {
+		t.Errorf("expected next step profile, got %q", next)
+	}
+	if !wizard.CanVisit("profile", completed) || wizard.CanVisit("confirm", completed) || wizard.CanVisit("other", completed) {
+		t.Error("unexpected CanVisit result")
+	}
+
+	var buf bytes.Buffer
+	if err := h.Render(&buf, wizard.Field(completed)); err != nil {
+		t.Fatal(err)
+	}
+	value := regexp.MustCompile(`value="([^"]*)"`).FindStringSubmatch(buf.String())[1]
+
+	submit := func(value string) ([]string, error) {
+		r := httptest.NewRequest("POST", "/", strings.NewReader(url.Values{"_wizard": {value}}.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return wizard.Completed(r)
+	}
+
+	got, err := submit(value)
+	if err != nil || !slices.Equal(got, []string{"account"}) {
+		t.Errorf("expected [account], got %v, %v", got, err)
+	}
+	if _, err := submit(strings.Replace(value, "account", "account,profile", 1)); err != ErrInvalidWizardState {
+		t.Errorf("expected ErrInvalidWizardState for a tampered state, got %v", err)
+	}
+
+	completed = wizard.Complete("confirm", wizard.Complete("profile", got))
+	if !slices.Equal(completed, wizard.Steps) || wizard.Next(completed) != "" {
+		t.Errorf("expected all steps completed, got %v", completed)
+	}
+}
@@ -0,0 +1,107 @@
+package h
+
+// Class names used by [EditableGrid].
+const (
+	ClassGridCell   = "grid-cell"
+	ClassGridEditor = "grid-editor"
+)
+
+// GridOptions configures [EditableGrid].
+type GridOptions[T any] struct {
+	TableOptions
+	// CellURL returns the URL of the cell of a row in the column with the
+	// given key, e.g. "/users/42/email". It is required.
+	CellURL func(row T, key string) string
+}
+
+// EditableGrid creates a table like [TableFrom] whose cells in Editable
+// columns are edited in place, like in a spreadsheet, with the htmx
+// click-to-edit pattern. Clicking a cell GETs CellURL+"/edit", to be
+// answered with [GridCellEditor]. Pressing Enter in the editor PUTs its
+// value to CellURL, and Escape GETs CellURL, both to be answered with
+// [GridCell] once the value is saved.
+//
+// Example:
+//
+//	var userColumns = []Column[User]{
+//		{Key: "name", Header: "Name", Cell: func(u User) any { return u.Name }, Editable: true},
+//		{Key: "email", Header: "Email", Cell: func(u User) any { return u.Email }, Editable: true, InputType: TypeEmail},
+//	}
+//
+//	func userCellURL(u User, key string) string { return fmt.Sprintf("/users/%d/%s", u.ID, key) }
+//
+//	EditableGrid(users, userColumns, GridOptions[User]{CellURL: userCellURL})
+//
+//	mux.Handle("GET /users/{id}/{key}/edit", Handler(func(r *http.Request) (HyperNode, error) {
+//		user, err := loadUser(r.PathValue("id"))
+//		column, ok := ColumnByKey(userColumns, r.PathValue("key"))
+//		...
+//		return GridCellEditor(user, column, userCellURL(user, column.Key)), nil
+//	}))
+//	mux.Handle("PUT /users/{id}/{key}", Handler(func(r *http.Request) (HyperNode, error) {
+//		... // Save r.FormValue("value")
+//		return GridCell(user, column, userCellURL(user, column.Key)), nil
+//	}))
+func EditableGrid[T any](rows []T, columns []Column[T], options GridOptions[T]) Element {
+	return buildTable(rows, columns, options.TableOptions, func(row T, column Column[T]) Element {
+		if !column.Editable {
+			return TD()(column.Cell(row))
+		}
+		return GridCell(row, column, options.CellURL(row, column.Key))
+	})
+}
+
+// GridCell creates the cell of row in column of an [EditableGrid], showing
+// its value and opening its editor when clicked or activated with the
+// keyboard.
+func GridCell[T any](row T, column Column[T], cellURL string) Element {
+	return TD(
+		AttrClass(ClassGridCell),
+		AttrTabIndex("0"),
+		Attr("hx-get", cellURL+"/edit"),
+		Attr("hx-trigger", "click, keyup[key=='Enter']"),
+		Attr("hx-swap", "outerHTML"),
+	)(column.Cell(row))
+}
+
+// GridCellEditor creates the cell of row in column of an [EditableGrid]
+// being edited: a form with an input holding the value of the cell, named
+// "value".
+func GridCellEditor[T any](row T, column Column[T], cellURL string) Element {
+	var value string
+	if column.Export != nil {
+		value = column.Export(row)
+	} else {
+		value = plainText(column.Cell(row))
+	}
+	return TD(AttrClass(ClassGridEditor))(
+		FORM(
+			Attr("hx-put", cellURL),
+			Attr("hx-target", "closest td"),
+			Attr("hx-swap", "outerHTML"),
+		)(
+			INPUT(
+				AttrType(IfElse(column.InputType != "", column.InputType, TypeText)),
+				AttrName("value"),
+				AttrValue(value),
+				AttrAriaLabel(plainText(column.Header)),
+				AttrAutofocus(true),
+				Attr("hx-get", cellURL),
+				Attr("hx-trigger", "keyup[key=='Escape']"),
+				Attr("hx-target", "closest td"),
+				Attr("hx-swap", "outerHTML"),
+			),
+		),
+	)
+}
+
+// ColumnByKey returns the column of columns with the given key, for the
+// handlers of an [EditableGrid].
+func ColumnByKey[T any](columns []Column[T], key string) (Column[T], bool) {
+	for _, column := range columns {
+		if column.Key == key {
+			return column, true
+		}
+	}
+	return Column[T]{}, false
+}
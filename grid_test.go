@@ -0,0 +1,41 @@
+package h
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEditableGrid(t *testing.T) {
+	columns := []Column[tableUser]{
+		{Key: "name", Header: "Name", Cell: func(u tableUser) any { return u.Name }},
+		{Key: "email", Header: "Email", Cell: func(u tableUser) any { return A(AttrHref("mailto:" + u.Email))(u.Email) }, Editable: true, InputType: TypeEmail},
+	}
+	cellURL := func(u tableUser, key string) string { return "/users/" + u.Name + "/" + key }
+	user := tableUser{"Ada", "ada@example.com"}
+
+	var buf bytes.Buffer
+	if err := Render(&buf, EditableGrid([]tableUser{user}, columns, GridOptions[tableUser]{CellURL: cellURL})); err != nil {
+		t.Fatal(err)
+	}
+	expected := `<table><thead><tr><th scope="col">Name</th><th scope="col">Email</th></tr></thead><tbody><tr><td>Ada</td>` +
+		`<td class="grid-cell" tabindex="0" hx-get="/users/Ada/email/edit" hx-trigger="click, keyup[key=='Enter']" hx-swap="outerHTML">` +
+		`<a href="mailto:ada@example.com">ada@example.com</a></td></tr></tbody></table>`
+	if buf.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, buf.String())
+	}
+
+	column, ok := ColumnByKey(columns, "email")
+	if !ok {
+		t.Fatal("expected the email column to be found")
+	}
+	buf.Reset()
+	if err := Render(&buf, GridCellEditor(user, column, cellURL(user, column.Key))); err != nil {
+		t.Fatal(err)
+	}
+	expected = `<td class="grid-editor"><form hx-put="/users/Ada/email" hx-target="closest td" hx-swap="outerHTML">` +
+		`<input type="email" name="value" value="ada@example.com" aria-label="Email" autofocus hx-get="/users/Ada/email" ` +
+		`hx-trigger="keyup[key=='Escape']" hx-target="closest td" hx-swap="outerHTML"></form></td>`
+	if buf.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, buf.String())
+	}
+}
@@ -0,0 +1,218 @@
+package h
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+)
+
+// HTTPError is an error carrying the HTTP status to respond with. Return it
+// from a [HandlerFunc] to show the matching error page.
+//
+// Example:
+//
+//	if project == nil {
+//		return nil, HTTPError{Status: http.StatusNotFound}
+//	}
+type HTTPError struct {
+	Status  int
+	Message string // Shown on the error page; the page's default message when empty
+	Err     error  // Underlying cause, logged but never shown to users
+}
+
+func (me HTTPError) Error() string {
+	text := fmt.Sprintf("%d %s", me.Status, http.StatusText(me.Status))
+	if me.Message != "" {
+		text += ": " + me.Message
+	}
+	if me.Err != nil {
+		text += ": " + me.Err.Error()
+	}
+	return text
+}
+
+func (me HTTPError) Unwrap() error {
+	return me.Err
+}
+
+// HandlerFunc builds the page for a request.
+type HandlerFunc func(r *http.Request) (HyperNode, error)
+
+// HandlerOptions customizes [Handler]. All fields are optional.
+type HandlerOptions struct {
+	// ErrorPage renders the page shown when the handler returns an error,
+	// panics, or its page fails to render. The default uses [NotFoundPage],
+	// [MaintenancePage], [InternalErrorPage] or [ErrorPage] depending on status.
+	ErrorPage func(r *http.Request, status int, err error) HyperNode
+	// Layout is passed to the default error pages.
+	Layout func(title string, content HyperNode) HyperNode
+	// RequestIDHeader names the request header holding the request id shown
+	// on the default error pages; defaults to "X-Request-Id".
+	RequestIDHeader string
+	// Logger receives server errors (status >= 500); defaults to slog.Default().
+	Logger *slog.Logger
+	// SecurityHeaders, when set, are added to every response, error pages
+	// included, with a nonce given to the request context beforehand.
+	SecurityHeaders *SecurityHeaders
+	// Maintenance, when set, answers with the 503 error page instead of
+	// calling the handler function while maintenance mode is on.
+	Maintenance *MaintenanceMode
+}
+
+// Handler adapts fn to an [http.Handler]. The page is rendered into a buffer
+// before anything is written, so a failing render results in a clean error
+// page rather than a half-written response. Errors are mapped to a status
+// with [HTTPError] (500 otherwise).
+//
+// Example:
+//
+//	mux.Handle("GET /projects/{id}", Handler(func(r *http.Request) (HyperNode, error) {
+//		project, err := store.Project(r.PathValue("id"))
+//		if err != nil {
+//			return nil, err
+//		}
+//		return ProjectPage(project), nil
+//	}))
+func Handler(fn HandlerFunc, options ...HandlerOptions) http.Handler {
+	var o HandlerOptions
+	if len(options) != 0 {
+		o = options[0]
+	}
+	return handler{fn: fn, options: o}
+}
+
+type handler struct {
+	fn      HandlerFunc
+	options HandlerOptions
+}
+
+func (me handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if me.options.SecurityHeaders != nil {
+		r = withNonce(r)
+	}
+
+	buf := getBuffer(mediumBufferSize)
+	defer putBuffer(buf)
+
+	if maintenance := me.options.Maintenance; maintenance != nil && maintenance.Active(r) {
+		maintenance.setHeaders(w)
+		me.fail(w, r, buf, HTTPError{Status: http.StatusServiceUnavailable, Message: maintenance.Message, Err: errMaintenance})
+		return
+	}
+	scriptHashes, err := me.render(buf, r)
+	if err != nil {
+		me.fail(w, r, buf, err)
+		return
+	}
+	me.setSecurityHeaders(w, r, scriptHashes)
+
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	}
+	w.Write(buf.Bytes())
+}
+
+// render calls the handler function and renders its page into buf,
+// turning panics into errors. It returns the CSP hash sources of the
+// scripts externalized from the page.
+func (me handler) render(buf *bytes.Buffer, r *http.Request) (scriptHashes []string, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
+			}
+			err = fmt.Errorf("h: handler panic: %v", recovered)
+		}
+	}()
+
+	node, err := me.fn(r)
+	if err != nil {
+		return nil, err
+	}
+	if node == nil {
+		return nil, nil
+	}
+	if IsHistoryRestore(r) {
+		node = StripSensitive(node)
+	}
+	if headers := me.options.SecurityHeaders; headers != nil && headers.Scripts != nil {
+		node, scriptHashes = headers.Scripts.externalize(node)
+	}
+	return scriptHashes, RenderCtx(r.Context(), buf, node)
+}
+
+func (me handler) fail(w http.ResponseWriter, r *http.Request, buf *bytes.Buffer, err error) {
+	status := errorStatus(err)
+	me.log(r, status, err)
+
+	errorPage := me.options.ErrorPage
+	if errorPage == nil {
+		errorPage = me.defaultErrorPage
+	}
+
+	me.setSecurityHeaders(w, r, nil)
+	buf.Reset()
+	if err := errorPage(r, status, err).Render(buf); err != nil {
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(buf.Bytes())
+}
+
+// setSecurityHeaders sets the configured security headers, once the page
+// has been rendered so the policy allows its externalized scripts by hash.
+func (me handler) setSecurityHeaders(w http.ResponseWriter, r *http.Request, scriptHashes []string) {
+	if me.options.SecurityHeaders != nil {
+		me.options.SecurityHeaders.set(w, r, scriptHashes)
+	}
+}
+
+// errorStatus returns the HTTP status for err: the one of an [HTTPError],
+// or 500.
+func errorStatus(err error) int {
+	var httpErr HTTPError
+	if errors.As(err, &httpErr) && httpErr.Status != 0 {
+		return httpErr.Status
+	}
+	return http.StatusInternalServerError
+}
+
+// log reports server errors (status >= 500).
+func (me handler) log(r *http.Request, status int, err error) {
+	if status < http.StatusInternalServerError || errors.Is(err, errMaintenance) {
+		return
+	}
+	logger := me.options.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	logger.ErrorContext(r.Context(), "render failed", "method", r.Method, "path", r.URL.Path, "status", status, "error", err)
+}
+
+func (me handler) defaultErrorPage(r *http.Request, status int, err error) HyperNode {
+	params := ErrorPageParams{
+		Status:    status,
+		RequestID: r.Header.Get(IfElse(me.options.RequestIDHeader != "", me.options.RequestIDHeader, "X-Request-Id")),
+		Layout:    me.options.Layout,
+		Nonce:     Nonce(r.Context()),
+	}
+	var httpErr HTTPError
+	if errors.As(err, &httpErr) {
+		params.Message = httpErr.Message
+	}
+
+	switch status {
+	case http.StatusNotFound:
+		return NotFoundPage(params)
+	case http.StatusInternalServerError:
+		return InternalErrorPage(params)
+	case http.StatusServiceUnavailable:
+		return MaintenancePage(params)
+	default:
+		return ErrorPage(params)
+	}
+}
@@ -0,0 +1,114 @@
+package h
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type failingNode struct{}
+
+func (failingNode) Render(io.Writer) error { return errors.New("boom") }
+
+func TestHandler(t *testing.T) {
+	quiet := HandlerOptions{Logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	tests := []struct {
+		name     string
+		fn       HandlerFunc
+		status   int
+		contains []string
+	}{
+		{
+			name:     "Page",
+			fn:       func(*http.Request) (HyperNode, error) { return P()("hello"), nil },
+			status:   http.StatusOK,
+			contains: []string{"<p>hello</p>"},
+		},
+		{
+			name:     "Not found",
+			fn:       func(*http.Request) (HyperNode, error) { return nil, HTTPError{Status: http.StatusNotFound} },
+			status:   http.StatusNotFound,
+			contains: []string{"<title>Page not found</title>", "Request ID: <code>req-1</code>"},
+		},
+		{
+			name: "Custom message",
+			fn: func(*http.Request) (HyperNode, error) {
+				return nil, HTTPError{Status: http.StatusForbidden, Message: "No access."}
+			},
+			status:   http.StatusForbidden,
+			contains: []string{"<h1>Forbidden</h1>", "No access."},
+		},
+		{
+			name:     "Render failure",
+			fn:       func(*http.Request) (HyperNode, error) { return DIV()(P()("partial"), failingNode{}), nil },
+			status:   http.StatusInternalServerError,
+			contains: []string{"Something went wrong"},
+		},
+		{
+			name:     "Panic",
+			fn:       func(*http.Request) (HyperNode, error) { panic("oops") },
+			status:   http.StatusInternalServerError,
+			contains: []string{"Something went wrong"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "/", nil)
+			r.Header.Set("X-Request-Id", "req-1")
+			Handler(tt.fn, quiet).ServeHTTP(w, r)
+
+			if w.Code != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, w.Code)
+			}
+			body := w.Body.String()
+			if strings.Contains(body, "partial") {
+				t.Errorf("partial output leaked into the response: %q", body)
+			}
+			for _, s := range tt.contains {
+				if !strings.Contains(body, s) {
+					t.Errorf("expected %q in %q", s, body)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerErrorPageLayout(t *testing.T) {
+	layout := func(title string, content HyperNode) HyperNode {
+		return DIV(AttrClass("app"))(content)
+	}
+	w := httptest.NewRecorder()
+	Handler(func(*http.Request) (HyperNode, error) {
+		return nil, HTTPError{Status: http.StatusServiceUnavailable}
+	}, HandlerOptions{Layout: layout}).ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+	body := w.Body.String()
+	if !strings.HasPrefix(body, `<div class="app"><main class="error-page">`) || !strings.Contains(body, "Down for maintenance") {
+		t.Errorf("unexpected body %q", body)
+	}
+}
+
+func TestHandlerErrorPageNonce(t *testing.T) {
+	handler := SecurityHeaders{}.Middleware(Handler(func(*http.Request) (HyperNode, error) {
+		return nil, HTTPError{Status: http.StatusNotFound}
+	}))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+	policy := w.Header().Get("Content-Security-Policy")
+	start := strings.Index(policy, "'nonce-")
+	if start < 0 {
+		t.Fatalf("expected a nonce in the policy %q", policy)
+	}
+	nonce, _, _ := strings.Cut(policy[start+len("'nonce-"):], "'")
+	if expected := `<style nonce="` + nonce + `">`; !strings.Contains(w.Body.String(), expected) {
+		t.Errorf("expected %q in %q", expected, w.Body.String())
+	}
+}
@@ -0,0 +1,87 @@
+package hx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+// ClassToast is the default class of the error toasts shown by [Defaults].
+const ClassToast = "hx-toast"
+
+// DefaultsParams configures [Defaults]. All fields are optional.
+type DefaultsParams struct {
+	// Timeout aborts requests taking longer; defaults to 10 seconds.
+	Timeout time.Duration
+	// Retries is the number of times a failed GET is retried, with
+	// exponential backoff; defaults to 3. Negative disables retries.
+	Retries int
+	// RetryDelay is the delay before the first retry, doubled for each of
+	// the next ones; defaults to 1 second.
+	RetryDelay time.Duration
+	// ErrorMessage is shown when the server answers with an error status.
+	ErrorMessage string
+	// NetworkMessage is shown when the server can't be reached.
+	NetworkMessage string
+	// ToastClass is the class of the toasts; defaults to [ClassToast]. The
+	// toasts are styled inline, so the class is only needed to restyle them.
+	ToastClass string
+}
+
+// defaultsScript shows a toast for failed requests, after retrying the
+// failed GETs (idempotent, so safe to retry) with exponential backoff.
+// Server errors (5xx) and network errors are retried; client errors (4xx)
+// aren't, as they would fail again.
+const defaultsScript = `(function(){var c=%s,attempts=new WeakMap();
+function toast(message){var box=document.getElementById("hx-toasts");if(!box){box=document.createElement("div");box.id="hx-toasts";box.setAttribute("aria-live","assertive");box.style.cssText="position:fixed;bottom:1rem;right:1rem;z-index:9999;display:flex;flex-direction:column;gap:.5rem";document.body.appendChild(box)}
+var t=document.createElement("div");t.className=c.toastClass;t.setAttribute("role","alert");t.style.cssText="padding:.75rem 1rem;border-radius:.375rem;background:#991b1b;color:#fff;box-shadow:0 4px 12px rgba(0,0,0,.2)";t.textContent=message;box.appendChild(t);setTimeout(function(){t.remove()},5000)}
+function failed(event,network){var d=event.detail,elt=d.elt,n=attempts.get(elt)||0;
+if(d.requestConfig&&d.requestConfig.verb==="get"&&n<c.retries&&(network||d.xhr.status>=500)){attempts.set(elt,n+1);setTimeout(function(){htmx.ajax("get",d.pathInfo.requestPath,{source:elt,target:d.target})},c.retryDelay*Math.pow(2,n));return}
+attempts.delete(elt);toast(network?c.networkMessage:c.errorMessage)}
+document.addEventListener("htmx:afterRequest",function(event){if(event.detail.successful)attempts.delete(event.detail.elt)});
+document.addEventListener("htmx:responseError",function(event){failed(event,false)});
+document.addEventListener("htmx:sendError",function(event){failed(event,true)});
+document.addEventListener("htmx:timeout",function(event){failed(event,true)})})()`
+
+// Defaults returns the <meta> and <script> elements wiring the standard
+// behavior of htmx requests: a global timeout, retries of failed GETs with
+// exponential backoff, and an error toast when a request finally fails.
+// Include it once in the <head>, after htmx. The script carries the CSP
+// nonce of ctx (see h.NonceKey).
+//
+// Example:
+//
+//	h.HEAD()(
+//		h.SCRIPT(h.AttrSrc("/static/htmx.min.js"))(),
+//		hx.Defaults(ctx, hx.DefaultsParams{ErrorMessage: "Oups, something went wrong."}),
+//	)
+func Defaults(ctx context.Context, params ...DefaultsParams) h.HyperNode {
+	var p DefaultsParams
+	if len(params) != 0 {
+		p = params[0]
+	}
+	timeout := h.IfElse(p.Timeout > 0, p.Timeout, 10*time.Second)
+	retries := h.IfElse(p.Retries != 0, max(p.Retries, 0), 3)
+	retryDelay := h.IfElse(p.RetryDelay > 0, p.RetryDelay, time.Second)
+
+	htmxConfig, _ := json.Marshal(map[string]any{"timeout": timeout.Milliseconds()})
+	config, _ := json.Marshal(map[string]any{
+		"retries":        retries,
+		"retryDelay":     retryDelay.Milliseconds(),
+		"errorMessage":   h.IfElse(p.ErrorMessage != "", p.ErrorMessage, "Something went wrong. Please try again."),
+		"networkMessage": h.IfElse(p.NetworkMessage != "", p.NetworkMessage, "Can't reach the server. Check your connection and try again."),
+		"toastClass":     h.IfElse(p.ToastClass != "", p.ToastClass, ClassToast),
+	})
+
+	var scriptAttrs []h.Attribute
+	if nonce := h.Nonce(ctx); nonce != "" {
+		scriptAttrs = append(scriptAttrs, h.AttrNonce(nonce))
+	}
+	return h.Group(
+		h.META(h.AttrName("htmx-config"), h.AttrContent(string(htmxConfig))),
+		h.SCRIPT(scriptAttrs...)(h.RawText(fmt.Sprintf(defaultsScript, config))),
+	)
+}
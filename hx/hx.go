@@ -0,0 +1,93 @@
+// Package hx provides typed helpers for htmx behaviors that take more than
+// a single attribute: event handlers, optimistic updates, request defaults.
+//
+// Attributes htmx reads directly (hx-get, hx-target...) are written with
+// h.Attr:
+//
+//	h.BUTTON(h.Attr("hx-post", "/like"), hx.Optimistic(hx.OptimisticParams{...}))("Like")
+package hx
+
+import (
+	"strings"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+// Events fired by htmx, for [On] and addEventListener.
+const (
+	EventAbort             = "htmx:abort"
+	EventAfterOnLoad       = "htmx:afterOnLoad"
+	EventAfterProcessNode  = "htmx:afterProcessNode"
+	EventAfterRequest      = "htmx:afterRequest"
+	EventAfterSettle       = "htmx:afterSettle"
+	EventAfterSwap         = "htmx:afterSwap"
+	EventBeforeHistorySave = "htmx:beforeHistorySave"
+	EventBeforeRequest     = "htmx:beforeRequest"
+	EventBeforeSend        = "htmx:beforeSend"
+	EventBeforeSwap        = "htmx:beforeSwap"
+	EventConfigRequest     = "htmx:configRequest"
+	EventHistoryRestore    = "htmx:historyRestore"
+	EventLoad              = "htmx:load"
+	EventResponseError     = "htmx:responseError"
+	EventSendError         = "htmx:sendError"
+	EventTimeout           = "htmx:timeout"
+)
+
+// On returns an hx-on attribute running script when event fires on the
+// element. htmx events are written in kebab-case, as attribute names are
+// case-insensitive: On(EventAfterRequest, ...) renders
+// hx-on:htmx:after-request.
+//
+// Example:
+//
+//	h.FORM(hx.On(hx.EventAfterRequest, "if (event.detail.successful) this.reset()"))(...)
+func On(event, script string) h.Attribute {
+	return h.Attr("hx-on:"+kebabCase(event), script)
+}
+
+// kebabCase converts the camelCase name of an htmx event to kebab-case.
+func kebabCase(event string) string {
+	var b strings.Builder
+	for _, r := range event {
+		if r >= 'A' && r <= 'Z' {
+			b.WriteByte('-')
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// NoHistory returns the hx-history="false" attribute, which keeps htmx from
+// saving the page holding the element to its localStorage history cache.
+// See also h.Sensitive.
+func NoHistory() h.Attribute {
+	return h.Attr("hx-history", "false")
+}
+
+// PreloadTrigger is the event starting a preload, for [Preload].
+type PreloadTrigger string
+
+const (
+	// PreloadMouseDown preloads when the link is pressed, ~100ms ahead of
+	// the click.
+	PreloadMouseDown PreloadTrigger = "mousedown"
+	// PreloadMouseOver preloads when the pointer rests on the link.
+	PreloadMouseOver PreloadTrigger = "mouseover"
+	// PreloadInit preloads as soon as the page is loaded; reserve it for
+	// the most likely next page.
+	PreloadInit PreloadTrigger = "preload:init"
+)
+
+// Preload returns the attribute making the htmx preload extension fetch
+// the element's link ahead of the click. The extension must be enabled on
+// an ancestor with [PreloadExtension]; see also h.PrefetchLinks.
+func Preload(trigger PreloadTrigger) h.Attribute {
+	return h.Attr("preload", string(trigger))
+}
+
+// PreloadExtension returns the hx-ext attribute enabling the htmx preload
+// extension for the element and its descendants.
+func PreloadExtension() h.Attribute {
+	return h.Attr("hx-ext", "preload")
+}
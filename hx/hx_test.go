@@ -0,0 +1,82 @@
+package hx
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+func render(t *testing.T, node h.HyperNode) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := h.Render(&buf, node); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestOn(t *testing.T) {
+	got := render(t, h.FORM(On(EventAfterRequest, "this.reset()"))())
+	if expected := `<form hx-on:htmx:after-request="this.reset()"></form>`; got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestOptimistic(t *testing.T) {
+	got := render(t, h.BUTTON(Optimistic(OptimisticParams{
+		Disable: "this",
+		Apply:   "this.classList.toggle('liked')",
+		Revert:  "this.classList.toggle('liked')",
+	}))("Like"))
+
+	expected := `<button` +
+		` hx-on:htmx:before-request="this.classList.add('pending');this.setAttribute('aria-busy','true');this.classList.toggle('liked')"` +
+		` hx-on:htmx:after-request="this.classList.remove('pending');this.removeAttribute('aria-busy')"` +
+		` hx-disabled-elt="this"` +
+		` hx-on:htmx:response-error="this.classList.toggle('liked')"` +
+		` hx-on:htmx:send-error="this.classList.toggle('liked')"` +
+		`>Like</button>`
+	if got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestDefaults(t *testing.T) {
+	ctx := h.WithValue(context.Background(), h.NonceKey, "abc")
+	got := render(t, Defaults(ctx, DefaultsParams{Timeout: 5 * time.Second, Retries: -1}))
+
+	for _, expected := range []string{
+		`<meta name="htmx-config" content="{&quot;timeout&quot;:5000}">`,
+		`<script nonce="abc">(function(){var c={"errorMessage":"Something went wrong. Please try again.",`,
+		`"retries":0,"retryDelay":1000,`,
+		`htmx:responseError`,
+	} {
+		if !strings.Contains(got, expected) {
+			t.Errorf("expected %q in %q", expected, got)
+		}
+	}
+}
+
+func TestSwap(t *testing.T) {
+	noFocusScroll := false
+	tests := []struct {
+		swap     Swap
+		expected string
+	}{
+		{swap: Swap{}, expected: ` hx-swap=""`},
+		{swap: Swap{Style: SwapBeforeEnd, Scroll: ScrollBottom}, expected: ` hx-swap="beforeend scroll:bottom"`},
+		{swap: Swap{Style: SwapOuterHTML, Show: ScrollTop, ShowTarget: "#results", FocusScroll: &noFocusScroll}, expected: ` hx-swap="outerHTML show:#results:top focus-scroll:false"`},
+		{swap: Swap{NoShow: true, Transition: true, Settle: 100 * time.Millisecond}, expected: ` hx-swap="show:none transition:true settle:100ms"`},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		test.swap.Attr().Render(&buf)
+		if buf.String() != test.expected {
+			t.Errorf("expected %q, got %q", test.expected, buf.String())
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package hx
+
+import (
+	"strings"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+// ClassPending is the default class of elements with a request in flight.
+const ClassPending = "pending"
+
+// OptimisticParams configures [Optimistic]. All fields are optional.
+type OptimisticParams struct {
+	// Disable is the hx-disabled-elt selector of the elements disabled
+	// during the request, e.g. "find button" or "this".
+	Disable string
+	// PendingClass is added to the element during the request; defaults to
+	// [ClassPending]. Style it to show the pending state.
+	PendingClass string
+	// Apply is a script updating the page before the response arrives,
+	// e.g. "this.classList.toggle('liked')".
+	Apply string
+	// Revert is a script undoing Apply, run when the request fails
+	// (htmx:responseError or htmx:sendError).
+	Revert string
+}
+
+// Optimistic returns the attributes showing the pending state of an
+// element's request — disabled controls, a pending class and aria-busy —
+// and optionally applying its outcome right away, rolled back if the
+// request fails.
+//
+// Example:
+//
+//	h.BUTTON(
+//		h.Attr("hx-post", "/posts/42/like"),
+//		h.Attr("hx-swap", "none"),
+//		hx.Optimistic(hx.OptimisticParams{
+//			Disable: "this",
+//			Apply:   "this.classList.toggle('liked')",
+//			Revert:  "this.classList.toggle('liked')",
+//		}),
+//	)("Like")
+func Optimistic(params OptimisticParams) h.Attributes {
+	class := jsString(h.IfElse(params.PendingClass != "", params.PendingClass, ClassPending))
+
+	before := []string{"this.classList.add(" + class + ")", "this.setAttribute('aria-busy','true')"}
+	if params.Apply != "" {
+		before = append(before, params.Apply)
+	}
+	after := []string{"this.classList.remove(" + class + ")", "this.removeAttribute('aria-busy')"}
+
+	attrs := h.Attributes{
+		On(EventBeforeRequest, strings.Join(before, ";")),
+		On(EventAfterRequest, strings.Join(after, ";")),
+	}
+	if params.Disable != "" {
+		attrs = append(attrs, h.Attr("hx-disabled-elt", params.Disable))
+	}
+	if params.Revert != "" {
+		attrs = append(attrs,
+			On(EventResponseError, params.Revert),
+			On(EventSendError, params.Revert),
+		)
+	}
+	return attrs
+}
+
+// Classes returns the attributes of the htmx class-tools extension, which
+// adds, removes or toggles classes on a schedule, e.g.
+// "add fade-out:2s" to fade out a confirmation.
+func Classes(spec string) h.Attributes {
+	return h.Attributes{h.Attr("hx-ext", "class-tools"), h.Attr("classes", spec)}
+}
+
+// jsString quotes s as a single-quoted JavaScript string, for use in
+// attributes.
+func jsString(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
@@ -0,0 +1,121 @@
+package hx
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+// Swap styles, for [Swap.Style].
+const (
+	SwapInnerHTML   = "innerHTML"
+	SwapOuterHTML   = "outerHTML"
+	SwapBeforeBegin = "beforebegin"
+	SwapAfterBegin  = "afterbegin"
+	SwapBeforeEnd   = "beforeend"
+	SwapAfterEnd    = "afterend"
+	SwapDelete      = "delete"
+	SwapNone        = "none"
+)
+
+// Scroll positions, for [Swap.Scroll] and [Swap.Show].
+const (
+	ScrollTop    = "top"
+	ScrollBottom = "bottom"
+)
+
+// Swap describes an hx-swap attribute: how the response is swapped in,
+// and where the page scrolls afterwards. All fields are optional.
+type Swap struct {
+	Style string // One of the Swap* constants; htmx defaults to innerHTML
+	// Scroll scrolls the target ([ScrollTop] or [ScrollBottom]), e.g. to
+	// the bottom of a chat. ScrollTarget scrolls another element instead,
+	// by selector ("window" for the page).
+	Scroll       string
+	ScrollTarget string
+	// Show scrolls the page so the top or bottom of the target is visible.
+	// ShowTarget shows another element instead, by selector.
+	Show       string
+	ShowTarget string
+	// NoShow disables the scrolling htmx does for boosted links
+	// (show:none).
+	NoShow bool
+	// FocusScroll scrolls to the focused input after the swap when true,
+	// and keeps htmx from doing so when false.
+	FocusScroll *bool
+	Transition  bool          // Uses the View Transitions API
+	Swap        time.Duration // Delay between receiving and swapping the response
+	Settle      time.Duration // Delay between swapping and settling
+}
+
+// Attr returns the hx-swap attribute.
+//
+// Example:
+//
+//	hx.Swap{Style: hx.SwapBeforeEnd, Scroll: hx.ScrollBottom}.Attr() // hx-swap="beforeend scroll:bottom"
+func (me Swap) Attr() h.Attribute {
+	var parts []string
+	if me.Style != "" {
+		parts = append(parts, me.Style)
+	}
+	position := func(modifier, target, pos string) {
+		if pos == "" {
+			return
+		}
+		if target != "" {
+			parts = append(parts, modifier+":"+target+":"+pos)
+		} else {
+			parts = append(parts, modifier+":"+pos)
+		}
+	}
+	position("scroll", me.ScrollTarget, me.Scroll)
+	position("show", me.ShowTarget, me.Show)
+	if me.NoShow {
+		parts = append(parts, "show:none")
+	}
+	if me.FocusScroll != nil {
+		parts = append(parts, "focus-scroll:"+h.IfElse(*me.FocusScroll, "true", "false"))
+	}
+	if me.Transition {
+		parts = append(parts, "transition:true")
+	}
+	if me.Swap > 0 {
+		parts = append(parts, "swap:"+me.Swap.String())
+	}
+	if me.Settle > 0 {
+		parts = append(parts, "settle:"+me.Settle.String())
+	}
+	return h.Attr("hx-swap", strings.Join(parts, " "))
+}
+
+// focusScript moves the focus into swapped content when the swap left it
+// on nothing (the focused element was replaced) or the target asks for it
+// with data-focus-on-swap: to the [autofocus] element, or else the first
+// heading, made focusable. Screen reader and keyboard users otherwise lose
+// their place, the most common accessibility regression of htmx pages.
+const focusScript = `document.addEventListener("htmx:afterSettle",function(event){var t=event.detail.target;if(!t||!t.isConnected)return;var a=document.activeElement;
+if(a&&a!==document.body&&a.isConnected&&!t.hasAttribute("data-focus-on-swap"))return;
+var f=t.querySelector("[autofocus]")||t.querySelector("h1,h2,h3,h4,h5,h6");if(!f)return;
+if(!f.hasAttribute("autofocus")&&!f.hasAttribute("tabindex"))f.setAttribute("tabindex","-1");f.focus({preventScroll:true})})`
+
+// FocusManagement returns the script moving the focus into swapped
+// content, to include once in the <head> after htmx: after a swap that
+// replaced the focused element, or into a target with [FocusOnSwap], focus
+// moves to its [autofocus] element, or else its first heading. The script
+// carries the CSP nonce of ctx (see h.NonceKey).
+func FocusManagement(ctx context.Context) h.HyperNode {
+	var attrs []h.Attribute
+	if nonce := h.Nonce(ctx); nonce != "" {
+		attrs = append(attrs, h.AttrNonce(nonce))
+	}
+	return h.SCRIPT(attrs...)(h.RawText(focusScript))
+}
+
+// FocusOnSwap returns the attribute making [FocusManagement] always move
+// the focus into the element after it is swapped, e.g. for the main
+// content region of boosted navigation.
+func FocusOnSwap() h.Attribute {
+	return h.Attr("data-focus-on-swap", true)
+}
@@ -0,0 +1,107 @@
+package hypertest
+
+import (
+	"fmt"
+	"strings"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+// AuditBoost reports the links and forms of node that break when boosted
+// by htmx (hx-boost="true" on them or an ancestor), to adopt boosting
+// safely. See [BoostRules].
+//
+// Example:
+//
+//	func TestBoost(t *testing.T) {
+//		issues, err := hypertest.AuditBoost(Layout(HomePage(fixture)))
+//		...
+//	}
+func AuditBoost(node h.HyperNode) ([]Issue, error) {
+	return Lint(node, BoostRules()...)
+}
+
+// BoostRules returns the rules of [AuditBoost], to combine with others.
+func BoostRules() []Rule {
+	return []Rule{BoostTargets, BoostExternalLinks, BoostMultipartForms}
+}
+
+// boosted reports whether e is boosted: the closest hx-boost attribute on
+// it or its ancestors is "true".
+func boosted(e LintElement) bool {
+	if value, ok := e.Attributes["hx-boost"]; ok {
+		return value == "true"
+	}
+	for i := len(e.Ancestors) - 1; i >= 0; i-- {
+		if value, ok := e.Ancestors[i].Attributes["hx-boost"]; ok {
+			return value == "true"
+		}
+	}
+	return false
+}
+
+// isBoostable reports whether e is a link or form htmx boosts.
+func isBoostable(e LintElement) bool {
+	switch e.Tag {
+	case "a":
+		_, ok := e.Attributes["href"]
+		return ok
+	case "form":
+		return true
+	}
+	return false
+}
+
+// BoostTargets reports boosted links and forms whose hx-target (on them or
+// inherited) refers to an id that no element of the page has, which makes
+// htmx drop the response.
+var BoostTargets = Rule{Name: "boost-targets", Check: func(elements []LintElement) []Issue {
+	ids := map[string]bool{}
+	for _, e := range elements {
+		if id := e.Attributes["id"]; id != "" {
+			ids[id] = true
+		}
+	}
+
+	var issues []Issue
+	for _, e := range elements {
+		if !isBoostable(e) || !boosted(e) {
+			continue
+		}
+		target, ok := e.Attributes["hx-target"]
+		for i := len(e.Ancestors) - 1; !ok && i >= 0; i-- {
+			target, ok = e.Ancestors[i].Attributes["hx-target"]
+		}
+		if id, isID := strings.CutPrefix(target, "#"); isID && !ids[id] {
+			issues = append(issues, Issue{Path: e.Path, Message: fmt.Sprintf("hx-target %q matches no element", target)})
+		}
+	}
+	return issues
+}}
+
+// BoostExternalLinks reports boosted links and forms pointing to another
+// site, which htmx would request with AJAX, failing on CORS. Opt them out
+// with hx-boost="false".
+var BoostExternalLinks = ElementRule("boost-external-links", func(e LintElement) string {
+	if !isBoostable(e) || !boosted(e) {
+		return ""
+	}
+	key := h.IfElse(e.Tag == "a", "href", "action")
+	value := strings.ToLower(strings.TrimSpace(e.Attributes[key]))
+	if strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://") || strings.HasPrefix(value, "//") {
+		return fmt.Sprintf(`external %s %s; add hx-boost="false"`, key, e.Attributes[key])
+	}
+	return ""
+})
+
+// BoostMultipartForms reports boosted multipart forms without
+// hx-encoding="multipart/form-data", whose files htmx wouldn't upload.
+var BoostMultipartForms = ElementRule("boost-multipart-forms", func(e LintElement) string {
+	if e.Tag != "form" || !boosted(e) || e.Attributes["enctype"] != h.EnctypeMultipartForm {
+		return ""
+	}
+	if e.Attributes["hx-encoding"] == h.EnctypeMultipartForm {
+		return ""
+	}
+	return `multipart form without hx-encoding="multipart/form-data"`
+})
@@ -0,0 +1,45 @@
+package hypertest
+
+import (
+	"testing"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+func TestAuditBoost(t *testing.T) {
+	page := h.BODY(h.Attr("hx-boost", "true"), h.Attr("hx-target", "#content"))(
+		h.NAV()(
+			h.A(h.AttrHref("/about"))("About"),
+			h.A(h.AttrHref("https://github.com/assaidy/hyper"))("GitHub"),
+			h.A(h.AttrHref("https://example.com"), h.Attr("hx-boost", "false"))("Elsewhere"),
+			h.A(h.AttrHref("/help"), h.Attr("hx-target", "#help"))("Help"),
+		),
+		h.MAIN(h.AttrID("content"))(
+			h.FORM(h.AttrAction("/upload"), h.AttrMethod(h.MethodPost), h.AttrEncType(h.EnctypeMultipartForm))(),
+			h.FORM(h.AttrAction("/upload"), h.AttrMethod(h.MethodPost), h.AttrEncType(h.EnctypeMultipartForm), h.Attr("hx-encoding", h.EnctypeMultipartForm))(),
+		),
+	)
+
+	issues, err := AuditBoost(page)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		`body > nav > a[4]: boost-targets: hx-target "#help" matches no element`,
+		`body > nav > a[2]: boost-external-links: external href https://github.com/assaidy/hyper; add hx-boost="false"`,
+		`body > main > form: boost-multipart-forms: multipart form without hx-encoding="multipart/form-data"`,
+	}
+	if len(issues) != len(expected) {
+		t.Fatalf("expected %d issues, got %v", len(expected), issues)
+	}
+	for i, issue := range issues {
+		if issue.String() != expected[i] {
+			t.Errorf("expected %s, got %s", expected[i], issue)
+		}
+	}
+
+	// Nothing is reported outside boosted regions.
+	if issues, _ := AuditBoost(h.A(h.AttrHref("https://example.com"))("x")); len(issues) != 0 {
+		t.Errorf("expected no issues, got %v", issues)
+	}
+}
@@ -0,0 +1,74 @@
+// Package hypertest provides helpers for testing code built with hyper.
+package hypertest
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+// CheckComponents instantiates every component registered with
+// [h.RegisterComponent] with zero-valued props and checks that it either
+// renders, or is rejected by validation. A component panicking or failing to
+// render with zero props, without declaring the props it depends on as
+// required (or checking them in Validate), is reported as an error. The
+// missing required props of each component are logged, documenting them.
+//
+// Example:
+//
+//	func TestComponents(t *testing.T) {
+//		hypertest.CheckComponents(t)
+//	}
+func CheckComponents(t testing.TB) {
+	t.Helper()
+	for _, componentType := range h.RegisteredComponents() {
+		component, ok := zeroComponent(componentType)
+		if !ok {
+			t.Errorf("%s: cannot instantiate a zero value implementing h.Component", componentType)
+			continue
+		}
+
+		err := h.ValidateProps(component)
+		var missing h.MissingPropsError
+		switch {
+		case errors.As(err, &missing):
+			t.Logf("%s: required props: %v", componentType, missing.Props)
+			continue
+		case err != nil:
+			t.Logf("%s: zero props rejected: %v", componentType, err)
+			continue
+		}
+
+		if err := render(component); err != nil {
+			t.Errorf("%s: rendering with zero props failed: %v; mark the props it needs as `hyper:\"required\"` or check them in Validate", componentType, err)
+		}
+	}
+}
+
+// zeroComponent returns the zero value of componentType, or a pointer to a
+// zero value for components with pointer receivers.
+func zeroComponent(componentType reflect.Type) (h.Component, bool) {
+	if componentType.Kind() == reflect.Pointer {
+		component, ok := reflect.New(componentType.Elem()).Interface().(h.Component)
+		return component, ok
+	}
+	component, ok := reflect.Zero(componentType).Interface().(h.Component)
+	return component, ok
+}
+
+func render(component h.Component) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("panic: %v", recovered)
+		}
+	}()
+	node := component.Node()
+	if node == nil {
+		return errors.New("nil node")
+	}
+	return node.Render(io.Discard)
+}
@@ -0,0 +1,38 @@
+package hypertest
+
+import (
+	"testing"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+type greeting struct {
+	Name string `hyper:"required"`
+}
+
+func (me greeting) Node() h.HyperNode { return h.P()("Hello, ", me.Name) }
+
+type fragile struct {
+	Items []string
+}
+
+func (me fragile) Node() h.HyperNode { return h.P()(me.Items[0]) }
+
+type recorder struct {
+	testing.TB
+	errors int
+}
+
+func (me *recorder) Helper()               {}
+func (me *recorder) Logf(string, ...any)   {}
+func (me *recorder) Errorf(string, ...any) { me.errors++ }
+
+func TestCheckComponents(t *testing.T) {
+	h.RegisterComponent(greeting{}, fragile{})
+
+	r := &recorder{TB: t}
+	CheckComponents(r)
+	if r.errors != 1 {
+		t.Errorf("expected the fragile component to be reported, got %d errors", r.errors)
+	}
+}
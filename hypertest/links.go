@@ -0,0 +1,230 @@
+package hypertest
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+// Page is a page of a site checked by [CheckLinks]: its URL path and its
+// content.
+type Page struct {
+	URL  string // e.g. "/docs/install"
+	Node h.HyperNode
+}
+
+// LinkCheckOptions configures [CheckLinks]. All fields are optional.
+type LinkCheckOptions struct {
+	// Handler serves the internal URLs that aren't pages, such as assets
+	// and dynamic routes; they are broken when it answers with an error
+	// status. When nil, internal links must point at pages.
+	Handler http.Handler
+	// External enables requesting the http(s) links to other sites, which
+	// are broken when they answer with an error status or not at all.
+	External bool
+	// Client requests external links; defaults to a client with a 10
+	// seconds timeout.
+	Client *http.Client
+}
+
+// BrokenLink is a link found broken by [CheckLinks].
+type BrokenLink struct {
+	Page      string // URL of the page holding the link
+	Path      string // Path of the element, e.g. "main > ul > li[2] > a"
+	Attribute string // "href" or "src"
+	URL       string // As written in the attribute
+	Reason    string
+}
+
+func (me BrokenLink) String() string {
+	return fmt.Sprintf("%s: %s %s=%q: %s", me.Page, me.Path, me.Attribute, me.URL, me.Reason)
+}
+
+// linkAttributes are the attributes holding URLs checked by CheckLinks.
+var linkAttributes = []string{"href", "src"}
+
+// CheckLinks checks the href and src URLs of pages: internal links must
+// point at one of the pages or be served by the handler of options, and
+// fragments of links to pages must match an id of the page. Links with
+// other schemes (mailto:, tel:, data:...) are ignored.
+//
+// Example:
+//
+//	var pages []hypertest.Page
+//	for _, post := range fixture.Posts {
+//		pages = append(pages, hypertest.Page{URL: "/blog/" + post.Slug, Node: PostPage(post)})
+//	}
+//	broken, err := hypertest.CheckLinks(pages, hypertest.LinkCheckOptions{Handler: staticFiles})
+func CheckLinks(pages []Page, options ...LinkCheckOptions) ([]BrokenLink, error) {
+	var o LinkCheckOptions
+	if len(options) != 0 {
+		o = options[0]
+	}
+	checker := linkChecker{
+		options:  o,
+		ids:      map[string]map[string]bool{},
+		external: map[string]string{},
+	}
+	if checker.options.Client == nil {
+		checker.options.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	elements := make([][]LintElement, len(pages))
+	for i, page := range pages {
+		nodes, err := normalize([]h.HyperNode{page.Node})
+		if err != nil {
+			return nil, fmt.Errorf("hypertest: page %s: %w", page.URL, err)
+		}
+		collectElements(&elements[i], nil, nodes)
+
+		ids := map[string]bool{}
+		for _, element := range elements[i] {
+			if id, ok := element.Attributes["id"]; ok {
+				ids[html.UnescapeString(id)] = true
+			}
+			if name, ok := element.Attributes["name"]; ok && element.Tag == "a" {
+				ids[html.UnescapeString(name)] = true
+			}
+		}
+		checker.ids[pagePath(page.URL)] = ids
+	}
+
+	var broken []BrokenLink
+	for i, page := range pages {
+		base, err := url.Parse(page.URL)
+		if err != nil {
+			return nil, fmt.Errorf("hypertest: page %s: %w", page.URL, err)
+		}
+		for _, element := range elements[i] {
+			for _, attribute := range linkAttributes {
+				value, ok := element.Attributes[attribute]
+				if !ok {
+					continue
+				}
+				value = html.UnescapeString(value)
+				if reason := checker.check(base, value); reason != "" {
+					broken = append(broken, BrokenLink{
+						Page:      page.URL,
+						Path:      element.Path,
+						Attribute: attribute,
+						URL:       value,
+						Reason:    reason,
+					})
+				}
+			}
+		}
+	}
+	return broken, nil
+}
+
+// AssertLinks reports the links found broken by [CheckLinks] as test
+// errors.
+//
+// Example:
+//
+//	func TestLinks(t *testing.T) {
+//		hypertest.AssertLinks(t, sitePages(fixture), hypertest.LinkCheckOptions{Handler: app})
+//	}
+func AssertLinks(t testing.TB, pages []Page, options ...LinkCheckOptions) {
+	t.Helper()
+	broken, err := CheckLinks(pages, options...)
+	if err != nil {
+		t.Errorf("links: %v", err)
+		return
+	}
+	for _, link := range broken {
+		t.Errorf("broken link: %s", link)
+	}
+}
+
+type linkChecker struct {
+	options  LinkCheckOptions
+	ids      map[string]map[string]bool // Ids of the pages by path
+	external map[string]string          // Results of the external links
+}
+
+// check returns why the link to value, on the page at base, is broken, or
+// "" when it isn't.
+func (me linkChecker) check(base *url.URL, value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "empty URL"
+	}
+	target, err := url.Parse(value)
+	if err != nil {
+		return "malformed URL"
+	}
+	switch {
+	case target.Scheme == "http" || target.Scheme == "https" || (target.Scheme == "" && target.Host != ""):
+		if target.Scheme == "" {
+			target.Scheme = "https"
+		}
+		return me.checkExternal(target.String())
+	case target.Scheme != "":
+		return ""
+	}
+
+	target = base.ResolveReference(target)
+	if ids, ok := me.ids[pagePath(target.Path)]; ok {
+		if target.Fragment != "" && !ids[target.Fragment] {
+			return fmt.Sprintf("no element with id %q", target.Fragment)
+		}
+		return ""
+	}
+	if me.options.Handler == nil {
+		return "no such page"
+	}
+
+	w := httptest.NewRecorder()
+	me.options.Handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target.RequestURI(), nil))
+	if w.Code >= http.StatusBadRequest {
+		return fmt.Sprintf("status %d", w.Code)
+	}
+	return ""
+}
+
+func (me linkChecker) checkExternal(u string) string {
+	if !me.options.External {
+		return ""
+	}
+	if reason, ok := me.external[u]; ok {
+		return reason
+	}
+
+	reason := ""
+	response, err := me.options.Client.Head(u)
+	if err == nil && (response.StatusCode == http.StatusMethodNotAllowed || response.StatusCode == http.StatusNotImplemented) {
+		response.Body.Close()
+		// Some servers don't support HEAD.
+		response, err = me.options.Client.Get(u)
+	}
+	switch {
+	case err != nil:
+		reason = err.Error()
+	case response.StatusCode >= http.StatusBadRequest:
+		reason = fmt.Sprintf("status %d", response.StatusCode)
+	}
+	if err == nil {
+		response.Body.Close()
+	}
+	me.external[u] = reason
+	return reason
+}
+
+// pagePath returns the path of a page URL, without query nor fragment,
+// with "/" for the root.
+func pagePath(u string) string {
+	path, _, _ := strings.Cut(u, "?")
+	path, _, _ = strings.Cut(path, "#")
+	if path == "" {
+		return "/"
+	}
+	return path
+}
@@ -0,0 +1,69 @@
+package hypertest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+func TestCheckLinks(t *testing.T) {
+	external := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/gone" {
+			http.NotFound(w, r)
+		}
+	}))
+	defer external.Close()
+
+	assets := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/logo.png" {
+			http.NotFound(w, r)
+		}
+	})
+
+	pages := []Page{
+		{URL: "/", Node: h.MAIN()(
+			h.IMG(h.AttrSrc("/logo.png"), h.AttrAlt("")),
+			h.A(h.AttrHref("/docs#install"))("Install"),
+			h.A(h.AttrHref("/docs#missing"))("Missing"),
+			h.A(h.AttrHref("/pricing"))("Pricing"),
+			h.A(h.AttrHref("mailto:team@example.com"))("Mail"),
+			h.A(h.AttrHref(external.URL+"/ok"))("Ok"),
+			h.A(h.AttrHref(external.URL+"/gone"))("Gone"),
+		)},
+		{URL: "/docs", Node: h.ARTICLE()(
+			h.H2(h.AttrID("install"))("Install"),
+			h.A(h.AttrHref("#install"))("Permalink"),
+			h.A(h.AttrHref("./"))("Home"),
+			h.IMG(h.AttrSrc("missing.png"), h.AttrAlt("")),
+		)},
+	}
+
+	broken, err := CheckLinks(pages, LinkCheckOptions{Handler: assets, External: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		`/: main > a[2] href="/docs#missing": no element with id "missing"`,
+		`/: main > a[3] href="/pricing": status 404`,
+		`/: main > a[6] href="` + external.URL + `/gone": status 404`,
+		`/docs: article > img src="missing.png": status 404`,
+	}
+	if len(broken) != len(expected) {
+		t.Fatalf("expected %d broken links, got %v", len(expected), broken)
+	}
+	for i, link := range broken {
+		if link.String() != expected[i] {
+			t.Errorf("expected %q, got %q", expected[i], link.String())
+		}
+	}
+
+	broken, err = CheckLinks(pages[1:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(broken) != 2 || broken[0].Reason != "no such page" {
+		t.Errorf("expected links outside the pages to be broken without handler, got %v", broken)
+	}
+}
@@ -0,0 +1,199 @@
+package hypertest
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+// LintElement is an element checked by lint rules.
+type LintElement struct {
+	Tag        string
+	Attributes map[string]string
+	Path       string        // e.g. "main > ul > li[2]"
+	Depth      int           // 1 for top-level elements
+	Ancestors  []LintElement // From the outermost
+}
+
+// Issue is a problem reported by a lint rule.
+type Issue struct {
+	Rule    string
+	Path    string
+	Message string
+}
+
+func (me Issue) String() string {
+	if me.Path == "" {
+		return fmt.Sprintf("%s: %s", me.Rule, me.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", me.Path, me.Rule, me.Message)
+}
+
+// Rule is a lint rule, checking all the elements of a tree in document
+// order. Most rules look at one element at a time and are best built with
+// [ElementRule].
+type Rule struct {
+	Name  string
+	Check func(elements []LintElement) []Issue
+}
+
+// ElementRule creates a rule checking each element with check, which
+// returns a message for elements breaking the rule and "" otherwise.
+//
+// Example:
+//
+//	noTables := hypertest.ElementRule("no-layout-tables", func(e hypertest.LintElement) string {
+//		if e.Tag == "table" && e.Attributes["role"] == "presentation" {
+//			return "use CSS for layout"
+//		}
+//		return ""
+//	})
+func ElementRule(name string, check func(LintElement) string) Rule {
+	return Rule{Name: name, Check: func(elements []LintElement) []Issue {
+		var issues []Issue
+		for _, element := range elements {
+			if message := check(element); message != "" {
+				issues = append(issues, Issue{Path: element.Path, Message: message})
+			}
+		}
+		return issues
+	}}
+}
+
+// Lint checks node with rules, returning the issues found.
+func Lint(node h.HyperNode, rules ...Rule) ([]Issue, error) {
+	nodes, err := normalize([]h.HyperNode{node})
+	if err != nil {
+		return nil, err
+	}
+	var elements []LintElement
+	collectElements(&elements, nil, nodes)
+
+	var issues []Issue
+	for _, rule := range rules {
+		for _, issue := range rule.Check(elements) {
+			issue.Rule = rule.Name
+			issues = append(issues, issue)
+		}
+	}
+	return issues, nil
+}
+
+// AssertLint reports the issues found by [Lint] as test errors.
+//
+// Example:
+//
+//	func TestPages(t *testing.T) {
+//		hypertest.AssertLint(t, HomePage(fixture), hypertest.Recommended()...)
+//	}
+func AssertLint(t testing.TB, node h.HyperNode, rules ...Rule) {
+	t.Helper()
+	issues, err := Lint(node, rules...)
+	if err != nil {
+		t.Errorf("lint: %v", err)
+		return
+	}
+	for _, issue := range issues {
+		t.Errorf("lint: %s", issue)
+	}
+}
+
+func collectElements(elements *[]LintElement, ancestors []LintElement, nodes []shadowNode) {
+	counts := map[string]int{}
+	for _, node := range nodes {
+		if node.tag == "" {
+			continue
+		}
+		counts[node.tag]++
+		element := LintElement{
+			Tag:        node.tag,
+			Attributes: node.attrs,
+			Depth:      len(ancestors) + 1,
+			Ancestors:  ancestors,
+		}
+		if len(ancestors) != 0 {
+			element.Path = ancestors[len(ancestors)-1].Path
+		}
+		element.Path = childPath(element.Path, node.tag, counts[node.tag])
+		*elements = append(*elements, element)
+		collectElements(elements, append(ancestors[:len(ancestors):len(ancestors)], element), node.children)
+	}
+}
+
+// Recommended returns the built-in rules, with limits suited to most pages.
+func Recommended() []Rule {
+	return []Rule{NoInlineHandlers, NoInsecureURLs, NoUnsafeBlankTargets, MaxDepth(32), MaxElements(1500)}
+}
+
+// NoInlineHandlers reports inline event handler attributes (onclick...),
+// which a strict Content-Security-Policy blocks.
+var NoInlineHandlers = ElementRule("no-inline-handlers", func(e LintElement) string {
+	var handlers []string
+	for key := range e.Attributes {
+		if strings.HasPrefix(key, "on") {
+			handlers = append(handlers, key)
+		}
+	}
+	if len(handlers) == 0 {
+		return ""
+	}
+	slices.Sort(handlers)
+	return "inline event handler " + strings.Join(handlers, ", ")
+})
+
+// urlAttributes are the attributes holding a URL.
+var urlAttributes = []string{"href", "src", "action", "formaction", "poster", "cite", "data"}
+
+// NoInsecureURLs reports http: URLs, which browsers block or warn about
+// as mixed content on https pages.
+var NoInsecureURLs = ElementRule("no-insecure-urls", func(e LintElement) string {
+	for _, key := range urlAttributes {
+		if value := strings.TrimSpace(e.Attributes[key]); strings.HasPrefix(strings.ToLower(value), "http:") {
+			return fmt.Sprintf("insecure URL in %s: %s", key, value)
+		}
+	}
+	for candidate := range strings.SplitSeq(e.Attributes["srcset"], ",") {
+		if value := strings.TrimSpace(candidate); strings.HasPrefix(strings.ToLower(value), "http:") {
+			return "insecure URL in srcset: " + value
+		}
+	}
+	return ""
+})
+
+// NoUnsafeBlankTargets reports target="_blank" links without
+// rel="noopener" (or noreferrer), which give the opened page access to
+// window.opener in older browsers.
+var NoUnsafeBlankTargets = ElementRule("no-unsafe-blank-targets", func(e LintElement) string {
+	if e.Attributes["target"] != h.TargetBlank {
+		return ""
+	}
+	rel := strings.Fields(strings.ToLower(e.Attributes["rel"]))
+	if slices.Contains(rel, "noopener") || slices.Contains(rel, "noreferrer") {
+		return ""
+	}
+	return `target="_blank" without rel="noopener"`
+})
+
+// MaxDepth reports elements nested deeper than max, as deep trees slow
+// down style and layout.
+func MaxDepth(max int) Rule {
+	return ElementRule("max-depth", func(e LintElement) string {
+		if e.Depth == max+1 {
+			return fmt.Sprintf("nested deeper than %d elements", max)
+		}
+		return ""
+	})
+}
+
+// MaxElements reports trees of more than max elements.
+func MaxElements(max int) Rule {
+	return Rule{Name: "max-elements", Check: func(elements []LintElement) []Issue {
+		if len(elements) <= max {
+			return nil
+		}
+		return []Issue{{Message: fmt.Sprintf("%d elements, limit is %d", len(elements), max)}}
+	}}
+}
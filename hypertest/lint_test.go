@@ -0,0 +1,51 @@
+package hypertest
+
+import (
+	"testing"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+func TestLint(t *testing.T) {
+	page := h.MAIN()(
+		h.BUTTON(h.Attr("onclick", "go()"))("Go"),
+		h.IMG(h.AttrSrc("http://cdn.example.com/a.png")),
+		h.A(h.AttrHref("https://example.com"), h.AttrTarget(h.TargetBlank))("unsafe"),
+		h.A(h.AttrHref("https://example.com"), h.AttrTarget(h.TargetBlank), h.AttrRel(h.RelNoOpener))("safe"),
+		h.DIV()(h.DIV()(h.DIV()(h.SPAN()("deep")))),
+	)
+
+	noSpans := ElementRule("no-spans", func(e LintElement) string {
+		if e.Tag == "span" {
+			return "spans are not allowed"
+		}
+		return ""
+	})
+
+	issues, err := Lint(page, NoInlineHandlers, NoInsecureURLs, NoUnsafeBlankTargets, MaxDepth(4), MaxElements(8), noSpans)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		`main > button: no-inline-handlers: inline event handler onclick`,
+		`main > img: no-insecure-urls: insecure URL in src: http://cdn.example.com/a.png`,
+		`main > a: no-unsafe-blank-targets: target="_blank" without rel="noopener"`,
+		`main > div > div > div > span: max-depth: nested deeper than 4 elements`,
+		`max-elements: 9 elements, limit is 8`,
+		`main > div > div > div > span: no-spans: spans are not allowed`,
+	}
+	if len(issues) != len(expected) {
+		t.Fatalf("expected %d issues, got %v", len(expected), issues)
+	}
+	for i, issue := range issues {
+		if issue.String() != expected[i] {
+			t.Errorf("expected %s, got %s", expected[i], issue)
+		}
+	}
+
+	r := &recorder{TB: t}
+	AssertLint(r, h.P()("clean"), Recommended()...)
+	if r.errors != 0 {
+		t.Errorf("expected no errors, got %d", r.errors)
+	}
+}
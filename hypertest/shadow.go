@@ -0,0 +1,255 @@
+package hypertest
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+// Mismatch is a difference between two trees found by [Compare].
+type Mismatch struct {
+	Path string // Path of the differing element, e.g. "main > ul > li[2]"
+	What string // What differs, e.g. `attribute "class"`
+	Old  string
+	New  string
+}
+
+func (me Mismatch) String() string {
+	return fmt.Sprintf("%s: %s: %q != %q", me.Path, me.What, me.Old, me.New)
+}
+
+// Shadow renders each case through the old and the new implementation of a
+// page builder and reports how their output differs, so large builders can
+// be refactored with confidence. Differences are structural (see
+// [Compare]), so attribute order and how the tree is grouped don't count.
+//
+// Example:
+//
+//	func TestDashboardRefactor(t *testing.T) {
+//		hypertest.Shadow(t, map[string]Data{
+//			"empty":  {},
+//			"loaded": fixtureData(),
+//		}, legacyDashboard, Dashboard)
+//	}
+func Shadow[T any](t testing.TB, cases map[string]T, old, new func(T) h.HyperNode) {
+	t.Helper()
+	for _, name := range slices.Sorted(maps.Keys(cases)) {
+		mismatches, err := Compare(old(cases[name]), new(cases[name]))
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		for _, mismatch := range mismatches {
+			t.Errorf("%s: %s", name, mismatch)
+		}
+	}
+}
+
+// Compare returns the differences between the output of old and new,
+// compared as trees of elements: attributes are compared by name, groups
+// are flattened and wrappers, such as [h.Named] or [h.Key], are unwrapped
+// (see [h.Wrapper]).
+// Nodes other than elements are compared by their rendered HTML, adjacent
+// ones together. Children are matched by position.
+func Compare(old, new h.HyperNode) ([]Mismatch, error) {
+	oldNodes, err := normalize([]h.HyperNode{old})
+	if err != nil {
+		return nil, fmt.Errorf("old: %w", err)
+	}
+	newNodes, err := normalize([]h.HyperNode{new})
+	if err != nil {
+		return nil, fmt.Errorf("new: %w", err)
+	}
+	var mismatches []Mismatch
+	compareChildren(&mismatches, "", oldNodes, newNodes)
+	return mismatches, nil
+}
+
+// shadowNode is an element, or text when tag is empty.
+type shadowNode struct {
+	tag      string
+	attrs    map[string]string
+	children []shadowNode
+	text     string
+}
+
+func (me shadowNode) String() string {
+	if me.tag == "" {
+		return me.text
+	}
+	return "<" + me.tag + ">"
+}
+
+// normalize flattens nodes into elements and merged text.
+func normalize(nodes []h.HyperNode) ([]shadowNode, error) {
+	var normalized []shadowNode
+	var text strings.Builder
+	flush := func() {
+		if text.Len() != 0 {
+			normalized = append(normalized, shadowNode{text: text.String()})
+			text.Reset()
+		}
+	}
+
+	// inline merges nodes into the parent's, joining text.
+	inline := func(children []shadowNode) {
+		for _, child := range children {
+			if child.tag == "" {
+				text.WriteString(child.text)
+				continue
+			}
+			flush()
+			normalized = append(normalized, child)
+		}
+	}
+
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case nil:
+		case h.Wrapper:
+			children, err := normalize(n.Unwrap())
+			if err != nil {
+				return nil, err
+			}
+			inline(children)
+		case h.Element:
+			children, err := normalize(n.Children)
+			if err != nil {
+				return nil, err
+			}
+			if n.Tag == "" {
+				inline(children)
+				continue
+			}
+			attrs, err := attributes(n)
+			if err != nil {
+				return nil, err
+			}
+			flush()
+			normalized = append(normalized, shadowNode{tag: n.Tag, attrs: attrs, children: children})
+		default:
+			var buf bytes.Buffer
+			if err := n.Render(&buf); err != nil {
+				return nil, err
+			}
+			text.WriteString(buf.String())
+		}
+	}
+	flush()
+	return normalized, nil
+}
+
+// attributes returns the attributes of element by name, parsed from their
+// rendered form so that custom attribute types are supported.
+func attributes(element h.Element) (map[string]string, error) {
+	var buf bytes.Buffer
+	for _, attr := range element.Attributes {
+		if err := attr.Render(&buf); err != nil {
+			return nil, err
+		}
+	}
+
+	attrs := map[string]string{}
+	rest := buf.String()
+	for {
+		rest = strings.TrimLeft(rest, " ")
+		if rest == "" {
+			return attrs, nil
+		}
+		end := strings.IndexAny(rest, "= ")
+		if end < 0 {
+			end = len(rest)
+		}
+		key := rest[:end]
+		rest = rest[end:]
+		value := ""
+		if strings.HasPrefix(rest, `="`) {
+			rest = rest[2:]
+			end := strings.IndexByte(rest, '"')
+			if end < 0 {
+				end = len(rest)
+			}
+			value, rest = rest[:end], rest[min(end+1, len(rest)):]
+		}
+		if _, ok := attrs[key]; !ok {
+			attrs[key] = value
+		}
+	}
+}
+
+func compareChildren(mismatches *[]Mismatch, path string, old, new []shadowNode) {
+	counts := map[string]int{}
+	for i := range max(len(old), len(new)) {
+		var tag string
+		switch {
+		case i < len(new):
+			tag = new[i].tag
+		default:
+			tag = old[i].tag
+		}
+		counts[tag]++
+		childPath := childPath(path, tag, counts[tag])
+
+		switch {
+		case i >= len(old):
+			*mismatches = append(*mismatches, Mismatch{Path: childPath, What: "added", New: new[i].String()})
+		case i >= len(new):
+			*mismatches = append(*mismatches, Mismatch{Path: childPath, What: "removed", Old: old[i].String()})
+		default:
+			compareNodes(mismatches, childPath, old[i], new[i])
+		}
+	}
+}
+
+func compareNodes(mismatches *[]Mismatch, path string, old, new shadowNode) {
+	if old.tag != new.tag {
+		*mismatches = append(*mismatches, Mismatch{Path: path, What: "node", Old: old.String(), New: new.String()})
+		return
+	}
+	if old.tag == "" {
+		if old.text != new.text {
+			*mismatches = append(*mismatches, Mismatch{Path: path, What: "text", Old: old.text, New: new.text})
+		}
+		return
+	}
+
+	keys := slices.Collect(maps.Keys(old.attrs))
+	for key := range new.attrs {
+		if _, ok := old.attrs[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		oldValue, oldOK := old.attrs[key]
+		newValue, newOK := new.attrs[key]
+		switch {
+		case !oldOK:
+			*mismatches = append(*mismatches, Mismatch{Path: path, What: fmt.Sprintf("attribute %q added", key), New: newValue})
+		case !newOK:
+			*mismatches = append(*mismatches, Mismatch{Path: path, What: fmt.Sprintf("attribute %q removed", key), Old: oldValue})
+		case oldValue != newValue:
+			*mismatches = append(*mismatches, Mismatch{Path: path, What: fmt.Sprintf("attribute %q", key), Old: oldValue, New: newValue})
+		}
+	}
+	compareChildren(mismatches, path, old.children, new.children)
+}
+
+// childPath returns the path of the n-th child with tag, e.g. "ul > li[2]".
+func childPath(parent, tag string, n int) string {
+	if tag == "" {
+		tag = "text()"
+	}
+	if n > 1 {
+		tag = fmt.Sprintf("%s[%d]", tag, n)
+	}
+	if parent == "" {
+		return tag
+	}
+	return parent + " > " + tag
+}
@@ -0,0 +1,69 @@
+package hypertest
+
+import (
+	"testing"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+func TestCompare(t *testing.T) {
+	old := h.MAIN(h.AttrClass("page"), h.AttrID("main"))(
+		h.H1()("Orders"),
+		h.UL()(h.LI()("a"), h.LI()("b")),
+		"Total: ", 3,
+	)
+
+	// Same output, built differently: attributes reordered, groups and
+	// names added.
+	same := h.MAIN(h.AttrID("main"), h.AttrClass("page"))(
+		h.Named("Title", h.H1()("Orders")),
+		h.UL()(h.Group(h.LI()("a"), h.LI()("b"))),
+		h.Group("Total: ", "3"),
+	)
+	mismatches, err := Compare(old, same)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mismatches) != 0 {
+		t.Errorf("expected no mismatches, got %v", mismatches)
+	}
+
+	changed := h.MAIN(h.AttrClass("page wide"), h.AttrID("main"), h.AttrHidden(true))(
+		h.H1()("Orders"),
+		h.UL()(h.LI()("a"), h.LI()("c"), h.LI()("d")),
+		"Total: ", 3,
+	)
+	mismatches, err = Compare(old, changed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		`main: attribute "class": "page" != "page wide"`,
+		`main: attribute "hidden" added: "" != ""`,
+		`main > ul > li[2] > text(): text: "b" != "c"`,
+		`main > ul > li[3]: added: "" != "<li>"`,
+	}
+	if len(mismatches) != len(expected) {
+		t.Fatalf("expected %d mismatches, got %v", len(expected), mismatches)
+	}
+	for i, mismatch := range mismatches {
+		if mismatch.String() != expected[i] {
+			t.Errorf("expected %s, got %s", expected[i], mismatch)
+		}
+	}
+}
+
+func TestShadow(t *testing.T) {
+	old := func(name string) h.HyperNode { return h.P()("Hello, ", name) }
+	new := func(name string) h.HyperNode { return h.P()("Hi, ", name) }
+
+	r := &recorder{TB: t}
+	Shadow(r, map[string]string{"empty": "", "named": "Ada"}, old, old)
+	if r.errors != 0 {
+		t.Errorf("expected no errors, got %d", r.errors)
+	}
+	Shadow(r, map[string]string{"empty": "", "named": "Ada"}, old, new)
+	if r.errors != 2 {
+		t.Errorf("expected a mismatch per case, got %d errors", r.errors)
+	}
+}
@@ -0,0 +1,120 @@
+package hypertest
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+// PageWeight measures a rendered page, see [Weigh].
+type PageWeight struct {
+	Bytes       int // Size of the rendered HTML
+	Elements    int
+	MaxDepth    int // Depth of the most nested element, 1 for top-level elements
+	ScriptBytes int // Content of the inline <script> elements
+	StyleBytes  int // Content of the <style> elements
+	Images      int // <img> elements
+}
+
+func (me PageWeight) String() string {
+	return fmt.Sprintf("%d bytes, %d elements, depth %d, %d bytes of inline scripts, %d bytes of styles, %d images",
+		me.Bytes, me.Elements, me.MaxDepth, me.ScriptBytes, me.StyleBytes, me.Images)
+}
+
+// Weigh renders node and measures it.
+func Weigh(node h.HyperNode) (PageWeight, error) {
+	var buf bytes.Buffer
+	if err := h.Render(&buf, node); err != nil {
+		return PageWeight{}, err
+	}
+	nodes, err := normalize([]h.HyperNode{node})
+	if err != nil {
+		return PageWeight{}, err
+	}
+
+	weight := PageWeight{Bytes: buf.Len()}
+	weigh(&weight, nodes, 1)
+	return weight, nil
+}
+
+func weigh(weight *PageWeight, nodes []shadowNode, depth int) {
+	for _, node := range nodes {
+		if node.tag == "" {
+			continue
+		}
+		weight.Elements++
+		weight.MaxDepth = max(weight.MaxDepth, depth)
+		switch node.tag {
+		case "script":
+			if _, external := node.attrs["src"]; !external {
+				weight.ScriptBytes += textLength(node.children)
+			}
+		case "style":
+			weight.StyleBytes += textLength(node.children)
+		case "img":
+			weight.Images++
+		}
+		weigh(weight, node.children, depth+1)
+	}
+}
+
+func textLength(nodes []shadowNode) int {
+	n := 0
+	for _, node := range nodes {
+		n += len(node.text)
+	}
+	return n
+}
+
+// WeightBudget bounds the [PageWeight] of pages. Zero fields mean no
+// limit.
+type WeightBudget struct {
+	Bytes       int
+	Elements    int
+	MaxDepth    int
+	ScriptBytes int
+	StyleBytes  int
+	Images      int
+}
+
+// Check returns the issues of weight exceeding the budget.
+func (me WeightBudget) Check(weight PageWeight) []Issue {
+	var issues []Issue
+	check := func(name string, value, limit int) {
+		if limit > 0 && value > limit {
+			issues = append(issues, Issue{Rule: "weight-budget", Message: fmt.Sprintf("%s is %d, budget is %d", name, value, limit)})
+		}
+	}
+	check("size", weight.Bytes, me.Bytes)
+	check("element count", weight.Elements, me.Elements)
+	check("depth", weight.MaxDepth, me.MaxDepth)
+	check("inline script size", weight.ScriptBytes, me.ScriptBytes)
+	check("style size", weight.StyleBytes, me.StyleBytes)
+	check("image count", weight.Images, me.Images)
+	return issues
+}
+
+// AssertWeight logs the weight of node, and reports the parts of it
+// exceeding budget as test errors, so pages stay lean as they grow.
+//
+// Example:
+//
+//	func TestPageWeight(t *testing.T) {
+//		budget := hypertest.WeightBudget{Bytes: 100_000, Elements: 1500, ScriptBytes: 10_000}
+//		hypertest.AssertWeight(t, HomePage(fixture), budget)
+//		hypertest.AssertWeight(t, ProductPage(fixture.Product), budget)
+//	}
+func AssertWeight(t testing.TB, node h.HyperNode, budget WeightBudget) {
+	t.Helper()
+	weight, err := Weigh(node)
+	if err != nil {
+		t.Errorf("weight: %v", err)
+		return
+	}
+	t.Logf("weight: %s", weight)
+	for _, issue := range budget.Check(weight) {
+		t.Errorf("weight: %s", issue)
+	}
+}
@@ -0,0 +1,37 @@
+package hypertest
+
+import (
+	"bytes"
+	"testing"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+func TestWeigh(t *testing.T) {
+	page := h.Group(
+		h.STYLE()(h.RawText("p{margin:0}")),
+		h.SCRIPT(h.AttrSrc("/app.js"))(),
+		h.MAIN()(
+			h.IMG(h.AttrSrc("/a.png"), h.AttrAlt("")),
+			h.DIV()(h.IMG(h.AttrSrc("/b.png"), h.AttrAlt(""))),
+			h.SCRIPT()(h.RawText("init()")),
+		),
+	)
+
+	weight, err := Weigh(page)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rendered bytes.Buffer
+	h.Render(&rendered, page)
+	expected := PageWeight{Bytes: rendered.Len(), Elements: 7, MaxDepth: 3, ScriptBytes: 6, StyleBytes: 11, Images: 2}
+	if weight != expected {
+		t.Errorf("expected %+v, got %+v", expected, weight)
+	}
+
+	issues := WeightBudget{Elements: 5, Images: 2, StyleBytes: 10}.Check(weight)
+	if len(issues) != 2 || issues[0].String() != "weight-budget: element count is 7, budget is 5" ||
+		issues[1].String() != "weight-budget: style size is 11, budget is 10" {
+		t.Errorf("unexpected issues %v", issues)
+	}
+}
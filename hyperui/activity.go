@@ -0,0 +1,105 @@
+package hyperui
+
+import (
+	"time"
+
+	"github.com/assaidy/hyper/v2"
+)
+
+// Activity is an entry of an ActivityFeed, read as a sentence: Actor
+// Action Target, e.g. "Jane" "merged" "#42".
+type Activity struct {
+	Icon   any // Typically an icon, shown in the timeline bullet; a dot when nil
+	Actor  any
+	Action any
+	Target any // Optional
+	Detail any // Optional, shown under the sentence, e.g. a comment excerpt
+	Time   time.Time
+}
+
+type ActivityFeedParams struct {
+	// Now is the time the day headings and relative times are computed
+	// from; defaults to the current time.
+	Now        time.Time
+	Locale     string // See h.FormatOptions
+	Empty      any    // Shown when there is no activity; defaults to "No activity yet"
+	Attributes []h.Attribute
+}
+
+// ActivityFeed renders activities, such as the entries of an audit log, as
+// a timeline grouped by day ("Today", "Yesterday", then dates), each entry
+// with its icon, sentence and relative time. Activities are rendered in
+// the order given, usually the most recent first.
+//
+// Example:
+//
+//	ActivityFeed([]Activity{{
+//		Icon:   heroicons.PencilSquare(icons.Options{Size: 16}),
+//		Actor:  h.A(h.AttrHref("/users/jane"))("Jane"),
+//		Action: "edited",
+//		Target: h.A(h.AttrHref("/docs/7"))("Onboarding guide"),
+//		Time:   entry.Time,
+//	}}, ActivityFeedParams{Locale: h.Locale(ctx)})
+func ActivityFeed(activities []Activity, params ...ActivityFeedParams) h.Element {
+	var p ActivityFeedParams
+	if len(params) != 0 {
+		p = params[0]
+	}
+	now := p.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	if len(activities) == 0 {
+		return EmptyState(nil, h.IfElse[any](p.Empty != nil, p.Empty, "No activity yet"), nil, nil, EmptyStateParams{Compact: true, Attributes: p.Attributes})
+	}
+
+	format := h.FormatOptions{Locale: p.Locale, Now: now, Attributes: []h.Attribute{h.AttrClass("shrink-0 text-xs text-gray-500")}}
+	element := h.DIV(p.Attributes...)(
+		h.GroupBy(activities, func(a Activity) string { return activityDay(a.Time, now) },
+			func(day string, activities []Activity) h.HyperNode {
+				return h.SECTION()(
+					h.H3(h.AttrClass("mb-3 text-xs font-semibold uppercase tracking-wide text-gray-500"))(day),
+					h.OL(h.AttrRole("list"), h.AttrClass("relative space-y-4 border-l border-gray-200 pl-6"))(
+						h.Range(activities, func(a Activity) h.HyperNode {
+							return h.LI(h.AttrClass("relative"))(
+								h.SPAN(
+									h.AttrAriaHidden("true"),
+									h.AttrClass("absolute -left-9 flex size-6 items-center justify-center rounded-full bg-white text-gray-500 ring-1 ring-gray-200"),
+								)(h.IfElse[any](a.Icon != nil, a.Icon, h.SPAN(h.AttrClass("size-1.5 rounded-full bg-gray-400"))())),
+								h.DIV(h.AttrClass("flex items-baseline justify-between gap-4"))(
+									h.P(h.AttrClass("text-sm text-gray-700"))(
+										h.SPAN(h.AttrClass("font-medium text-gray-900"))(a.Actor),
+										" ", a.Action,
+										h.If(a.Target != nil, h.Group(" ", h.SPAN(h.AttrClass("font-medium text-gray-900"))(a.Target))),
+									),
+									h.RelativeTime(a.Time, format),
+								),
+								h.If(a.Detail != nil, h.DIV(h.AttrClass("mt-1 text-sm text-gray-500"))(a.Detail)),
+							)
+						}),
+					),
+				)
+			},
+		),
+	)
+	mergeStyles(&element, "space-y-6")
+	return element
+}
+
+// activityDay returns the heading of the day of t.
+func activityDay(t, now time.Time) string {
+	t = t.In(now.Location())
+	y, m, d := t.Date()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch day := time.Date(y, m, d, 0, 0, 0, 0, now.Location()); {
+	case day.Equal(today):
+		return "Today"
+	case day.Equal(today.AddDate(0, 0, -1)):
+		return "Yesterday"
+	case y == now.Year():
+		return t.Format("Monday, January 2")
+	default:
+		return t.Format("January 2, 2006")
+	}
+}
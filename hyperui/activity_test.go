@@ -0,0 +1,54 @@
+package hyperui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/assaidy/hyper/v2"
+)
+
+func TestActivityFeed(t *testing.T) {
+	now := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+	got := render(t, ActivityFeed([]Activity{
+		{Actor: "Jane", Action: "merged", Target: h.A(h.AttrHref("/pulls/42"))("#42"), Time: now.Add(-time.Hour)},
+		{Actor: "Ali", Action: "commented", Detail: "Looks good", Time: now.Add(-30 * time.Hour)},
+		{Actor: "Sam", Action: "joined", Time: time.Date(2024, 12, 31, 9, 0, 0, 0, time.UTC)},
+	}, ActivityFeedParams{Now: now}))
+
+	assertContains(t, got,
+		`<div class="space-y-6"><section>`,
+		`>Today</h3>`,
+		`<span class="font-medium text-gray-900">Jane</span> merged <span class="font-medium text-gray-900"><a href="/pulls/42">#42</a></span>`,
+		`>Yesterday</h3>`,
+		`<div class="mt-1 text-sm text-gray-500">Looks good</div>`,
+		`>December 31, 2024</h3>`,
+		`<span class="size-1.5 rounded-full bg-gray-400"></span>`,
+	)
+	if strings.Count(got, "<section>") != 3 {
+		t.Errorf("expected a section per day, got %q", got)
+	}
+}
+
+func TestActivityFeed_Empty(t *testing.T) {
+	got := render(t, ActivityFeed(nil, ActivityFeedParams{Attributes: []h.Attribute{h.AttrID("feed")}}))
+	assertContains(t, got, `<div role="status" data-empty-state="" id="feed"`, `>No activity yet</p>`)
+}
+
+func TestActivityDay(t *testing.T) {
+	now := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		time     time.Time
+		expected string
+	}{
+		{now.Add(-11 * time.Hour), "Today"},
+		{now.Add(-13 * time.Hour), "Yesterday"},
+		{time.Date(2025, 3, 3, 8, 0, 0, 0, time.UTC), "Monday, March 3"},
+		{time.Date(2024, 3, 3, 8, 0, 0, 0, time.UTC), "March 3, 2024"},
+	}
+	for _, tt := range tests {
+		if got := activityDay(tt.time, now); got != tt.expected {
+			t.Errorf("activityDay(%v) = %q, want %q", tt.time, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package hyperui
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/assaidy/hyper/v2"
+	"github.com/assaidy/hyper/v2/icons"
+	"github.com/assaidy/hyper/v2/icons/heroicons"
+)
+
+type AnnouncementVariant uint
+
+const (
+	AnnouncementInfo AnnouncementVariant = iota
+	AnnouncementWarn
+	AnnouncementCritical
+)
+
+var announcementVariantClasses = map[AnnouncementVariant]string{
+	AnnouncementInfo:     "bg-blue-600 text-white",
+	AnnouncementWarn:     "bg-yellow-300 text-yellow-950",
+	AnnouncementCritical: "bg-red-700 text-white",
+}
+
+type AnnouncementBarParams struct {
+	Variant AnnouncementVariant
+	// Dismissible adds a close button remembering the dismissal in a
+	// cookie, for MaxAge days (365 by default). Requires the
+	// AnnouncementBarScript.
+	Dismissible bool
+	MaxAge      int
+	// Dismissed suppresses the bar, typically set to
+	// AnnouncementDismissed(r, id).
+	Dismissed  bool
+	Attributes []h.Attribute
+}
+
+// announcementCookie returns the name of the cookie remembering the
+// dismissal of the announcement id.
+func announcementCookie(id string) string {
+	return "announcement-" + id
+}
+
+// AnnouncementDismissed reports whether the visitor dismissed the
+// announcement id, so it isn't rendered again.
+func AnnouncementDismissed(r *http.Request, id string) bool {
+	_, err := r.Cookie(announcementCookie(id))
+	return err == nil
+}
+
+// AnnouncementBar renders a full-width bar announcing message, such as a
+// release or planned maintenance. id names the announcement: give a new
+// announcement a new id, so it shows to the visitors who dismissed the
+// previous one. Critical announcements get role="alert" so they are
+// announced immediately.
+//
+// Example:
+//
+//	AnnouncementBar("maintenance-2025-06", "Scheduled maintenance on June 3, 22:00 UTC.", AnnouncementBarParams{
+//		Variant:     AnnouncementWarn,
+//		Dismissible: true,
+//		Dismissed:   AnnouncementDismissed(r, "maintenance-2025-06"),
+//	})
+func AnnouncementBar(id string, message any, params ...AnnouncementBarParams) h.HyperNode {
+	var p AnnouncementBarParams
+	if len(params) != 0 {
+		p = params[0]
+	}
+	if p.Dismissed {
+		return h.Group()
+	}
+	classes, ok := announcementVariantClasses[p.Variant]
+	if !ok {
+		panic("invalid announcement variant")
+	}
+
+	attrs := []h.Attribute{h.Attr("data-announcement", id)}
+	if p.Variant == AnnouncementCritical {
+		attrs = append(attrs, h.AttrRole("alert"))
+	} else {
+		attrs = append(attrs, h.AttrRole("region"), h.AttrAriaLabel("Announcement"))
+	}
+
+	var dismiss h.HyperNode = h.Group()
+	if p.Dismissible {
+		dismiss = h.BUTTON(
+			h.AttrType(h.TypeButton),
+			h.Attr("data-announcement-dismiss", announcementCookie(id)),
+			h.Attr("data-max-age", strconv.Itoa(h.IfElse(p.MaxAge > 0, p.MaxAge, 365)*86400)),
+			h.AttrAriaLabel("Dismiss"),
+			h.AttrClass("absolute right-2 top-1/2 -translate-y-1/2 rounded p-1 opacity-80 hover:opacity-100 focus:outline-none focus:ring-2 focus:ring-current"),
+		)(heroicons.XMark(icons.Options{Size: 16}))
+	}
+
+	element := h.DIV(append(attrs, p.Attributes...)...)(
+		h.P()(message),
+		dismiss,
+	)
+	mergeStyles(&element, "relative px-10 py-2 text-center text-sm font-medium "+classes)
+	return element
+}
+
+// announcementBarScript stores the dismissal of an AnnouncementBar in its
+// cookie and removes the bar.
+const announcementBarScript = `document.addEventListener("click",function(e){var b=e.target.closest&&e.target.closest("[data-announcement-dismiss]");if(!b)return;
+document.cookie=encodeURIComponent(b.getAttribute("data-announcement-dismiss"))+"=1;path=/;max-age="+b.getAttribute("data-max-age")+";samesite=lax";b.closest("[data-announcement]").remove()})`
+
+// AnnouncementBarScript returns the <script> element driving the dismiss
+// buttons of AnnouncementBars. It carries the CSP nonce of ctx (see
+// h.NonceKey).
+func AnnouncementBarScript(ctx context.Context) h.Element {
+	var attrs []h.Attribute
+	if nonce := h.Nonce(ctx); nonce != "" {
+		attrs = append(attrs, h.AttrNonce(nonce))
+	}
+	return h.SCRIPT(attrs...)(h.RawText(announcementBarScript))
+}
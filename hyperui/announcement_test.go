@@ -0,0 +1,54 @@
+package hyperui
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/assaidy/hyper/v2"
+)
+
+func TestAnnouncementBar(t *testing.T) {
+	got := render(t, AnnouncementBar("release-2", "Version 2 is out."))
+	assertContains(t, got,
+		`<div data-announcement="release-2" role="region" aria-label="Announcement" class="relative px-10 py-2 text-center text-sm font-medium bg-blue-600 text-white">`,
+		`<p>Version 2 is out.</p>`,
+	)
+	if strings.Contains(got, "<button") {
+		t.Errorf("expected no dismiss button, got %q", got)
+	}
+
+	got = render(t, AnnouncementBar("outage", "Service degraded.", AnnouncementBarParams{
+		Variant:     AnnouncementCritical,
+		Dismissible: true,
+		MaxAge:      2,
+	}))
+	assertContains(t, got,
+		`<div data-announcement="outage" role="alert" class="`,
+		`<button type="button" data-announcement-dismiss="announcement-outage" data-max-age="172800" aria-label="Dismiss"`,
+		`<svg`,
+	)
+
+	if got := render(t, AnnouncementBar("outage", "Service degraded.", AnnouncementBarParams{Dismissed: true})); got != "" {
+		t.Errorf("expected dismissed announcements to render nothing, got %q", got)
+	}
+}
+
+func TestAnnouncementDismissed(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	r.AddCookie(&http.Cookie{Name: "announcement-outage", Value: "1"})
+	if !AnnouncementDismissed(r, "outage") {
+		t.Error("expected the announcement with a cookie to be dismissed")
+	}
+	if AnnouncementDismissed(r, "release-2") {
+		t.Error("expected other announcements not to be dismissed")
+	}
+}
+
+func TestAnnouncementBarScript(t *testing.T) {
+	ctx := h.WithValue(context.Background(), h.NonceKey, "abc")
+	assertContains(t, render(t, AnnouncementBarScript(ctx)), `<script nonce="abc">document.addEventListener("click"`)
+	assertContains(t, render(t, AnnouncementBarScript(context.Background())), `<script>document.addEventListener`)
+}
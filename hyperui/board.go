@@ -0,0 +1,92 @@
+package hyperui
+
+import (
+	"strconv"
+
+	"github.com/assaidy/hyper/v2"
+)
+
+// BoardColumn is a column of a Board, holding its cards in order.
+type BoardColumn[T any] struct {
+	ID    string // Stable id, sent to MoveURL
+	Title any
+	Cards []T
+}
+
+type BoardParams[T any] struct {
+	// CardID returns the stable id of a card, sent to MoveURL. Required.
+	CardID func(card T) string
+	// MoveURL, when set, receives a PATCH request when cards are dropped
+	// in a column, with the form values "column" (the column id) and
+	// "card" (the ids of its cards, in their new order).
+	MoveURL    string
+	Attributes []h.Attribute
+}
+
+// Board renders a kanban board: columns of cards, such as tasks grouped by
+// status. It carries no drag-and-drop code; cards are draggable and the
+// card lists are marked with data-board-list, for a library such as
+// SortableJS to make them sortable. Each list is a form posting its new
+// order to params.MoveURL with htmx when the library fires its "end"
+// event.
+//
+// Example:
+//
+//	Board(columns, TaskCard, BoardParams[Task]{
+//		CardID:  func(t Task) string { return t.ID },
+//		MoveURL: "/tasks/move",
+//	})
+//
+//	// with SortableJS:
+//	// htmx.onLoad(content => content.querySelectorAll("[data-board-list]").forEach(list =>
+//	//     new Sortable(list, {group: "board", draggable: "[data-card-id]"})))
+func Board[T any](columns []BoardColumn[T], card func(card T) h.HyperNode, params BoardParams[T]) h.HyperNode {
+	renderedColumns := make([]any, len(columns))
+	for i, column := range columns {
+		titleID := "board-column-" + column.ID + "-title"
+
+		listAttrs := []h.Attribute{
+			h.Attr("data-board-list", true),
+			h.Attr("data-column-id", column.ID),
+			h.AttrClass("flex min-h-16 flex-col gap-2"),
+		}
+		if params.MoveURL != "" {
+			listAttrs = append(listAttrs,
+				h.Attr("hx-patch", params.MoveURL),
+				h.Attr("hx-trigger", "end"),
+				h.Attr("hx-swap", "none"),
+			)
+		}
+
+		cards := []any{h.INPUT(h.AttrType(h.TypeHidden), h.AttrName("column"), h.AttrValue(column.ID))}
+		for _, c := range column.Cards {
+			id := params.CardID(c)
+			cards = append(cards, h.DIV(
+				h.AttrID("board-card-"+id),
+				h.Attr("data-card-id", id),
+				h.Attr("draggable", "true"),
+				h.AttrClass("cursor-grab rounded-md border border-gray-200 bg-white p-3 shadow-sm"),
+			)(
+				h.INPUT(h.AttrType(h.TypeHidden), h.AttrName("card"), h.AttrValue(id)),
+				card(c),
+			))
+		}
+
+		renderedColumns[i] = h.SECTION(
+			h.AttrID("board-column-"+column.ID),
+			h.Attr("data-column-id", column.ID),
+			h.AttrAriaLabelledBy(titleID),
+			h.AttrClass("flex w-72 shrink-0 flex-col gap-3 rounded-lg bg-gray-100 p-3"),
+		)(
+			h.H3(h.AttrID(titleID), h.AttrClass("flex items-center justify-between text-sm font-semibold text-gray-700"))(
+				column.Title,
+				h.SPAN(h.AttrClass("rounded-full bg-gray-200 px-2 text-xs"))(strconv.Itoa(len(column.Cards))),
+			),
+			h.FORM(listAttrs...)(cards...),
+		)
+	}
+
+	element := h.DIV(params.Attributes...)(renderedColumns...)
+	mergeStyles(&element, "flex gap-4 overflow-x-auto")
+	return element
+}
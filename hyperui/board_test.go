@@ -0,0 +1,37 @@
+package hyperui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/assaidy/hyper/v2"
+)
+
+type task struct{ ID, Title string }
+
+func TestBoard(t *testing.T) {
+	columns := []BoardColumn[task]{
+		{ID: "todo", Title: "To do", Cards: []task{{"1", "Write tests"}, {"2", "Review"}}},
+		{ID: "done", Title: "Done"},
+	}
+	got := render(t, Board(columns, func(t task) h.HyperNode { return h.Text(t.Title) }, BoardParams[task]{
+		CardID:  func(t task) string { return t.ID },
+		MoveURL: "/tasks/move",
+	}))
+	assertContains(t, got,
+		`<div class="flex gap-4 overflow-x-auto"><section id="board-column-todo" data-column-id="todo" aria-labelledby="board-column-todo-title"`,
+		`<h3 id="board-column-todo-title" class="`,
+		`To do<span class="rounded-full bg-gray-200 px-2 text-xs">2</span></h3>`,
+		`<form data-board-list data-column-id="todo" class="flex min-h-16 flex-col gap-2" hx-patch="/tasks/move" hx-trigger="end" hx-swap="none"><input type="hidden" name="column" value="todo">`,
+		`<div id="board-card-1" data-card-id="1" draggable="true" class="`,
+		`<input type="hidden" name="card" value="1">Write tests</div>`,
+		`Done<span class="rounded-full bg-gray-200 px-2 text-xs">0</span>`,
+	)
+
+	got = render(t, Board(columns, func(t task) h.HyperNode { return h.Text(t.Title) }, BoardParams[task]{
+		CardID: func(t task) string { return t.ID },
+	}))
+	if strings.Contains(got, "hx-patch") {
+		t.Errorf("expected no requests without MoveURL, got %q", got)
+	}
+}
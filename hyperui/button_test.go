@@ -0,0 +1,29 @@
+package hyperui
+
+import (
+	"testing"
+
+	"github.com/assaidy/hyper/v2"
+)
+
+func TestButton(t *testing.T) {
+	got := render(t, Button()("Save"))
+	assertContains(t, got, `<button class="inline-flex`, `bg-gray-100`, `px-4 py-2 text-sm`, `>Save</button>`)
+
+	got = render(t, Button(ButtonParams{
+		Variant:     VariantPrimary,
+		Size:        SizeLarge,
+		IsFullWidth: true,
+		Attributes:  []h.Attribute{h.AttrID("save"), h.AttrType(h.TypeSubmit)},
+	})("Save"))
+	assertContains(t, got, `<button id="save" type="submit" class="`, `bg-blue-600`, `px-6 py-3`, `w-full`)
+}
+
+func TestButton_InvalidVariant(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for an invalid variant")
+		}
+	}()
+	Button(ButtonParams{Variant: 42})("Save")
+}
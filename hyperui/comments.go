@@ -0,0 +1,185 @@
+package hyperui
+
+import (
+	"html"
+	"strings"
+	"time"
+
+	"github.com/assaidy/hyper/v2"
+)
+
+// Comment is a node of a CommentThread.
+type Comment struct {
+	ID      string
+	Author  string
+	Avatar  string // Email address or image URL, see h.Avatar; initials of Author when empty
+	Body    string // Markdown, or plain text when the thread has no Markdown renderer
+	Time    time.Time
+	Replies []Comment
+}
+
+type CommentThreadParams struct {
+	// ReplyURL, when set, adds reply forms posting to it with htmx, with
+	// the id of the replied comment as "parent" (empty for a new
+	// top-level comment) and the text as "body". It must answer with
+	// CommentReply for the created comment, which is appended to the
+	// replies of its parent.
+	ReplyURL string
+	// MaxDepth is the number of nested levels rendered; the replies deeper
+	// are replaced by a link to ThreadURL, or dropped when it isn't set.
+	// 0 means no limit.
+	MaxDepth  int
+	ThreadURL func(c Comment) string
+	// Markdown converts a body to HTML. Its output is sanitized with
+	// Policy, so it may keep raw HTML from the body. Bodies are rendered
+	// as plain text paragraphs when nil.
+	Markdown func(body string) string
+	// Policy defaults to h.SanitizeComments.
+	Policy     *h.SanitizePolicy
+	Attributes []h.Attribute
+}
+
+// CommentThread renders a tree of comments, each with its author, time,
+// body and, when ReplyURL is set, a reply form; the replies are indented
+// under their parent up to MaxDepth levels.
+//
+// Example:
+//
+//	CommentThread(post.Comments, CommentThreadParams{
+//		ReplyURL: "/posts/" + post.ID + "/comments",
+//		MaxDepth: 4,
+//		ThreadURL: func(c Comment) string {
+//			return "/posts/" + post.ID + "/comments/" + c.ID
+//		},
+//		Markdown: func(body string) string {
+//			var buf bytes.Buffer
+//			goldmark.Convert([]byte(body), &buf)
+//			return buf.String()
+//		},
+//	})
+func CommentThread(comments []Comment, params ...CommentThreadParams) h.Element {
+	var p CommentThreadParams
+	if len(params) != 0 {
+		p = params[0]
+	}
+
+	thread := commentRenderer{params: p}
+	element := h.SECTION(append([]h.Attribute{h.AttrAriaLabel("Comments")}, p.Attributes...)...)(
+		h.If(p.ReplyURL != "", thread.form("", "comments-root")),
+		thread.list("comments-root", comments, 1),
+	)
+	mergeStyles(&element, "space-y-4")
+	return element
+}
+
+// CommentReply renders a single comment at depth (1 for a top-level
+// comment), the answer to the reply forms of a CommentThread. Params must
+// be those given to the thread.
+func CommentReply(comment Comment, depth int, params ...CommentThreadParams) h.HyperNode {
+	var p CommentThreadParams
+	if len(params) != 0 {
+		p = params[0]
+	}
+
+	return commentRenderer{params: p}.item(comment, depth)
+}
+
+type commentRenderer struct {
+	params CommentThreadParams
+}
+
+func (me commentRenderer) list(id string, comments []Comment, depth int) h.HyperNode {
+	items := make([]any, len(comments))
+	for i, c := range comments {
+		items[i] = me.item(c, depth)
+	}
+	return h.OL(
+		h.AttrID(id),
+		h.AttrRole("list"),
+		h.AttrClass(h.IfElse(depth > 1, "mt-4 space-y-4 border-l border-gray-200 pl-4", "space-y-4")),
+	)(items...)
+}
+
+func (me commentRenderer) item(c Comment, depth int) h.HyperNode {
+	var replies h.HyperNode = h.Group()
+	switch {
+	case me.params.MaxDepth == 0 || depth < me.params.MaxDepth:
+		replies = me.list("comment-"+c.ID+"-replies", c.Replies, depth+1)
+	case len(c.Replies) != 0 && me.params.ThreadURL != nil:
+		replies = h.A(
+			h.AttrHref(me.params.ThreadURL(c)),
+			h.AttrClass("mt-2 inline-block text-sm font-medium text-blue-600 hover:underline"),
+		)("Continue this thread →")
+	}
+
+	return h.LI(h.AttrID("comment-" + c.ID))(
+		h.ARTICLE(h.AttrClass("flex gap-3"))(
+			h.Avatar(c.Avatar, 32, c.Author, h.AttrClass("size-8 shrink-0 rounded-full")),
+			h.DIV(h.AttrClass("min-w-0 flex-1"))(
+				h.HEADER(h.AttrClass("flex items-baseline gap-2 text-sm"))(
+					h.SPAN(h.AttrClass("font-semibold text-gray-900"))(c.Author),
+					h.If(!c.Time.IsZero(), h.TIME(
+						h.AttrDateTime(c.Time.Format(time.RFC3339)),
+						h.AttrClass("text-xs text-gray-500"),
+					)(c.Time.Format("Jan 2, 2006 15:04"))),
+				),
+				h.DIV(h.AttrClass("mt-1 text-sm text-gray-700 [&_a]:text-blue-600 [&_a]:underline [&_p+p]:mt-2"))(me.body(c.Body)),
+				// Replies to comments at the limit would be hidden.
+				h.If(me.params.ReplyURL != "" && (me.params.MaxDepth == 0 || depth < me.params.MaxDepth),
+					h.DETAILS(h.AttrClass("mt-1"))(
+						h.SUMMARY(h.AttrClass("cursor-pointer text-xs font-medium text-gray-500 hover:text-gray-900"))("Reply"),
+						me.form(c.ID, "comment-"+c.ID+"-replies"),
+					),
+				),
+				replies,
+			),
+		),
+	)
+}
+
+func (me commentRenderer) body(body string) h.HyperNode {
+	policy := h.SanitizeComments
+	if me.params.Policy != nil {
+		policy = *me.params.Policy
+	}
+
+	var htmlText string
+	if me.params.Markdown != nil {
+		htmlText = me.params.Markdown(body)
+	} else {
+		var b strings.Builder
+		for paragraph := range strings.SplitSeq(strings.ReplaceAll(body, "\r\n", "\n"), "\n\n") {
+			if paragraph = strings.TrimSpace(paragraph); paragraph != "" {
+				b.WriteString("<p>" + strings.ReplaceAll(html.EscapeString(paragraph), "\n", "<br>") + "</p>")
+			}
+		}
+		htmlText = b.String()
+	}
+	return h.Sanitize(htmlText, policy)
+}
+
+// form renders a reply form to parent, appending the created comment to
+// the list with id target.
+func (me commentRenderer) form(parent, target string) h.HyperNode {
+	return h.FORM(
+		h.Attr("hx-post", me.params.ReplyURL),
+		h.Attr("hx-target", "#"+target),
+		h.Attr("hx-swap", "beforeend"),
+		// Clear and close the form once the reply is added.
+		h.Attr("hx-on::after-request", "if (event.detail.successful) { this.reset(); this.closest('details')?.removeAttribute('open') }"),
+		h.AttrClass("mt-2 space-y-2"),
+	)(
+		h.INPUT(h.AttrType(h.TypeHidden), h.AttrName("parent"), h.AttrValue(parent)),
+		h.TEXTAREA(
+			h.AttrName("body"),
+			h.AttrRows("3"),
+			h.AttrRequired(true),
+			h.AttrAriaLabel(h.IfElse(parent == "", "Comment", "Reply")),
+			h.AttrPlaceholder(h.IfElse(parent == "", "Add a comment…", "Write a reply…")),
+			h.AttrClass("block w-full rounded-md border border-gray-300 p-2 text-sm focus:border-blue-500 focus:ring-blue-500"),
+		)(),
+		Button(ButtonParams{Variant: VariantPrimary, Size: SizeSmall, Attributes: []h.Attribute{h.AttrType(h.TypeSubmit)}})(
+			h.IfElse(parent == "", "Comment", "Reply"),
+		),
+	)
+}
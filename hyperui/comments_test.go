@@ -0,0 +1,69 @@
+package hyperui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/assaidy/hyper/v2"
+)
+
+func TestCommentThread(t *testing.T) {
+	comments := []Comment{{
+		ID:     "1",
+		Author: "Jane Doe",
+		Body:   "First <b>line</b>\nsecond line\n\nNew paragraph",
+		Time:   time.Date(2025, 6, 10, 9, 30, 0, 0, time.UTC),
+		Replies: []Comment{{
+			ID:      "2",
+			Author:  "Ali",
+			Body:    "Agreed",
+			Replies: []Comment{{ID: "3", Author: "Sam", Body: "Deep"}},
+		}},
+	}}
+
+	got := render(t, CommentThread(comments, CommentThreadParams{
+		ReplyURL:  "/comments",
+		MaxDepth:  2,
+		ThreadURL: func(c Comment) string { return "/comments/" + c.ID },
+	}))
+	assertContains(t, got,
+		`<section aria-label="Comments" class="space-y-4">`,
+		`<form hx-post="/comments" hx-target="#comments-root" hx-swap="beforeend"`,
+		`<input type="hidden" name="parent" value="">`,
+		`<ol id="comments-root" role="list" class="space-y-4"><li id="comment-1">`,
+		`<p>First &lt;b&gt;line&lt;/b&gt;<br>second line</p><p>New paragraph</p>`,
+		`<time datetime="2025-06-10T09:30:00Z" class="text-xs text-gray-500">Jun 10, 2025 09:30</time>`,
+		`hx-target="#comment-1-replies"`,
+		`<ol id="comment-1-replies" role="list" class="mt-4 space-y-4 border-l border-gray-200 pl-4"><li id="comment-2">`,
+		`<a href="/comments/2" class="`,
+		`Continue this thread →</a>`,
+	)
+	if strings.Contains(got, "Deep") || strings.Contains(got, `hx-target="#comment-2-replies"`) {
+		t.Errorf("expected the replies past MaxDepth to be cut, got %q", got)
+	}
+}
+
+func TestCommentThread_Markdown(t *testing.T) {
+	got := render(t, CommentThread([]Comment{{ID: "1", Author: "Jane", Body: "hi"}}, CommentThreadParams{
+		Markdown: func(body string) string { return "<p><em>" + body + "</em><script>alert(1)</script></p>" },
+	}))
+	assertContains(t, got, `<p><em>hi</em></p>`)
+	if strings.Contains(got, "<script") || strings.Contains(got, "<form") {
+		t.Errorf("expected sanitized bodies and no reply forms, got %q", got)
+	}
+}
+
+func TestCommentReply(t *testing.T) {
+	got := render(t, CommentReply(Comment{ID: "9", Author: "Jane", Body: "Reply"}, 2, CommentThreadParams{ReplyURL: "/comments"}))
+	assertContains(t, got, `<li id="comment-9">`, `<p>Reply</p>`, `hx-target="#comment-9-replies"`, `<ol id="comment-9-replies"`)
+}
+
+func TestCommentThread_Policy(t *testing.T) {
+	policy := h.SanitizePolicy{Elements: map[string][]string{"p": nil}}
+	got := render(t, CommentThread([]Comment{{ID: "1", Author: "Jane", Body: "x"}}, CommentThreadParams{
+		Markdown: func(body string) string { return "<p><em>" + body + "</em></p>" },
+		Policy:   &policy,
+	}))
+	assertContains(t, got, `<p>x</p>`)
+}
@@ -0,0 +1,61 @@
+package hyperui
+
+import (
+	"context"
+
+	"github.com/assaidy/hyper/v2"
+)
+
+type CopyButtonParams struct {
+	Text string // The text copied
+	// Target is a CSS selector of the element whose text, or value for
+	// form fields, is copied instead of Text, e.g. "#api-key".
+	Target      string
+	Label       any // Defaults to "Copy"
+	CopiedLabel any // Shown for 2 seconds once copied; defaults to "Copied!"
+	Button      ButtonParams
+}
+
+// CopyButton renders a button copying text to the clipboard, briefly
+// showing a success label announced to screen readers. Include
+// CopyButtonScript once in the page.
+//
+// Example:
+//
+//	h.PRE(h.AttrID("install"))("go get github.com/assaidy/hyper/v2"),
+//	CopyButton(CopyButtonParams{Target: "#install"})
+//
+//	CopyButton(CopyButtonParams{Text: apiKey, Label: "Copy API key"})
+func CopyButton(params CopyButtonParams) h.Element {
+	attrs := []h.Attribute{h.AttrType(h.TypeButton)}
+	if params.Target != "" {
+		attrs = append(attrs, h.Attr("data-copy-target", params.Target))
+	} else {
+		attrs = append(attrs, h.Attr("data-copy", params.Text))
+	}
+	params.Button.Attributes = append(attrs, params.Button.Attributes...)
+
+	return Button(params.Button)(
+		h.SPAN(h.Attr("data-copy-label", true))(h.IfElse[any](params.Label != nil, params.Label, "Copy")),
+		h.SPAN(h.Attr("data-copy-done", true), h.AttrRole("status"), h.AttrHidden(true))(
+			h.IfElse[any](params.CopiedLabel != nil, params.CopiedLabel, "Copied!"),
+		),
+	)
+}
+
+// copyButtonScript copies the text of a CopyButton on click, and swaps its
+// labels for 2 seconds once copied.
+const copyButtonScript = `document.addEventListener("click",function(e){var b=e.target.closest&&e.target.closest("[data-copy],[data-copy-target]");if(!b)return;
+var text=b.getAttribute("data-copy");if(text===null){var t=document.querySelector(b.getAttribute("data-copy-target"));if(!t)return;text=/^(INPUT|TEXTAREA|SELECT)$/.test(t.tagName)?t.value:t.textContent}
+navigator.clipboard.writeText(text).then(function(){var label=b.querySelector("[data-copy-label]"),done=b.querySelector("[data-copy-done]");
+label.hidden=true;done.hidden=false;clearTimeout(b._copyTimer);b._copyTimer=setTimeout(function(){label.hidden=false;done.hidden=true},2000)})})`
+
+// CopyButtonScript returns the <script> element driving the CopyButtons
+// of the page. It carries the CSP nonce of ctx (see h.NonceKey).
+func CopyButtonScript(ctx context.Context) h.Element {
+	var attrs []h.Attribute
+	if nonce := h.Nonce(ctx); nonce != "" {
+		attrs = append(attrs, h.AttrNonce(nonce))
+	}
+	return h.SCRIPT(attrs...)(h.RawText(copyButtonScript))
+}
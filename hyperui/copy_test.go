@@ -0,0 +1,34 @@
+package hyperui
+
+import (
+	"context"
+	"testing"
+
+	"github.com/assaidy/hyper/v2"
+)
+
+func TestCopyButton(t *testing.T) {
+	got := render(t, CopyButton(CopyButtonParams{Text: "secret-key"}))
+	assertContains(t, got,
+		`<button type="button" data-copy="secret-key" class="inline-flex`,
+		`<span data-copy-label>Copy</span>`,
+		`<span data-copy-done role="status" hidden>Copied!</span>`,
+	)
+
+	got = render(t, CopyButton(CopyButtonParams{
+		Target:      "#install",
+		Label:       "Copy command",
+		CopiedLabel: "Done",
+		Button:      ButtonParams{Attributes: []h.Attribute{h.AttrID("copy")}},
+	}))
+	assertContains(t, got,
+		`<button type="button" data-copy-target="#install" id="copy" class="`,
+		`<span data-copy-label>Copy command</span>`,
+		`hidden>Done</span>`,
+	)
+}
+
+func TestCopyButtonScript(t *testing.T) {
+	ctx := h.WithValue(context.Background(), h.NonceKey, "abc")
+	assertContains(t, render(t, CopyButtonScript(ctx)), `<script nonce="abc">document.addEventListener("click"`, `navigator.clipboard.writeText`)
+}
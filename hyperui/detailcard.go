@@ -0,0 +1,43 @@
+package hyperui
+
+import (
+	"github.com/assaidy/hyper/v2"
+)
+
+type DetailCardParams struct {
+	Title      any // Optional card heading
+	Attributes []h.Attribute
+}
+
+// DetailCard renders the fields of a struct as a card of term/description
+// pairs, the usual "object detail page" layout. Fields are picked and
+// labeled with the `detail` struct tag, see h.DetailsFrom.
+//
+// Example:
+//
+//	// simple
+//	DetailCard(order)
+//
+//	// with params
+//	DetailCard(order, DetailCardParams{Title: "Order #" + order.ID})
+func DetailCard(v any, params ...DetailCardParams) h.HyperNode {
+	var p DetailCardParams
+	if len(params) != 0 {
+		p = params[0]
+	}
+
+	var pairs []any
+	for _, pair := range h.DetailsFrom(v) {
+		pairs = append(pairs, h.DIV(h.AttrClass("grid grid-cols-3 gap-4 px-4 py-3"))(
+			h.DT(h.AttrClass("text-sm font-medium text-gray-500"))(pair.Term),
+			h.DD(h.AttrClass("col-span-2 text-sm text-gray-900"))(pair.Description),
+		))
+	}
+
+	element := h.DIV(p.Attributes...)(
+		h.If(p.Title != nil, h.H2(h.AttrClass("border-b border-gray-200 px-4 py-3 text-base font-semibold text-gray-900"))(p.Title)),
+		h.DL(h.AttrClass("divide-y divide-gray-100"))(pairs...),
+	)
+	mergeStyles(&element, "overflow-hidden rounded-lg border border-gray-200 bg-white")
+	return element
+}
@@ -0,0 +1,26 @@
+package hyperui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDetailCard(t *testing.T) {
+	type order struct {
+		ID       string `detail:"Order"`
+		Customer string
+		Secret   string `detail:"-"`
+	}
+
+	got := render(t, DetailCard(order{ID: "42", Customer: "Jane", Secret: "x"}, DetailCardParams{Title: "Order #42"}))
+	assertContains(t, got,
+		`<div class="overflow-hidden rounded-lg border border-gray-200 bg-white"><h2 class="`,
+		`>Order #42</h2><dl class="divide-y divide-gray-100">`,
+		`<dt class="text-sm font-medium text-gray-500">Order</dt><dd class="col-span-2 text-sm text-gray-900">42</dd>`,
+		`>Customer</dt>`,
+		`>Jane</dd>`,
+	)
+	if strings.Contains(got, "Secret") {
+		t.Errorf("expected fields tagged detail:\"-\" to be skipped, got %q", got)
+	}
+}
@@ -0,0 +1,52 @@
+package hyperui
+
+import (
+	"github.com/assaidy/hyper/v2"
+)
+
+type EmptyStateParams struct {
+	Compact    bool // Less padding and a smaller icon, for panels and dropdowns
+	Attributes []h.Attribute
+}
+
+// EmptyState renders the placeholder of a list or page with nothing to
+// show yet: an icon, a title, a message explaining why and an action,
+// typically a button or link creating the first item. All but the title
+// are optional (nil).
+//
+// It has role="status", so it is announced when swapped in, e.g. by a
+// search returning no results. It carries a data-empty-state attribute to
+// select it in stylesheets and tests.
+//
+// Example:
+//
+//	h.IfElse[h.HyperNode](len(users) == 0,
+//		EmptyState(
+//			heroicons.User(icons.Options{Size: 48}),
+//			"No users found",
+//			"Add your first user to get started.",
+//			h.A(h.AttrHref("/users/new"))("Add user"),
+//		),
+//		UsersTable(users),
+//	)
+func EmptyState(icon any, title any, message any, action any, params ...EmptyStateParams) h.Element {
+	var p EmptyStateParams
+	if len(params) != 0 {
+		p = params[0]
+	}
+
+	element := h.DIV(append([]h.Attribute{h.AttrRole("status"), h.Attr("data-empty-state", "")}, p.Attributes...)...)(
+		h.If(icon != nil, h.DIV(
+			h.AttrAriaHidden("true"),
+			h.AttrClass(h.IfElse(p.Compact, "mb-2 text-gray-400 [&_svg]:size-8", "mb-4 text-gray-400 [&_svg]:size-12")),
+		)(icon)),
+		h.P(h.AttrClass(h.IfElse(p.Compact, "text-sm font-semibold text-gray-900", "text-base font-semibold text-gray-900")))(title),
+		h.If(message != nil, h.P(h.AttrClass("mt-1 max-w-sm text-sm text-gray-500"))(message)),
+		h.If(action != nil, h.DIV(h.AttrClass(h.IfElse(p.Compact, "mt-3", "mt-6")))(action)),
+	)
+	mergeStyles(&element, h.IfElse(p.Compact,
+		"flex flex-col items-center px-4 py-6 text-center",
+		"flex flex-col items-center rounded-lg border-2 border-dashed border-gray-200 px-6 py-12 text-center",
+	))
+	return element
+}
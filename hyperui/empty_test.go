@@ -0,0 +1,25 @@
+package hyperui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/assaidy/hyper/v2"
+)
+
+func TestEmptyState(t *testing.T) {
+	got := render(t, EmptyState(h.SPAN()("icon"), "No users", "Add one.", h.A(h.AttrHref("/users/new"))("Add user")))
+	assertContains(t, got,
+		`<div role="status" data-empty-state="" class="flex flex-col items-center rounded-lg border-2`,
+		`<div aria-hidden="true" class="mb-4 text-gray-400 [&_svg]:size-12"><span>icon</span></div>`,
+		`>No users</p>`,
+		`>Add one.</p>`,
+		`<div class="mt-6"><a href="/users/new">Add user</a></div>`,
+	)
+
+	got = render(t, EmptyState(nil, "Nothing here", nil, nil, EmptyStateParams{Compact: true}))
+	assertContains(t, got, `class="flex flex-col items-center px-4 py-6 text-center"`, `text-sm font-semibold`)
+	if strings.Contains(got, "aria-hidden") || strings.Count(got, "<p") != 1 {
+		t.Errorf("expected only the title, got %q", got)
+	}
+}
@@ -0,0 +1,168 @@
+package hyperui
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/assaidy/hyper/v2"
+	"github.com/assaidy/hyper/v2/icons"
+	"github.com/assaidy/hyper/v2/icons/heroicons"
+)
+
+// Notification is an item of a NotificationList.
+type Notification struct {
+	ID    string
+	Title any
+	Body  any
+	URL   string // Opened when the notification is clicked, if set
+	Time  time.Time
+	Read  bool
+}
+
+type NotificationBellParams struct {
+	Unread int // Number of unread notifications shown on the badge
+	// ListURL is requested with htmx when the dropdown opens, and should
+	// answer with a NotificationList.
+	ListURL string
+	// EventsURL, when set, is a server-sent events endpoint (e.g. a
+	// live.Bus SSE endpoint) pushing UnreadBadgeUpdate fragments.
+	EventsURL  string
+	ID         string // Id of the bell, prefixing the ids of its parts; defaults to "notifications"
+	Attributes []h.Attribute
+}
+
+// NotificationBell renders a bell button with an unread count badge,
+// opening a dropdown whose list is loaded on demand. The badge is updated
+// live by publishing an UnreadBadgeUpdate on the SSE endpoint of the bell.
+//
+// Example:
+//
+//	NotificationBell(NotificationBellParams{
+//		Unread:    unread,
+//		ListURL:   "/notifications",
+//		EventsURL: "/notifications/events",
+//	})
+//
+//	// When a notification is created or read:
+//	bus.Publish("user:"+userID, hyperui.UnreadBadgeUpdate("notifications", unread))
+func NotificationBell(params NotificationBellParams) h.Element {
+	id := h.IfElse(params.ID != "", params.ID, "notifications")
+
+	var events h.HyperNode = h.Group()
+	if params.EventsURL != "" {
+		// Swaps nothing itself: the pushed fragments are out-of-band swaps.
+		events = h.DIV(
+			h.Attr("hx-ext", "sse"),
+			h.Attr("sse-connect", params.EventsURL),
+			h.Attr("sse-swap", "message"),
+			h.Attr("hx-swap", "none"),
+			h.AttrHidden(true),
+		)()
+	}
+
+	element := h.DIV(append([]h.Attribute{h.AttrID(id)}, params.Attributes...)...)(
+		h.DETAILS(h.AttrClass("relative"))(
+			h.SUMMARY(
+				h.AttrClass("relative inline-flex cursor-pointer list-none rounded-full p-2 text-gray-600 hover:bg-gray-100"),
+				h.AttrAriaLabel("Notifications"),
+			)(
+				heroicons.Bell(icons.Options{Size: 24}),
+				UnreadBadge(id, params.Unread),
+			),
+			h.DIV(
+				h.AttrID(id+"-list"),
+				h.AttrClass("absolute right-0 z-10 mt-2 w-80 rounded-md border border-gray-200 bg-white shadow-lg"),
+				h.Attr("hx-get", params.ListURL),
+				h.Attr("hx-trigger", "toggle once from:closest details"),
+			)(
+				h.P(h.AttrClass("p-4 text-sm text-gray-500"))("Loading…"),
+			),
+		),
+		events,
+	)
+	mergeStyles(&element, "relative inline-block")
+	return element
+}
+
+// UnreadBadge renders the unread count of the bell with id, hidden when
+// there is nothing unread. Counts over 99 show as "99+".
+func UnreadBadge(id string, unread int) h.Element {
+	label := strconv.Itoa(unread)
+	if unread > 99 {
+		label = "99+"
+	}
+	return h.SPAN(
+		h.AttrID(id+"-badge"),
+		h.AttrClass("absolute -right-0.5 -top-0.5 min-w-5 rounded-full bg-red-600 px-1 text-center text-xs font-semibold text-white"),
+		h.AttrHidden(unread == 0),
+		h.AttrAriaLabel(fmt.Sprintf("%d unread", unread)),
+	)(label)
+}
+
+// UnreadBadgeUpdate returns the UnreadBadge of the bell with id as an
+// out-of-band swap, to push over SSE or WebSocket.
+func UnreadBadgeUpdate(id string, unread int) h.Element {
+	badge := UnreadBadge(id, unread)
+	badge.Attributes = append(badge.Attributes, h.Attr("hx-swap-oob", "true"))
+	return badge
+}
+
+type NotificationListParams struct {
+	// MarkReadURL, when set, is posted to with htmx when an unread
+	// notification is clicked, with the id of the notification as "id".
+	MarkReadURL string
+	Empty       any // Shown when there are no notifications; defaults to "No notifications"
+	Attributes  []h.Attribute
+}
+
+// NotificationList renders the content of the dropdown of a
+// NotificationBell, answering its ListURL.
+func NotificationList(notifications []Notification, params ...NotificationListParams) h.Element {
+	var p NotificationListParams
+	if len(params) != 0 {
+		p = params[0]
+	}
+
+	if len(notifications) == 0 {
+		return EmptyState(nil, h.IfElse[any](p.Empty != nil, p.Empty, "No notifications"), nil, nil, EmptyStateParams{Compact: true, Attributes: p.Attributes})
+	}
+
+	element := h.UL(append([]h.Attribute{h.AttrRole("list")}, p.Attributes...)...)(
+		h.Range(notifications, func(n Notification) h.HyperNode {
+			content := []any{
+				h.P(h.AttrClass(h.IfElse(n.Read, "text-sm text-gray-700", "text-sm font-semibold text-gray-900")))(n.Title),
+				h.If(n.Body != nil, h.P(h.AttrClass("text-sm text-gray-500"))(n.Body)),
+				h.If(!n.Time.IsZero(), h.TIME(
+					h.AttrDateTime(n.Time.Format(time.RFC3339)),
+					h.AttrClass("text-xs text-gray-400"),
+				)(n.Time.Format("Jan 2, 15:04"))),
+			}
+
+			var item h.HyperNode
+			if n.URL != "" {
+				item = h.A(h.AttrHref(n.URL), h.AttrClass("block px-4 py-3 hover:bg-gray-50"))(content...)
+			} else {
+				item = h.DIV(h.AttrClass("px-4 py-3"))(content...)
+			}
+
+			// The request is sent from the item rather than the link, which
+			// htmx would keep from navigating.
+			var attrs []h.Attribute
+			if !n.Read {
+				attrs = append(attrs, h.AttrClass("bg-blue-50"))
+				if p.MarkReadURL != "" {
+					attrs = append(attrs,
+						h.Attr("hx-post", p.MarkReadURL),
+						h.Attr("hx-vals", fmt.Sprintf(`{"id":%q}`, n.ID)),
+						h.Attr("hx-trigger", "click"),
+						h.Attr("hx-swap", "none"),
+					)
+				}
+			}
+			return h.LI(attrs...)(item)
+		}),
+	)
+	mergeStyles(&element, "max-h-96 divide-y divide-gray-100 overflow-y-auto")
+	return element
+}
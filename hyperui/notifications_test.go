@@ -0,0 +1,50 @@
+package hyperui
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNotificationBell(t *testing.T) {
+	got := render(t, NotificationBell(NotificationBellParams{
+		Unread:    3,
+		ListURL:   "/notifications",
+		EventsURL: "/notifications/events",
+	}))
+	assertContains(t, got,
+		`<div id="notifications" class="relative inline-block">`,
+		`aria-label="Notifications"`,
+		`<span id="notifications-badge" class="`,
+		`aria-label="3 unread">3</span>`,
+		`<div id="notifications-list" class="`,
+		`hx-get="/notifications" hx-trigger="toggle once from:closest details"`,
+		`<div hx-ext="sse" sse-connect="/notifications/events" sse-swap="message" hx-swap="none" hidden></div>`,
+	)
+
+	got = render(t, NotificationBell(NotificationBellParams{ID: "bell", ListURL: "/n"}))
+	assertContains(t, got, `<div id="bell" class=`, `id="bell-badge"`, `hidden aria-label="0 unread">0</span>`)
+	if strings.Contains(got, "sse-connect") {
+		t.Errorf("expected no SSE connection without EventsURL, got %q", got)
+	}
+}
+
+func TestUnreadBadgeUpdate(t *testing.T) {
+	got := render(t, UnreadBadgeUpdate("notifications", 120))
+	assertContains(t, got, `<span id="notifications-badge"`, `aria-label="120 unread" hx-swap-oob="true">99+</span>`)
+}
+
+func TestNotificationList(t *testing.T) {
+	got := render(t, NotificationList([]Notification{
+		{ID: "n1", Title: "New comment", Body: "Nice post!", URL: "/posts/1", Time: time.Date(2025, 6, 10, 9, 30, 0, 0, time.UTC)},
+		{ID: "n2", Title: "Welcome", Read: true},
+	}, NotificationListParams{MarkReadURL: "/notifications/read"}))
+	assertContains(t, got,
+		`<ul role="list" class="max-h-96`,
+		`<li class="bg-blue-50" hx-post="/notifications/read" hx-vals="{&quot;id&quot;:&quot;n1&quot;}" hx-trigger="click" hx-swap="none"><a href="/posts/1"`,
+		`<time datetime="2025-06-10T09:30:00Z" class="text-xs text-gray-400">Jun 10, 09:30</time>`,
+		`<li><div class="px-4 py-3"><p class="text-sm text-gray-700">Welcome</p></div></li>`,
+	)
+
+	assertContains(t, render(t, NotificationList(nil)), `data-empty-state=""`, `>No notifications</p>`)
+}
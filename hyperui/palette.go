@@ -0,0 +1,135 @@
+package hyperui
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/assaidy/hyper/v2"
+)
+
+// Command is a result of a CommandPalette search.
+type Command struct {
+	Label any
+	Hint  any    // Shown after the label, e.g. a section or a shortcut
+	URL   string // Followed when the command is chosen
+	// Attributes are added to the link of the command, e.g. hx-post to run
+	// an action instead of following URL.
+	Attributes []h.Attribute
+}
+
+type CommandPaletteParams struct {
+	// SearchURL is requested with htmx as the user types, with the query
+	// as "q", and should answer with CommandResults for the palette's ID.
+	SearchURL   string
+	ID          string // Id of the palette, prefixing the ids of its parts; defaults to "command-palette"
+	Placeholder string // Defaults to "Type a command or search…"
+	Attributes  []h.Attribute
+}
+
+// CommandPalette renders a modal palette opened with Ctrl+K (⌘K on macOS)
+// or by clicking an element with a data-command-palette-open attribute. Its
+// search input is an ARIA combobox driving a listbox of results loaded from
+// SearchURL, navigated with the arrow keys and chosen with Enter. Include
+// CommandPaletteScript once in the page.
+//
+// Example:
+//
+//	hyperui.CommandPalette(hyperui.CommandPaletteParams{SearchURL: "/commands"})
+//
+//	mux.Handle("GET /commands", h.Handler(func(r *http.Request) (h.HyperNode, error) {
+//		return hyperui.CommandResults("command-palette", searchCommands(r.FormValue("q"))), nil
+//	}))
+func CommandPalette(params CommandPaletteParams) h.Element {
+	id := h.IfElse(params.ID != "", params.ID, "command-palette")
+
+	element := h.DIALOG(append([]h.Attribute{
+		h.AttrID(id),
+		h.Attr("data-command-palette", true),
+		h.AttrAriaLabel("Command palette"),
+	}, params.Attributes...)...)(
+		h.INPUT(
+			h.AttrType(h.TypeSearch),
+			h.AttrName("q"),
+			h.AttrClass("w-full border-b border-gray-200 px-4 py-3 text-base outline-none"),
+			h.AttrPlaceholder(h.IfElse(params.Placeholder != "", params.Placeholder, "Type a command or search…")),
+			h.AttrAutocomplete("off"),
+			h.AttrSpellCheck("false"),
+			h.AttrRole("combobox"),
+			h.AttrAriaAutocomplete("list"),
+			h.AttrAriaControls(id+"-results"),
+			h.AttrAriaExpanded("true"),
+			h.Attr("hx-get", params.SearchURL),
+			h.Attr("hx-trigger", "input changed delay:150ms, search, focus once"),
+			h.Attr("hx-target", "#"+id+"-results"),
+			h.Attr("hx-swap", "outerHTML"),
+		),
+		CommandResults(id, nil),
+	)
+	mergeStyles(&element, "mx-auto mt-24 w-full max-w-xl overflow-hidden rounded-lg border border-gray-200 bg-white p-0 shadow-xl backdrop:bg-gray-900/40")
+	return element
+}
+
+// CommandResults renders the results of the palette with id, answering its
+// SearchURL. The first command is selected.
+func CommandResults(id string, commands []Command) h.Element {
+	options := make([]any, len(commands))
+	for i, c := range commands {
+		options[i] = h.LI(
+			h.AttrID(id+"-option-"+strconv.Itoa(i)),
+			h.AttrRole("option"),
+			h.AttrAriaSelected(strconv.FormatBool(i == 0)),
+			h.AttrClass("aria-selected:bg-blue-50"),
+		)(
+			// The link is out of the tab order: the selection follows the
+			// combobox's aria-activedescendant instead of the focus.
+			h.A(append([]h.Attribute{
+				h.AttrHref(c.URL),
+				h.AttrTabIndex("-1"),
+				h.AttrClass("flex items-center justify-between px-4 py-2 text-sm text-gray-900"),
+			}, c.Attributes...)...)(
+				h.SPAN()(c.Label),
+				h.If(c.Hint != nil, h.SPAN(h.AttrClass("text-xs text-gray-400"))(c.Hint)),
+			),
+		)
+	}
+
+	element := h.UL(
+		h.AttrID(id+"-results"),
+		h.AttrRole("listbox"),
+		h.AttrAriaLabel("Commands"),
+	)(options...)
+	mergeStyles(&element, "max-h-80 overflow-y-auto py-1 empty:hidden")
+	return element
+}
+
+// commandPaletteScript opens the palette, moves the selection of its
+// listbox with the arrow keys and mouse, and chooses the selected command
+// with Enter. Escape closes the dialog natively.
+const commandPaletteScript = `(function(){
+function palette(){return document.querySelector("dialog[data-command-palette]")}
+function options(d){return Array.prototype.slice.call(d.querySelectorAll('[role="option"]'))}
+function select(d,o){var input=d.querySelector('[role="combobox"]');options(d).forEach(function(x){x.setAttribute("aria-selected",x===o?"true":"false")});
+if(o){input.setAttribute("aria-activedescendant",o.id);o.scrollIntoView({block:"nearest"})}else input.removeAttribute("aria-activedescendant")}
+function open(d){if(!d.open){d.showModal();d.querySelector('[role="combobox"]').select()}}
+document.addEventListener("keydown",function(e){var d=palette();if(!d)return;
+if((e.ctrlKey||e.metaKey)&&e.key.toLowerCase()==="k"){e.preventDefault();d.open?d.close():open(d);return}
+if(!d.open||!d.contains(e.target))return;
+var all=options(d),current=d.querySelector('[role="option"][aria-selected="true"]'),i=all.indexOf(current);
+if((e.key==="ArrowDown"||e.key==="ArrowUp")&&all.length){e.preventDefault();i=e.key==="ArrowDown"?(i+1)%all.length:(i<=0?all.length-1:i-1);select(d,all[i])}
+else if(e.key==="Enter"&&current){e.preventDefault();current.querySelector("a").click();d.close()}});
+document.addEventListener("mousemove",function(e){var d=palette(),o=e.target.closest&&e.target.closest('[role="option"]');if(d&&o&&d.contains(o))select(d,o)});
+document.addEventListener("click",function(e){var d=palette();if(!d)return;
+if(e.target===d||e.target.closest('[role="option"]')&&d.contains(e.target))d.close();
+else if(e.target.closest("[data-command-palette-open]")){e.preventDefault();open(d)}});
+document.addEventListener("htmx:afterSettle",function(e){var d=palette();if(d&&d.contains(e.detail.elt))select(d,options(d)[0])})})()`
+
+// CommandPaletteScript returns the <script> element driving the
+// CommandPalette of the page. It carries the CSP nonce of ctx (see
+// h.NonceKey).
+func CommandPaletteScript(ctx context.Context) h.Element {
+	var attrs []h.Attribute
+	if nonce := h.Nonce(ctx); nonce != "" {
+		attrs = append(attrs, h.AttrNonce(nonce))
+	}
+	return h.SCRIPT(attrs...)(h.RawText(commandPaletteScript))
+}
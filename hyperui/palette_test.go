@@ -0,0 +1,38 @@
+package hyperui
+
+import (
+	"context"
+	"testing"
+
+	"github.com/assaidy/hyper/v2"
+)
+
+func TestCommandPalette(t *testing.T) {
+	got := render(t, CommandPalette(CommandPaletteParams{SearchURL: "/commands"}))
+	assertContains(t, got,
+		`<dialog id="command-palette" data-command-palette aria-label="Command palette" class="`,
+		`role="combobox" aria-autocomplete="list" aria-controls="command-palette-results" aria-expanded="true"`,
+		`hx-get="/commands"`,
+		`hx-target="#command-palette-results" hx-swap="outerHTML"`,
+		`placeholder="Type a command or search…"`,
+		`<ul id="command-palette-results" role="listbox" aria-label="Commands" class="`,
+	)
+}
+
+func TestCommandResults(t *testing.T) {
+	got := render(t, CommandResults("palette", []Command{
+		{Label: "Settings", Hint: "Ctrl+,", URL: "/settings"},
+		{Label: "Sign out", URL: "/logout", Attributes: []h.Attribute{h.Attr("hx-post", "/logout")}},
+	}))
+	assertContains(t, got,
+		`<li id="palette-option-0" role="option" aria-selected="true" class="aria-selected:bg-blue-50"><a href="/settings" tabindex="-1"`,
+		`<span>Settings</span><span class="text-xs text-gray-400">Ctrl+,</span>`,
+		`<li id="palette-option-1" role="option" aria-selected="false"`,
+		`hx-post="/logout"><span>Sign out</span></a>`,
+	)
+}
+
+func TestCommandPaletteScript(t *testing.T) {
+	ctx := h.WithValue(context.Background(), h.NonceKey, "abc")
+	assertContains(t, render(t, CommandPaletteScript(ctx)), `<script nonce="abc">(function(){`)
+}
@@ -0,0 +1,131 @@
+package hyperui
+
+import (
+	"strings"
+
+	"github.com/assaidy/hyper/v2"
+)
+
+// Placement is the side of its trigger a Tooltip or Popover opens on.
+type Placement uint
+
+const (
+	// PlacementAuto is the default side of the component: the top for
+	// tooltips, the bottom for popovers.
+	PlacementAuto Placement = iota
+	PlacementTop
+	PlacementBottom
+	PlacementLeft
+	PlacementRight
+)
+
+var placementAreas = map[Placement]string{
+	PlacementTop:    "top",
+	PlacementBottom: "bottom",
+	PlacementLeft:   "left",
+	PlacementRight:  "right",
+}
+
+// placementFallbackClasses position tooltips next to their trigger in
+// browsers without CSS anchor positioning.
+var placementFallbackClasses = map[Placement]string{
+	PlacementTop:    "absolute inset-auto m-0 bottom-full left-1/2 -translate-x-1/2 mb-2",
+	PlacementBottom: "absolute inset-auto m-0 top-full left-1/2 -translate-x-1/2 mt-2",
+	PlacementLeft:   "absolute inset-auto m-0 right-full top-1/2 -translate-y-1/2 mr-2",
+	PlacementRight:  "absolute inset-auto m-0 left-full top-1/2 -translate-y-1/2 ml-2",
+}
+
+// anchorSupport is the feature query of CSS anchor positioning.
+const anchorSupport = "[position-area:top]"
+
+// anchored returns the style and classes of a popover positioned next to
+// the anchor named after id: with anchor positioning where supported, and
+// with fallback classes when not, if set.
+func anchored(id string, placement Placement, fallback bool) (h.Attribute, string) {
+	area, ok := placementAreas[placement]
+	if !ok {
+		panic("invalid placement")
+	}
+	classes := []string{"supports-" + anchorSupport + ":m-2"}
+	if fallback {
+		for class := range strings.FieldsSeq(placementFallbackClasses[placement]) {
+			classes = append(classes, "not-supports-"+anchorSupport+":"+class)
+		}
+	}
+	return h.AttrStyle("position-anchor:--" + id + ";position-area:" + area), strings.Join(classes, " ")
+}
+
+type TooltipParams struct {
+	Placement  Placement
+	Attributes []h.Attribute
+}
+
+// Tooltip renders trigger with a tooltip of text, shown while the trigger
+// is hovered or focused, without JavaScript. The tooltip is a popover hint
+// positioned with CSS anchor positioning, falling back to absolute
+// positioning, and describes the trigger (aria-describedby). id must be
+// unique in the page.
+//
+// Example:
+//
+//	Tooltip("copy-tip", Button()(heroicons.Clipboard()), "Copy to clipboard")
+func Tooltip(id string, trigger h.Element, text any, params ...TooltipParams) h.Element {
+	var p TooltipParams
+	if len(params) != 0 {
+		p = params[0]
+	}
+
+	placement := h.IfElse(p.Placement != PlacementAuto, p.Placement, PlacementTop)
+	style, classes := anchored(id, placement, true)
+
+	trigger.Attributes = append(trigger.Attributes, h.AttrAriaDescribedBy(id), h.Attr("interestfor", id))
+	tooltip := h.SPAN(append([]h.Attribute{
+		h.AttrID(id),
+		h.AttrRole("tooltip"),
+		h.AttrPopover(h.PopoverHint),
+		style,
+	}, p.Attributes...)...)(text)
+	// Shown by CSS on hover and focus: popovers are only hidden by the
+	// browser's default styles.
+	mergeStyles(&tooltip, "pointer-events-none whitespace-nowrap rounded bg-gray-900 px-2 py-1 text-xs text-white shadow group-hover:block group-focus-within:block "+classes)
+
+	return h.SPAN(
+		h.AttrClass("group relative inline-block"),
+		h.AttrStyle("anchor-name:--"+id),
+	)(trigger, tooltip)
+}
+
+type PopoverParams struct {
+	Placement  Placement
+	Label      string // Accessible name of the popover, when its content has no heading
+	Attributes []h.Attribute
+}
+
+// Popover renders trigger, typically a button, toggling a popover of
+// content when pressed, without JavaScript. The browser closes it on
+// Escape and clicks outside, and exposes its state on the trigger
+// (aria-expanded). It opens next to the trigger with CSS anchor
+// positioning, or centered in the viewport without. id must be unique in
+// the page.
+//
+// Example:
+//
+//	Popover("filters", Button()("Filters"), FiltersForm(query), PopoverParams{Label: "Filters"})
+func Popover(id string, trigger h.Element, content any, params ...PopoverParams) h.Element {
+	var p PopoverParams
+	if len(params) != 0 {
+		p = params[0]
+	}
+	placement := h.IfElse(p.Placement != PlacementAuto, p.Placement, PlacementBottom)
+	style, classes := anchored(id, placement, false)
+
+	trigger.Attributes = append(trigger.Attributes, h.AttrPopoverTarget(id))
+	attrs := []h.Attribute{h.AttrID(id), h.AttrPopover(h.PopoverAuto), h.AttrRole("dialog"), style}
+	if p.Label != "" {
+		attrs = append(attrs, h.AttrAriaLabel(p.Label))
+	}
+	popover := h.DIV(append(attrs, p.Attributes...)...)(content)
+	mergeStyles(&popover, "rounded-md border border-gray-200 bg-white p-4 text-sm text-gray-900 shadow-lg "+classes)
+
+	return h.SPAN(h.AttrStyle("anchor-name:--"+id))(trigger, popover)
+}
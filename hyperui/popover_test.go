@@ -0,0 +1,39 @@
+package hyperui
+
+import (
+	"testing"
+
+	"github.com/assaidy/hyper/v2"
+)
+
+func TestTooltip(t *testing.T) {
+	got := render(t, Tooltip("copy-tip", h.BUTTON()("Copy"), "Copy to clipboard"))
+	assertContains(t, got,
+		`<span class="group relative inline-block" style="anchor-name:--copy-tip">`,
+		`<button aria-describedby="copy-tip" interestfor="copy-tip">Copy</button>`,
+		`<span id="copy-tip" role="tooltip" popover="hint" style="position-anchor:--copy-tip;position-area:top" class="`,
+		`not-supports-[position-area:top]:bottom-full`,
+		`>Copy to clipboard</span></span>`,
+	)
+
+	got = render(t, Tooltip("tip", h.BUTTON()("?"), "Help", TooltipParams{Placement: PlacementRight}))
+	assertContains(t, got, `position-area:right`, `not-supports-[position-area:top]:left-full`)
+}
+
+func TestPopover(t *testing.T) {
+	got := render(t, Popover("filters", h.BUTTON()("Filters"), h.P()("Form"), PopoverParams{Label: "Filters"}))
+	assertContains(t, got,
+		`<span style="anchor-name:--filters"><button popovertarget="filters">Filters</button>`,
+		`<div id="filters" popover="auto" role="dialog" style="position-anchor:--filters;position-area:bottom" aria-label="Filters" class="`,
+		`<p>Form</p></div></span>`,
+	)
+}
+
+func TestPopover_InvalidPlacement(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for an invalid placement")
+		}
+	}()
+	Popover("p", h.BUTTON()("Open"), "content", PopoverParams{Placement: 42})
+}
@@ -0,0 +1,132 @@
+package hyperui
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/assaidy/hyper/v2"
+	"github.com/assaidy/hyper/v2/icons"
+	"github.com/assaidy/hyper/v2/icons/brands"
+	"github.com/assaidy/hyper/v2/icons/heroicons"
+)
+
+// ShareNetwork is a destination of a ShareBar.
+type ShareNetwork uint
+
+const (
+	ShareX ShareNetwork = iota
+	ShareFacebook
+	ShareLinkedIn
+	ShareReddit
+	ShareEmail
+	ShareCopyLink
+)
+
+var defaultShareNetworks = []ShareNetwork{ShareX, ShareFacebook, ShareLinkedIn, ShareReddit, ShareEmail, ShareCopyLink}
+
+type ShareBarParams struct {
+	Networks   []ShareNetwork // Defaults to all of them, in the order of the constants
+	Attributes []h.Attribute
+}
+
+// ShareURL returns the URL sharing the page at pageURL, titled title, on
+// network. It is empty for ShareCopyLink.
+func ShareURL(network ShareNetwork, pageURL, title string) string {
+	switch network {
+	case ShareX:
+		return "https://x.com/intent/post?" + url.Values{"text": {title}, "url": {pageURL}}.Encode()
+	case ShareFacebook:
+		return "https://www.facebook.com/sharer/sharer.php?" + url.Values{"u": {pageURL}}.Encode()
+	case ShareLinkedIn:
+		return "https://www.linkedin.com/sharing/share-offsite/?" + url.Values{"url": {pageURL}}.Encode()
+	case ShareReddit:
+		return "https://www.reddit.com/submit?" + url.Values{"title": {title}, "url": {pageURL}}.Encode()
+	case ShareEmail:
+		// Mail clients don't decode "+" as a space.
+		return "mailto:?subject=" + mailtoEscape(title) + "&body=" + mailtoEscape(pageURL)
+	default:
+		return ""
+	}
+}
+
+func mailtoEscape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+var shareLabels = map[ShareNetwork]string{
+	ShareX:        "Share on X",
+	ShareFacebook: "Share on Facebook",
+	ShareLinkedIn: "Share on LinkedIn",
+	ShareReddit:   "Share on Reddit",
+	ShareEmail:    "Share by email",
+	ShareCopyLink: "Copy link",
+}
+
+var shareIcons = map[ShareNetwork]func(...icons.Options) h.HyperNode{
+	ShareX:        brands.X,
+	ShareFacebook: brands.Facebook,
+	ShareLinkedIn: brands.LinkedIn,
+	ShareReddit:   brands.Reddit,
+	ShareEmail:    heroicons.Envelope,
+	ShareCopyLink: heroicons.Link,
+}
+
+// ShareBar renders links sharing the page at pageURL, titled title, on
+// social networks and by email, plus a button copying pageURL. The links
+// open in a new tab without giving it access to the page (noopener) or
+// its URL (noreferrer). Include CopyButtonScript once in the page for the
+// copy button.
+//
+// Example:
+//
+//	ShareBar("https://example.com/posts/hello", "Hello, world")
+//
+//	// without Facebook
+//	ShareBar(postURL, post.Title, ShareBarParams{
+//		Networks: []ShareNetwork{ShareX, ShareLinkedIn, ShareCopyLink},
+//	})
+func ShareBar(pageURL, title string, params ...ShareBarParams) h.Element {
+	var p ShareBarParams
+	if len(params) != 0 {
+		p = params[0]
+	}
+	networks := p.Networks
+	if len(networks) == 0 {
+		networks = defaultShareNetworks
+	}
+
+	const itemClass = "inline-flex items-center justify-center rounded-full p-2 text-gray-600 hover:bg-gray-100 hover:text-gray-900"
+	items := make([]any, len(networks))
+	for i, network := range networks {
+		label, ok := shareLabels[network]
+		if !ok {
+			panic("invalid share network")
+		}
+		icon := shareIcons[network](icons.Options{Size: 20})
+
+		if network == ShareCopyLink {
+			items[i] = h.LI()(CopyButton(CopyButtonParams{
+				Text:        pageURL,
+				Label:       h.Group(icon, h.SPAN(h.AttrClass("sr-only"))(label)),
+				CopiedLabel: h.SPAN(h.AttrClass("text-xs"))("Copied!"),
+				Button:      ButtonParams{Attributes: []h.Attribute{h.AttrTitle(label), h.AttrClass(itemClass)}},
+			}))
+			continue
+		}
+
+		attrs := []h.Attribute{
+			h.AttrHref(ShareURL(network, pageURL, title)),
+			h.AttrTitle(label),
+			h.AttrAriaLabel(label),
+			h.AttrClass(itemClass),
+		}
+		if network != ShareEmail {
+			attrs = append(attrs, h.AttrTarget(h.TargetBlank), h.AttrRel(h.RelNoOpener+" "+h.RelNoReferrer))
+		}
+		items[i] = h.LI()(h.A(attrs...)(icon))
+	}
+
+	element := h.UL(append([]h.Attribute{h.AttrAriaLabel("Share")}, p.Attributes...)...)(items...)
+	mergeStyles(&element, "flex items-center gap-1")
+	return element
+}
@@ -0,0 +1,46 @@
+package hyperui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestShareURL(t *testing.T) {
+	const page, title = "https://example.com/posts/1?ref=a", "Hello & welcome"
+	tests := []struct {
+		network  ShareNetwork
+		expected string
+	}{
+		{ShareX, "https://x.com/intent/post?text=Hello+%26+welcome&url=https%3A%2F%2Fexample.com%2Fposts%2F1%3Fref%3Da"},
+		{ShareFacebook, "https://www.facebook.com/sharer/sharer.php?u=https%3A%2F%2Fexample.com%2Fposts%2F1%3Fref%3Da"},
+		{ShareLinkedIn, "https://www.linkedin.com/sharing/share-offsite/?url=https%3A%2F%2Fexample.com%2Fposts%2F1%3Fref%3Da"},
+		{ShareReddit, "https://www.reddit.com/submit?title=Hello+%26+welcome&url=https%3A%2F%2Fexample.com%2Fposts%2F1%3Fref%3Da"},
+		{ShareEmail, "mailto:?subject=Hello%20%26%20welcome&body=https%3A%2F%2Fexample.com%2Fposts%2F1%3Fref%3Da"},
+		{ShareCopyLink, ""},
+	}
+	for _, tt := range tests {
+		if got := ShareURL(tt.network, page, title); got != tt.expected {
+			t.Errorf("ShareURL(%d) = %q, want %q", tt.network, got, tt.expected)
+		}
+	}
+}
+
+func TestShareBar(t *testing.T) {
+	got := render(t, ShareBar("https://example.com/", "Hi"))
+	assertContains(t, got,
+		`<ul aria-label="Share" class="flex items-center gap-1">`,
+		`title="Share on X" aria-label="Share on X"`,
+		`target="_blank" rel="noopener noreferrer"`,
+		`<a href="mailto:?subject=Hi&body=https%3A%2F%2Fexample.com%2F" title="Share by email" aria-label="Share by email" class="`,
+		`data-copy="https://example.com/"`,
+		`<span class="sr-only">Copy link</span>`,
+	)
+	if strings.Count(got, "<li>") != 6 {
+		t.Errorf("expected all the networks by default, got %q", got)
+	}
+
+	got = render(t, ShareBar("https://example.com/", "Hi", ShareBarParams{Networks: []ShareNetwork{ShareLinkedIn}}))
+	if strings.Count(got, "<li>") != 1 || !strings.Contains(got, "linkedin.com") {
+		t.Errorf("expected only LinkedIn, got %q", got)
+	}
+}
@@ -0,0 +1,104 @@
+package hyperui
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/assaidy/hyper/v2"
+)
+
+// LineRange is a range of lines of a Snippet, from 1, inclusive.
+type LineRange struct {
+	From, To int
+}
+
+type SnippetParams struct {
+	Filename string // Shown in a header when set
+	// Language of the code, added as a language-* class to the <code> for
+	// client-side highlighters, and passed to Highlight.
+	Language string
+	// Highlight returns the highlighted markup of each line of code, e.g.
+	// from a Chroma formatter. By default the code is rendered as plain
+	// text.
+	Highlight   func(code, language string) []h.HyperNode
+	LineNumbers bool
+	Lines       []LineRange // Lines to emphasize
+	Wrap        bool        // Wraps long lines instead of scrolling them
+	NoCopy      bool        // Removes the copy button
+	Attributes  []h.Attribute
+}
+
+// Snippet renders a block of code for documentation: an optional filename
+// header, line numbers and emphasized lines, and a CopyButton copying the
+// code without its line numbers. Include CopyButtonScript once in the page
+// unless NoCopy is set.
+//
+// Example:
+//
+//	Snippet(source, SnippetParams{
+//		Filename:    "main.go",
+//		Language:    "go",
+//		LineNumbers: true,
+//		Lines:       []LineRange{{From: 4, To: 6}},
+//	})
+func Snippet(code string, params ...SnippetParams) h.Element {
+	var p SnippetParams
+	if len(params) != 0 {
+		p = params[0]
+	}
+	code = strings.TrimSuffix(code, "\n")
+
+	var lines []h.HyperNode
+	if p.Highlight != nil {
+		lines = p.Highlight(code, p.Language)
+	} else {
+		for line := range strings.SplitSeq(code, "\n") {
+			lines = append(lines, h.Text(line))
+		}
+	}
+
+	numberWidth := len(strconv.Itoa(len(lines)))
+	children := make([]any, 0, 2*len(lines))
+	for i, line := range lines {
+		number := i + 1
+		emphasized := false
+		for _, r := range p.Lines {
+			emphasized = emphasized || r.From <= number && number <= max(r.From, r.To)
+		}
+		children = append(children, h.SPAN(
+			h.Attr("data-line", strconv.Itoa(number)),
+			h.AttrClass(h.IfElse(emphasized, "inline-block w-full bg-yellow-400/20", "inline-block w-full")),
+		)(
+			h.If(p.LineNumbers, h.SPAN(
+				h.AttrAriaHidden("true"),
+				h.AttrClass("mr-4 inline-block select-none text-right text-gray-500"),
+				h.AttrStyle("width:"+strconv.Itoa(numberWidth)+"ch"),
+			)(strconv.Itoa(number))),
+			line,
+		), "\n")
+	}
+
+	var codeAttrs []h.Attribute
+	if p.Language != "" {
+		codeAttrs = append(codeAttrs, h.AttrClass("language-"+p.Language))
+	}
+	var copyButton h.HyperNode = h.Group()
+	if !p.NoCopy {
+		copyButton = CopyButton(CopyButtonParams{
+			Text:   code,
+			Button: ButtonParams{Size: SizeSmall, Attributes: []h.Attribute{h.AttrClass("absolute right-2 top-2")}},
+		})
+	}
+
+	element := h.FIGURE(p.Attributes...)(
+		h.If(p.Filename != "", h.FIGCAPTION(h.AttrClass("border-b border-gray-700 px-4 py-2 font-mono text-xs text-gray-400"))(p.Filename)),
+		h.DIV(h.AttrClass("relative"))(
+			h.PRE(h.AttrClass(h.IfElse(p.Wrap, "whitespace-pre-wrap break-words p-4", "overflow-x-auto p-4")))(
+				h.CODE(codeAttrs...)(children...),
+			),
+			copyButton,
+		),
+	)
+	mergeStyles(&element, "overflow-hidden rounded-lg bg-gray-900 font-mono text-sm text-gray-100")
+	return element
+}
@@ -0,0 +1,43 @@
+package hyperui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/assaidy/hyper/v2"
+)
+
+func TestSnippet(t *testing.T) {
+	code := "package main\n\nfunc main() {\n\tprintln(\"<hi>\")\n}\n"
+	got := render(t, Snippet(code, SnippetParams{
+		Filename:    "main.go",
+		Language:    "go",
+		LineNumbers: true,
+		Lines:       []LineRange{{From: 3, To: 4}},
+	}))
+	assertContains(t, got,
+		`<figure class="overflow-hidden rounded-lg`,
+		`>main.go</figcaption>`,
+		`<code class="language-go"><span data-line="1" class="inline-block w-full"><span aria-hidden="true" class="mr-4 inline-block select-none text-right text-gray-500" style="width:1ch">1</span>package main</span>`,
+		`<span data-line="3" class="inline-block w-full bg-yellow-400/20">`,
+		`println(&#34;&lt;hi&gt;&#34;)</span>`,
+		`<span data-line="5" class="inline-block w-full"><span`,
+		`data-copy="package main`,
+	)
+	if strings.Contains(got, `data-line="6"`) {
+		t.Errorf("expected the trailing newline to be dropped, got %q", got)
+	}
+}
+
+func TestSnippet_Highlight(t *testing.T) {
+	got := render(t, Snippet("a\nb", SnippetParams{
+		NoCopy: true,
+		Highlight: func(code, language string) []h.HyperNode {
+			return []h.HyperNode{h.B()("a"), h.I()("b")}
+		},
+	}))
+	assertContains(t, got, `<code><span data-line="1" class="inline-block w-full"><b>a</b></span>`, `<i>b</i></span>`)
+	if strings.Contains(got, "<button") || strings.Contains(got, "figcaption") {
+		t.Errorf("expected no copy button and no header, got %q", got)
+	}
+}
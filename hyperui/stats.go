@@ -0,0 +1,116 @@
+package hyperui
+
+import (
+	"github.com/assaidy/hyper/v2"
+	"github.com/assaidy/hyper/v2/charts"
+)
+
+type StatCardParams struct {
+	// Delta is the relative change of the value over the period, e.g. 0.12
+	// for +12%; not shown when 0.
+	Delta float64
+	// InvertDelta marks decreases as good, for values such as churn or
+	// response times.
+	InvertDelta bool
+	Period      any       // Describes the delta, e.g. "vs last month"
+	Trend       []float64 // Recent values, drawn as a sparkline
+	Locale      string    // See h.FormatOptions
+	Attributes  []h.Attribute
+}
+
+// StatCard renders a key figure of a dashboard: its label, value, change
+// and trend. Numeric values (ints and floats) are formatted for the locale
+// with up to 2 decimals; other values, such as h.Money, are rendered as is.
+//
+// Example:
+//
+//	StatCard("Active users", 12840, StatCardParams{
+//		Delta:  0.082,
+//		Period: "vs last week",
+//		Trend:  dailyActiveUsers,
+//		Locale: h.Locale(ctx),
+//	})
+//
+//	StatCard("Revenue", h.Money(48210, "EUR", h.FormatOptions{Locale: h.Locale(ctx)}))
+func StatCard(label string, value any, params ...StatCardParams) h.Element {
+	var p StatCardParams
+	if len(params) != 0 {
+		p = params[0]
+	}
+
+	format := h.FormatOptions{Locale: p.Locale}
+	switch v := value.(type) {
+	case int:
+		value = h.Decimal(float64(v), 0, format)
+	case int64:
+		value = h.Decimal(float64(v), 0, format)
+	case float64:
+		value = h.Decimal(v, 2, format)
+	}
+
+	var delta h.HyperNode = h.Group()
+	if p.Delta != 0 {
+		good := (p.Delta > 0) != p.InvertDelta
+		delta = h.P(h.AttrClass("mt-1 flex items-center gap-1 text-xs"))(
+			h.SPAN(h.AttrClass(h.IfElse(good, "font-medium text-green-600", "font-medium text-red-600")))(
+				// Signs for color blind users, and a plus sign as Percent has none.
+				h.IfElse(p.Delta > 0, "▲ +", "▼ "), h.Percent(p.Delta, format),
+			),
+			h.If(p.Period != nil, h.SPAN(h.AttrClass("text-gray-500"))(p.Period)),
+		)
+	}
+
+	var trend h.HyperNode = h.Group()
+	if len(p.Trend) > 1 {
+		rising := p.Trend[len(p.Trend)-1] >= p.Trend[0]
+		trend = h.DIV(h.AttrClass(h.IfElse(rising != p.InvertDelta, "text-green-500", "text-red-500")))(
+			charts.Sparkline(p.Trend, charts.SparklineOptions{Width: 80, Height: 32, Title: label + " trend"}),
+		)
+	}
+
+	element := h.DIV(p.Attributes...)(
+		h.DIV(h.AttrClass("flex items-start justify-between gap-4"))(
+			h.DIV()(
+				h.P(h.AttrClass("text-sm font-medium text-gray-500"))(label),
+				h.P(h.AttrClass("mt-1 text-2xl font-semibold tracking-tight text-gray-900"))(value),
+				delta,
+			),
+			trend,
+		),
+	)
+	mergeStyles(&element, "rounded-lg border border-gray-200 bg-white p-4 shadow-sm")
+	return element
+}
+
+// Tailwind only generates the classes it finds in the sources, so they
+// can't be built from the number of columns.
+var statGridColumns = map[int]string{
+	1: "",
+	2: "sm:grid-cols-2",
+	3: "sm:grid-cols-2 lg:grid-cols-3",
+	4: "sm:grid-cols-2 lg:grid-cols-4",
+	5: "sm:grid-cols-2 lg:grid-cols-5",
+	6: "sm:grid-cols-3 lg:grid-cols-6",
+}
+
+// StatGrid lays out StatCards in a grid of up to columns (1 to 6) columns
+// on large screens, fewer on small ones.
+//
+// Example:
+//
+//	StatGrid(4,
+//		StatCard("Users", users),
+//		StatCard("Orders", orders),
+//		StatCard("Revenue", h.Money(revenue, "USD")),
+//		StatCard("Refunds", refunds, StatCardParams{Delta: -0.02, InvertDelta: true}),
+//	)
+func StatGrid(columns int, cards ...h.HyperNode) h.Element {
+	classes, ok := statGridColumns[columns]
+	if !ok {
+		panic("invalid stat grid columns")
+	}
+
+	element := h.Element{Tag: "div", Children: cards}
+	mergeStyles(&element, "grid grid-cols-1 gap-4 "+classes)
+	return element
+}
@@ -0,0 +1,36 @@
+package hyperui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStatCard(t *testing.T) {
+	got := render(t, StatCard("Active users", 12840, StatCardParams{
+		Delta:  0.082,
+		Period: "vs last week",
+		Trend:  []float64{1, 3, 2, 5},
+	}))
+	assertContains(t, got,
+		`<div class="rounded-lg border border-gray-200 bg-white p-4 shadow-sm">`,
+		`>Active users</p>`,
+		`<span data-raw="12840">12,840</span></p>`,
+		`text-green-600">▲ +<span data-raw="0.082">8.2%</span></span>`,
+		`>vs last week</span>`,
+		`<div class="text-green-500"><svg`,
+	)
+
+	got = render(t, StatCard("Churn", 0.5, StatCardParams{Delta: 0.1, InvertDelta: true}))
+	assertContains(t, got, `<span data-raw="0.5">0.5</span></p>`, `text-red-600">▲ +<span data-raw="0.1">10%</span></span>`)
+	if strings.Contains(got, "<svg") {
+		t.Errorf("expected no sparkline without a trend, got %q", got)
+	}
+}
+
+func TestStatGrid(t *testing.T) {
+	got := render(t, StatGrid(3, StatCard("A", 1), StatCard("B", 2)))
+	assertContains(t, got, `<div class="grid grid-cols-1 gap-4 sm:grid-cols-2 lg:grid-cols-3">`)
+	if strings.Count(got, ">A</p>")+strings.Count(got, ">B</p>") != 2 {
+		t.Errorf("expected both cards, got %q", got)
+	}
+}
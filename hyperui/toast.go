@@ -0,0 +1,93 @@
+package hyperui
+
+import (
+	"strings"
+
+	"github.com/assaidy/hyper/v2"
+)
+
+type ToastVariant uint
+
+const (
+	ToastInfo ToastVariant = iota
+	ToastSuccess
+	ToastWarning
+	ToastError
+)
+
+var toastVariantClasses = map[ToastVariant]string{
+	ToastInfo:    "border-blue-200 bg-blue-50 text-blue-900",
+	ToastSuccess: "border-green-200 bg-green-50 text-green-900",
+	ToastWarning: "border-yellow-200 bg-yellow-50 text-yellow-900",
+	ToastError:   "border-red-200 bg-red-50 text-red-900",
+}
+
+type ToastParams struct {
+	Variant    ToastVariant
+	Attributes []h.Attribute
+}
+
+// Toast renders a notification message. Error toasts get role="alert" so
+// they are announced immediately; the others are left to the surrounding
+// live region (e.g. flash.Flashes).
+//
+// Example:
+//
+//	// simple
+//	Toast()("Saved.")
+//
+//	// with params
+//	Toast(ToastParams{Variant: ToastError})("Something went wrong.")
+//
+//	// as the renderer of flash messages
+//	flash.Flashes(ctx, flash.Params{
+//		Render: func(m flash.Message) HyperNode {
+//			return Toast(ToastParams{Variant: ToastVariantFromLevel(string(m.Level))})(m.Text)
+//		},
+//	})
+func Toast(params ...ToastParams) h.ElementBuilder {
+	var p ToastParams
+	if len(params) != 0 {
+		p = params[0]
+	}
+
+	return func(children ...any) h.Element {
+		attrs := p.Attributes
+		if p.Variant == ToastError {
+			attrs = append([]h.Attribute{h.AttrRole("alert")}, attrs...)
+		}
+		element := h.DIV(attrs...)(children...)
+		mergeStyles(&element, getToastStylesClass(p))
+		return element
+	}
+}
+
+// ToastVariantFromLevel maps a severity name ("info", "success", "warning"
+// or "error") to a toast variant, defaulting to ToastInfo.
+func ToastVariantFromLevel(level string) ToastVariant {
+	switch level {
+	case "success":
+		return ToastSuccess
+	case "warning":
+		return ToastWarning
+	case "error":
+		return ToastError
+	default:
+		return ToastInfo
+	}
+}
+
+func getToastStylesClass(params ToastParams) string {
+	var stylesBuilder strings.Builder
+
+	stylesBuilder.WriteString("rounded-md border px-4 py-3 text-sm shadow-sm")
+	stylesBuilder.WriteByte(' ')
+
+	if class, ok := toastVariantClasses[params.Variant]; ok {
+		stylesBuilder.WriteString(class)
+	} else {
+		panic("invalid toast variant")
+	}
+
+	return stylesBuilder.String()
+}
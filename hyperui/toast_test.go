@@ -0,0 +1,32 @@
+package hyperui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToast(t *testing.T) {
+	got := render(t, Toast()("Saved."))
+	assertContains(t, got, `<div class="rounded-md border`, `bg-blue-50`, `>Saved.</div>`)
+	if strings.Contains(got, "role=") {
+		t.Errorf("expected info toasts to have no role, got %q", got)
+	}
+
+	got = render(t, Toast(ToastParams{Variant: ToastVariantFromLevel("error")})("Failed."))
+	assertContains(t, got, `<div role="alert" class="`, `bg-red-50`)
+}
+
+func TestToastVariantFromLevel(t *testing.T) {
+	tests := map[string]ToastVariant{
+		"info":    ToastInfo,
+		"success": ToastSuccess,
+		"warning": ToastWarning,
+		"error":   ToastError,
+		"debug":   ToastInfo,
+	}
+	for level, expected := range tests {
+		if got := ToastVariantFromLevel(level); got != expected {
+			t.Errorf("ToastVariantFromLevel(%q) = %d, want %d", level, got, expected)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package hyperui
+
+import (
+	"strconv"
+
+	"github.com/assaidy/hyper/v2"
+)
+
+type TreeViewParams[T any] struct {
+	// ExpandDepth is the number of levels expanded initially; 0 collapses
+	// the root.
+	ExpandDepth int
+	// LazyURL, when set, makes the branches deeper than LazyDepth load
+	// their children with htmx when first expanded. The URL must respond
+	// with TreeBranch for the node.
+	LazyURL   func(node T) string
+	LazyDepth int
+	// HasChildren reports whether a lazy branch has children, so leaves
+	// aren't rendered as expandable; all lazy nodes are expandable when nil.
+	HasChildren func(node T) bool
+	Attributes  []h.Attribute
+}
+
+// TreeView renders hierarchical data, such as folders or categories, as
+// nested lists following the ARIA tree pattern: branches are <details>
+// elements, expanded and collapsed natively, inside treeitems whose
+// aria-expanded tracks them.
+//
+// Example:
+//
+//	// simple
+//	TreeView(rootFolder, Folder.Subfolders, func(f Folder) h.HyperNode {
+//		return h.Text(f.Name)
+//	})
+//
+//	// with params: load the branches deeper than 2 levels on demand
+//	TreeView(rootFolder, Folder.Subfolders, renderFolder, TreeViewParams[Folder]{
+//		ExpandDepth: 1,
+//		LazyDepth:   2,
+//		LazyURL:     func(f Folder) string { return "/folders/" + f.ID + "/tree" },
+//	})
+func TreeView[T any](root T, children func(node T) []T, render func(node T) h.HyperNode, params ...TreeViewParams[T]) h.HyperNode {
+	var p TreeViewParams[T]
+	if len(params) != 0 {
+		p = params[0]
+	}
+
+	tree := treeRenderer[T]{children: children, render: render, params: p}
+	element := h.UL(append([]h.Attribute{h.AttrRole("tree")}, p.Attributes...)...)(tree.item(root, 0))
+	mergeStyles(&element, "text-sm text-gray-900")
+	return element
+}
+
+// TreeBranch renders the items of the children of node, the response to
+// the requests of branches loaded lazily by TreeView. Params must be those
+// given to TreeView; depths restart from node.
+func TreeBranch[T any](node T, children func(node T) []T, render func(node T) h.HyperNode, params ...TreeViewParams[T]) h.HyperNode {
+	var p TreeViewParams[T]
+	if len(params) != 0 {
+		p = params[0]
+	}
+
+	tree := treeRenderer[T]{children: children, render: render, params: p}
+	return tree.items(children(node), 1)
+}
+
+type treeRenderer[T any] struct {
+	children func(node T) []T
+	render   func(node T) h.HyperNode
+	params   TreeViewParams[T]
+}
+
+func (me treeRenderer[T]) items(nodes []T, depth int) h.HyperNode {
+	items := make([]any, len(nodes))
+	for i, node := range nodes {
+		items[i] = me.item(node, depth)
+	}
+	return h.Group(items...)
+}
+
+func (me treeRenderer[T]) item(node T, depth int) h.HyperNode {
+	lazy := me.params.LazyURL != nil && depth >= me.params.LazyDepth
+	var children []T
+	if lazy {
+		if me.params.HasChildren != nil && !me.params.HasChildren(node) {
+			return me.leaf(node)
+		}
+	} else if children = me.children(node); len(children) == 0 {
+		return me.leaf(node)
+	}
+
+	expanded := !lazy && depth < me.params.ExpandDepth
+	detailsAttrs := []h.Attribute{
+		h.AttrOpen(expanded),
+		// Keep aria-expanded in sync with the native state of <details>.
+		h.Attr("ontoggle", "this.parentElement.setAttribute('aria-expanded', this.open)"),
+	}
+	group := h.UL(h.AttrRole("group"), h.AttrClass("ml-4 border-l border-gray-200 pl-2"))
+	var branch h.Element
+	if lazy {
+		detailsAttrs = append(detailsAttrs,
+			h.Attr("hx-get", me.params.LazyURL(node)),
+			h.Attr("hx-trigger", "toggle once"),
+			h.Attr("hx-target", "find ul"),
+			h.Attr("hx-swap", "innerHTML"),
+		)
+		branch = group()
+	} else {
+		branch = group(me.items(children, depth+1))
+	}
+
+	return h.LI(h.AttrRole("treeitem"), h.AttrAriaExpanded(strconv.FormatBool(expanded)))(
+		h.DETAILS(detailsAttrs...)(
+			h.SUMMARY(h.AttrClass("cursor-pointer rounded px-1 py-0.5 hover:bg-gray-100"))(me.render(node)),
+			branch,
+		),
+	)
+}
+
+func (me treeRenderer[T]) leaf(node T) h.HyperNode {
+	return h.LI(h.AttrRole("treeitem"), h.AttrClass("px-1 py-0.5"))(me.render(node))
+}
@@ -0,0 +1,55 @@
+package hyperui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/assaidy/hyper/v2"
+)
+
+type folder struct {
+	Name    string
+	Folders []folder
+}
+
+func (me folder) children() []folder { return me.Folders }
+
+func renderFolder(f folder) h.HyperNode { return h.Text(f.Name) }
+
+var folders = folder{Name: "root", Folders: []folder{
+	{Name: "docs", Folders: []folder{{Name: "guides"}}},
+	{Name: "README"},
+}}
+
+func TestTreeView(t *testing.T) {
+	got := render(t, TreeView(folders, folder.children, renderFolder, TreeViewParams[folder]{ExpandDepth: 1}))
+	assertContains(t, got,
+		`<ul role="tree" class="text-sm text-gray-900"><li role="treeitem" aria-expanded="true"><details open ontoggle=`,
+		`>root</summary><ul role="group"`,
+		`<li role="treeitem" aria-expanded="false"><details ontoggle=`,
+		`<li role="treeitem" class="px-1 py-0.5">guides</li>`,
+		`<li role="treeitem" class="px-1 py-0.5">README</li>`,
+	)
+}
+
+func TestTreeView_Lazy(t *testing.T) {
+	params := TreeViewParams[folder]{
+		ExpandDepth: 1,
+		LazyDepth:   1,
+		LazyURL:     func(f folder) string { return "/tree/" + f.Name },
+		HasChildren: func(f folder) bool { return len(f.Folders) != 0 },
+	}
+	got := render(t, TreeView(folders, folder.children, renderFolder, params))
+	assertContains(t, got,
+		`hx-get="/tree/docs" hx-trigger="toggle once" hx-target="find ul" hx-swap="innerHTML"`,
+		`<li role="treeitem" class="px-1 py-0.5">README</li>`,
+	)
+	if strings.Contains(got, "guides") {
+		t.Errorf("expected lazy branches to be loaded later, got %q", got)
+	}
+
+	got = render(t, TreeBranch(folders.Folders[0], folder.children, renderFolder, params))
+	if expected := `<li role="treeitem" class="px-1 py-0.5">guides</li>`; got != expected {
+		t.Errorf("TreeBranch() = %q, want %q", got, expected)
+	}
+}
@@ -0,0 +1,56 @@
+package hyperui
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/assaidy/hyper/v2"
+)
+
+func render(t *testing.T, node h.HyperNode) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := h.Render(&buf, node); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	return buf.String()
+}
+
+// assertContains checks that html contains each of expected.
+func assertContains(t *testing.T, html string, expected ...string) {
+	t.Helper()
+	for _, e := range expected {
+		if !strings.Contains(html, e) {
+			t.Errorf("expected %q in %q", e, html)
+		}
+	}
+}
+
+func TestMergeStyles(t *testing.T) {
+	tests := []struct {
+		name     string
+		element  h.Element
+		expected string
+	}{
+		{
+			name:     "Without class",
+			element:  h.DIV(h.AttrID("a"))(),
+			expected: `<div id="a" class="p-4"></div>`,
+		},
+		{
+			name:     "With class",
+			element:  h.DIV(h.AttrClass("custom"), h.AttrID("a"))(),
+			expected: `<div class="custom p-4" id="a"></div>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mergeStyles(&tt.element, "p-4")
+			if got := render(t, tt.element); got != tt.expected {
+				t.Errorf("got %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
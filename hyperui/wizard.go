@@ -0,0 +1,99 @@
+package hyperui
+
+import (
+	"slices"
+	"strconv"
+
+	"github.com/assaidy/hyper/v2"
+)
+
+// WizardStep is a step of a Wizard.
+type WizardStep struct {
+	ID    string
+	Title any
+}
+
+type WizardParams struct {
+	Steps     []WizardStep
+	Current   string   // ID of the step shown
+	Completed []string // IDs of the completed steps, e.g. from forms.Wizard.Completed
+	// StepURL returns the URL of a step. When set, the completed steps and
+	// the next one are links loading the wizard at that step with htmx.
+	StepURL    func(id string) string
+	ID         string // Id of the wizard, the target of the step links; defaults to "wizard"
+	Attributes []h.Attribute
+}
+
+// Wizard renders a multi-step form: a list of step headers marking the
+// current and completed steps, a progress bar, and the children, the
+// fragment of the current step. The fragment's form typically posts with
+// htmx (hx-post, hx-target="#wizard", hx-swap="outerHTML") to a handler
+// answering with the wizard at the next step, or at the same step with its
+// validation errors.
+//
+// Example:
+//
+//	Wizard(WizardParams{
+//		Steps:     []WizardStep{{"account", "Account"}, {"profile", "Profile"}, {"confirm", "Confirm"}},
+//		Current:   "profile",
+//		Completed: completed,
+//		StepURL:   func(id string) string { return "/signup/" + id },
+//	})(
+//		ProfileForm(signup.Field(completed)),
+//	)
+func Wizard(params WizardParams) h.ElementBuilder {
+	id := h.IfElse(params.ID != "", params.ID, "wizard")
+
+	var headers []any
+	reachable := true // Steps are reachable until the first one not completed
+	for i, step := range params.Steps {
+		completed := slices.Contains(params.Completed, step.ID)
+		current := step.ID == params.Current
+
+		label := []any{
+			h.SPAN(h.AttrClass(h.IfElse(completed,
+				"flex size-6 items-center justify-center rounded-full bg-blue-600 text-xs text-white",
+				"flex size-6 items-center justify-center rounded-full border border-gray-300 text-xs",
+			)))(h.IfElse(completed, "✓", strconv.Itoa(i+1))),
+			h.SPAN()(step.Title),
+		}
+		var header h.HyperNode
+		switch {
+		case params.StepURL != nil && reachable && !current:
+			url := params.StepURL(step.ID)
+			header = h.A(
+				h.AttrHref(url),
+				h.Attr("hx-get", url),
+				h.Attr("hx-target", "#"+id),
+				h.Attr("hx-swap", "outerHTML"),
+				h.Attr("hx-push-url", "true"),
+				h.AttrClass("flex items-center gap-2 hover:text-blue-700"),
+			)(label...)
+		default:
+			header = h.SPAN(h.AttrClass("flex items-center gap-2"))(label...)
+		}
+		reachable = reachable && completed
+
+		attrs := []h.Attribute{h.AttrClass(h.IfElse(current, "font-semibold text-blue-700", "text-gray-600"))}
+		if current {
+			attrs = append(attrs, h.AttrAriaCurrent("step"))
+		}
+		headers = append(headers, h.LI(attrs...)(header))
+	}
+
+	return func(children ...any) h.Element {
+		element := h.DIV(append([]h.Attribute{h.AttrID(id)}, params.Attributes...)...)(
+			h.NAV(h.AttrAriaLabel("Progress"))(
+				h.OL(h.AttrClass("flex gap-6 text-sm"))(headers...),
+			),
+			h.PROGRESS(
+				h.AttrValue(strconv.Itoa(len(params.Completed))),
+				h.AttrMax(strconv.Itoa(len(params.Steps))),
+				h.AttrClass("h-1 w-full"),
+			)(strconv.Itoa(len(params.Completed))+"/"+strconv.Itoa(len(params.Steps))),
+			h.SECTION()(children...),
+		)
+		mergeStyles(&element, "flex flex-col gap-4")
+		return element
+	}
+}
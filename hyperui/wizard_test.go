@@ -0,0 +1,29 @@
+package hyperui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/assaidy/hyper/v2"
+)
+
+func TestWizard(t *testing.T) {
+	got := render(t, Wizard(WizardParams{
+		Steps:     []WizardStep{{"account", "Account"}, {"profile", "Profile"}, {"confirm", "Confirm"}},
+		Current:   "profile",
+		Completed: []string{"account"},
+		StepURL:   func(id string) string { return "/signup/" + id },
+	})(h.FORM()("fields")))
+	assertContains(t, got,
+		`<div id="wizard" class="flex flex-col gap-4"><nav aria-label="Progress">`,
+		`<a href="/signup/account" hx-get="/signup/account" hx-target="#wizard" hx-swap="outerHTML" hx-push-url="true"`,
+		`>✓</span><span>Account</span></a>`,
+		`<li class="font-semibold text-blue-700" aria-current="step"><span class="flex items-center gap-2">`,
+		`>3</span><span>Confirm</span></span></li>`,
+		`<progress value="1" max="3" class="h-1 w-full">1/3</progress>`,
+		`<section><form>fields</form></section>`,
+	)
+	if strings.Contains(got, "/signup/confirm") {
+		t.Errorf("expected the steps after the next one to be unreachable, got %q", got)
+	}
+}
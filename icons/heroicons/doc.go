@@ -0,0 +1,10 @@
+// Package heroicons provides the outline variant of the Heroicons set
+// (https://heroicons.com, MIT licensed) as hyper nodes.
+//
+// Only the icons listed in icons.txt are generated. To add one, list it
+// and regenerate from a checkout of the Heroicons repository:
+//
+//	HEROICONS_DIR=~/src/heroicons go generate ./icons/heroicons
+package heroicons
+
+//go:generate go run ../../cmd/h-icons -pkg heroicons -names icons.txt -o heroicons.go $HEROICONS_DIR/optimized/24/outline
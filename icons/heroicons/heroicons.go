@@ -0,0 +1,143 @@
+// Code generated by h-icons; DO NOT EDIT.
+
+package heroicons
+
+import (
+	h "github.com/assaidy/hyper/v2"
+	"github.com/assaidy/hyper/v2/icons"
+)
+
+// set holds the presentation attributes shared by the icons of this file.
+var set = icons.Set{
+	ViewBox:        "0 0 24 24",
+	Fill:           "none",
+	Stroke:         "currentColor",
+	StrokeWidth:    "1.5",
+	StrokeLinecap:  "round",
+	StrokeLinejoin: "round",
+}
+
+// ArrowLeft renders the arrow-left icon.
+func ArrowLeft(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="M10.5 19.5 3 12m0 0 7.5-7.5M3 12h18"/>`, opts...)
+}
+
+// ArrowRight renders the arrow-right icon.
+func ArrowRight(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="M13.5 4.5 21 12m0 0-7.5 7.5M21 12H3"/>`, opts...)
+}
+
+// Bars3 renders the bars-3 icon.
+func Bars3(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="M3.75 6.75h16.5M3.75 12h16.5m-16.5 5.25h16.5"/>`, opts...)
+}
+
+// Bell renders the bell icon.
+func Bell(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="M14.857 17.082a23.848 23.848 0 0 0 5.454-1.31A8.967 8.967 0 0 1 18 9.75V9A6 6 0 0 0 6 9v.75a8.967 8.967 0 0 1-2.312 6.022c1.733.64 3.56 1.085 5.455 1.31m5.714 0a24.255 24.255 0 0 1-5.714 0m5.714 0a3 3 0 1 1-5.714 0"/>`, opts...)
+}
+
+// Check renders the check icon.
+func Check(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="m4.5 12.75 6 6 9-13.5"/>`, opts...)
+}
+
+// CheckCircle renders the check-circle icon.
+func CheckCircle(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="M9 12.75 11.25 15 15 9.75M21 12a9 9 0 1 1-18 0 9 9 0 0 1 18 0Z"/>`, opts...)
+}
+
+// ChevronDown renders the chevron-down icon.
+func ChevronDown(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="m19.5 8.25-7.5 7.5-7.5-7.5"/>`, opts...)
+}
+
+// ChevronLeft renders the chevron-left icon.
+func ChevronLeft(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="M15.75 19.5 8.25 12l7.5-7.5"/>`, opts...)
+}
+
+// ChevronRight renders the chevron-right icon.
+func ChevronRight(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="m8.25 4.5 7.5 7.5-7.5 7.5"/>`, opts...)
+}
+
+// ChevronUp renders the chevron-up icon.
+func ChevronUp(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="m4.5 15.75 7.5-7.5 7.5 7.5"/>`, opts...)
+}
+
+// DocumentDuplicate renders the document-duplicate icon.
+func DocumentDuplicate(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="M15.75 17.25v3.375c0 .621-.504 1.125-1.125 1.125h-9.75a1.125 1.125 0 0 1-1.125-1.125V7.875c0-.621.504-1.125 1.125-1.125H6.75a9.06 9.06 0 0 1 1.5.124m7.5 10.376h3.375c.621 0 1.125-.504 1.125-1.125V11.25c0-4.46-3.243-8.161-7.5-8.876a9.06 9.06 0 0 0-1.5-.124H9.375c-.621 0-1.125.504-1.125 1.125v3.5m7.5 10.375H9.375a1.125 1.125 0 0 1-1.125-1.125v-9.25m12 6.625v-1.875a3.375 3.375 0 0 0-3.375-3.375h-1.5a1.125 1.125 0 0 1-1.125-1.125v-1.5a3.375 3.375 0 0 0-3.375-3.375H9.75"/>`, opts...)
+}
+
+// Envelope renders the envelope icon.
+func Envelope(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="M21.75 6.75v10.5a2.25 2.25 0 0 1-2.25 2.25h-15a2.25 2.25 0 0 1-2.25-2.25V6.75m19.5 0A2.25 2.25 0 0 0 19.5 4.5h-15a2.25 2.25 0 0 0-2.25 2.25m19.5 0v.243a2.25 2.25 0 0 1-1.07 1.916l-7.5 4.615a2.25 2.25 0 0 1-2.36 0L3.32 8.91a2.25 2.25 0 0 1-1.07-1.916V6.75"/>`, opts...)
+}
+
+// ExclamationTriangle renders the exclamation-triangle icon.
+func ExclamationTriangle(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="M12 9v3.75m-9.303 3.376c-.866 1.5.217 3.374 1.948 3.374h14.71c1.73 0 2.813-1.874 1.948-3.374L13.949 3.378c-.866-1.5-3.032-1.5-3.898 0L2.697 16.126ZM12 15.75h.007v.008H12v-.008Z"/>`, opts...)
+}
+
+// Home renders the home icon.
+func Home(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="m2.25 12 8.954-8.955c.44-.439 1.152-.439 1.591 0L21.75 12M4.5 9.75v10.125c0 .621.504 1.125 1.125 1.125H9.75v-4.875c0-.621.504-1.125 1.125-1.125h2.25c.621 0 1.125.504 1.125 1.125V21h4.125c.621 0 1.125-.504 1.125-1.125V9.75M8.25 21h8.25"/>`, opts...)
+}
+
+// Inbox renders the inbox icon.
+func Inbox(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="M2.25 13.5h3.86a2.25 2.25 0 0 1 2.012 1.244l.256.512a2.25 2.25 0 0 0 2.013 1.244h3.218a2.25 2.25 0 0 0 2.013-1.244l.256-.512a2.25 2.25 0 0 1 2.013-1.244h3.859m-19.5.338V18a2.25 2.25 0 0 0 2.25 2.25h15A2.25 2.25 0 0 0 21.75 18v-4.162c0-.224-.034-.447-.1-.661L19.24 5.338a2.25 2.25 0 0 0-2.15-1.588H6.911a2.25 2.25 0 0 0-2.15 1.588L2.35 13.177a2.25 2.25 0 0 0-.1.661Z"/>`, opts...)
+}
+
+// InformationCircle renders the information-circle icon.
+func InformationCircle(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="m11.25 11.25.041-.02a.75.75 0 0 1 1.063.852l-.708 2.836a.75.75 0 0 0 1.063.853l.041-.021M21 12a9 9 0 1 1-18 0 9 9 0 0 1 18 0Zm-9-3.75h.008v.008H12V8.25Z"/>`, opts...)
+}
+
+// Link renders the link icon.
+func Link(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="M13.19 8.688a4.5 4.5 0 0 1 1.242 7.244l-4.5 4.5a4.5 4.5 0 0 1-6.364-6.364l1.757-1.757m13.35-.622 1.757-1.757a4.5 4.5 0 0 0-6.364-6.364l-4.5 4.5a4.5 4.5 0 0 0 1.242 7.244"/>`, opts...)
+}
+
+// MagnifyingGlass renders the magnifying-glass icon.
+func MagnifyingGlass(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="m21 21-5.197-5.197m0 0A7.5 7.5 0 1 0 5.196 5.196a7.5 7.5 0 0 0 10.607 10.607Z"/>`, opts...)
+}
+
+// Minus renders the minus icon.
+func Minus(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="M5 12h14"/>`, opts...)
+}
+
+// Plus renders the plus icon.
+func Plus(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="M12 4.5v15m7.5-7.5h-15"/>`, opts...)
+}
+
+// Share renders the share icon.
+func Share(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="M7.217 10.907a2.25 2.25 0 1 0 0 2.186m0-2.186c.18.324.283.696.283 1.093s-.103.77-.283 1.093m0-2.186 9.566-5.314m-9.566 7.5 9.566 5.314m0 0a2.25 2.25 0 1 0 3.935 2.186 2.25 2.25 0 0 0-3.935-2.186Zm0-12.814a2.25 2.25 0 1 0 3.933-2.185 2.25 2.25 0 0 0-3.933 2.185Z"/>`, opts...)
+}
+
+// Trash renders the trash icon.
+func Trash(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="m14.74 9-.346 9m-4.788 0L9.26 9m9.968-3.21c.342.052.682.107 1.022.166m-1.022-.165L18.16 19.673a2.25 2.25 0 0 1-2.244 2.077H8.084a2.25 2.25 0 0 1-2.244-2.077L4.772 5.79m14.456 0a48.108 48.108 0 0 0-3.478-.397m-12 .562c.34-.059.68-.114 1.022-.165m0 0a48.11 48.11 0 0 1 3.478-.397m7.5 0v-.916c0-1.18-.91-2.164-2.09-2.201a51.964 51.964 0 0 0-3.32 0c-1.18.037-2.09 1.022-2.09 2.201v.916m7.5 0a48.667 48.667 0 0 0-7.5 0"/>`, opts...)
+}
+
+// User renders the user icon.
+func User(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="M15.75 6a3.75 3.75 0 1 1-7.5 0 3.75 3.75 0 0 1 7.5 0ZM4.501 20.118a7.5 7.5 0 0 1 14.998 0A17.933 17.933 0 0 1 12 21.75c-2.676 0-5.216-.584-7.499-1.632Z"/>`, opts...)
+}
+
+// XCircle renders the x-circle icon.
+func XCircle(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="m9.75 9.75 4.5 4.5m0-4.5-4.5 4.5M21 12a9 9 0 1 1-18 0 9 9 0 0 1 18 0Z"/>`, opts...)
+}
+
+// XMark renders the x-mark icon.
+func XMark(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="M6 18 18 6M6 6l12 12"/>`, opts...)
+}
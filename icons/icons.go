@@ -0,0 +1,103 @@
+// Package icons renders inline SVG icons as hyper nodes.
+//
+// The icon sets live in their own subpackages ([heroicons], [lucide]); every
+// icon is a plain Go function, so only the icons an application actually
+// calls end up in its binary.
+//
+// The sets hold a subset of their upstream icons, generated by the h-icons
+// command from the names listed in their icons.txt. Applications needing
+// other icons generate their own package the same way, from the upstream
+// SVG files:
+//
+//	//go:generate go run github.com/assaidy/hyper/v2/cmd/h-icons -pkg appicons -names icons.txt -o icons.go ../node_modules/lucide-static/icons
+//
+// Example:
+//
+//	BUTTON(AttrType(TypeButton))(
+//		heroicons.XMark(icons.Options{Size: 16, Title: "Close"}),
+//	)
+package icons
+
+import (
+	"strconv"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+// DefaultSize is the width/height in pixels used when [Options.Size] is zero.
+const DefaultSize = 24
+
+// Options customizes a rendered icon. All fields are optional.
+type Options struct {
+	Size        int           // Width and height in pixels; defaults to [DefaultSize]
+	Class       string        // Value of the class attribute
+	Title       string        // Accessible label; decorative (aria-hidden) when empty
+	StrokeWidth string        // Overrides the set's stroke width for outline icons
+	Attributes  []h.Attribute // Extra attributes appended to the <svg> element
+}
+
+// Set describes the shared presentation attributes of an icon set.
+type Set struct {
+	ViewBox        string
+	Fill           string
+	Stroke         string
+	StrokeWidth    string
+	StrokeLinecap  string
+	StrokeLinejoin string
+}
+
+// New renders an icon of the given set. body is the trusted inner SVG markup
+// of the icon and is written without escaping.
+//
+// Icon set packages wrap New in one function per icon; it is exported so
+// applications can add their own icons with the same options.
+func New(set Set, body string, options ...Options) h.HyperNode {
+	var opts Options
+	if len(options) != 0 {
+		opts = options[0]
+	}
+
+	size := opts.Size
+	if size <= 0 {
+		size = DefaultSize
+	}
+	dimension := strconv.Itoa(size)
+
+	element := h.Element{Tag: "svg"}
+	element.Attributes = append(element.Attributes,
+		h.Attr("xmlns", "http://www.w3.org/2000/svg"),
+		h.AttrWidth(dimension),
+		h.AttrHeight(dimension),
+		h.Attr("viewBox", set.ViewBox),
+		h.Attr("fill", set.Fill),
+	)
+	if set.Stroke != "" {
+		strokeWidth := set.StrokeWidth
+		if opts.StrokeWidth != "" {
+			strokeWidth = opts.StrokeWidth
+		}
+		element.Attributes = append(element.Attributes,
+			h.Attr("stroke", set.Stroke),
+			h.Attr("stroke-width", strokeWidth),
+		)
+	}
+	if set.StrokeLinecap != "" {
+		element.Attributes = append(element.Attributes, h.Attr("stroke-linecap", set.StrokeLinecap))
+	}
+	if set.StrokeLinejoin != "" {
+		element.Attributes = append(element.Attributes, h.Attr("stroke-linejoin", set.StrokeLinejoin))
+	}
+	if opts.Class != "" {
+		element.Attributes = append(element.Attributes, h.AttrClass(opts.Class))
+	}
+	if opts.Title != "" {
+		element.Attributes = append(element.Attributes, h.AttrRole("img"), h.AttrAriaLabel(opts.Title))
+		element.Children = append(element.Children, h.WithChildren(h.Element{Tag: "title"})(opts.Title))
+	} else {
+		element.Attributes = append(element.Attributes, h.AttrAriaHidden("true"))
+	}
+	element.Attributes = append(element.Attributes, opts.Attributes...)
+
+	element.Children = append(element.Children, h.RawText(body))
+	return element
+}
@@ -0,0 +1,46 @@
+package icons_test
+
+import (
+	"bytes"
+	"testing"
+
+	h "github.com/assaidy/hyper/v2"
+	"github.com/assaidy/hyper/v2/icons"
+	"github.com/assaidy/hyper/v2/icons/lucide"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name     string
+		node     h.HyperNode
+		expected string
+	}{
+		{
+			name:     "Decorative icon with defaults",
+			node:     lucide.Check(),
+			expected: `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" aria-hidden="true"><path d="M20 6 9 17l-5-5"/></svg>`,
+		},
+		{
+			name:     "Labelled icon with options",
+			node:     lucide.Check(icons.Options{Size: 16, Class: "ok", Title: "Done", StrokeWidth: "3"}),
+			expected: `<svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="3" stroke-linecap="round" stroke-linejoin="round" class="ok" role="img" aria-label="Done"><title>Done</title><path d="M20 6 9 17l-5-5"/></svg>`,
+		},
+		{
+			name:     "Filled custom set",
+			node:     icons.New(icons.Set{ViewBox: "0 0 10 10", Fill: "currentColor"}, `<circle r="5"/>`),
+			expected: `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 10 10" fill="currentColor" aria-hidden="true"><circle r="5"/></svg>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := h.Render(&buf, tt.node); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("New() = %q, want %q", buf.String(), tt.expected)
+			}
+		})
+	}
+}
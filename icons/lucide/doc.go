@@ -0,0 +1,10 @@
+// Package lucide provides icons from the Lucide set
+// (https://lucide.dev, ISC licensed) as hyper nodes.
+//
+// Only the icons listed in icons.txt are generated. To add one, list it
+// and regenerate from a checkout of the Lucide repository:
+//
+//	LUCIDE_DIR=~/src/lucide go generate ./icons/lucide
+package lucide
+
+//go:generate go run ../../cmd/h-icons -pkg lucide -names icons.txt -o lucide.go $LUCIDE_DIR/icons
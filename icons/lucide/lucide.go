@@ -0,0 +1,143 @@
+// Code generated by h-icons; DO NOT EDIT.
+
+package lucide
+
+import (
+	h "github.com/assaidy/hyper/v2"
+	"github.com/assaidy/hyper/v2/icons"
+)
+
+// set holds the presentation attributes shared by the icons of this file.
+var set = icons.Set{
+	ViewBox:        "0 0 24 24",
+	Fill:           "none",
+	Stroke:         "currentColor",
+	StrokeWidth:    "2",
+	StrokeLinecap:  "round",
+	StrokeLinejoin: "round",
+}
+
+// ArrowLeft renders the arrow-left icon.
+func ArrowLeft(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="m12 19-7-7 7-7"/><path d="M19 12H5"/>`, opts...)
+}
+
+// ArrowRight renders the arrow-right icon.
+func ArrowRight(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="M5 12h14"/><path d="m12 5 7 7-7 7"/>`, opts...)
+}
+
+// Bell renders the bell icon.
+func Bell(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="M6 8a6 6 0 0 1 12 0c0 7 3 9 3 9H3s3-2 3-9"/><path d="M10.3 21a1.94 1.94 0 0 0 3.4 0"/>`, opts...)
+}
+
+// Check renders the check icon.
+func Check(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="M20 6 9 17l-5-5"/>`, opts...)
+}
+
+// ChevronDown renders the chevron-down icon.
+func ChevronDown(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="m6 9 6 6 6-6"/>`, opts...)
+}
+
+// ChevronLeft renders the chevron-left icon.
+func ChevronLeft(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="m15 18-6-6 6-6"/>`, opts...)
+}
+
+// ChevronRight renders the chevron-right icon.
+func ChevronRight(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="m9 18 6-6-6-6"/>`, opts...)
+}
+
+// ChevronUp renders the chevron-up icon.
+func ChevronUp(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="m18 15-6-6-6 6"/>`, opts...)
+}
+
+// CircleCheck renders the circle-check icon.
+func CircleCheck(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<circle cx="12" cy="12" r="10"/><path d="m9 12 2 2 4-4"/>`, opts...)
+}
+
+// CircleX renders the circle-x icon.
+func CircleX(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<circle cx="12" cy="12" r="10"/><path d="m15 9-6 6"/><path d="m9 9 6 6"/>`, opts...)
+}
+
+// Copy renders the copy icon.
+func Copy(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<rect width="14" height="14" x="8" y="8" rx="2" ry="2"/><path d="M4 16c-1.1 0-2-.9-2-2V4c0-1.1.9-2 2-2h10c1.1 0 2 .9 2 2"/>`, opts...)
+}
+
+// House renders the house icon.
+func House(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="M15 21v-8a1 1 0 0 0-1-1h-4a1 1 0 0 0-1 1v8"/><path d="M3 10a2 2 0 0 1 .709-1.528l7-5.999a2 2 0 0 1 2.582 0l7 5.999A2 2 0 0 1 21 10v9a2 2 0 0 1-2 2H5a2 2 0 0 1-2-2z"/>`, opts...)
+}
+
+// Inbox renders the inbox icon.
+func Inbox(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<polyline points="22 12 16 12 14 15 10 15 8 12 2 12"/><path d="M5.45 5.11 2 12v6a2 2 0 0 0 2 2h16a2 2 0 0 0 2-2v-6l-3.45-6.89A2 2 0 0 0 16.76 4H7.24a2 2 0 0 0-1.79 1.11z"/>`, opts...)
+}
+
+// Info renders the info icon.
+func Info(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<circle cx="12" cy="12" r="10"/><path d="M12 16v-4"/><path d="M12 8h.01"/>`, opts...)
+}
+
+// Link renders the link icon.
+func Link(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="M10 13a5 5 0 0 0 7.54.54l3-3a5 5 0 0 0-7.07-7.07l-1.72 1.71"/><path d="M14 11a5 5 0 0 0-7.54-.54l-3 3a5 5 0 0 0 7.07 7.07l1.71-1.71"/>`, opts...)
+}
+
+// Mail renders the mail icon.
+func Mail(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<rect width="20" height="16" x="2" y="4" rx="2"/><path d="m22 7-8.97 5.7a1.94 1.94 0 0 1-2.06 0L2 7"/>`, opts...)
+}
+
+// Menu renders the menu icon.
+func Menu(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<line x1="4" x2="20" y1="12" y2="12"/><line x1="4" x2="20" y1="6" y2="6"/><line x1="4" x2="20" y1="18" y2="18"/>`, opts...)
+}
+
+// Minus renders the minus icon.
+func Minus(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="M5 12h14"/>`, opts...)
+}
+
+// Plus renders the plus icon.
+func Plus(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="M5 12h14"/><path d="M12 5v14"/>`, opts...)
+}
+
+// Search renders the search icon.
+func Search(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<circle cx="11" cy="11" r="8"/><path d="m21 21-4.3-4.3"/>`, opts...)
+}
+
+// Share2 renders the share-2 icon.
+func Share2(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<circle cx="18" cy="5" r="3"/><circle cx="6" cy="12" r="3"/><circle cx="18" cy="19" r="3"/><line x1="8.59" x2="15.42" y1="13.51" y2="17.49"/><line x1="15.41" x2="8.59" y1="6.51" y2="10.49"/>`, opts...)
+}
+
+// Trash2 renders the trash-2 icon.
+func Trash2(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="M3 6h18"/><path d="M19 6v14c0 1-1 2-2 2H7c-1 0-2-1-2-2V6"/><path d="M8 6V4c0-1 1-2 2-2h4c1 0 2 1 2 2v2"/><line x1="10" x2="10" y1="11" y2="17"/><line x1="14" x2="14" y1="11" y2="17"/>`, opts...)
+}
+
+// TriangleAlert renders the triangle-alert icon.
+func TriangleAlert(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="m21.73 18-8-14a2 2 0 0 0-3.48 0l-8 14A2 2 0 0 0 4 21h16a2 2 0 0 0 1.73-3"/><path d="M12 9v4"/><path d="M12 17h.01"/>`, opts...)
+}
+
+// User renders the user icon.
+func User(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="M19 21v-2a4 4 0 0 0-4-4H9a4 4 0 0 0-4 4v2"/><circle cx="12" cy="7" r="4"/>`, opts...)
+}
+
+// X renders the x icon.
+func X(opts ...icons.Options) h.HyperNode {
+	return icons.New(set, `<path d="M18 6 6 18"/><path d="m6 6 12 12"/>`, opts...)
+}
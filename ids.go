@@ -0,0 +1,124 @@
+package h
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"sync/atomic"
+)
+
+// idCounter makes the ids generated by [UniqueID] unique within the process.
+var idCounter atomic.Uint64
+
+// UniqueID returns an id starting with prefix that no other call returns
+// within the process, e.g. UniqueID("menu") returns "menu-1", then "menu-2".
+// Use it for ids wiring elements together (label for=, aria-controls,
+// hx-target...) in components rendered more than once per page.
+func UniqueID(prefix string) string {
+	return prefix + "-" + strconv.FormatUint(idCounter.Add(1), 10)
+}
+
+// DuplicateIDError is returned when rendering a tree checked by [CheckIDs]
+// that uses an id more than once.
+type DuplicateIDError struct {
+	ID string
+}
+
+func (me DuplicateIDError) Error() string {
+	return fmt.Sprintf("h: duplicate id %q", me.ID)
+}
+
+// IDOptions configures [CheckIDs].
+type IDOptions struct {
+	// OnDuplicate is called for each duplicated id instead of failing the
+	// render, e.g. to log a warning in production.
+	OnDuplicate func(id string)
+}
+
+// CheckIDs returns a node rendering node after checking that the ids of its
+// elements are unique: duplicated ids silently break htmx targets, label
+// for= wiring and fragment links. By default the render fails with a
+// [DuplicateIDError]; set [IDOptions.OnDuplicate] to only report them.
+//
+// Ids are collected from elements, including those wrapped by [Named] and
+// [Key]. Custom node types are opaque and not checked.
+//
+// Example:
+//
+//	// fail loudly in development, log in production
+//	page = CheckIDs(page, IDOptions{OnDuplicate: func(id string) {
+//		slog.Warn("duplicate id", "id", id)
+//	}})
+func CheckIDs(node HyperNode, options ...IDOptions) HyperNode {
+	var o IDOptions
+	if len(options) != 0 {
+		o = options[0]
+	}
+	return idChecker{node: node, options: o}
+}
+
+type idChecker struct {
+	node    HyperNode
+	options IDOptions
+}
+
+func (me idChecker) Render(w io.Writer) error {
+	seen := map[string]bool{}
+	reported := map[string]bool{}
+	var err error
+	walkElements(me.node, func(element Element) bool {
+		id, ok := element.Attribute("id")
+		if !ok || id == "" {
+			return true
+		}
+		if !seen[id] {
+			seen[id] = true
+			return true
+		}
+		if me.options.OnDuplicate == nil {
+			err = DuplicateIDError{ID: id}
+			return false
+		}
+		if !reported[id] {
+			reported[id] = true
+			me.options.OnDuplicate(id)
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	return me.node.Render(w)
+}
+
+// Unwrap implements [Wrapper].
+func (me idChecker) Unwrap() []HyperNode {
+	return []HyperNode{me.node}
+}
+
+func (me idChecker) mapNodes(fn func(HyperNode) HyperNode) HyperNode {
+	return idChecker{node: fn(me.node), options: me.options}
+}
+
+// walkElements calls fn for each element of node in document order, until
+// fn returns false.
+func walkElements(node HyperNode, fn func(Element) bool) bool {
+	switch n := node.(type) {
+	case Element:
+		if n.Tag != "" && !fn(n) {
+			return false
+		}
+		for _, child := range n.Children {
+			if !walkElements(child, fn) {
+				return false
+			}
+		}
+	case Wrapper:
+		for _, child := range n.Unwrap() {
+			if !walkElements(child, fn) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,39 @@
+package h
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestUniqueID(t *testing.T) {
+	first, second := UniqueID("menu"), UniqueID("menu")
+	if first == second || !strings.HasPrefix(first, "menu-") {
+		t.Errorf("expected unique prefixed ids, got %q and %q", first, second)
+	}
+}
+
+func TestCheckIDs(t *testing.T) {
+	page := DIV(AttrID("main"))(
+		Named("Card", SECTION(AttrID("card"))(P()("one"))),
+		Key("k", SECTION(AttrID("card"))(P()("two"))),
+		SPAN(AttrID("card"))(),
+	)
+
+	var duplicate DuplicateIDError
+	if err := Render(io.Discard, CheckIDs(page)); !errors.As(err, &duplicate) || duplicate.ID != "card" {
+		t.Errorf("expected a duplicate id error for %q, got %v", "card", err)
+	}
+
+	var reported []string
+	err := Render(io.Discard, CheckIDs(page, IDOptions{OnDuplicate: func(id string) { reported = append(reported, id) }}))
+	if err != nil || !slices.Equal(reported, []string{"card"}) {
+		t.Errorf("expected %q to be reported once, got %v (err %v)", "card", reported, err)
+	}
+
+	if err := Render(io.Discard, CheckIDs(DIV(AttrID("a"))(P(AttrID("b"))()))); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
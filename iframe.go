@@ -0,0 +1,135 @@
+package h
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// IFrameParams configures [SandboxedIFrame].
+type IFrameParams struct {
+	// Title describes the embedded content to assistive technologies; an
+	// <iframe> without one is an accessibility failure.
+	Title string
+	// Sandbox lists the Sandbox* permissions granted to the embedded page.
+	// The sandbox attribute is always rendered, so nil grants nothing.
+	Sandbox []string
+	// Allow lists the permissions policy features delegated to the
+	// embedded page, e.g. "autoplay" or "fullscreen".
+	Allow []string
+	// Width and Height are the size of the frame, reserving its space to
+	// avoid layout shifts.
+	Width, Height int
+	Attributes    []Attribute // Added to the <iframe>
+}
+
+// SandboxedIFrame creates a sandboxed, lazy-loaded <iframe> of src that
+// doesn't leak the page URL to the embedded site.
+//
+// Example:
+//
+//	SandboxedIFrame("https://example.com/widget", IFrameParams{
+//		Title:   "Weather widget",
+//		Sandbox: []string{SandboxAllowScripts},
+//	})
+func SandboxedIFrame(src string, params IFrameParams) Element {
+	attrs := []Attribute{
+		AttrSrc(src),
+		AttrTitle(params.Title),
+		AttrSandbox(strings.Join(params.Sandbox, " ")),
+		AttrLoading(LoadingLazy),
+		AttrReferrerPolicy(ReferrerPolicyStrictOriginWhenCrossOrigin),
+	}
+	if len(params.Allow) != 0 {
+		attrs = append(attrs, AttrAllow(strings.Join(params.Allow, "; ")))
+	}
+	if params.Width > 0 && params.Height > 0 {
+		attrs = append(attrs, AttrWidth(strconv.Itoa(params.Width)), AttrHeight(strconv.Itoa(params.Height)))
+	}
+	attrs = append(attrs, params.Attributes...)
+	return IFRAME(attrs...)()
+}
+
+// EmbedOptions configures [YouTube], [Vimeo] and [GoogleMap] embeds.
+type EmbedOptions struct {
+	// Start is where videos start playing.
+	Start time.Duration
+	// Width and Height are the size of the frame, 560×315 when unset.
+	Width, Height int
+	Attributes    []Attribute // Added to the <iframe>
+}
+
+// videoSandbox and videoAllow are what video players need to play,
+// including fullscreen and picture-in-picture.
+var (
+	videoSandbox = []string{SandboxAllowScripts, SandboxAllowSameOrigin, SandboxAllowPresentation, SandboxAllowPopups}
+	videoAllow   = []string{"autoplay", "encrypted-media", "fullscreen", "picture-in-picture"}
+)
+
+// YouTube embeds the YouTube video with the given ID using the
+// privacy-enhanced youtube-nocookie.com player, which doesn't set cookies
+// until the video plays.
+//
+// Example:
+//
+//	YouTube("dQw4w9WgXcQ", "Launch keynote", EmbedOptions{Start: 90 * time.Second})
+func YouTube(videoID, title string, options ...EmbedOptions) Element {
+	o := embedOptions(options)
+	src := "https://www.youtube-nocookie.com/embed/" + url.PathEscape(videoID)
+	if o.Start > 0 {
+		src += "?start=" + strconv.Itoa(int(o.Start.Seconds()))
+	}
+	return videoIFrame(src, title, o)
+}
+
+// Vimeo embeds the Vimeo video with the given ID, with tracking disabled.
+func Vimeo(videoID, title string, options ...EmbedOptions) Element {
+	o := embedOptions(options)
+	src := "https://player.vimeo.com/video/" + url.PathEscape(videoID) + "?dnt=1"
+	if o.Start > 0 {
+		src += "#t=" + strconv.Itoa(int(o.Start.Seconds())) + "s"
+	}
+	return videoIFrame(src, title, o)
+}
+
+// GoogleMap embeds a Google map of query, a place name or an address. The
+// embedded map only runs scripts and can't navigate the page; its links
+// open in new windows.
+//
+// Example:
+//
+//	GoogleMap("Eiffel Tower, Paris", "Map of our venue")
+func GoogleMap(query, title string, options ...EmbedOptions) Element {
+	o := embedOptions(options)
+	return SandboxedIFrame("https://maps.google.com/maps?output=embed&q="+url.QueryEscape(query), IFrameParams{
+		Title:      title,
+		Sandbox:    []string{SandboxAllowScripts, SandboxAllowSameOrigin, SandboxAllowPopups, SandboxAllowPopupsToEscapeSandbox},
+		Allow:      []string{"fullscreen"},
+		Width:      o.Width,
+		Height:     o.Height,
+		Attributes: o.Attributes,
+	})
+}
+
+func embedOptions(options []EmbedOptions) EmbedOptions {
+	var o EmbedOptions
+	if len(options) != 0 {
+		o = options[0]
+	}
+	if o.Width <= 0 || o.Height <= 0 {
+		o.Width, o.Height = 560, 315
+	}
+	return o
+}
+
+func videoIFrame(src, title string, o EmbedOptions) Element {
+	return SandboxedIFrame(src, IFrameParams{
+		Title:      title,
+		Sandbox:    videoSandbox,
+		Allow:      videoAllow,
+		Width:      o.Width,
+		Height:     o.Height,
+		Attributes: append([]Attribute{Attr("allowfullscreen", true)}, o.Attributes...),
+	})
+}
@@ -0,0 +1,59 @@
+package h
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestSandboxedIFrame(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, SandboxedIFrame("https://example.com/widget", IFrameParams{Title: "Widget"})); err != nil {
+		t.Fatal(err)
+	}
+	expected := `<iframe src="https://example.com/widget" title="Widget" sandbox="" loading="lazy" referrerpolicy="strict-origin-when-cross-origin"></iframe>`
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
+
+func TestEmbeds(t *testing.T) {
+	tests := []struct {
+		name     string
+		node     HyperNode
+		expected string
+	}{
+		{
+			name: "YouTube",
+			node: YouTube("abc 123", "Keynote", EmbedOptions{Start: 90 * time.Second}),
+			expected: `<iframe src="https://www.youtube-nocookie.com/embed/abc%20123?start=90" title="Keynote" ` +
+				`sandbox="allow-scripts allow-same-origin allow-presentation allow-popups" loading="lazy" referrerpolicy="strict-origin-when-cross-origin" ` +
+				`allow="autoplay; encrypted-media; fullscreen; picture-in-picture" width="560" height="315" allowfullscreen></iframe>`,
+		},
+		{
+			name: "Vimeo",
+			node: Vimeo("76979871", "Demo", EmbedOptions{Width: 640, Height: 360}),
+			expected: `<iframe src="https://player.vimeo.com/video/76979871?dnt=1" title="Demo" ` +
+				`sandbox="allow-scripts allow-same-origin allow-presentation allow-popups" loading="lazy" referrerpolicy="strict-origin-when-cross-origin" ` +
+				`allow="autoplay; encrypted-media; fullscreen; picture-in-picture" width="640" height="360" allowfullscreen></iframe>`,
+		},
+		{
+			name: "GoogleMap",
+			node: GoogleMap("Eiffel Tower, Paris", "Venue"),
+			expected: `<iframe src="https://maps.google.com/maps?output=embed&q=Eiffel+Tower%2C+Paris" title="Venue" ` +
+				`sandbox="allow-scripts allow-same-origin allow-popups allow-popups-to-escape-sandbox" loading="lazy" referrerpolicy="strict-origin-when-cross-origin" ` +
+				`allow="fullscreen" width="560" height="315"></iframe>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Render(&buf, tt.node); err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, buf.String())
+			}
+		})
+	}
+}
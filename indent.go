@@ -0,0 +1,176 @@
+package h
+
+import (
+	"bytes"
+	"html"
+	"io"
+	"strings"
+)
+
+// preservedTags are the elements whose content is whitespace-sensitive,
+// rendered as is by RenderIndent.
+var preservedTags = map[string]bool{"pre": true, "textarea": true, "script": true, "style": true}
+
+// RenderIndent renders node to w like [Render], but human-readable: each
+// element whose children are all elements starts them on their own lines,
+// indented by indent per level of nesting. Elements containing text, such
+// as paragraphs, and <pre>, <textarea>, <script> and <style> elements stay
+// on one line, so text and its whitespace are rendered unchanged.
+//
+// Only the whitespace between elements differs from [Render], which can
+// matter between inline elements. Use it for debugging and golden files of
+// tests, and keep [Render] for serving pages.
+//
+// Example:
+//
+//	RenderIndent(os.Stdout, UL()(LI()("One"), LI()(A(AttrHref("/2"))("Two"))), "  ")
+//	// <ul>
+//	//   <li>One</li>
+//	//   <li><a href="/2">Two</a></li>
+//	// </ul>
+func RenderIndent(w io.Writer, node HyperNode, indent string) error {
+	buf := getBuffer(mediumBufferSize)
+	defer putBuffer(buf)
+	i := indenter{buf: buf, indent: indent, limits: limits.Load()}
+	if err := i.lines(flattenNodes([]HyperNode{node}), 0); err != nil {
+		return err
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+type indenter struct {
+	buf    *bytes.Buffer
+	indent string
+	limits *Limits
+}
+
+// lines renders nodes one per line, or on a single line when they contain
+// text.
+func (me *indenter) lines(nodes []HyperNode, depth int) error {
+	if hasText(nodes) {
+		me.writeIndent(depth)
+		for _, node := range nodes {
+			if err := me.inline(node); err != nil {
+				return err
+			}
+		}
+		me.buf.WriteByte('\n')
+		return nil
+	}
+
+	for _, node := range nodes {
+		if text, ok := node.(Text); ok && strings.TrimSpace(string(text)) == "" {
+			continue
+		}
+		me.writeIndent(depth)
+		element, ok := node.(Element)
+		if !ok {
+			if err := me.inline(node); err != nil {
+				return err
+			}
+			me.buf.WriteByte('\n')
+			continue
+		}
+		if err := me.element(element, depth); err != nil {
+			return err
+		}
+		me.buf.WriteByte('\n')
+	}
+	return nil
+}
+
+func (me *indenter) element(element Element, depth int) error {
+	children := flattenNodes(element.Children)
+	if element.IsVoid || len(children) == 0 || preservedTags[element.Tag] || hasText(children) {
+		return element.render(me.buf)
+	}
+
+	if me.limits != nil {
+		if err := checkElementLimits(me.limits, element); err != nil {
+			return err
+		}
+	}
+	me.buf.WriteByte('<')
+	me.buf.WriteString(element.Tag)
+	if err := element.renderAttrs(me.buf); err != nil {
+		return err
+	}
+	me.buf.WriteString(">\n")
+	if err := me.lines(children, depth+1); err != nil {
+		return err
+	}
+	if me.limits != nil {
+		if err := checkOutputLimit(me.limits, element.Tag, me.buf); err != nil {
+			return err
+		}
+	}
+	me.writeIndent(depth)
+	me.buf.WriteString("</")
+	me.buf.WriteString(element.Tag)
+	me.buf.WriteByte('>')
+	return nil
+}
+
+// inline renders node as Render does.
+func (me *indenter) inline(node HyperNode) error {
+	switch n := node.(type) {
+	case Element:
+		return n.render(me.buf)
+	case Text:
+		me.buf.WriteString(html.EscapeString(string(n)))
+	case RawText:
+		me.buf.WriteString(string(n))
+	case BufferRenderer:
+		return n.RenderToBuffer(me.buf)
+	default:
+		return n.Render(me.buf)
+	}
+	return nil
+}
+
+func (me *indenter) writeIndent(depth int) {
+	for range depth {
+		me.buf.WriteString(me.indent)
+	}
+}
+
+// flattenNodes replaces the groups and wrappers of nodes, which render no
+// tag, by their content.
+func flattenNodes(nodes []HyperNode) []HyperNode {
+	var result []HyperNode
+	for _, node := range nodes {
+		if group, ok := node.(Element); ok && group.Tag == "" {
+			result = append(result, flattenNodes(group.Children)...)
+			continue
+		}
+		if nodes, ok := inlined(node); ok {
+			result = append(result, flattenNodes(nodes)...)
+			continue
+		}
+		result = append(result, node)
+	}
+	return result
+}
+
+// hasText reports whether nodes contain text other than whitespace, which
+// line breaks would alter.
+func hasText(nodes []HyperNode) bool {
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case Text:
+			if strings.TrimSpace(string(n)) != "" {
+				return true
+			}
+		case RawText:
+			if strings.TrimSpace(string(n)) != "" {
+				return true
+			}
+		case staticHTML:
+			if strings.TrimSpace(n.html) != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
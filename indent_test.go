@@ -0,0 +1,79 @@
+package h
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestRenderIndent(t *testing.T) {
+	tests := []struct {
+		name     string
+		node     HyperNode
+		expected string
+	}{
+		{
+			name: "Nested elements",
+			node: HTML()(
+				HEAD()(TITLE()("Home"), META(AttrCharset("utf-8"))),
+				BODY()(MAIN(AttrClass("page"))(DIV()(), P()("Hello ", B()("world")))),
+			),
+			expected: "<html>\n" +
+				"  <head>\n" +
+				"    <title>Home</title>\n" +
+				"    <meta charset=\"utf-8\">\n" +
+				"  </head>\n" +
+				"  <body>\n" +
+				"    <main class=\"page\">\n" +
+				"      <div></div>\n" +
+				"      <p>Hello <b>world</b></p>\n" +
+				"    </main>\n" +
+				"  </body>\n" +
+				"</html>\n",
+		},
+		{
+			name:     "Groups and wrappers are flattened",
+			node:     UL()(Group(LI()("a"), Named("Item", LI()("b"))), Key("c", LI()("c"))),
+			expected: "<ul>\n  <li>a</li>\n  <li>b</li>\n  <li>c</li>\n</ul>\n",
+		},
+		{
+			name:     "Whitespace-sensitive elements stay as is",
+			node:     DIV()(PRE()(CODE()("a"), CODE()("b")), SCRIPT()(RawText("let x = 1"))),
+			expected: "<div>\n  <pre><code>a</code><code>b</code></pre>\n  <script>let x = 1</script>\n</div>\n",
+		},
+		{
+			name:     "Whitespace text between elements is dropped",
+			node:     DIV()(" ", SPAN()("a"), "\n", SPAN()("b")),
+			expected: "<div>\n  <span>a</span>\n  <span>b</span>\n</div>\n",
+		},
+		{
+			name:     "Top-level text",
+			node:     Group("Hello ", EM()("you")),
+			expected: "Hello <em>you</em>\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := RenderIndent(&buf, tt.node, "  "); err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, buf.String())
+			}
+		})
+	}
+}
+
+func TestRenderIndentLimits(t *testing.T) {
+	SetLimits(Limits{MaxChildren: 2})
+	defer SetLimits(Limits{})
+
+	var buf bytes.Buffer
+	err := RenderIndent(&buf, DIV()(DIV()(SPAN()(), SPAN()(), SPAN()())), "\t")
+	var limitErr LimitError
+	if !errors.As(err, &limitErr) || limitErr.Limit != "children" {
+		t.Errorf("expected a children limit error, got %v", err)
+	}
+}
@@ -0,0 +1,308 @@
+package h
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+)
+
+// MaxInlineImageSize is the largest file, in bytes, that [InlineImage] embeds.
+// Data URIs are base64-encoded (a third larger) and cannot be cached separately
+// from the page, so only small images such as icons and logos are worth inlining.
+var MaxInlineImageSize int64 = 32 << 10
+
+// DataURI returns a base64 data: URI holding data with the given MIME type.
+// When mimeType is empty, it is sniffed from the content.
+//
+// Example:
+//
+//	LINK(AttrRel("icon"), AttrHref(DataURI("image/png", favicon)))
+func DataURI(mimeType string, data []byte) string {
+	if mimeType == "" {
+		mimeType = http.DetectContentType(data)
+	}
+	return "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(data)
+}
+
+// InlineImage creates an <img> whose src is a data: URI of the image at name
+// in fsys, so the image ships with the page instead of costing a request.
+//
+// The MIME type is sniffed from the content (SVG files are detected by their
+// extension). Rendering the returned node fails if the file cannot be read,
+// is larger than [MaxInlineImageSize], or is not an image.
+//
+// Example:
+//
+//	//go:embed static
+//	var static embed.FS
+//
+//	InlineImage(static, "static/logo.png", AttrAlt("Acme"), AttrClass("h-8"))
+func InlineImage(fsys fs.FS, name string, attrs ...Attribute) HyperNode {
+	data, err := readInlineFile(fsys, name)
+	if err != nil {
+		return errorNode{err: err}
+	}
+
+	mimeType := http.DetectContentType(data)
+	if strings.EqualFold(path.Ext(name), ".svg") {
+		mimeType = "image/svg+xml"
+	}
+	if !strings.HasPrefix(mimeType, "image/") {
+		return errorNode{err: fmt.Errorf("inline image %q: unsupported content type %q", name, mimeType)}
+	}
+
+	element := Element{Tag: "img", IsVoid: true}
+	element.Attributes = append(element.Attributes, AttrSrc(DataURI(mimeType, data)))
+	element.Attributes = append(element.Attributes, attrs...)
+	return element
+}
+
+// InlineSVGFile embeds the SVG file at name in fsys directly into the page, so
+// it can be styled with CSS (e.g. fill="currentColor") like any other element.
+//
+// Any XML declaration or doctype before the <svg> root is dropped. When
+// sanitize is true, the markup is re-serialized keeping only an allowlist of
+// SVG elements and attributes, safe to inline from untrusted sources: scripts,
+// styles, <foreignObject>, HTML elements, event handler attributes (on*),
+// animations of links, and URLs with schemes other than http, https and
+// mailto are removed, as are end tags not matching the elements kept, so
+// the SVG can't close the elements around it. Leave sanitize false only for
+// files you control.
+//
+// Rendering the returned node fails if the file cannot be read, is larger
+// than [MaxInlineImageSize], or is not valid XML.
+//
+// Example:
+//
+//	InlineSVGFile(uploads, "avatars/42.svg", true)
+func InlineSVGFile(fsys fs.FS, name string, sanitize bool) HyperNode {
+	data, err := readInlineFile(fsys, name)
+	if err != nil {
+		return errorNode{err: err}
+	}
+
+	if !sanitize {
+		if i := bytes.Index(data, []byte("<svg")); i > 0 {
+			data = data[i:]
+		}
+		return RawText(data)
+	}
+
+	sanitized, err := sanitizeSVG(data)
+	if err != nil {
+		return errorNode{err: fmt.Errorf("inline svg %q: %w", name, err)}
+	}
+	return RawText(sanitized)
+}
+
+func readInlineFile(fsys fs.FS, name string) ([]byte, error) {
+	info, err := fs.Stat(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > MaxInlineImageSize {
+		return nil, fmt.Errorf("inline file %q: size %d exceeds the limit of %d bytes", name, info.Size(), MaxInlineImageSize)
+	}
+	return fs.ReadFile(fsys, name)
+}
+
+// svgPolicy is the allowlist of the SVG markup kept by [InlineSVGFile] when
+// sanitizing. Names are listed lowercased and matched case-insensitively,
+// so viewBox and linearGradient match.
+// <script>, <style>, <foreignObject>, <feImage> and anything that isn't SVG
+// are not listed, so they are removed with their content.
+var svgPolicy = SanitizePolicy{
+	Elements: map[string][]string{
+		"svg":    {"xmlns", "xmlns:xlink", "viewbox", "width", "height", "x", "y", "preserveaspectratio", "version"},
+		"g":      nil,
+		"defs":   nil,
+		"symbol": {"viewbox", "preserveaspectratio", "x", "y", "width", "height"},
+		"use":    {"href", "xlink:href", "x", "y", "width", "height"},
+		"title":  nil,
+		"desc":   nil,
+		"a":      {"href", "xlink:href"},
+		"image":  {"href", "xlink:href", "x", "y", "width", "height", "preserveaspectratio"},
+
+		"path":     {"d", "pathlength"},
+		"rect":     {"x", "y", "width", "height", "rx", "ry"},
+		"circle":   {"cx", "cy", "r"},
+		"ellipse":  {"cx", "cy", "rx", "ry"},
+		"line":     {"x1", "y1", "x2", "y2"},
+		"polyline": {"points"},
+		"polygon":  {"points"},
+		"text":     {"x", "y", "dx", "dy", "rotate", "textlength", "lengthadjust"},
+		"tspan":    {"x", "y", "dx", "dy", "rotate", "textlength", "lengthadjust"},
+		"textpath": {"href", "xlink:href", "startoffset", "method", "spacing"},
+
+		"lineargradient": {"x1", "y1", "x2", "y2", "gradientunits", "gradienttransform", "spreadmethod", "href", "xlink:href"},
+		"radialgradient": {"cx", "cy", "r", "fx", "fy", "fr", "gradientunits", "gradienttransform", "spreadmethod", "href", "xlink:href"},
+		"stop":           {"offset"},
+		"pattern":        {"x", "y", "width", "height", "patternunits", "patterncontentunits", "patterntransform", "viewbox", "preserveaspectratio", "href", "xlink:href"},
+		"clippath":       {"clippathunits"},
+		"mask":           {"x", "y", "width", "height", "maskunits", "maskcontentunits"},
+		"marker":         {"viewbox", "refx", "refy", "markerunits", "markerwidth", "markerheight", "orient", "preserveaspectratio"},
+
+		"filter":         {"x", "y", "width", "height", "filterunits", "primitiveunits"},
+		"fegaussianblur": {"in", "result", "stddeviation", "edgemode"},
+		"feoffset":       {"in", "result", "dx", "dy"},
+		"feblend":        {"in", "in2", "result", "mode"},
+		"fecolormatrix":  {"in", "result", "type", "values"},
+		"feflood":        {"result", "flood-color", "flood-opacity"},
+		"fecomposite":    {"in", "in2", "result", "operator", "k1", "k2", "k3", "k4"},
+		"femerge":        {"result"},
+		"femergenode":    {"in"},
+		"femorphology":   {"in", "result", "operator", "radius"},
+		"fedropshadow":   {"in", "result", "dx", "dy", "stddeviation", "flood-color", "flood-opacity"},
+
+		"animate":          svgAnimationAttrs,
+		"set":              svgAnimationAttrs,
+		"animatetransform": svgAnimationAttrs,
+		"animatemotion":    append(slices.Clone(svgAnimationAttrs), "path", "keypoints", "rotate"),
+		"mpath":            {"href", "xlink:href"},
+	},
+	GlobalAttributes: []string{
+		"id", "class", "lang", "xml:lang", "xml:space", "role", "aria-*", "transform",
+		"fill", "fill-opacity", "fill-rule", "stroke", "stroke-width", "stroke-linecap",
+		"stroke-linejoin", "stroke-miterlimit", "stroke-dasharray", "stroke-dashoffset",
+		"stroke-opacity", "opacity", "color", "display", "visibility", "overflow",
+		"clip-path", "clip-rule", "mask", "filter", "marker-start", "marker-mid", "marker-end",
+		"font-family", "font-size", "font-weight", "font-style", "text-anchor",
+		"dominant-baseline", "letter-spacing", "word-spacing", "text-decoration",
+		"stop-color", "stop-opacity", "shape-rendering", "vector-effect", "paint-order",
+	},
+}
+
+var svgAnimationAttrs = []string{
+	"attributename", "attributetype", "from", "to", "by", "values", "dur", "begin", "end",
+	"repeatcount", "repeatdur", "keytimes", "keysplines", "calcmode", "additive",
+	"accumulate", "type", "restart", "min", "max",
+}
+
+// sanitizeSVG re-serializes an SVG document keeping only the elements and
+// attributes of [svgPolicy], with URLs restricted to its schemes. Comments,
+// processing instructions, directives and the content after the root element
+// are dropped, and elements left open are closed.
+func sanitizeSVG(data []byte) (string, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = false
+
+	// open holds the elements being read, to only write the end tags of
+	// the written elements, in order: a crafted end tag such as </div>
+	// can't close the elements around the SVG.
+	type openElement struct {
+		name    string
+		written bool
+	}
+	var open []openElement
+	var out strings.Builder
+	skipped := 0 // number of removed elements in open
+	for {
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			name := qualifiedName(t.Name)
+			allowed, ok := svgPolicy.Elements[strings.ToLower(name)]
+			if skipped > 0 || !ok || animatesSVGLink(t) {
+				open = append(open, openElement{name: name})
+				skipped++
+				continue
+			}
+			open = append(open, openElement{name: name, written: true})
+			out.WriteByte('<')
+			out.WriteString(name)
+			for _, a := range t.Attr {
+				if !allowsSVGAttr(allowed, a) {
+					continue
+				}
+				out.WriteByte(' ')
+				out.WriteString(qualifiedName(a.Name))
+				out.WriteString(`="`)
+				out.WriteString(html.EscapeString(a.Value))
+				out.WriteByte('"')
+			}
+			out.WriteByte('>')
+		case xml.EndElement:
+			if len(open) == 0 || open[len(open)-1].name != qualifiedName(t.Name) {
+				continue
+			}
+			top := open[len(open)-1]
+			open = open[:len(open)-1]
+			if !top.written {
+				skipped--
+				continue
+			}
+			out.WriteString("</" + top.name + ">")
+			if len(open) == 0 {
+				// Content after the root element isn't part of the SVG.
+				return out.String(), nil
+			}
+		case xml.CharData:
+			if len(open) > 0 && skipped == 0 {
+				out.WriteString(html.EscapeString(string(t)))
+			}
+		}
+	}
+	// Close the elements left open, so they don't swallow the page after
+	// the SVG.
+	for i := len(open) - 1; i >= 0; i-- {
+		if open[i].written {
+			out.WriteString("</" + open[i].name + ">")
+		}
+	}
+
+	return out.String(), nil
+}
+
+// animatesSVGLink reports whether t is an animation element changing a link,
+// which would let <set to="javascript:..."> swap in a URL no attribute check sees.
+func animatesSVGLink(t xml.StartElement) bool {
+	for _, a := range t.Attr {
+		if strings.EqualFold(a.Name.Local, "attributeName") {
+			target := strings.ToLower(strings.TrimSpace(a.Value))
+			return target == "href" || target == "xlink:href"
+		}
+	}
+	return false
+}
+
+func allowsSVGAttr(allowed []string, a xml.Attr) bool {
+	name := strings.ToLower(qualifiedName(a.Name))
+	if !svgPolicy.allowsAttr(allowed, htmlAttr{name: name, value: a.Value}) {
+		return false
+	}
+	// Besides href, checked by allowsAttr, links and animated values may
+	// hold URLs.
+	switch name {
+	case "xlink:href", "from", "to", "by":
+		return svgPolicy.allowsURL(a.Value)
+	case "values":
+		for value := range strings.SplitSeq(a.Value, ";") {
+			if !svgPolicy.allowsURL(strings.TrimSpace(value)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func qualifiedName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
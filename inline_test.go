@@ -0,0 +1,146 @@
+package h
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestInlineImage(t *testing.T) {
+	fsys := fstest.MapFS{
+		"logo.png":  {Data: pngHeader},
+		"logo.svg":  {Data: []byte(`<svg xmlns="http://www.w3.org/2000/svg"></svg>`)},
+		"notes.txt": {Data: []byte("hello")},
+		"big.png":   {Data: append(pngHeader, make([]byte, MaxInlineImageSize)...)},
+	}
+
+	tests := []struct {
+		name     string
+		file     string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "PNG is sniffed",
+			file:     "logo.png",
+			expected: `<img src="data:image/png;base64,iVBORw0KGgoAAAANSUhEUg==" alt="Logo">`,
+		},
+		{
+			name:     "SVG is detected by extension",
+			file:     "logo.svg",
+			expected: `<img src="data:image/svg+xml;base64,PHN2ZyB4bWxucz0iaHR0cDovL3d3dy53My5vcmcvMjAwMC9zdmciPjwvc3ZnPg==" alt="Logo">`,
+		},
+		{name: "Not an image", file: "notes.txt", wantErr: true},
+		{name: "Too large", file: "big.png", wantErr: true},
+		{name: "Missing file", file: "missing.png", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := Render(&buf, DIV()(InlineImage(fsys, tt.file, AttrAlt("Logo"))))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Render() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && buf.String() != "<div>"+tt.expected+"</div>" {
+				t.Errorf("InlineImage() = %q, want %q", buf.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestInlineSVGFile(t *testing.T) {
+	fsys := fstest.MapFS{
+		"icon.svg": {Data: []byte(`<?xml version="1.0"?>
+<!-- comment --><svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink" onload="alert(1)">` +
+			`<script>alert(2)</script><a xlink:href="javascript:alert(3)"><path d="M0 0" onclick="x()"/></a>` +
+			`<foreignObject><div>html</div></foreignObject><text>a &amp; b</text></svg>`)},
+	}
+
+	var buf bytes.Buffer
+	if err := Render(&buf, InlineSVGFile(fsys, "icon.svg", true)); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	expected := `<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink"><a><path d="M0 0"></path></a><text>a &amp; b</text></svg>`
+	if buf.String() != expected {
+		t.Errorf("InlineSVGFile() sanitized = %q, want %q", buf.String(), expected)
+	}
+
+	buf.Reset()
+	if err := Render(&buf, InlineSVGFile(fsys, "icon.svg", false)); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), `<svg xmlns`) || !strings.Contains(buf.String(), "<script>") {
+		t.Errorf("InlineSVGFile() raw = %q, want the file content from <svg>", buf.String())
+	}
+}
+
+func TestInlineSVGFile_Allowlist(t *testing.T) {
+	tests := []struct {
+		name     string
+		svg      string
+		expected string
+	}{
+		{
+			name:     "Kept markup",
+			svg:      `<svg viewBox="0 0 24 24"><linearGradient id="g"><stop offset="0" stop-color="red"/></linearGradient><use xlink:href="#g" fill="url(#g)"/></svg>`,
+			expected: `<svg viewBox="0 0 24 24"><linearGradient id="g"><stop offset="0" stop-color="red"></stop></linearGradient><use xlink:href="#g" fill="url(#g)"></use></svg>`,
+		},
+		{
+			name:     "set of href",
+			svg:      `<svg><a href="/"><set attributeName="href" to="javascript:alert(1)"/><text>x</text></a></svg>`,
+			expected: `<svg><a href="/"><text>x</text></a></svg>`,
+		},
+		{
+			name:     "animate of xlink:href",
+			svg:      `<svg><a><animate attributeName=" XLINK:HREF " values="javascript:alert(1)"/></a></svg>`,
+			expected: `<svg><a></a></svg>`,
+		},
+		{
+			name:     "Animated URL values",
+			svg:      `<svg><rect><animate attributeName="fill" values="red;javascript:alert(1)" from="java&#x09;script:x" to="blue" dur="1s"/></rect></svg>`,
+			expected: `<svg><rect><animate attributeName="fill" to="blue" dur="1s"></animate></rect></svg>`,
+		},
+		{
+			name:     "HTML breakout",
+			svg:      `<svg><p/><base href="//evil.example/"/><form><button formaction="javascript:alert(1)">x</button></form><circle r="1"/></svg>`,
+			expected: `<svg><circle r="1"></circle></svg>`,
+		},
+		{
+			name:     "Styles and filters loading content",
+			svg:      `<svg><style>*{}</style><filter><feImage href="https://evil.example/x"/></filter><path d="M0" style="x"/></svg>`,
+			expected: `<svg><filter></filter><path d="M0"></path></svg>`,
+		},
+		{
+			name:     "Stray end tags",
+			svg:      `<svg><g></div></g></svg></div><p>x</p>`,
+			expected: `<svg><g></g></svg>`,
+		},
+		{
+			name:     "End tags inside removed elements",
+			svg:      `<svg><script></svg><text>x</text></script><circle r="1"/></svg>`,
+			expected: `<svg><circle r="1"></circle></svg>`,
+		},
+		{
+			name:     "Unclosed elements",
+			svg:      `<svg><g><circle r="1"/>`,
+			expected: `<svg><g><circle r="1"></circle></g></svg>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := fstest.MapFS{"icon.svg": {Data: []byte(tt.svg)}}
+			var buf bytes.Buffer
+			if err := Render(&buf, InlineSVGFile(fsys, "icon.svg", true)); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("InlineSVGFile() = %q, want %q", buf.String(), tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,483 @@
+// Package qrcode implements a QR Code Model 2 encoder (ISO/IEC 18004) for
+// byte-mode data, versions 1 through 40.
+package qrcode
+
+import "errors"
+
+// Level is the error correction level of a QR code.
+type Level int
+
+const (
+	LevelL Level = iota // Recovers ~7% of damaged data
+	LevelM              // Recovers ~15% of damaged data
+	LevelQ              // Recovers ~25% of damaged data
+	LevelH              // Recovers ~30% of damaged data
+)
+
+// ErrDataTooLong is returned when the data does not fit in a version 40 symbol.
+var ErrDataTooLong = errors.New("qrcode: data too long")
+
+// formatBits are the 2-bit level indicators used in the format information.
+var formatBits = [4]int{LevelL: 1, LevelM: 0, LevelQ: 3, LevelH: 2}
+
+// eccCodewordsPerBlock is indexed by level then version (index 0 unused).
+var eccCodewordsPerBlock = [4][41]int{
+	{-1, 7, 10, 15, 20, 26, 18, 20, 24, 30, 18, 20, 24, 26, 30, 22, 24, 28, 30, 28, 28, 28, 28, 30, 30, 26, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26, 26, 26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28},
+	{-1, 13, 22, 18, 26, 18, 24, 18, 22, 20, 24, 28, 26, 24, 20, 30, 24, 28, 28, 26, 30, 28, 30, 30, 30, 30, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 17, 28, 22, 16, 22, 28, 26, 26, 24, 28, 24, 28, 22, 24, 24, 30, 28, 28, 26, 28, 30, 24, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+}
+
+// numErrorCorrectionBlocks is indexed by level then version (index 0 unused).
+var numErrorCorrectionBlocks = [4][41]int{
+	{-1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 4, 4, 4, 4, 4, 6, 6, 6, 6, 7, 8, 8, 9, 9, 10, 12, 12, 12, 13, 14, 15, 16, 17, 18, 19, 19, 20, 21, 22, 24, 25},
+	{-1, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14, 16, 17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49},
+	{-1, 1, 1, 2, 2, 4, 4, 6, 6, 8, 8, 8, 10, 12, 16, 12, 17, 16, 18, 21, 20, 23, 23, 25, 27, 29, 34, 34, 35, 38, 40, 43, 45, 48, 51, 53, 56, 59, 62, 65, 68},
+	{-1, 1, 1, 2, 4, 4, 4, 5, 6, 8, 8, 11, 11, 16, 16, 18, 16, 19, 21, 25, 25, 25, 34, 30, 32, 35, 37, 40, 42, 45, 48, 51, 54, 57, 60, 63, 66, 70, 74, 77, 81},
+}
+
+// Code is an encoded QR code symbol.
+type Code struct {
+	Size    int // Number of modules per side (21 to 177)
+	Version int // Symbol version (1 to 40)
+
+	modules    [][]bool
+	isFunction [][]bool
+}
+
+// Dark reports whether the module at column x and row y is dark.
+// Coordinates outside the symbol are reported as light.
+func (me *Code) Dark(x, y int) bool {
+	if x < 0 || y < 0 || x >= me.Size || y >= me.Size {
+		return false
+	}
+	return me.modules[y][x]
+}
+
+// Encode encodes data in byte mode using the smallest version that fits
+// at the given error correction level.
+func Encode(data []byte, level Level) (*Code, error) {
+	version := 0
+	for v := 1; v <= 40; v++ {
+		if 4+charCountBits(v)+len(data)*8 <= numDataCodewords(v, level)*8 {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, ErrDataTooLong
+	}
+
+	var bits bitBuffer
+	bits.append(0b0100, 4) // byte mode indicator
+	bits.append(len(data), charCountBits(version))
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+
+	capacity := numDataCodewords(version, level) * 8
+	bits.append(0, min(4, capacity-len(bits)))
+	bits.append(0, (8-len(bits)%8)%8)
+	for pad := 0xEC; len(bits) < capacity; pad ^= 0xEC ^ 0x11 {
+		bits.append(pad, 8)
+	}
+
+	code := newCode(version)
+	code.drawFunctionPatterns(level)
+	code.drawCodewords(addECCAndInterleave(bits.bytes(), version, level))
+
+	bestMask, minPenalty := 0, -1
+	for mask := range 8 {
+		code.applyMask(mask)
+		code.drawFormatBits(level, mask)
+		if penalty := code.penaltyScore(); minPenalty < 0 || penalty < minPenalty {
+			bestMask, minPenalty = mask, penalty
+		}
+		code.applyMask(mask) // XOR undoes the mask
+	}
+	code.applyMask(bestMask)
+	code.drawFormatBits(level, bestMask)
+
+	return code, nil
+}
+
+func newCode(version int) *Code {
+	size := version*4 + 17
+	code := &Code{Size: size, Version: version}
+	code.modules = make([][]bool, size)
+	code.isFunction = make([][]bool, size)
+	for i := range size {
+		code.modules[i] = make([]bool, size)
+		code.isFunction[i] = make([]bool, size)
+	}
+	return code
+}
+
+func charCountBits(version int) int {
+	if version <= 9 {
+		return 8
+	}
+	return 16
+}
+
+// numRawDataModules returns the number of modules available for data and
+// error correction codewords, after all function patterns are excluded.
+func numRawDataModules(version int) int {
+	result := (16*version+128)*version + 64
+	if version >= 2 {
+		numAlign := version/7 + 2
+		result -= (25*numAlign-10)*numAlign - 55
+		if version >= 7 {
+			result -= 36
+		}
+	}
+	return result
+}
+
+func numDataCodewords(version int, level Level) int {
+	return numRawDataModules(version)/8 -
+		eccCodewordsPerBlock[level][version]*numErrorCorrectionBlocks[level][version]
+}
+
+func alignmentPatternPositions(version int) []int {
+	if version == 1 {
+		return nil
+	}
+
+	numAlign := version/7 + 2
+	step := 26
+	if version != 32 {
+		step = (version*4 + numAlign*2 + 1) / (numAlign*2 - 2) * 2
+	}
+
+	result := make([]int, numAlign)
+	result[0] = 6
+	for i, pos := numAlign-1, version*4+10; i >= 1; i, pos = i-1, pos-step {
+		result[i] = pos
+	}
+	return result
+}
+
+func (me *Code) setFunction(x, y int, dark bool) {
+	me.modules[y][x] = dark
+	me.isFunction[y][x] = true
+}
+
+func (me *Code) drawFunctionPatterns(level Level) {
+	for i := range me.Size {
+		me.setFunction(6, i, i%2 == 0)
+		me.setFunction(i, 6, i%2 == 0)
+	}
+
+	me.drawFinderPattern(3, 3)
+	me.drawFinderPattern(me.Size-4, 3)
+	me.drawFinderPattern(3, me.Size-4)
+
+	positions := alignmentPatternPositions(me.Version)
+	last := len(positions) - 1
+	for i := range positions {
+		for j := range positions {
+			// Skip the three positions overlapping the finder patterns.
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			me.drawAlignmentPattern(positions[i], positions[j])
+		}
+	}
+
+	// Reserve the format areas; the real bits are drawn once the mask is known.
+	me.drawFormatBits(level, 0)
+	me.drawVersion()
+}
+
+func (me *Code) drawFinderPattern(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || yy < 0 || xx >= me.Size || yy >= me.Size {
+				continue
+			}
+			dist := max(abs(dx), abs(dy))
+			me.setFunction(xx, yy, dist != 2 && dist != 4)
+		}
+	}
+}
+
+func (me *Code) drawAlignmentPattern(x, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			me.setFunction(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+func (me *Code) drawFormatBits(level Level, mask int) {
+	data := formatBits[level]<<3 | mask
+	rem := data
+	for range 10 {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+
+	// First copy, around the top-left finder pattern.
+	for i := 0; i <= 5; i++ {
+		me.setFunction(8, i, bit(bits, i))
+	}
+	me.setFunction(8, 7, bit(bits, 6))
+	me.setFunction(8, 8, bit(bits, 7))
+	me.setFunction(7, 8, bit(bits, 8))
+	for i := 9; i < 15; i++ {
+		me.setFunction(14-i, 8, bit(bits, i))
+	}
+
+	// Second copy, split between the other two finder patterns.
+	for i := range 8 {
+		me.setFunction(me.Size-1-i, 8, bit(bits, i))
+	}
+	for i := 8; i < 15; i++ {
+		me.setFunction(8, me.Size-15+i, bit(bits, i))
+	}
+	me.setFunction(8, me.Size-8, true) // always dark
+}
+
+func (me *Code) drawVersion() {
+	if me.Version < 7 {
+		return
+	}
+
+	rem := me.Version
+	for range 12 {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	bits := me.Version<<12 | rem
+
+	for i := range 18 {
+		a, b := me.Size-11+i%3, i/3
+		me.setFunction(a, b, bit(bits, i))
+		me.setFunction(b, a, bit(bits, i))
+	}
+}
+
+// drawCodewords places the data bits in the zig-zag order defined by the
+// standard, skipping function modules.
+func (me *Code) drawCodewords(data []byte) {
+	i := 0
+	for right := me.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5 // skip the vertical timing pattern
+		}
+		for vert := range me.Size {
+			for j := range 2 {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = me.Size - 1 - vert
+				}
+				if !me.isFunction[y][x] && i < len(data)*8 {
+					me.modules[y][x] = bit(int(data[i>>3]), 7-i&7)
+					i++
+				}
+			}
+		}
+	}
+}
+
+func (me *Code) applyMask(mask int) {
+	for y := range me.Size {
+		for x := range me.Size {
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert && !me.isFunction[y][x] {
+				me.modules[y][x] = !me.modules[y][x]
+			}
+		}
+	}
+}
+
+// penaltyScore evaluates the four mask penalty rules of the standard.
+func (me *Code) penaltyScore() int {
+	result := 0
+	finderLike := [2][11]bool{
+		{true, false, true, true, true, false, true, false, false, false, false},
+		{false, false, false, false, true, false, true, true, true, false, true},
+	}
+
+	line := make([]bool, me.Size)
+	for _, vertical := range []bool{false, true} {
+		for i := range me.Size {
+			for j := range me.Size {
+				if vertical {
+					line[j] = me.modules[j][i]
+				} else {
+					line[j] = me.modules[i][j]
+				}
+			}
+
+			run := 1
+			for j := 1; j <= me.Size; j++ {
+				if j < me.Size && line[j] == line[j-1] {
+					run++
+					continue
+				}
+				if run >= 5 {
+					result += 3 + run - 5
+				}
+				run = 1
+			}
+
+			for j := 0; j+11 <= me.Size; j++ {
+				for _, pattern := range finderLike {
+					matches := true
+					for k, dark := range pattern {
+						if line[j+k] != dark {
+							matches = false
+							break
+						}
+					}
+					if matches {
+						result += 40
+					}
+				}
+			}
+		}
+	}
+
+	dark := 0
+	for y := range me.Size {
+		for x := range me.Size {
+			if me.modules[y][x] {
+				dark++
+			}
+			if x+1 < me.Size && y+1 < me.Size {
+				color := me.modules[y][x]
+				if color == me.modules[y][x+1] && color == me.modules[y+1][x] && color == me.modules[y+1][x+1] {
+					result += 3
+				}
+			}
+		}
+	}
+
+	total := me.Size * me.Size
+	result += abs(dark*100/total-50) / 5 * 10
+	return result
+}
+
+func addECCAndInterleave(data []byte, version int, level Level) []byte {
+	numBlocks := numErrorCorrectionBlocks[level][version]
+	blockECCLen := eccCodewordsPerBlock[level][version]
+	rawCodewords := numRawDataModules(version) / 8
+	numShortBlocks := numBlocks - rawCodewords%numBlocks
+	shortBlockLen := rawCodewords / numBlocks
+
+	divisor := reedSolomonDivisor(blockECCLen)
+	blocks := make([][]byte, 0, numBlocks)
+	for i, k := 0, 0; i < numBlocks; i++ {
+		dataLen := shortBlockLen - blockECCLen
+		if i >= numShortBlocks {
+			dataLen++
+		}
+		block := append([]byte{}, data[k:k+dataLen]...)
+		k += dataLen
+		ecc := reedSolomonRemainder(block, divisor)
+		if i < numShortBlocks {
+			block = append(block, 0) // padding, skipped when interleaving
+		}
+		blocks = append(blocks, append(block, ecc...))
+	}
+
+	result := make([]byte, 0, rawCodewords)
+	for i := range blocks[0] {
+		for j, block := range blocks {
+			if i != shortBlockLen-blockECCLen || j >= numShortBlocks {
+				result = append(result, block[i])
+			}
+		}
+	}
+	return result
+}
+
+func reedSolomonDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for range degree {
+		for j := range result {
+			result[j] = gfMultiply(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+	return result
+}
+
+func reedSolomonRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, coef := range divisor {
+			result[i] ^= gfMultiply(coef, factor)
+		}
+	}
+	return result
+}
+
+// gfMultiply multiplies two elements of GF(2^8) modulo x^8 + x^4 + x^3 + x^2 + 1.
+func gfMultiply(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int(y>>i&1) * int(x)
+	}
+	return byte(z)
+}
+
+// bitBuffer is a sequence of bits, most significant first.
+type bitBuffer []bool
+
+func (me *bitBuffer) append(value, length int) {
+	for i := length - 1; i >= 0; i-- {
+		*me = append(*me, bit(value, i))
+	}
+}
+
+func (me bitBuffer) bytes() []byte {
+	result := make([]byte, len(me)/8)
+	for i, b := range me {
+		if b {
+			result[i>>3] |= 1 << (7 - i&7)
+		}
+	}
+	return result
+}
+
+func bit(value, i int) bool {
+	return value>>i&1 != 0
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,75 @@
+package qrcode
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestNumDataCodewords(t *testing.T) {
+	tests := []struct {
+		version  int
+		expected [4]int
+	}{
+		{1, [4]int{19, 16, 13, 9}},
+		{2, [4]int{34, 28, 22, 16}},
+		{5, [4]int{108, 86, 62, 46}},
+		{7, [4]int{156, 124, 88, 66}},
+		{10, [4]int{274, 216, 154, 122}},
+		{40, [4]int{2956, 2334, 1666, 1276}},
+	}
+
+	for _, tt := range tests {
+		for level, want := range tt.expected {
+			if got := numDataCodewords(tt.version, Level(level)); got != want {
+				t.Errorf("numDataCodewords(%d, %d) = %d, want %d", tt.version, level, got, want)
+			}
+		}
+	}
+}
+
+func TestReedSolomonRemainder(t *testing.T) {
+	// "HELLO WORLD" as version 1-M, from the worked example at thonky.com.
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	expected := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+
+	if got := reedSolomonRemainder(data, reedSolomonDivisor(len(expected))); !bytes.Equal(got, expected) {
+		t.Errorf("reedSolomonRemainder() = %v, want %v", got, expected)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name    string
+		size    int
+		level   Level
+		version int
+	}{
+		{"Short text", 11, LevelM, 1},
+		{"Fills version 1-L", 17, LevelL, 1},
+		{"Needs version 2", 18, LevelL, 2},
+		{"Version info block", 200, LevelH, 15},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := bytes.Repeat([]byte{'a'}, tt.size)
+			code, err := Encode(data, tt.level)
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if code.Version != tt.version || code.Size != tt.version*4+17 {
+				t.Errorf("Encode() version = %d size = %d, want version %d", code.Version, code.Size, tt.version)
+			}
+			// Finder pattern corners and the always-dark module.
+			for _, xy := range [][2]int{{0, 0}, {code.Size - 1, 0}, {0, code.Size - 1}, {8, code.Size - 8}} {
+				if !code.Dark(xy[0], xy[1]) {
+					t.Errorf("module %v should be dark", xy)
+				}
+			}
+		})
+	}
+
+	if _, err := Encode(make([]byte, 3000), LevelL); err != ErrDataTooLong {
+		t.Errorf("Encode() error = %v, want %v", err, ErrDataTooLong)
+	}
+}
@@ -0,0 +1,82 @@
+package websocket
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestEcho(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := Upgrade(w, r)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			conn.WriteMessage(message)
+		}
+	}))
+	defer server.Close()
+
+	conn, err := Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	for _, size := range []int{0, 5, 125, 126, 300, 70_000} {
+		message := bytes.Repeat([]byte("x"), size)
+		if err := conn.WriteMessage(message); err != nil {
+			t.Fatal(err)
+		}
+		echo, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(echo, message) {
+			t.Errorf("size %d: got %d bytes back", size, len(echo))
+		}
+	}
+
+	// A ping is answered while waiting for a message.
+	if err := conn.writeFrame(opPing, []byte("hi")); err != nil {
+		t.Fatal(err)
+	}
+	_, opcode, payload, err := conn.readFrame()
+	if err != nil || opcode != opPong || string(payload) != "hi" {
+		t.Errorf("expected a pong, got %x %q %v", opcode, payload, err)
+	}
+
+	if err := conn.writeFrame(opClose, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := conn.ReadMessage(); !errors.Is(err, ErrClosed) {
+		t.Errorf("expected the close to be echoed, got %v", err)
+	}
+}
+
+func TestUpgradeRejects(t *testing.T) {
+	w := httptest.NewRecorder()
+	if _, err := Upgrade(w, httptest.NewRequest(http.MethodGet, "/", nil)); err == nil || w.Code != http.StatusUpgradeRequired {
+		t.Errorf("expected plain requests to be rejected, got %d %v", w.Code, err)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+	r.Header.Set("Connection", "Upgrade")
+	r.Header.Set("Upgrade", "websocket")
+	r.Header.Set("Sec-WebSocket-Version", "13")
+	r.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+	r.Header.Set("Origin", "https://evil.example")
+	w = httptest.NewRecorder()
+	if _, err := Upgrade(w, r); err == nil || w.Code != http.StatusForbidden {
+		t.Errorf("expected cross-origin requests to be rejected, got %d %v", w.Code, err)
+	}
+}
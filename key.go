@@ -0,0 +1,47 @@
+package h
+
+import (
+	"bytes"
+	"io"
+)
+
+// KeyedNode gives a node a key identifying it among its siblings across
+// renders, like keys of list items in React. It renders the wrapped node
+// unchanged; [Diff] uses keys to match list items that moved, were inserted
+// or were removed, instead of matching them by position.
+type KeyedNode struct {
+	Key  string
+	Node HyperNode
+}
+
+// Key wraps node in a [KeyedNode]. Keys must be unique among siblings.
+//
+// Example:
+//
+//	UL(AttrID("todos"))(Range(todos, func(todo Todo) HyperNode {
+//		return Key(todo.ID, LI(AttrID("todo-"+todo.ID))(todo.Title))
+//	}))
+func Key(key string, node HyperNode) KeyedNode {
+	return KeyedNode{Key: key, Node: node}
+}
+
+func (me KeyedNode) Render(w io.Writer) error {
+	return me.Node.Render(w)
+}
+
+// RenderToBuffer implements [BufferRenderer].
+func (me KeyedNode) RenderToBuffer(buf *bytes.Buffer) error {
+	if node, ok := me.Node.(BufferRenderer); ok {
+		return node.RenderToBuffer(buf)
+	}
+	return me.Node.Render(buf)
+}
+
+// Unwrap implements [Wrapper].
+func (me KeyedNode) Unwrap() []HyperNode {
+	return []HyperNode{me.Node}
+}
+
+func (me KeyedNode) mapNodes(fn func(HyperNode) HyperNode) HyperNode {
+	return Key(me.Key, fn(me.Node))
+}
@@ -0,0 +1,72 @@
+package h
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDiffKeyed(t *testing.T) {
+	list := func(items ...string) HyperNode {
+		return UL(AttrID("todos"))(Range(items, func(item string) HyperNode {
+			return Key(item, LI(AttrID("todo-"+item))(item))
+		}))
+	}
+
+	tests := []struct {
+		name     string
+		old, new HyperNode
+		expected string
+	}{
+		{
+			name:     "Insert in the middle",
+			old:      list("a", "c"),
+			new:      list("a", "b", "c"),
+			expected: `<template hx-swap-oob="afterend:#todo-a"><li id="todo-b">b</li></template>`,
+		},
+		{
+			name:     "Insert first",
+			old:      list("b"),
+			new:      list("a", "b"),
+			expected: `<template hx-swap-oob="afterbegin:#todos"><li id="todo-a">a</li></template>`,
+		},
+		{
+			name:     "Remove",
+			old:      list("a", "b", "c"),
+			new:      list("a", "c"),
+			expected: `<li id="todo-b" hx-swap-oob="delete"></li>`,
+		},
+		{
+			name:     "Reorder replaces the list",
+			old:      list("a", "b"),
+			new:      list("b", "a"),
+			expected: `<ul id="todos" hx-swap-oob="true"><li id="todo-b">b</li><li id="todo-a">a</li></ul>`,
+		},
+		{
+			name: "Changed item",
+			old: UL(AttrID("todos"))(
+				Key("a", LI(AttrID("todo-a"))("a")),
+				Key("b", LI(AttrID("todo-b"))("b")),
+			),
+			new: UL(AttrID("todos"))(
+				Key("b", LI(AttrID("todo-b"))("B")),
+			),
+			expected: `<li id="todo-a" hx-swap-oob="delete"></li><li id="todo-b" hx-swap-oob="true">B</li>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fragments, err := Diff(tt.old, tt.new)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var buf bytes.Buffer
+			for _, fragment := range fragments {
+				fragment.Render(&buf)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, buf.String())
+			}
+		})
+	}
+}
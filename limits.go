@@ -0,0 +1,123 @@
+package h
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// Limits caps the size of rendered trees, so endpoints rendering
+// user-controlled collections can't be used to exhaust memory. Zero fields
+// mean no limit.
+type Limits struct {
+	MaxAttributes  int // Maximum number of attributes per element
+	MaxChildren    int // Maximum number of children per element
+	MaxOutputBytes int // Maximum size of the output of a single Render call
+}
+
+// ErrLimitExceeded is matched by the [LimitError] returned when rendering
+// exceeds the configured [Limits].
+var ErrLimitExceeded = errors.New("h: render limit exceeded")
+
+// LimitError reports which limit a render exceeded, and where.
+type LimitError struct {
+	Limit string // "attributes", "children" or "output bytes"
+	Tag   string // Tag of the offending element
+	Size  int
+	Max   int
+}
+
+func (me LimitError) Error() string {
+	element := IfElse(me.Tag != "", "<"+me.Tag+">", "group")
+	if me.Limit == "output bytes" {
+		return fmt.Sprintf("h: output reached %d bytes while rendering %s, limit is %d", me.Size, element, me.Max)
+	}
+	return fmt.Sprintf("h: %s has %d %s, limit is %d", element, me.Size, me.Limit, me.Max)
+}
+
+func (me LimitError) Is(target error) bool {
+	return target == ErrLimitExceeded
+}
+
+var limits atomic.Pointer[Limits]
+
+// SetLimits sets the limits enforced by all renders in the process. Limits
+// are checked as the tree is rendered, so an oversized page fails early
+// with a [LimitError] instead of being buffered whole.
+//
+// Nodes rendering through their own io.Writer-based Render method (custom
+// nodes not implementing [BufferRenderer]) count towards the output of their
+// parent element once written.
+//
+// Example:
+//
+//	func init() {
+//		SetLimits(Limits{MaxChildren: 10_000, MaxOutputBytes: 8 << 20})
+//	}
+func SetLimits(l Limits) {
+	if l == (Limits{}) {
+		limits.Store(nil)
+		return
+	}
+	limits.Store(&l)
+}
+
+// CurrentLimits returns the limits set with [SetLimits].
+func CurrentLimits() Limits {
+	if l := limits.Load(); l != nil {
+		return *l
+	}
+	return Limits{}
+}
+
+// checkElementLimits checks the attribute and child counts of element.
+func checkElementLimits(l *Limits, element Element) error {
+	if l.MaxAttributes > 0 {
+		if n := countAttributes(element.Attributes); n > l.MaxAttributes {
+			return LimitError{Limit: "attributes", Tag: element.Tag, Size: n, Max: l.MaxAttributes}
+		}
+	}
+	if l.MaxChildren > 0 {
+		if n := countChildren(element.Children); n > l.MaxChildren {
+			return LimitError{Limit: "children", Tag: element.Tag, Size: n, Max: l.MaxChildren}
+		}
+	}
+	return nil
+}
+
+// countAttributes returns the number of attributes rendered by attrs,
+// counting the content of [Attributes] groups rather than the groups.
+func countAttributes(attrs []Attribute) int {
+	n := 0
+	for _, attr := range attrs {
+		if group, ok := attr.(Attributes); ok {
+			n += countAttributes(group)
+		} else {
+			n++
+		}
+	}
+	return n
+}
+
+// countChildren returns the number of children rendered by children,
+// counting the content of groups rather than the groups.
+func countChildren(children []HyperNode) int {
+	n := 0
+	for _, child := range children {
+		if group, ok := child.(Element); ok && group.Tag == "" {
+			n += countChildren(group.Children)
+		} else {
+			n++
+		}
+	}
+	return n
+}
+
+// checkOutputLimit checks the size of the output rendered so far.
+func checkOutputLimit(l *Limits, tag string, buf *bytes.Buffer) error {
+	if l.MaxOutputBytes > 0 && buf.Len() > l.MaxOutputBytes {
+		return LimitError{Limit: "output bytes", Tag: tag, Size: buf.Len(), Max: l.MaxOutputBytes}
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package h
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLimits(t *testing.T) {
+	defer SetLimits(Limits{})
+
+	items := func(n int) []any {
+		children := make([]any, n)
+		for i := range children {
+			children[i] = LI()("item")
+		}
+		return children
+	}
+
+	tests := []struct {
+		name     string
+		limits   Limits
+		node     HyperNode
+		expected string
+	}{
+		{
+			name:   "Within limits",
+			limits: Limits{MaxAttributes: 2, MaxChildren: 10, MaxOutputBytes: 1024},
+			node:   UL(AttrClass("list"))(items(10)...),
+		},
+		{
+			name:     "Too many children",
+			limits:   Limits{MaxChildren: 10},
+			node:     DIV()(UL()(items(11)...)),
+			expected: "h: <ul> has 11 children, limit is 10",
+		},
+		{
+			name:     "Too many attributes",
+			limits:   Limits{MaxAttributes: 1},
+			node:     INPUT(AttrType(TypeText), AttrName("q")),
+			expected: "h: <input> has 2 attributes, limit is 1",
+		},
+		{
+			name:     "Group children",
+			limits:   Limits{MaxChildren: 2},
+			node:     Group("a", "b", "c"),
+			expected: "h: group has 3 children, limit is 2",
+		},
+		{
+			name:     "Attribute groups",
+			limits:   Limits{MaxAttributes: 2},
+			node:     INPUT(AttrName("n"), MinMax(1, 5)),
+			expected: "h: <input> has 3 attributes, limit is 2",
+		},
+		{
+			name:     "Children in groups",
+			limits:   Limits{MaxChildren: 10},
+			node:     UL()(Group(items(6)...), Group(items(5)...)),
+			expected: "h: <ul> has 11 children, limit is 10",
+		},
+		{
+			name:     "Optimized children",
+			limits:   Limits{MaxChildren: 10},
+			node:     Optimize(DIV()(UL()(items(11)...))),
+			expected: "h: <ul> has 11 children, limit is 10",
+		},
+		{
+			name:     "Optimized attributes",
+			limits:   Limits{MaxAttributes: 1},
+			node:     Optimize(P()("a", INPUT(AttrType(TypeText), AttrName("q")))),
+			expected: "h: <input> has 2 attributes, limit is 1",
+		},
+		{
+			name:     "Optimized output",
+			limits:   Limits{MaxOutputBytes: 100},
+			node:     Optimize(UL()(items(100)...)),
+			expected: "h: output reached 1309 bytes while rendering <ul>, limit is 100",
+		},
+		{
+			name:     "Output too large",
+			limits:   Limits{MaxOutputBytes: 100},
+			node:     UL()(items(100)...),
+			expected: "h: output reached 103 bytes while rendering <li>, limit is 100",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetLimits(tt.limits)
+			err := Render(io.Discard, tt.node)
+			if tt.expected == "" {
+				if err != nil {
+					t.Errorf("unexpected error %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrLimitExceeded) || err.Error() != tt.expected {
+				t.Errorf("expected %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func BenchmarkLimits(b *testing.B) {
+	defer SetLimits(Limits{})
+	page := UL()(func() []any {
+		children := make([]any, 1000)
+		for i := range children {
+			children[i] = LI(AttrClass("item"))(strings.Repeat("x", 20))
+		}
+		return children
+	}()...)
+
+	b.Run("Unlimited", func(b *testing.B) {
+		SetLimits(Limits{})
+		for b.Loop() {
+			page.Render(io.Discard)
+		}
+	})
+	b.Run("Limited", func(b *testing.B) {
+		SetLimits(Limits{MaxAttributes: 64, MaxChildren: 10_000, MaxOutputBytes: 8 << 20})
+		for b.Loop() {
+			page.Render(io.Discard)
+		}
+	})
+}
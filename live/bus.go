@@ -0,0 +1,188 @@
+package live
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+// subscriptionBuffer is the number of fragments a subscriber can lag
+// behind before being dropped.
+const subscriptionBuffer = 16
+
+// Bus delivers fragments published on topics to the pages subscribed to
+// them, over server-sent events ([Bus.SSE]) or live view sessions
+// ([Session.Subscribe]): e.g. a new comment appended for all viewers of a
+// post. Publish fragments with hx-swap-oob attributes saying where they go.
+//
+// Example:
+//
+//	bus := live.NewBus()
+//	mux.Handle("GET /posts/{id}/events", bus.SSE(func(r *http.Request) []string {
+//		return []string{"post:" + r.PathValue("id")}
+//	}))
+//
+//	// After saving a comment:
+//	bus.Publish("post:"+postID, h.DIV(h.AttrID("comments"), h.Attr("hx-swap-oob", "beforeend"))(Comment(comment)))
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[*Subscription]struct{}
+}
+
+// NewBus creates a bus without subscribers.
+func NewBus() *Bus {
+	return &Bus{subscribers: map[string]map[*Subscription]struct{}{}}
+}
+
+// Subscription receives the fragments published on a topic, rendered.
+type Subscription struct {
+	// C receives the fragments. It is closed when the subscription is
+	// closed, or when the subscriber lags too far behind.
+	C <-chan string
+
+	c     chan string
+	bus   *Bus
+	topic string
+	once  sync.Once
+}
+
+// Subscribe subscribes to topic. Close the subscription when done.
+func (me *Bus) Subscribe(topic string) *Subscription {
+	c := make(chan string, subscriptionBuffer)
+	subscription := &Subscription{C: c, c: c, bus: me, topic: topic}
+	me.mu.Lock()
+	if me.subscribers[topic] == nil {
+		me.subscribers[topic] = map[*Subscription]struct{}{}
+	}
+	me.subscribers[topic][subscription] = struct{}{}
+	me.mu.Unlock()
+	return subscription
+}
+
+// Close ends the subscription.
+func (me *Subscription) Close() {
+	me.bus.mu.Lock()
+	defer me.bus.mu.Unlock()
+	me.close()
+}
+
+// close ends the subscription; the bus lock must be held.
+func (me *Subscription) close() {
+	me.once.Do(func() {
+		delete(me.bus.subscribers[me.topic], me)
+		if len(me.bus.subscribers[me.topic]) == 0 {
+			delete(me.bus.subscribers, me.topic)
+		}
+		close(me.c)
+	})
+}
+
+// Publish renders node once and delivers it to the subscribers of topic.
+// Subscribers lagging too far behind are dropped rather than blocking the
+// publisher; SSE clients then reconnect.
+func (me *Bus) Publish(topic string, node h.HyperNode) error {
+	var fragment strings.Builder
+	if err := node.Render(&fragment); err != nil {
+		return err
+	}
+
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	for subscription := range me.subscribers[topic] {
+		select {
+		case subscription.c <- fragment.String():
+		default:
+			subscription.close()
+		}
+	}
+	return nil
+}
+
+// Subscribers returns the number of subscribers of topic.
+func (me *Bus) Subscribers(topic string) int {
+	me.mu.RLock()
+	defer me.mu.RUnlock()
+	return len(me.subscribers[topic])
+}
+
+// SSE returns a server-sent events endpoint streaming the fragments of the
+// topics returned by topics for each request, as "message" events. Connect
+// pages with the htmx sse extension:
+//
+//	h.DIV(h.Attr("hx-ext", "sse"), h.Attr("sse-connect", "/posts/42/events"), h.Attr("sse-swap", "message"))()
+func (me *Bus) SSE(topics func(r *http.Request) []string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		controller := http.NewResponseController(w)
+		fragments := make(chan string)
+		// dropped is closed when a subscription is dropped, ending the
+		// stream so that the client reconnects.
+		dropped := make(chan struct{})
+		var drop sync.Once
+		for _, topic := range topics(r) {
+			subscription := me.Subscribe(topic)
+			defer subscription.Close()
+			go func() {
+				for fragment := range subscription.C {
+					select {
+					case fragments <- fragment:
+					case <-r.Context().Done():
+						return
+					}
+				}
+				drop.Do(func() { close(dropped) })
+			}()
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.WriteHeader(http.StatusOK)
+		if err := controller.Flush(); err != nil {
+			return
+		}
+
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-dropped:
+				return
+			case fragment := <-fragments:
+				var event strings.Builder
+				event.WriteString("event: message\n")
+				for line := range strings.SplitSeq(fragment, "\n") {
+					fmt.Fprintf(&event, "data: %s\n", line)
+				}
+				event.WriteString("\n")
+				if _, err := w.Write([]byte(event.String())); err != nil {
+					return
+				}
+				controller.Flush()
+			}
+		}
+	})
+}
+
+// Subscribe pushes the fragments published on topic to the page of the
+// session, until the session ends.
+func (me *Session) Subscribe(bus *Bus, topic string) {
+	subscription := bus.Subscribe(topic)
+	go func() {
+		defer subscription.Close()
+		for {
+			select {
+			case <-me.ctx.Done():
+				return
+			case fragment, ok := <-subscription.C:
+				if !ok {
+					return
+				}
+				if err := me.conn.WriteMessage([]byte(fragment)); err != nil {
+					return
+				}
+			}
+		}
+	}()
+}
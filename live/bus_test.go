@@ -0,0 +1,119 @@
+package live
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	h "github.com/assaidy/hyper/v2"
+	"github.com/assaidy/hyper/v2/internal/websocket"
+)
+
+// waitSubscribers waits for topic to have n subscribers.
+func waitSubscribers(t *testing.T, bus *Bus, topic string, n int) {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); bus.Subscribers(topic) != n; {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d subscribers of %s, got %d", n, topic, bus.Subscribers(topic))
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestBusPublish(t *testing.T) {
+	bus := NewBus()
+	subscription := bus.Subscribe("post:1")
+	other := bus.Subscribe("post:2")
+	defer other.Close()
+
+	if err := bus.Publish("post:1", h.P()("new comment")); err != nil {
+		t.Fatal(err)
+	}
+	if fragment := <-subscription.C; fragment != "<p>new comment</p>" {
+		t.Errorf("unexpected fragment %q", fragment)
+	}
+	if len(other.C) != 0 {
+		t.Error("expected other topics not to receive the fragment")
+	}
+
+	// Lagging subscribers are dropped.
+	for range subscriptionBuffer + 1 {
+		bus.Publish("post:1", h.P()("spam"))
+	}
+	for range subscription.C {
+	}
+	if n := bus.Subscribers("post:1"); n != 0 {
+		t.Errorf("expected the lagging subscriber to be dropped, got %d subscribers", n)
+	}
+	subscription.Close()
+}
+
+func TestBusSSE(t *testing.T) {
+	bus := NewBus()
+	server := httptest.NewServer(bus.SSE(func(r *http.Request) []string {
+		return []string{r.URL.Query().Get("topic")}
+	}))
+	defer server.Close()
+
+	response, err := http.Get(server.URL + "?topic=feed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	if response.Header.Get("Content-Type") != "text/event-stream" {
+		t.Errorf("unexpected content type %q", response.Header.Get("Content-Type"))
+	}
+
+	waitSubscribers(t, bus, "feed", 1)
+	bus.Publish("feed", h.PRE()("a\nb"))
+
+	reader := bufio.NewReader(response.Body)
+	var lines []string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		if line == "\n" {
+			break
+		}
+		lines = append(lines, strings.TrimSuffix(line, "\n"))
+	}
+	expected := "event: message|data: <pre>a|data: b</pre>"
+	if got := strings.Join(lines, "|"); got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestSessionSubscribe(t *testing.T) {
+	bus := NewBus()
+	server := NewServer()
+	httpServer := httptest.NewServer(server.Handler(func(r *http.Request) (View, error) {
+		return &counter{}, nil
+	}))
+	defer httpServer.Close()
+
+	conn, err := websocket.Dial("ws"+strings.TrimPrefix(httpServer.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	waitSession(t, server).Subscribe(bus, "presence")
+	waitSubscribers(t, bus, "presence", 1)
+
+	bus.Publish("presence", h.SPAN(h.AttrID("online"), h.Attr("hx-swap-oob", "true"))("3 online"))
+	message, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `<span id="online" hx-swap-oob="true">3 online</span>`; string(message) != expected {
+		t.Errorf("expected %q, got %q", expected, message)
+	}
+
+	conn.Close()
+	waitSubscribers(t, bus, "presence", 0)
+}
@@ -0,0 +1,353 @@
+// Package live turns components into interactive views driven over a
+// WebSocket, in the style of Phoenix LiveView: the server holds the state
+// of each connected view, handles the events sent by the page through the
+// htmx ws extension, re-renders the view and pushes only what changed, as
+// out-of-band swaps computed by [h.Diff].
+//
+// A view is mounted twice: once to render the page, and once per WebSocket
+// connection, from the connection request. Both mounts must produce the
+// same tree, so derive the initial state from the URL passed to [Connect].
+//
+// Example:
+//
+//	type Counter struct{ Count int }
+//
+//	func (me *Counter) Node() h.HyperNode {
+//		return h.DIV(h.AttrID("counter"))(
+//			h.SPAN(h.AttrID("count"))(me.Count),
+//			h.BUTTON(h.AttrID("increment"), live.Send())("+1"),
+//		)
+//	}
+//
+//	func (me *Counter) HandleEvent(ctx context.Context, event live.Event) error {
+//		if event.Trigger == "increment" {
+//			me.Count++
+//		}
+//		return nil
+//	}
+//
+//	server := live.NewServer()
+//	mux.Handle("GET /live/counter", server.Handler(func(r *http.Request) (live.View, error) {
+//		return &Counter{}, nil
+//	}))
+//	// In the page, with htmx and its ws extension loaded:
+//	h.DIV(live.Connect("/live/counter"))((&Counter{}).Node())
+package live
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+
+	h "github.com/assaidy/hyper/v2"
+	"github.com/assaidy/hyper/v2/internal/websocket"
+)
+
+// View is a component with server-side state, updated by the events of the
+// page.
+type View interface {
+	// Node renders the current state. Its root must be an element with an
+	// id, replaced whole when it changes; give the elements that change an
+	// id too, so updates replace them alone (see [h.Diff]).
+	Node() h.HyperNode
+	// HandleEvent updates the state for an event of the page.
+	HandleEvent(ctx context.Context, event Event) error
+}
+
+// Event is a message sent by the page: an element with ws-send was
+// triggered, e.g. a button clicked or a form submitted.
+type Event struct {
+	Trigger     string     // Id of the triggering element
+	TriggerName string     // Name of the triggering element
+	Target      string     // Id of the target element, if any
+	Values      url.Values // Values of the form, or of the element
+}
+
+// Connect returns the attributes connecting the element to the live view
+// endpoint at path. The element should wrap the view's node.
+func Connect(path string) h.Attributes {
+	return h.Attributes{h.Attr("hx-ext", "ws"), h.Attr("ws-connect", path)}
+}
+
+// ErrNoRootID is returned when the tree of a view isn't an element with an
+// id, which updates need to target the view.
+var ErrNoRootID = errors.New("live: the root of the view is not an element with an id")
+
+// Send returns the attribute making an element send an [Event] when
+// triggered (on submit for forms, on click otherwise; see hx-trigger). The
+// element needs an id or a name, which identifies it in the event.
+func Send() h.Attribute {
+	return h.Attr("ws-send", true)
+}
+
+// Server manages the sessions of live views.
+type Server struct {
+	// Logger receives the errors of views; defaults to slog.Default().
+	Logger *slog.Logger
+
+	mu       sync.RWMutex
+	sessions map[string]*Session
+}
+
+// NewServer creates a server without sessions.
+func NewServer() *Server {
+	return &Server{sessions: map[string]*Session{}}
+}
+
+// Handler returns the WebSocket endpoint of a view, mounting a new view
+// with mount for each connection.
+func (me *Server) Handler(mount func(r *http.Request) (View, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		view, err := mount(r)
+		if err != nil {
+			me.logger().ErrorContext(r.Context(), "live: mount failed", "path", r.URL.Path, "error", err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		node := view.Node()
+		if _, ok := rootElement(node); !ok {
+			me.logger().ErrorContext(r.Context(), "live: mount failed", "path", r.URL.Path, "error", ErrNoRootID)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		conn, err := websocket.Upgrade(w, r)
+		if err != nil {
+			return
+		}
+
+		ctx, cancel := context.WithCancel(r.Context())
+		session := &Session{
+			ID:     newSessionID(),
+			ctx:    ctx,
+			cancel: cancel,
+			conn:   conn,
+			view:   view,
+			last:   node,
+		}
+		me.mu.Lock()
+		me.sessions[session.ID] = session
+		me.mu.Unlock()
+		defer func() {
+			me.mu.Lock()
+			delete(me.sessions, session.ID)
+			me.mu.Unlock()
+			session.Close()
+		}()
+
+		for {
+			message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			event, err := parseEvent(message)
+			if err != nil {
+				me.logger().WarnContext(ctx, "live: invalid event", "session", session.ID, "error", err)
+				continue
+			}
+			err = session.Update(func(view View) error {
+				return view.HandleEvent(ctx, event)
+			})
+			if err != nil {
+				me.logger().ErrorContext(ctx, "live: event failed", "session", session.ID, "trigger", event.Trigger, "error", err)
+			}
+		}
+	})
+}
+
+func (me *Server) logger() *slog.Logger {
+	if me.Logger != nil {
+		return me.Logger
+	}
+	return slog.Default()
+}
+
+// Sessions returns the connected sessions, sorted by id.
+func (me *Server) Sessions() []*Session {
+	me.mu.RLock()
+	defer me.mu.RUnlock()
+	sessions := make([]*Session, 0, len(me.sessions))
+	for _, session := range me.sessions {
+		sessions = append(sessions, session)
+	}
+	slices.SortFunc(sessions, func(a, b *Session) int { return strings.Compare(a.ID, b.ID) })
+	return sessions
+}
+
+// Session returns the connected session with id.
+func (me *Server) Session(id string) (*Session, bool) {
+	me.mu.RLock()
+	defer me.mu.RUnlock()
+	session, ok := me.sessions[id]
+	return session, ok
+}
+
+// Session is a view connected to a page.
+type Session struct {
+	ID string
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	conn   *websocket.Conn
+
+	mu   sync.Mutex // Guards view and last
+	view View
+	last h.HyperNode
+}
+
+// Context returns the context of the session, canceled when it ends.
+func (me *Session) Context() context.Context {
+	return me.ctx
+}
+
+// Update calls fn with the view, then pushes the changes of its tree to the
+// page. Use it to update a view from outside its events, e.g. when a
+// background job completes. Calls are serialized with event handling.
+func (me *Session) Update(fn func(View) error) error {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	if err := fn(me.view); err != nil {
+		return err
+	}
+
+	node := me.view.Node()
+	fragments, err := h.Diff(me.last, node)
+	if errors.Is(err, h.ErrNoDiffTarget) {
+		fragments, err = replaceRoot(me.last, node)
+	}
+	if err != nil {
+		return err
+	}
+	me.last = node
+	nodes := make([]h.HyperNode, len(fragments))
+	for i, fragment := range fragments {
+		nodes[i] = fragment
+	}
+	return me.Push(nodes...)
+}
+
+// Push sends nodes to the page as they are, rendered with the context of
+// the session; give them hx-swap-oob attributes to say where they go.
+func (me *Session) Push(nodes ...h.HyperNode) error {
+	if len(nodes) == 0 {
+		return nil
+	}
+	var message strings.Builder
+	for _, node := range nodes {
+		if err := h.RenderCtx(me.ctx, &message, node); err != nil {
+			return err
+		}
+	}
+	return me.conn.WriteMessage([]byte(message.String()))
+}
+
+// replaceRoot returns the fragment replacing the root element of old by the
+// one of new, for changes [h.Diff] can't target, such as a new root id.
+func replaceRoot(old, new h.HyperNode) ([]h.Element, error) {
+	oldRoot, ok := rootElement(old)
+	if !ok {
+		return nil, ErrNoRootID
+	}
+	root, ok := rootElement(new)
+	if !ok {
+		return nil, ErrNoRootID
+	}
+	oldID, _ := oldRoot.Attribute("id")
+	root.Attributes = append(slices.Clone(root.Attributes), h.Attr("hx-swap-oob", "outerHTML:#"+oldID))
+	return []h.Element{root}, nil
+}
+
+// rootElement returns the root element of a view's tree, looking through
+// groups and wrappers of a single node, if it has an id.
+func rootElement(node h.HyperNode) (h.Element, bool) {
+	for {
+		var children []h.HyperNode
+		switch n := node.(type) {
+		case h.Element:
+			if n.Tag != "" {
+				id, _ := n.Attribute("id")
+				return n, id != ""
+			}
+			children = n.Children
+		case h.Wrapper:
+			children = n.Unwrap()
+		}
+		if len(children) != 1 {
+			return h.Element{}, false
+		}
+		node = children[0]
+	}
+}
+
+// Close ends the session, closing its connection.
+func (me *Session) Close() error {
+	me.cancel()
+	return me.conn.Close()
+}
+
+func newSessionID() string {
+	b := make([]byte, 12)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// parseEvent parses a message of the htmx ws extension: the values of the
+// triggering form or element, with the request headers under "HEADERS".
+func parseEvent(message []byte) (Event, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(message, &fields); err != nil {
+		return Event{}, err
+	}
+
+	var event Event
+	if raw, ok := fields["HEADERS"]; ok {
+		var headers map[string]*string
+		if err := json.Unmarshal(raw, &headers); err != nil {
+			return Event{}, err
+		}
+		value := func(key string) string {
+			if v := headers[key]; v != nil {
+				return *v
+			}
+			return ""
+		}
+		event.Trigger = value("HX-Trigger")
+		event.TriggerName = value("HX-Trigger-Name")
+		event.Target = value("HX-Target")
+		delete(fields, "HEADERS")
+	}
+
+	event.Values = url.Values{}
+	for key, raw := range fields {
+		var values []any
+		if err := json.Unmarshal(raw, &values); err != nil {
+			var value any
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return Event{}, err
+			}
+			values = []any{value}
+		}
+		for _, value := range values {
+			switch v := value.(type) {
+			case string:
+				event.Values.Add(key, v)
+			case nil:
+				event.Values.Add(key, "")
+			default:
+				b, _ := json.Marshal(v)
+				event.Values.Add(key, string(b))
+			}
+		}
+	}
+	if event.Trigger == "" && event.TriggerName == "" {
+		return Event{}, errors.New("missing HX-Trigger header")
+	}
+	return event, nil
+}
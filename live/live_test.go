@@ -0,0 +1,189 @@
+package live
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	h "github.com/assaidy/hyper/v2"
+	"github.com/assaidy/hyper/v2/internal/websocket"
+)
+
+type counter struct {
+	Count int
+	Step  string
+}
+
+func (me *counter) Node() h.HyperNode {
+	return h.DIV(h.AttrID("counter"))(
+		h.SPAN(h.AttrID("count"))(me.Count),
+		h.BUTTON(h.AttrID("increment"), Send())("+1"),
+	)
+}
+
+func (me *counter) HandleEvent(ctx context.Context, event Event) error {
+	if event.Trigger == "increment" {
+		me.Count++
+		me.Step = event.Values.Get("step")
+	}
+	return nil
+}
+
+func TestServer(t *testing.T) {
+	server := NewServer()
+	httpServer := httptest.NewServer(server.Handler(func(r *http.Request) (View, error) {
+		return &counter{}, nil
+	}))
+	defer httpServer.Close()
+
+	conn, err := websocket.Dial("ws"+strings.TrimPrefix(httpServer.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	send := `{"step":"1","HEADERS":{"HX-Request":"true","HX-Trigger":"increment","HX-Trigger-Name":null,"HX-Target":"counter"}}`
+	if err := conn.WriteMessage([]byte(send)); err != nil {
+		t.Fatal(err)
+	}
+	message, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `<span id="count" hx-swap-oob="true">1</span>`; string(message) != expected {
+		t.Errorf("expected %q, got %q", expected, message)
+	}
+
+	// Sessions can be updated from outside their events.
+	err = waitSession(t, server).Update(func(view View) error {
+		view.(*counter).Count = 10
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if message, err = conn.ReadMessage(); err != nil || !strings.Contains(string(message), ">10</span>") {
+		t.Errorf("expected the pushed update, got %q %v", message, err)
+	}
+}
+
+// toggle is a view whose root changes id.
+type toggle struct{ On bool }
+
+func (me *toggle) Node() h.HyperNode {
+	return h.DIV(h.AttrID(h.IfElse(me.On, "on", "off")))(h.IfElse(me.On, "On", "Off"))
+}
+
+func (me *toggle) HandleEvent(ctx context.Context, event Event) error {
+	me.On = !me.On
+	return nil
+}
+
+func TestServerReplacesRoot(t *testing.T) {
+	server := NewServer()
+	httpServer := httptest.NewServer(server.Handler(func(r *http.Request) (View, error) {
+		return &toggle{}, nil
+	}))
+	defer httpServer.Close()
+
+	conn, err := websocket.Dial("ws"+strings.TrimPrefix(httpServer.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	for _, expected := range []string{
+		`<div id="on" hx-swap-oob="outerHTML:#off">On</div>`,
+		`<div id="off" hx-swap-oob="outerHTML:#on">Off</div>`,
+	} {
+		if err := conn.WriteMessage([]byte(`{"HEADERS":{"HX-Trigger":"toggle"}}`)); err != nil {
+			t.Fatal(err)
+		}
+		message, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(message) != expected {
+			t.Errorf("expected %q, got %q", expected, message)
+		}
+	}
+}
+
+// grouped is a view without a root element.
+type grouped struct{}
+
+func (me grouped) Node() h.HyperNode {
+	return h.Group(h.SPAN(h.AttrID("a"))("a"), h.SPAN(h.AttrID("b"))("b"))
+}
+
+func (me grouped) HandleEvent(ctx context.Context, event Event) error { return nil }
+
+func TestServerRequiresRootID(t *testing.T) {
+	server := NewServer()
+	httpServer := httptest.NewServer(server.Handler(func(r *http.Request) (View, error) {
+		return grouped{}, nil
+	}))
+	defer httpServer.Close()
+
+	if conn, err := websocket.Dial("ws"+strings.TrimPrefix(httpServer.URL, "http"), nil); err == nil {
+		conn.Close()
+		t.Error("expected views without a root id to be rejected")
+	}
+}
+
+func TestSessionPushContext(t *testing.T) {
+	server := NewServer()
+	httpServer := httptest.NewServer(server.Handler(func(r *http.Request) (View, error) {
+		return &counter{}, nil
+	}))
+	defer httpServer.Close()
+
+	conn, err := websocket.Dial("ws"+strings.TrimPrefix(httpServer.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	session := waitSession(t, server)
+	err = session.Push(h.CtxFunc(func(ctx context.Context) h.HyperNode {
+		return h.Text(h.IfElse(ctx == session.Context(), "session", "other"))
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if message, err := conn.ReadMessage(); err != nil || string(message) != "session" {
+		t.Errorf("expected nodes rendered with the session context, got %q %v", message, err)
+	}
+}
+
+// waitSession waits for the server to have a session, and returns it.
+func waitSession(t *testing.T, server *Server) *Session {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); ; time.Sleep(time.Millisecond) {
+		if sessions := server.Sessions(); len(sessions) != 0 {
+			return sessions[0]
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected a session")
+		}
+	}
+}
+
+func TestParseEvent(t *testing.T) {
+	event, err := parseEvent([]byte(`{"tags":["a","b"],"qty":3,"HEADERS":{"HX-Trigger":"form","HX-Trigger-Name":"save"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.Trigger != "form" || event.TriggerName != "save" {
+		t.Errorf("unexpected trigger: %+v", event)
+	}
+	if tags := event.Values["tags"]; len(tags) != 2 || event.Values.Get("qty") != "3" {
+		t.Errorf("unexpected values: %v", event.Values)
+	}
+	if _, err := parseEvent([]byte(`{"HEADERS":{}}`)); err == nil {
+		t.Error("expected events without a trigger to be rejected")
+	}
+}
@@ -0,0 +1,238 @@
+package live
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+// Class names of the presence components.
+const (
+	ClassPresence = "presence"
+	ClassTyping   = "typing"
+)
+
+// Member is a user present on a topic, e.g. the viewers of a document.
+type Member struct {
+	ID     string
+	Name   string
+	Avatar string // Image URL or email address, see [h.Avatar]
+}
+
+// Presence tracks who is on each topic, and who is typing, and publishes
+// the [PresenceRow] and [TypingIndicator] of a topic on a [Bus] when they
+// change, as out-of-band swaps.
+//
+// Example:
+//
+//	presence := live.NewPresence(bus)
+//
+//	// When mounting the view of a chat room:
+//	session.Join(presence, "room:"+roomID, live.Member{ID: user.ID, Name: user.Name, Avatar: user.Email})
+//
+//	// In the page:
+//	presence.Node("room:" + roomID)
+//
+//	// In HandleEvent, for the message input (hx-trigger="keyup changed delay:300ms"):
+//	presence.Typing("room:"+roomID, user.ID)
+type Presence struct {
+	// TypingTimeout is how long a member shows as typing after their last
+	// call to Typing; defaults to 5 seconds.
+	TypingTimeout time.Duration
+
+	bus   *Bus
+	mu    sync.Mutex
+	rooms map[string]*room
+}
+
+type room struct {
+	members []Member       // In join order
+	joins   map[string]int // Connections by member id, for members with several tabs
+	typing  map[string]*time.Timer
+}
+
+// NewPresence creates a tracker publishing on bus.
+func NewPresence(bus *Bus) *Presence {
+	return &Presence{bus: bus, rooms: map[string]*room{}}
+}
+
+// Join adds member to topic until leave is called. A member joining from
+// several connections stays present until all of them leave.
+func (me *Presence) Join(topic string, member Member) (leave func()) {
+	me.mu.Lock()
+	r := me.rooms[topic]
+	if r == nil {
+		r = &room{joins: map[string]int{}, typing: map[string]*time.Timer{}}
+		me.rooms[topic] = r
+	}
+	r.joins[member.ID]++
+	if r.joins[member.ID] == 1 {
+		r.members = append(r.members, member)
+	}
+	me.mu.Unlock()
+	me.publishMembers(topic)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { me.leave(topic, member.ID) })
+	}
+}
+
+func (me *Presence) leave(topic, memberID string) {
+	me.mu.Lock()
+	r := me.rooms[topic]
+	r.joins[memberID]--
+	if r.joins[memberID] > 0 {
+		me.mu.Unlock()
+		return
+	}
+	delete(r.joins, memberID)
+	r.members = slices.DeleteFunc(r.members, func(m Member) bool { return m.ID == memberID })
+	if timer := r.typing[memberID]; timer != nil {
+		timer.Stop()
+		delete(r.typing, memberID)
+	}
+	if len(r.members) == 0 {
+		delete(me.rooms, topic)
+	}
+	me.mu.Unlock()
+	me.publishMembers(topic)
+	me.publishTyping(topic)
+}
+
+// Typing marks the member with memberID as typing on topic, until
+// TypingTimeout passes without another call.
+func (me *Presence) Typing(topic, memberID string) {
+	me.mu.Lock()
+	r := me.rooms[topic]
+	if r == nil || r.joins[memberID] == 0 {
+		me.mu.Unlock()
+		return
+	}
+	timeout := h.IfElse(me.TypingTimeout > 0, me.TypingTimeout, 5*time.Second)
+	if timer := r.typing[memberID]; timer != nil && timer.Stop() {
+		timer.Reset(timeout)
+		me.mu.Unlock()
+		return
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(timeout, func() {
+		me.mu.Lock()
+		expired := r.typing[memberID] == timer
+		if expired {
+			delete(r.typing, memberID)
+		}
+		me.mu.Unlock()
+		if expired {
+			me.publishTyping(topic)
+		}
+	})
+	r.typing[memberID] = timer
+	me.mu.Unlock()
+	me.publishTyping(topic)
+}
+
+// Members returns the members present on topic, in join order.
+func (me *Presence) Members(topic string) []Member {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	if r := me.rooms[topic]; r != nil {
+		return slices.Clone(r.members)
+	}
+	return nil
+}
+
+// TypingNames returns the names of the members typing on topic, in join
+// order.
+func (me *Presence) TypingNames(topic string) []string {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	r := me.rooms[topic]
+	if r == nil {
+		return nil
+	}
+	var names []string
+	for _, member := range r.members {
+		if r.typing[member.ID] != nil {
+			names = append(names, member.Name)
+		}
+	}
+	return names
+}
+
+// Node renders the presence row and typing indicator of topic, for the
+// initial page.
+func (me *Presence) Node(topic string) h.HyperNode {
+	return h.Group(PresenceRow(topic, me.Members(topic)), TypingIndicator(topic, me.TypingNames(topic)))
+}
+
+func (me *Presence) publishMembers(topic string) {
+	me.bus.Publish(topic, oob(PresenceRow(topic, me.Members(topic))))
+}
+
+func (me *Presence) publishTyping(topic string) {
+	me.bus.Publish(topic, oob(TypingIndicator(topic, me.TypingNames(topic))))
+}
+
+// oob marks element for an out-of-band swap.
+func oob(element h.Element) h.Element {
+	element.Attributes = append(element.Attributes, h.Attr("hx-swap-oob", "true"))
+	return element
+}
+
+// topicID returns an element id derived from topic.
+func topicID(prefix, topic string) string {
+	return prefix + "-" + strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' {
+			return r
+		}
+		return '-'
+	}, topic)
+}
+
+// PresenceRow renders the avatars of the members present on topic.
+func PresenceRow(topic string, members []Member) h.Element {
+	row := h.UL(
+		h.AttrID(topicID(ClassPresence, topic)),
+		h.AttrClass(ClassPresence),
+		h.AttrAriaLabel(fmt.Sprintf("%d online", len(members))),
+	)
+	return row(h.Range(members, func(member Member) h.HyperNode {
+		return h.LI(h.AttrTitle(member.Name))(h.Avatar(member.Avatar, 32, member.Name))
+	}))
+}
+
+// TypingIndicator renders who is typing on topic, e.g. "Ada and Grace are
+// typing…", in a polite live region.
+func TypingIndicator(topic string, names []string) h.Element {
+	var text string
+	switch len(names) {
+	case 0:
+	case 1:
+		text = names[0] + " is typing…"
+	case 2:
+		text = names[0] + " and " + names[1] + " are typing…"
+	default:
+		text = fmt.Sprintf("%d people are typing…", len(names))
+	}
+	return h.P(
+		h.AttrID(topicID(ClassTyping, topic)),
+		h.AttrClass(ClassTyping),
+		h.AttrAriaLive("polite"),
+	)(text)
+}
+
+// Join makes the member present on topic for the lifetime of the session,
+// and subscribes the session to the updates of topic.
+func (me *Session) Join(presence *Presence, topic string, member Member) {
+	me.Subscribe(presence.bus, topic)
+	leave := presence.Join(topic, member)
+	go func() {
+		<-me.ctx.Done()
+		leave()
+	}()
+}
@@ -0,0 +1,75 @@
+package live
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPresence(t *testing.T) {
+	bus := NewBus()
+	updates := bus.Subscribe("room:1")
+	defer updates.Close()
+	next := func() string {
+		select {
+		case fragment := <-updates.C:
+			return fragment
+		case <-time.After(time.Second):
+			t.Fatal("expected an update")
+			return ""
+		}
+	}
+
+	presence := NewPresence(bus)
+	presence.TypingTimeout = 200 * time.Millisecond
+
+	leaveAda := presence.Join("room:1", Member{ID: "1", Name: "Ada Lovelace"})
+	if fragment := next(); !strings.Contains(fragment, `<ul id="presence-room-1" class="presence" aria-label="1 online" hx-swap-oob="true">`) {
+		t.Errorf("unexpected presence update %q", fragment)
+	}
+	leaveAdaTab := presence.Join("room:1", Member{ID: "1", Name: "Ada Lovelace"})
+	next()
+	presence.Join("room:1", Member{ID: "2", Name: "Grace Hopper"})
+	if fragment := next(); !strings.Contains(fragment, `aria-label="2 online"`) || !strings.Contains(fragment, `title="Grace Hopper"`) {
+		t.Errorf("unexpected presence update %q", fragment)
+	}
+
+	presence.Typing("room:1", "1")
+	presence.Typing("room:1", "2")
+	next()
+	if fragment := next(); !strings.Contains(fragment, ">Ada Lovelace and Grace Hopper are typing…</p>") {
+		t.Errorf("unexpected typing update %q", fragment)
+	}
+
+	// Typing expires, one member at a time.
+	for fragment := next(); !strings.Contains(fragment, `<p id="typing-room-1" class="typing" aria-live="polite" hx-swap-oob="true"></p>`); fragment = next() {
+		if !strings.Contains(fragment, "is typing…</p>") {
+			t.Fatalf("expected typing to expire, got %q", fragment)
+		}
+	}
+
+	// Ada stays present until her last tab leaves.
+	leaveAda()
+	if members := presence.Members("room:1"); len(members) != 2 {
+		t.Errorf("expected Ada to stay present, got %v", members)
+	}
+	leaveAdaTab()
+	if members := presence.Members("room:1"); len(members) != 1 || members[0].ID != "2" {
+		t.Errorf("expected Ada to have left, got %v", members)
+	}
+}
+
+func TestTypingIndicator(t *testing.T) {
+	tests := map[int]string{
+		1: "Ada is typing…",
+		3: "3 people are typing…",
+	}
+	names := []string{"Ada", "Grace", "Linus"}
+	for n, expected := range tests {
+		var buf strings.Builder
+		TypingIndicator("t", names[:n]).Render(&buf)
+		if !strings.Contains(buf.String(), ">"+expected+"<") {
+			t.Errorf("%d typing: expected %q, got %q", n, expected, buf.String())
+		}
+	}
+}
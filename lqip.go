@@ -0,0 +1,128 @@
+package h
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"strconv"
+)
+
+// Class names used by [ProgressiveImage] and styled by
+// [ProgressiveImageAssets].
+const (
+	ClassProgressiveImage       = "lqip"
+	ClassProgressivePlaceholder = "lqip-placeholder"
+)
+
+// progressiveImageCSS blurs the placeholder under the image, which fades in
+// once loaded. Images are only hidden once the script has run, so they
+// still show without JavaScript.
+const progressiveImageCSS = `.lqip{position:relative;display:inline-block;overflow:hidden}` +
+	`.lqip-placeholder{position:absolute;inset:0;width:100%;height:100%;object-fit:cover;filter:blur(16px);transform:scale(1.1)}` +
+	`.lqip img[data-lqip]{position:relative;transition:opacity .4s}` +
+	`.lqip-js .lqip img[data-lqip]:not([data-loaded]){opacity:0}`
+
+// progressiveImageScript marks images as loaded, including those loaded
+// before it ran (cached ones).
+const progressiveImageScript = `document.documentElement.classList.add("lqip-js");` +
+	`document.addEventListener("load",function(e){var t=e.target;if(t.tagName==="IMG"&&t.hasAttribute("data-lqip"))t.setAttribute("data-loaded","")},true);` +
+	`document.addEventListener("DOMContentLoaded",function(){document.querySelectorAll("img[data-lqip]").forEach(function(i){if(i.complete)i.setAttribute("data-loaded","")})})`
+
+// ProgressiveImageParams configures [ProgressiveImage].
+type ProgressiveImageParams struct {
+	// Placeholder is the URL of a tiny version of the image, typically a
+	// data URI made with [PlaceholderDataURI], shown blurred while the
+	// image loads.
+	Placeholder string
+	// Width and Height are the intrinsic size of the image, reserving its
+	// space to avoid layout shifts.
+	Width, Height int
+	Attributes    []Attribute // Added to the <img>
+}
+
+// ProgressiveImage renders a lazy-loaded image over a blurred placeholder,
+// fading in once loaded (the LQIP technique). Include
+// [ProgressiveImageAssets] once in the page.
+//
+// Example:
+//
+//	ProgressiveImage(photo.URL, photo.Caption, ProgressiveImageParams{
+//		Placeholder: photo.Placeholder, // PlaceholderDataURI, computed on upload
+//		Width:       1600,
+//		Height:      900,
+//	})
+func ProgressiveImage(src, alt string, params ProgressiveImageParams) Element {
+	attrs := []Attribute{AttrSrc(src), AttrAlt(alt), AttrLoading(LoadingLazy), AttrDecoding(DecodingAsync), Attr("data-lqip", true)}
+	if params.Width > 0 && params.Height > 0 {
+		attrs = append(attrs, AttrWidth(strconv.Itoa(params.Width)), AttrHeight(strconv.Itoa(params.Height)))
+	}
+	attrs = append(attrs, params.Attributes...)
+
+	return SPAN(AttrClass(ClassProgressiveImage))(
+		If(params.Placeholder != "", IMG(
+			AttrSrc(params.Placeholder),
+			AttrAlt(""),
+			AttrAriaHidden("true"),
+			AttrClass(ClassProgressivePlaceholder),
+		)),
+		IMG(attrs...),
+	)
+}
+
+// ProgressiveImageAssets returns the <style> and <script> elements used by
+// [ProgressiveImage], to include once in the <head>. They carry the CSP
+// nonce of ctx (see [NonceKey]).
+func ProgressiveImageAssets(ctx context.Context) HyperNode {
+	var attrs []Attribute
+	if nonce := Nonce(ctx); nonce != "" {
+		attrs = append(attrs, AttrNonce(nonce))
+	}
+	return Group(
+		STYLE(attrs...)(RawText(progressiveImageCSS)),
+		SCRIPT(attrs...)(RawText(progressiveImageScript)),
+	)
+}
+
+// PlaceholderDataURI returns a PNG data URI of img scaled down to fit in
+// size×size pixels (16 when size <= 0), a few hundred bytes to use as the
+// placeholder of a [ProgressiveImage]. Compute it once, e.g. on upload.
+func PlaceholderDataURI(img image.Image, size int) (string, error) {
+	if size <= 0 {
+		size = 16
+	}
+	bounds := img.Bounds()
+	width, height := size, size
+	if bounds.Dx() > bounds.Dy() {
+		height = max(1, size*bounds.Dy()/bounds.Dx())
+	} else {
+		width = max(1, size*bounds.Dx()/bounds.Dy())
+	}
+
+	// Each pixel of the thumbnail averages the block of pixels it covers.
+	thumbnail := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*bounds.Dy()/height)
+		for x := range width {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := max(x0+1, bounds.Min.X+(x+1)*bounds.Dx()/width)
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					c := color.NRGBAModel.Convert(img.At(sx, sy)).(color.NRGBA)
+					r, g, b, a = r+uint64(c.R), g+uint64(c.G), b+uint64(c.B), a+uint64(c.A)
+					n++
+				}
+			}
+			thumbnail.SetNRGBA(x, y, color.NRGBA{R: uint8(r / n), G: uint8(g / n), B: uint8(b / n), A: uint8(a / n)})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, thumbnail); err != nil {
+		return "", err
+	}
+	return DataURI("image/png", buf.Bytes()), nil
+}
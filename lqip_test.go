@@ -0,0 +1,71 @@
+package h
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestProgressiveImage(t *testing.T) {
+	node := ProgressiveImage("/photo.jpg", "A photo", ProgressiveImageParams{
+		Placeholder: "data:image/png;base64,AAAA",
+		Width:       1600,
+		Height:      900,
+	})
+
+	var buf bytes.Buffer
+	if err := Render(&buf, node); err != nil {
+		t.Fatal(err)
+	}
+	expected := `<span class="lqip"><img src="data:image/png;base64,AAAA" alt="" aria-hidden="true" class="lqip-placeholder">` +
+		`<img src="/photo.jpg" alt="A photo" loading="lazy" decoding="async" data-lqip width="1600" height="900"></span>`
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+
+	buf.Reset()
+	ctx := WithValue(context.Background(), NonceKey, "abc")
+	if err := Render(&buf, ProgressiveImageAssets(ctx)); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(buf.String(), `nonce="abc"`) != 2 {
+		t.Errorf("expected style and script to carry the nonce, got %q", buf.String())
+	}
+}
+
+func TestPlaceholderDataURI(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 200, 100))
+	for y := range 100 {
+		for x := range 200 {
+			img.SetNRGBA(x, y, color.NRGBA{R: 255, A: 255})
+		}
+	}
+
+	uri, err := PlaceholderDataURI(img, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prefix := "data:image/png;base64,"
+	if !strings.HasPrefix(uri, prefix) {
+		t.Fatalf("expected a PNG data URI, got %q", uri)
+	}
+	if len(uri) > 512 {
+		t.Errorf("expected a tiny placeholder, got %d bytes", len(uri))
+	}
+
+	thumbnail, err := png.Decode(base64.NewDecoder(base64.StdEncoding, strings.NewReader(strings.TrimPrefix(uri, prefix))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size := thumbnail.Bounds().Size(); size != image.Pt(16, 8) {
+		t.Errorf("expected a 16x8 thumbnail, got %v", size)
+	}
+	if c := color.NRGBAModel.Convert(thumbnail.At(3, 3)).(color.NRGBA); c != (color.NRGBA{R: 255, A: 255}) {
+		t.Errorf("expected averaged colors to be preserved, got %v", c)
+	}
+}
@@ -0,0 +1,90 @@
+package h
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaintenanceFlag is the feature flag turning maintenance mode on when
+// [MaintenanceMode.Enabled] is nil, so it can be switched at runtime with
+// the [FlagProvider] of the app.
+const MaintenanceFlag = "maintenance"
+
+// errMaintenance marks the errors of requests turned down by maintenance
+// mode, which aren't logged.
+var errMaintenance = errors.New("h: maintenance mode")
+
+// MaintenanceMode short-circuits requests to a maintenance page (503
+// Service Unavailable) while it is on. Use its [MaintenanceMode.Middleware],
+// or set it in [HandlerOptions] to show the error page of the handler. All
+// fields are optional.
+type MaintenanceMode struct {
+	// Enabled reports whether maintenance mode is on for r; defaults to
+	// the [MaintenanceFlag] of the request context (see [FlagEnabled]).
+	Enabled func(r *http.Request) bool
+	// Allow lists the path prefixes still served, such as health checks,
+	// static assets or the admin, e.g. "/healthz" or "/admin/".
+	Allow []string
+	// RetryAfter, when set, is sent in the Retry-After header, telling
+	// clients and crawlers when to come back.
+	RetryAfter time.Duration
+	Message    string // Shown on the page; the default message of MaintenancePage when empty
+	// Layout is passed to [MaintenancePage] by the middleware.
+	Layout func(title string, content HyperNode) HyperNode
+}
+
+// Active reports whether r is turned down by maintenance mode.
+func (me MaintenanceMode) Active(r *http.Request) bool {
+	for _, prefix := range me.Allow {
+		if strings.HasPrefix(r.URL.Path, prefix) {
+			return false
+		}
+	}
+	if me.Enabled != nil {
+		return me.Enabled(r)
+	}
+	return FlagEnabled(r.Context(), MaintenanceFlag)
+}
+
+// setHeaders sets the headers of a maintenance response.
+func (me MaintenanceMode) setHeaders(w http.ResponseWriter) {
+	if me.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(me.RetryAfter.Round(time.Second)/time.Second)))
+	}
+	// Don't let caches keep serving the page once the site is back.
+	w.Header().Set("Cache-Control", "no-store")
+}
+
+// Middleware serves the maintenance page instead of calling next while
+// maintenance mode is on.
+//
+// Example:
+//
+//	maintenance := MaintenanceMode{
+//		Allow:      []string{"/healthz", "/static/"},
+//		RetryAfter: 30 * time.Minute,
+//		Layout:     appLayout,
+//	}
+//	http.ListenAndServe(":8080", maintenance.Middleware(mux))
+func (me MaintenanceMode) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !me.Active(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		buf := getBuffer(mediumBufferSize)
+		defer putBuffer(buf)
+		me.setHeaders(w)
+		if err := MaintenancePage(ErrorPageParams{Message: me.Message, Layout: me.Layout, Nonce: Nonce(r.Context())}).Render(buf); err != nil {
+			http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write(buf.Bytes())
+	})
+}
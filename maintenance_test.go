@@ -0,0 +1,78 @@
+package h
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMaintenanceMode(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.Write([]byte("app")) })
+	maintenance := MaintenanceMode{
+		Enabled:    func(*http.Request) bool { return true },
+		Allow:      []string{"/healthz"},
+		RetryAfter: 10 * time.Minute,
+		Message:    "Back at 10:00 UTC.",
+	}
+
+	w := httptest.NewRecorder()
+	maintenance.Middleware(next).ServeHTTP(w, httptest.NewRequest("GET", "/projects", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503, got %d", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "600" {
+		t.Errorf("expected Retry-After 600, got %q", got)
+	}
+	if body := w.Body.String(); !strings.Contains(body, "Down for maintenance") || !strings.Contains(body, "Back at 10:00 UTC.") {
+		t.Errorf("unexpected body %q", body)
+	}
+
+	w = httptest.NewRecorder()
+	maintenance.Middleware(next).ServeHTTP(w, httptest.NewRequest("GET", "/healthz", nil))
+	if w.Code != http.StatusOK || w.Body.String() != "app" {
+		t.Errorf("expected the allowed path to be served, got %d %q", w.Code, w.Body.String())
+	}
+}
+
+func TestMaintenanceModeFlag(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	if (MaintenanceMode{}).Active(r) {
+		t.Error("expected maintenance mode off by default")
+	}
+	ctx := WithValue(context.Background(), FlagProviderKey, FlagProvider(StaticFlags{MaintenanceFlag: true}))
+	if !(MaintenanceMode{}).Active(r.WithContext(ctx)) {
+		t.Error("expected the maintenance flag to turn maintenance mode on")
+	}
+}
+
+func TestHandlerMaintenance(t *testing.T) {
+	var logs bytes.Buffer
+	called := false
+	handler := Handler(func(*http.Request) (HyperNode, error) {
+		called = true
+		return P()("hello"), nil
+	}, HandlerOptions{
+		Maintenance: &MaintenanceMode{Enabled: func(*http.Request) bool { return true }},
+		Logger:      slog.New(slog.NewTextHandler(&logs, nil)),
+	})
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	if called {
+		t.Error("expected the handler function not to be called")
+	}
+	if w.Code != http.StatusServiceUnavailable || !strings.Contains(w.Body.String(), "Down for maintenance") {
+		t.Errorf("unexpected response %d %q", w.Code, w.Body.String())
+	}
+	if w.Header().Get("Cache-Control") != "no-store" {
+		t.Errorf("expected Cache-Control no-store, got %q", w.Header().Get("Cache-Control"))
+	}
+	if logs.Len() != 0 {
+		t.Errorf("expected maintenance responses not to be logged, got %q", logs.String())
+	}
+}
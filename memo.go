@@ -0,0 +1,375 @@
+package h
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// MemoOptions configures [Memo]. All fields are optional.
+type MemoOptions struct {
+	TTL time.Duration // How long the output is cached; forever when zero
+	// PerTheme caches a version per theme (see [ThemeKey]), for fragments
+	// rendering differently per theme. Versions are always cached per
+	// locale (see [LocaleKey]).
+	PerTheme bool
+	Cache    RenderCache // Where the output is cached; defaults to [DefaultRenderCache]
+	// StaleWhileRevalidate keeps serving the output for this long after its
+	// TTL, while a fresh version is rendered in the background. Use it for
+	// fragments backed by slow data, such as a stats widget, so no request
+	// waits for them once cached. It requires a TTL.
+	StaleWhileRevalidate time.Duration
+	// Jitter randomizes the TTL by up to this fraction (0.1 for ±10%), so
+	// fragments cached at the same time don't all expire at the same time.
+	// It is capped below 1, so jittered fragments always expire.
+	Jitter float64
+	// LockTimeout, when the cache is a [CacheLocker], makes the instances
+	// missing the fragment wait up to this long for the one rendering it,
+	// instead of all querying the data behind it at once, and only one
+	// instance refreshes stale output. It also bounds the time the lock is
+	// held. Concurrent renders within an instance are always deduplicated.
+	LockTimeout time.Duration
+}
+
+// OnMemoHit, when set, is called with the render context and the key of
+// each fragment [Memo] serves from cache, e.g. by the debug overlay of the
+// dev package to count cache hits. It must be set before rendering starts.
+var OnMemoHit func(ctx context.Context, key string)
+
+var (
+	// memoFlights deduplicates concurrent renders of the same missing fragment.
+	memoFlights flightGroup
+	// memoRefreshes tracks the fragments being refreshed in the background.
+	memoRefreshes sync.Map
+)
+
+// staleHeader starts the cached values of fragments using
+// stale-while-revalidate. It is followed by the time until which the value
+// is fresh, in Unix nanoseconds.
+const staleHeader = "hyper-swr:"
+
+// memoLockPoll is the interval at which instances waiting for another one
+// to render a fragment check the cache.
+var memoLockPoll = 25 * time.Millisecond
+
+// Memo returns a node rendering the node built by build, caching its output
+// under key: build is only called, and its node only rendered, when the
+// cache has no fresh output for key. Use it for fragments that are costly
+// to build and the same for many requests, such as navigation menus or
+// footers.
+//
+// The output is cached per locale carried by ctx, so i18n-ized fragments
+// are never served in the wrong language, and optionally per theme.
+// Whatever else the fragment depends on must be part of key.
+//
+// The output is served to other requests, so it must not carry per-request
+// values: the fragment renders without the CSP nonce of ctx (see
+// [NonceKey]), and nodes needing a nonce, such as inline scripts and
+// styles, must stay out of it.
+//
+// Example:
+//
+//	func Footer(ctx context.Context) HyperNode {
+//		return Memo(ctx, "footer", func() HyperNode {
+//			return FOOTER()(Sitemap(ctx), Copyright(ctx))
+//		}, MemoOptions{TTL: time.Hour})
+//	}
+func Memo(ctx context.Context, key string, build func() HyperNode, options ...MemoOptions) HyperNode {
+	var o MemoOptions
+	if len(options) != 0 {
+		o = options[0]
+	}
+	if o.Cache == nil {
+		o.Cache = DefaultRenderCache
+	}
+	return memo{ctx: ctx, key: memoKey(ctx, key, o), build: build, options: o, tree: &memoTree{}}
+}
+
+// MemoFor is [Memo] keyed by a value, typically the view model of the
+// fragment, instead of a string: the fragment is cached under a hash of the
+// type and fields of key, so it is rebuilt whenever any of them changes.
+// The fields must not hold pointers, channels or functions, whose
+// addresses aren't stable; the node fails to render otherwise.
+//
+// Example:
+//
+//	type productCard struct {
+//		ID      int
+//		Version int // Bumped on each change of the product
+//		Price   float64
+//	}
+//
+//	MemoFor(ctx, productCard{p.ID, p.Version, p.Price}, func() HyperNode {
+//		return ProductCard(p)
+//	})
+func MemoFor[K comparable](ctx context.Context, key K, build func() HyperNode, options ...MemoOptions) HyperNode {
+	sum := sha256.New()
+	if err := hashValue(sum, reflect.ValueOf(&key).Elem()); err != nil {
+		return errorNode{err: fmt.Errorf("h: MemoFor key %T: %w", key, err)}
+	}
+	return Memo(ctx, "memofor\x00"+hex.EncodeToString(sum.Sum(nil)), build, options...)
+}
+
+// hashValue writes v to sum, prefixing it with its type so values of
+// different types never collide.
+func hashValue(sum hash.Hash, v reflect.Value) error {
+	t := v.Type()
+	fmt.Fprintf(sum, "%s.%s(%d)", t.PkgPath(), t.String(), t.Kind())
+
+	var b [8]byte
+	switch v.Kind() {
+	case reflect.Bool:
+		b[0] = IfElse[byte](v.Bool(), 1, 0)
+		sum.Write(b[:1])
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		sum.Write(binary.BigEndian.AppendUint64(b[:0], uint64(v.Int())))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		sum.Write(binary.BigEndian.AppendUint64(b[:0], v.Uint()))
+	case reflect.Float32, reflect.Float64:
+		sum.Write(binary.BigEndian.AppendUint64(b[:0], math.Float64bits(v.Float())))
+	case reflect.Complex64, reflect.Complex128:
+		sum.Write(binary.BigEndian.AppendUint64(b[:0], math.Float64bits(real(v.Complex()))))
+		sum.Write(binary.BigEndian.AppendUint64(b[:0], math.Float64bits(imag(v.Complex()))))
+	case reflect.String:
+		// Length-prefixed, so ("ab", "c") and ("a", "bc") differ.
+		sum.Write(binary.BigEndian.AppendUint64(b[:0], uint64(v.Len())))
+		io.WriteString(sum, v.String())
+	case reflect.Array:
+		for i := range v.Len() {
+			if err := hashValue(sum, v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		for i := range v.NumField() {
+			if err := hashValue(sum, v.Field(i)); err != nil {
+				return fmt.Errorf("field %s: %w", t.Field(i).Name, err)
+			}
+		}
+	case reflect.Interface:
+		if v.IsNil() {
+			sum.Write([]byte{0})
+			return nil
+		}
+		return hashValue(sum, v.Elem())
+	default:
+		return fmt.Errorf("unsupported %s", v.Kind())
+	}
+	return nil
+}
+
+// memoKey derives the cache key of a fragment from its key and the
+// request-scoped values it varies with.
+func memoKey(ctx context.Context, key string, o MemoOptions) string {
+	key += "\x00locale=" + Locale(ctx)
+	if o.PerTheme {
+		key += "\x00theme=" + Theme(ctx)
+	}
+	return key
+}
+
+// PurgeMemo removes all the fragments cached by [Memo] in
+// [DefaultRenderCache], when the cache supports it.
+func PurgeMemo() {
+	if cache, ok := DefaultRenderCache.(interface{ Purge() }); ok {
+		cache.Purge()
+	}
+}
+
+type memo struct {
+	ctx     context.Context
+	key     string
+	build   func() HyperNode
+	options MemoOptions
+	tree    *memoTree
+}
+
+// memoTree holds the node built for a fragment, shared by the copies of
+// its memo, so walking and rendering it builds it once.
+type memoTree struct {
+	once sync.Once
+	node HyperNode
+}
+
+// node returns the node built by build, building it on the first call.
+func (me memo) node() HyperNode {
+	me.tree.once.Do(func() { me.tree.node = me.build() })
+	return me.tree.node
+}
+
+func (me memo) Render(w io.Writer) error {
+	output, err := me.output()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(output)
+	return err
+}
+
+func (me memo) RenderToBuffer(buf *bytes.Buffer) error {
+	output, err := me.output()
+	if err != nil {
+		return err
+	}
+	buf.Write(output)
+	return nil
+}
+
+// Unwrap implements [Wrapper]. It builds the fragment, even when its
+// output is cached, so functions walking trees, such as [Query] and
+// [StripSensitive], see inside it: avoid walking pages with costly
+// fragments on hot paths. The node is built once per [Memo] call, and
+// reused to render the output when it isn't cached.
+func (me memo) Unwrap() []HyperNode {
+	return []HyperNode{me.node()}
+}
+
+func (me memo) output() ([]byte, error) {
+	if output, stale, ok := me.cached(); ok {
+		if OnMemoHit != nil {
+			OnMemoHit(me.ctx, me.key)
+		}
+		if stale {
+			me.refresh()
+		}
+		return output, nil
+	}
+	// The render is shared by the requests waiting for it, so it must not
+	// fail when the request starting it goes away.
+	shared := me
+	shared.ctx = context.WithoutCancel(me.ctx)
+	return memoFlights.do(me.ctx, me.key, shared.renderShared)
+}
+
+// cached returns the cached output of the fragment, and whether it is
+// stale.
+func (me memo) cached() ([]byte, bool, bool) {
+	// Cache errors are treated as misses: a cache outage slows pages down
+	// rather than breaking them.
+	value, ok, err := me.options.Cache.Get(me.ctx, me.key)
+	if err != nil || !ok {
+		return nil, false, false
+	}
+	if me.options.StaleWhileRevalidate <= 0 || me.options.TTL <= 0 {
+		return value, false, true
+	}
+	output, freshUntil, ok := decodeStale(value)
+	if !ok {
+		return nil, false, false
+	}
+	return output, time.Now().After(freshUntil), true
+}
+
+// lock acquires the lock of the fragment across instances, reporting
+// false when another instance holds it. It always succeeds when the cache
+// isn't a CacheLocker, or fails.
+func (me memo) lock() (func(), bool) {
+	locker, ok := me.options.Cache.(CacheLocker)
+	if !ok || me.options.LockTimeout <= 0 {
+		return func() {}, true
+	}
+	unlock, ok, err := locker.TryLock(me.ctx, me.key+"\x00lock", me.options.LockTimeout)
+	if err != nil {
+		return func() {}, true
+	}
+	return unlock, ok
+}
+
+// renderShared renders the fragment, unless another instance is rendering
+// it, in which case it waits for its output until LockTimeout, and renders
+// it anyway past that.
+func (me memo) renderShared() ([]byte, error) {
+	if unlock, ok := me.lock(); ok {
+		defer unlock()
+		return me.render()
+	}
+
+	ticker := time.NewTicker(memoLockPoll)
+	defer ticker.Stop()
+	timeout := time.NewTimer(me.options.LockTimeout)
+	defer timeout.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if output, _, ok := me.cached(); ok {
+				return output, nil
+			}
+		case <-timeout.C:
+			return me.render()
+		}
+	}
+}
+
+// render renders the fragment with the context of [Memo], so the
+// [NodeCtx] nodes inside it read its values, and caches its output. The
+// CSP nonce is left out of the context: the output is served to other
+// requests, whose policies have other nonces.
+func (me memo) render() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := RenderCtx(WithValue(me.ctx, NonceKey, ""), &buf, me.node()); err != nil {
+		return nil, err
+	}
+
+	ttl := jitterTTL(me.options.TTL, me.options.Jitter)
+	value := buf.Bytes()
+	if me.options.StaleWhileRevalidate > 0 && ttl > 0 {
+		value = encodeStale(value, time.Now().Add(ttl))
+		ttl += me.options.StaleWhileRevalidate
+	}
+	me.options.Cache.Set(me.ctx, me.key, value, ttl)
+	return buf.Bytes(), nil
+}
+
+// jitterTTL randomizes ttl by up to jitter, clamped to [0, 1), keeping
+// positive TTLs positive: a zero TTL would cache the fragment forever.
+func jitterTTL(ttl time.Duration, jitter float64) time.Duration {
+	if ttl <= 0 || !(jitter > 0) {
+		return ttl
+	}
+	jitter = min(jitter, math.Nextafter(1, 0))
+	ttl += time.Duration((rand.Float64()*2 - 1) * jitter * float64(ttl))
+	return max(ttl, time.Nanosecond)
+}
+
+// refresh renders the fragment again in the background, unless it is
+// already being refreshed.
+func (me memo) refresh() {
+	if _, refreshing := memoRefreshes.LoadOrStore(me.key, true); refreshing {
+		return
+	}
+	me.ctx = context.WithoutCancel(me.ctx)
+	me.tree = &memoTree{} // Rebuilt, as its data may have changed
+	go func() {
+		defer memoRefreshes.Delete(me.key)
+		// Another instance holding the lock is refreshing it.
+		if unlock, ok := me.lock(); ok {
+			defer unlock()
+			me.render()
+		}
+	}()
+}
+
+func encodeStale(output []byte, freshUntil time.Time) []byte {
+	value := make([]byte, 0, len(staleHeader)+8+len(output))
+	value = append(value, staleHeader...)
+	value = binary.BigEndian.AppendUint64(value, uint64(freshUntil.UnixNano()))
+	return append(value, output...)
+}
+
+func decodeStale(value []byte) ([]byte, time.Time, bool) {
+	if len(value) < len(staleHeader)+8 || string(value[:len(staleHeader)]) != staleHeader {
+		return nil, time.Time{}, false
+	}
+	freshUntil := int64(binary.BigEndian.Uint64(value[len(staleHeader):]))
+	return value[len(staleHeader)+8:], time.Unix(0, freshUntil), true
+}
@@ -0,0 +1,273 @@
+package h
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMemo(t *testing.T) {
+	defer PurgeMemo()
+
+	builds := 0
+	footer := func(ctx context.Context, options ...MemoOptions) HyperNode {
+		return Memo(ctx, "footer", func() HyperNode {
+			builds++
+			return FOOTER()(Locale(ctx), " ", Theme(ctx))
+		}, options...)
+	}
+	render := func(node HyperNode) string {
+		var buf bytes.Buffer
+		if err := Render(&buf, DIV()(node)); err != nil {
+			t.Fatal(err)
+		}
+		return buf.String()
+	}
+
+	en := WithValue(context.Background(), LocaleKey, "en")
+	fr := WithValue(context.Background(), LocaleKey, "fr")
+
+	if output := render(footer(en)); output != "<div><footer>en </footer></div>" {
+		t.Errorf("unexpected output %q", output)
+	}
+	render(footer(en))
+	if builds != 1 {
+		t.Errorf("expected 1 build, got %d", builds)
+	}
+	if output := render(footer(fr)); output != "<div><footer>fr </footer></div>" || builds != 2 {
+		t.Errorf("expected a build per locale, got %q after %d builds", output, builds)
+	}
+
+	// Themes share a version unless PerTheme is set.
+	dark := WithValue(en, ThemeKey, "dark")
+	if output := render(footer(dark)); output != "<div><footer>en </footer></div>" {
+		t.Errorf("unexpected output %q", output)
+	}
+	if output := render(footer(dark, MemoOptions{PerTheme: true})); output != "<div><footer>en dark</footer></div>" {
+		t.Errorf("unexpected output %q", output)
+	}
+
+	// Expired entries are rebuilt.
+	PurgeMemo()
+	builds = 0
+	render(footer(en, MemoOptions{TTL: time.Nanosecond}))
+	time.Sleep(time.Millisecond)
+	render(footer(en, MemoOptions{TTL: time.Nanosecond}))
+	if builds != 2 {
+		t.Errorf("expected expired entry to be rebuilt, got %d builds", builds)
+	}
+}
+
+func TestMemoUnwrap(t *testing.T) {
+	defer PurgeMemo()
+	builds := 0
+	ctx := WithValue(WithValue(context.Background(), LocaleKey, "fr"), NonceKey, "abc")
+	node := Memo(ctx, "unwrap", func() HyperNode {
+		builds++
+		return P()(CtxFunc(func(ctx context.Context) HyperNode { return Text(Locale(ctx) + Nonce(ctx)) }))
+	})
+
+	// Walking and rendering the fragment builds it once.
+	for range 3 {
+		if elements, err := Query(node, "p"); err != nil || len(elements) != 1 {
+			t.Fatalf("expected the walk to see inside the fragment, got %v (err=%v)", elements, err)
+		}
+	}
+	var buf bytes.Buffer
+	if err := Render(&buf, node); err != nil {
+		t.Fatal(err)
+	}
+	if builds != 1 {
+		t.Errorf("expected 1 build, got %d", builds)
+	}
+	// The fragment renders with the context of Memo, without its nonce,
+	// as its output is shared.
+	if buf.String() != "<p>fr</p>" {
+		t.Errorf("expected the fragment rendered with its context but no nonce, got %q", buf.String())
+	}
+}
+
+func TestOnMemoHit(t *testing.T) {
+	defer PurgeMemo()
+	var hits []string
+	OnMemoHit = func(_ context.Context, key string) { hits = append(hits, key) }
+	defer func() { OnMemoHit = nil }()
+
+	node := Memo(context.Background(), "hit", func() HyperNode { return P()("hit") })
+	for range 3 {
+		if err := Render(&bytes.Buffer{}, node); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(hits) != 2 || hits[0] != "hit\x00locale=" {
+		t.Errorf("expected 2 hits of the fragment, got %q", hits)
+	}
+}
+
+func TestJitterTTL(t *testing.T) {
+	for _, jitter := range []float64{0.5, 1, 5, math.Inf(1)} {
+		for range 1000 {
+			if ttl := jitterTTL(time.Millisecond, jitter); ttl <= 0 || ttl >= 2*time.Millisecond {
+				t.Fatalf("jitter %v: expected a TTL in (0, 2ms), got %v", jitter, ttl)
+			}
+		}
+	}
+	for _, jitter := range []float64{0, -1, math.NaN()} {
+		if ttl := jitterTTL(time.Minute, jitter); ttl != time.Minute {
+			t.Errorf("jitter %v: expected the TTL unchanged, got %v", jitter, ttl)
+		}
+	}
+	if ttl := jitterTTL(0, 0.5); ttl != 0 {
+		t.Errorf("expected fragments cached forever to stay so, got %v", ttl)
+	}
+}
+
+func TestMemoStaleWhileRevalidate(t *testing.T) {
+	defer PurgeMemo()
+
+	var version atomic.Int32
+	options := MemoOptions{TTL: 10 * time.Millisecond, StaleWhileRevalidate: time.Hour, Jitter: 0.1}
+	stats := func() HyperNode {
+		return Memo(context.Background(), "stats", func() HyperNode {
+			return SPAN()(version.Add(1))
+		}, options)
+	}
+	render := func() string {
+		var buf bytes.Buffer
+		if err := Render(&buf, stats()); err != nil {
+			t.Fatal(err)
+		}
+		return buf.String()
+	}
+
+	if output := render(); output != "<span>1</span>" {
+		t.Fatalf("unexpected output %q", output)
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	// The stale output is served while a fresh one renders in the background.
+	if output := render(); output != "<span>1</span>" {
+		t.Errorf("expected the stale output, got %q", output)
+	}
+	for deadline := time.Now().Add(time.Second); version.Load() < 2 && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		if _, refreshing := memoRefreshes.Load(memoKey(context.Background(), "stats", options)); !refreshing {
+			break
+		}
+	}
+	if output := render(); output != "<span>2</span>" {
+		t.Errorf("expected the refreshed output, got %q", output)
+	}
+}
+
+func TestMemoFor(t *testing.T) {
+	defer PurgeMemo()
+
+	type card struct {
+		ID      int
+		Version int
+		Tags    [2]string
+		Extra   any
+	}
+	builds := 0
+	render := func(key card) string {
+		var buf bytes.Buffer
+		err := Render(&buf, MemoFor(context.Background(), key, func() HyperNode {
+			builds++
+			return P()(key.ID, " v", key.Version)
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return buf.String()
+	}
+
+	render(card{ID: 1, Version: 1, Tags: [2]string{"ab", "c"}})
+	if output := render(card{ID: 1, Version: 1, Tags: [2]string{"ab", "c"}}); output != "<p>1 v1</p>" || builds != 1 {
+		t.Errorf("expected a cache hit, got %q after %d builds", output, builds)
+	}
+	if output := render(card{ID: 1, Version: 2, Tags: [2]string{"ab", "c"}}); output != "<p>1 v2</p>" || builds != 2 {
+		t.Errorf("expected a new version to be built, got %q after %d builds", output, builds)
+	}
+	render(card{ID: 1, Version: 2, Tags: [2]string{"a", "bc"}})
+	render(card{ID: 1, Version: 2, Tags: [2]string{"ab", "c"}, Extra: "x"})
+	if builds != 4 {
+		t.Errorf("expected a build per distinct key, got %d builds", builds)
+	}
+
+	type pointerKey struct{ P *int }
+	var buf bytes.Buffer
+	err := Render(&buf, MemoFor(context.Background(), pointerKey{P: new(int)}, func() HyperNode { return P()() }))
+	if err == nil {
+		t.Error("expected keys holding pointers to fail to render")
+	}
+}
+
+// lockingCache is an in-memory CacheLocker standing for a cache shared
+// between instances.
+type lockingCache struct {
+	*LRUCache
+	mu    sync.Mutex
+	locks map[string]bool
+}
+
+func (me *lockingCache) TryLock(_ context.Context, key string, _ time.Duration) (func(), bool, error) {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	if me.locks[key] {
+		return nil, false, nil
+	}
+	me.locks[key] = true
+	return func() {
+		me.mu.Lock()
+		defer me.mu.Unlock()
+		delete(me.locks, key)
+	}, true, nil
+}
+
+func TestMemoLock(t *testing.T) {
+	cache := &lockingCache{LRUCache: NewLRUCache(16), locks: map[string]bool{}}
+	options := MemoOptions{Cache: cache, LockTimeout: time.Second}
+	ctx := context.Background()
+	builds := 0
+	widget := Memo(ctx, "widget", func() HyperNode {
+		builds++
+		return P()("fresh")
+	}, options)
+
+	// Another instance holds the lock and caches its output shortly after.
+	key := memoKey(ctx, "widget", options)
+	unlock, _, _ := cache.TryLock(ctx, key+"\x00lock", time.Second)
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cache.Set(ctx, key, []byte("<p>from another instance</p>"), 0)
+		unlock()
+	}()
+
+	var buf bytes.Buffer
+	if err := Render(&buf, widget); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "<p>from another instance</p>" || builds != 0 {
+		t.Errorf("expected to wait for the other instance, got %q after %d builds", buf.String(), builds)
+	}
+
+	// Without an output past the timeout, the fragment is rendered anyway.
+	cache.Purge()
+	cache.TryLock(ctx, key+"\x00lock", time.Second)
+	options.LockTimeout = 50 * time.Millisecond
+	buf.Reset()
+	Render(&buf, Memo(ctx, "widget", func() HyperNode {
+		builds++
+		return P()("fresh")
+	}, options))
+	if buf.String() != "<p>fresh</p>" || builds != 1 {
+		t.Errorf("expected a render past the timeout, got %q after %d builds", buf.String(), builds)
+	}
+}
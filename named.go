@@ -0,0 +1,47 @@
+package h
+
+import (
+	"bytes"
+	"io"
+)
+
+// NamedNode labels a node with a component name. It renders the wrapped node
+// unchanged; the name is only used by development tools, such as the debug
+// overlay of the dev package, to show the component tree of a page.
+type NamedNode struct {
+	Name string
+	Node HyperNode
+}
+
+// Named wraps node in a [NamedNode].
+//
+// Example:
+//
+//	func UserCard(user User) HyperNode {
+//		return Named("UserCard", DIV(AttrClass("card"))(user.Name))
+//	}
+func Named(name string, node HyperNode) NamedNode {
+	return NamedNode{Name: name, Node: node}
+}
+
+func (me NamedNode) Render(w io.Writer) error {
+	return me.Node.Render(w)
+}
+
+// RenderToBuffer implements [BufferRenderer], so naming a node does not take
+// it off the fast path.
+func (me NamedNode) RenderToBuffer(buf *bytes.Buffer) error {
+	if node, ok := me.Node.(BufferRenderer); ok {
+		return node.RenderToBuffer(buf)
+	}
+	return me.Node.Render(buf)
+}
+
+// Unwrap implements [Wrapper].
+func (me NamedNode) Unwrap() []HyperNode {
+	return []HyperNode{me.Node}
+}
+
+func (me NamedNode) mapNodes(fn func(HyperNode) HyperNode) HyperNode {
+	return Named(me.Name, fn(me.Node))
+}
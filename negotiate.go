@@ -0,0 +1,134 @@
+package h
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// NegotiateFunc builds both representations of a resource: the page shown to
+// browsers and the data served to API clients.
+type NegotiateFunc func(r *http.Request) (node HyperNode, data any, err error)
+
+// Negotiate adapts fn to an [http.Handler] serving HTML or JSON depending on
+// the request (see [WantsJSON]), so an endpoint backs both the UI and the
+// API without duplicating its logic. HTML responses behave like [Handler];
+// JSON responses encode data, and errors as {"error": "message"} with the
+// status of an [HTTPError] (500 otherwise).
+//
+// Example:
+//
+//	mux.Handle("GET /projects/{id}", Negotiate(func(r *http.Request) (HyperNode, any, error) {
+//		project, err := store.Project(r.PathValue("id"))
+//		if err != nil {
+//			return nil, nil, err
+//		}
+//		return ProjectPage(project), project, nil
+//	}))
+func Negotiate(fn NegotiateFunc, options ...HandlerOptions) http.Handler {
+	var o HandlerOptions
+	if len(options) != 0 {
+		o = options[0]
+	}
+	return negotiateHandler{
+		fn: fn,
+		html: handler{
+			fn: func(r *http.Request) (HyperNode, error) {
+				node, _, err := fn(r)
+				return node, err
+			},
+			options: o,
+		},
+	}
+}
+
+type negotiateHandler struct {
+	fn   NegotiateFunc
+	html handler
+}
+
+func (me negotiateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Add("Vary", "Accept, HX-Request")
+	if !WantsJSON(r) {
+		me.html.ServeHTTP(w, r)
+		return
+	}
+	me.html.setSecurityHeaders(w, r, nil)
+
+	buf := getBuffer(mediumBufferSize)
+	defer putBuffer(buf)
+
+	status := http.StatusOK
+	if err := me.encode(buf, r); err != nil {
+		status = errorStatus(err)
+		me.html.log(r, status, err)
+
+		message := http.StatusText(status)
+		var httpErr HTTPError
+		if errors.As(err, &httpErr) && httpErr.Message != "" {
+			message = httpErr.Message
+		}
+		buf.Reset()
+		json.NewEncoder(buf).Encode(map[string]string{"error": message})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(buf.Bytes())
+}
+
+// encode calls the handler function and encodes its data into buf, turning
+// panics into errors.
+func (me negotiateHandler) encode(buf *bytes.Buffer, r *http.Request) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
+			}
+			err = fmt.Errorf("h: handler panic: %v", recovered)
+		}
+	}()
+
+	_, data, err := me.fn(r)
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(buf).Encode(data)
+}
+
+// WantsJSON reports whether r prefers JSON over HTML. htmx requests
+// (HX-Request: true) always get HTML; otherwise JSON is preferred only when
+// the Accept header ranks application/json (or a +json type) strictly above
+// text/html, so browsers, which send "text/html, ..., */*", get HTML.
+func WantsJSON(r *http.Request) bool {
+	if r.Header.Get("HX-Request") == "true" {
+		return false
+	}
+	var htmlQ, jsonQ float64
+	for _, accept := range r.Header.Values("Accept") {
+		for part := range strings.SplitSeq(accept, ",") {
+			mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+			if err != nil {
+				continue
+			}
+			q := 1.0
+			if value, ok := params["q"]; ok {
+				if q, err = strconv.ParseFloat(value, 64); err != nil {
+					continue
+				}
+			}
+			switch {
+			case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+				jsonQ = max(jsonQ, q)
+			case mediaType == "text/html" || mediaType == "application/xhtml+xml" || mediaType == "*/*" || mediaType == "text/*":
+				htmlQ = max(htmlQ, q)
+			}
+		}
+	}
+	return jsonQ > htmlQ
+}
@@ -0,0 +1,78 @@
+package h
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWantsJSON(t *testing.T) {
+	tests := []struct {
+		accept   string
+		htmx     bool
+		expected bool
+	}{
+		{accept: "", expected: false},
+		{accept: "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", expected: false},
+		{accept: "application/json", expected: true},
+		{accept: "application/vnd.api+json", expected: true},
+		{accept: "application/json, */*;q=0.1", expected: true},
+		{accept: "text/html;q=0.5, application/json;q=0.9", expected: true},
+		{accept: "text/html, application/json", expected: false},
+		{accept: "application/json", htmx: true, expected: false},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Accept", test.accept)
+		if test.htmx {
+			r.Header.Set("HX-Request", "true")
+		}
+		if got := WantsJSON(r); got != test.expected {
+			t.Errorf("Accept %q (htmx: %v): expected %v, got %v", test.accept, test.htmx, test.expected, got)
+		}
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	quiet := HandlerOptions{Logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	handler := Negotiate(func(r *http.Request) (HyperNode, any, error) {
+		if r.URL.Path == "/missing" {
+			return nil, nil, HTTPError{Status: http.StatusNotFound, Message: "No such project"}
+		}
+		project := map[string]string{"name": "Hyper"}
+		return H1()(project["name"]), project, nil
+	}, quiet)
+
+	tests := []struct {
+		path, accept string
+		status       int
+		contentType  string
+		body         string
+	}{
+		{path: "/", accept: "text/html", status: http.StatusOK, contentType: "text/html; charset=utf-8", body: "<h1>Hyper</h1>"},
+		{path: "/", accept: "application/json", status: http.StatusOK, contentType: "application/json", body: `{"name":"Hyper"}` + "\n"},
+		{path: "/missing", accept: "application/json", status: http.StatusNotFound, contentType: "application/json", body: `{"error":"No such project"}` + "\n"},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest(http.MethodGet, test.path, nil)
+		r.Header.Set("Accept", test.accept)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		if w.Code != test.status {
+			t.Errorf("%s %s: expected status %d, got %d", test.path, test.accept, test.status, w.Code)
+		}
+		if got := w.Header().Get("Content-Type"); got != test.contentType {
+			t.Errorf("%s %s: expected content type %q, got %q", test.path, test.accept, test.contentType, got)
+		}
+		if got := w.Body.String(); got != test.body {
+			t.Errorf("%s %s: expected body %q, got %q", test.path, test.accept, test.body, got)
+		}
+		if !strings.Contains(w.Header().Get("Vary"), "Accept") {
+			t.Errorf("%s %s: expected Vary: Accept", test.path, test.accept)
+		}
+	}
+}
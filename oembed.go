@@ -0,0 +1,344 @@
+package h
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ClassEmbed is the class of the <figure> rendered by [Embed].
+const ClassEmbed = "embed"
+
+// OEmbed is the description of a URL returned by an oEmbed provider (see
+// https://oembed.com).
+type OEmbed struct {
+	Type            string `json:"type"` // "photo", "video", "link" or "rich"
+	Title           string `json:"title,omitempty"`
+	AuthorName      string `json:"author_name,omitempty"`
+	AuthorURL       string `json:"author_url,omitempty"`
+	ProviderName    string `json:"provider_name,omitempty"`
+	ProviderURL     string `json:"provider_url,omitempty"`
+	URL             string `json:"url,omitempty"`  // The image of photos
+	HTML            string `json:"html,omitempty"` // The markup of videos and rich content
+	Width           int    `json:"width,omitempty"`
+	Height          int    `json:"height,omitempty"`
+	ThumbnailURL    string `json:"thumbnail_url,omitempty"`
+	ThumbnailWidth  int    `json:"thumbnail_width,omitempty"`
+	ThumbnailHeight int    `json:"thumbnail_height,omitempty"`
+}
+
+// UnmarshalJSON accepts sizes given as strings, as some providers do.
+func (me *OEmbed) UnmarshalJSON(data []byte) error {
+	type plain OEmbed
+	var raw struct {
+		plain
+		Width           json.RawMessage `json:"width"`
+		Height          json.RawMessage `json:"height"`
+		ThumbnailWidth  json.RawMessage `json:"thumbnail_width"`
+		ThumbnailHeight json.RawMessage `json:"thumbnail_height"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*me = OEmbed(raw.plain)
+	me.Width = oembedSize(raw.Width)
+	me.Height = oembedSize(raw.Height)
+	me.ThumbnailWidth = oembedSize(raw.ThumbnailWidth)
+	me.ThumbnailHeight = oembedSize(raw.ThumbnailHeight)
+	return nil
+}
+
+func oembedSize(raw json.RawMessage) int {
+	size, _ := strconv.Atoi(strings.Trim(string(raw), `"`))
+	return size
+}
+
+// OEmbedClient resolves URLs to their oEmbed description, for [Embed].
+// Implementations must be safe for concurrent use.
+type OEmbedClient interface {
+	Resolve(ctx context.Context, url string) (OEmbed, error)
+}
+
+// ErrNoOEmbedProvider is returned by [HTTPOEmbed] for URLs no provider
+// handles.
+var ErrNoOEmbedProvider = errors.New("no oEmbed provider for URL")
+
+// OEmbedProvider is an oEmbed endpoint and the URLs it describes.
+type OEmbedProvider struct {
+	Name     string
+	Endpoint string
+	// URLs are patterns of the URLs handled by the provider, where "*"
+	// matches any sequence of characters.
+	URLs []string
+}
+
+// DefaultOEmbedProviders are the providers of the [DefaultOEmbedClient].
+var DefaultOEmbedProviders = []OEmbedProvider{
+	{
+		Name:     "YouTube",
+		Endpoint: "https://www.youtube.com/oembed",
+		URLs:     []string{"https://www.youtube.com/watch?*", "https://youtu.be/*", "https://www.youtube.com/shorts/*"},
+	},
+	{
+		Name:     "Vimeo",
+		Endpoint: "https://vimeo.com/api/oembed.json",
+		URLs:     []string{"https://vimeo.com/*", "https://player.vimeo.com/video/*"},
+	},
+	{
+		Name:     "X",
+		Endpoint: "https://publish.twitter.com/oembed",
+		URLs:     []string{"https://twitter.com/*/status/*", "https://x.com/*/status/*"},
+	},
+	{
+		Name:     "SoundCloud",
+		Endpoint: "https://soundcloud.com/oembed",
+		URLs:     []string{"https://soundcloud.com/*"},
+	},
+}
+
+// HTTPOEmbed is an [OEmbedClient] querying the oEmbed endpoints of a list
+// of providers.
+type HTTPOEmbed struct {
+	Providers []OEmbedProvider
+	Client    *http.Client // Defaults to a client timing out after 5 seconds
+	// MaxWidth and MaxHeight ask providers for embeds fitting in this size.
+	MaxWidth, MaxHeight int
+}
+
+// maxOEmbedResponseSize bounds the responses read from providers.
+const maxOEmbedResponseSize = 1 << 20
+
+var defaultOEmbedHTTPClient = &http.Client{Timeout: 5 * time.Second}
+
+func (me HTTPOEmbed) Resolve(ctx context.Context, rawURL string) (OEmbed, error) {
+	var provider *OEmbedProvider
+	for i := range me.Providers {
+		for _, pattern := range me.Providers[i].URLs {
+			if matchWildcard(pattern, rawURL) {
+				provider = &me.Providers[i]
+				break
+			}
+		}
+	}
+	if provider == nil {
+		return OEmbed{}, fmt.Errorf("%w: %s", ErrNoOEmbedProvider, rawURL)
+	}
+
+	query := url.Values{"url": {rawURL}, "format": {"json"}}
+	if me.MaxWidth > 0 {
+		query.Set("maxwidth", strconv.Itoa(me.MaxWidth))
+	}
+	if me.MaxHeight > 0 {
+		query.Set("maxheight", strconv.Itoa(me.MaxHeight))
+	}
+	endpoint := provider.Endpoint + "?" + query.Encode()
+	if strings.Contains(provider.Endpoint, "?") {
+		endpoint = provider.Endpoint + "&" + query.Encode()
+	}
+	r, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return OEmbed{}, err
+	}
+	r.Header.Set("Accept", "application/json")
+
+	client := me.Client
+	if client == nil {
+		client = defaultOEmbedHTTPClient
+	}
+	response, err := client.Do(r)
+	if err != nil {
+		return OEmbed{}, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return OEmbed{}, fmt.Errorf("oembed %s: %s", provider.Name, response.Status)
+	}
+	var embed OEmbed
+	if err := json.NewDecoder(io.LimitReader(response.Body, maxOEmbedResponseSize)).Decode(&embed); err != nil {
+		return OEmbed{}, fmt.Errorf("oembed %s: %w", provider.Name, err)
+	}
+	return embed, nil
+}
+
+// matchWildcard reports whether s matches pattern, where "*" matches any
+// sequence of characters.
+func matchWildcard(pattern, s string) bool {
+	prefix, rest, wildcard := strings.Cut(pattern, "*")
+	if !wildcard {
+		return pattern == s
+	}
+	if !strings.HasPrefix(s, prefix) {
+		return false
+	}
+	s = s[len(prefix):]
+	for i := 0; i <= len(s); i++ {
+		if matchWildcard(rest, s[i:]) {
+			return true
+		}
+	}
+	return false
+}
+
+// CachedOEmbed is an [OEmbedClient] caching the descriptions resolved by
+// another client, so pages don't query providers on every render.
+// Concurrent resolutions of the same URL are deduplicated. Errors are not
+// cached.
+type CachedOEmbed struct {
+	Client OEmbedClient
+	Cache  RenderCache   // Defaults to [DefaultRenderCache]
+	TTL    time.Duration // How long descriptions are cached; forever when zero
+}
+
+// oembedFlights deduplicates concurrent resolutions of the same URL.
+var oembedFlights flightGroup
+
+func (me CachedOEmbed) Resolve(ctx context.Context, rawURL string) (OEmbed, error) {
+	cache := me.Cache
+	if cache == nil {
+		cache = DefaultRenderCache
+	}
+	key := "oembed:" + rawURL
+
+	// Cache errors are treated as misses, as in [Memo].
+	value, ok, err := cache.Get(ctx, key)
+	if err != nil || !ok {
+		// The resolution is shared by the requests waiting for it, so it
+		// must not fail when the request starting it goes away.
+		shared := context.WithoutCancel(ctx)
+		value, err = oembedFlights.do(ctx, key, func() ([]byte, error) {
+			embed, err := me.Client.Resolve(shared, rawURL)
+			if err != nil {
+				return nil, err
+			}
+			value, err := json.Marshal(embed)
+			if err != nil {
+				return nil, err
+			}
+			_ = cache.Set(shared, key, value, me.TTL)
+			return value, nil
+		})
+		if err != nil {
+			return OEmbed{}, err
+		}
+	}
+	var embed OEmbed
+	err = json.Unmarshal(value, &embed)
+	return embed, err
+}
+
+// DefaultOEmbedClient resolves the URLs of [Embed] when the context carries
+// no client (see [OEmbedClientKey]). It queries the
+// [DefaultOEmbedProviders] and caches their answers for a day.
+var DefaultOEmbedClient OEmbedClient = CachedOEmbed{
+	Client: HTTPOEmbed{Providers: DefaultOEmbedProviders},
+	TTL:    24 * time.Hour,
+}
+
+// OEmbedClientKey holds an [OEmbedClient] overriding [DefaultOEmbedClient]
+// for a request.
+var OEmbedClientKey = NewContextKey[OEmbedClient]("oembed")
+
+// OEmbedPolicy is the [SanitizePolicy] applied to the markup of embeds:
+// scripts are removed, so providers' JavaScript widgets render as their
+// static markup, and iframes are sandboxed and lazy-loaded.
+var OEmbedPolicy = SanitizePolicy{
+	Elements: map[string][]string{
+		"blockquote": {"cite"},
+		"p":          nil,
+		"a":          {"href"},
+		"br":         nil,
+		"em":         nil,
+		"strong":     nil,
+		"b":          nil,
+		"i":          nil,
+		"span":       nil,
+		"div":        nil,
+		"figure":     nil,
+		"figcaption": nil,
+		"img":        {"src", "alt", "width", "height"},
+		"iframe":     {"src", "width", "height", "title", "allow", "allowfullscreen"},
+	},
+	GlobalAttributes: []string{"class", "lang", "dir"},
+	URLSchemes:       []string{"https", "http"},
+	SetAttributes: map[string]map[string]string{
+		"a": {"rel": RelNoOpener + " " + RelNoReferrer, "target": TargetBlank},
+		"iframe": {
+			"sandbox":        SandboxAllowScripts + " " + SandboxAllowSameOrigin + " " + SandboxAllowPresentation + " " + SandboxAllowPopups,
+			"loading":        LoadingLazy,
+			"referrerpolicy": ReferrerPolicyStrictOriginWhenCrossOrigin,
+		},
+		"img": {"loading": LoadingLazy},
+	},
+}
+
+// Embed renders the content at url, such as a video or a post, from its
+// oEmbed description: the provider's markup is sanitized with
+// [OEmbedPolicy] and rendered server-side, instead of loading the
+// provider's embed script in the page. Photos render as images and links
+// as links, all in a <figure class="embed">.
+//
+// The description is resolved when the node renders, with the client of
+// ctx. When it can't be, the node renders a plain link to url, so an
+// unavailable provider doesn't break the page.
+//
+// Example:
+//
+//	ARTICLE()(
+//		P()(post.Intro),
+//		Embed(ctx, "https://www.youtube.com/watch?v=dQw4w9WgXcQ"),
+//	)
+func Embed(ctx context.Context, url string) HyperNode {
+	return embedNode{ctx: ctx, url: url}
+}
+
+type embedNode struct {
+	ctx context.Context
+	url string
+}
+
+func (me embedNode) Render(w io.Writer) error {
+	var buf bytes.Buffer
+	if err := me.RenderToBuffer(&buf); err != nil {
+		return err
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func (me embedNode) RenderToBuffer(buf *bytes.Buffer) error {
+	client, ok := Value(me.ctx, OEmbedClientKey)
+	if !ok || client == nil {
+		client = DefaultOEmbedClient
+	}
+	embed, err := client.Resolve(me.ctx, me.url)
+	if err != nil {
+		return A(AttrHref(me.url))(me.url).render(buf)
+	}
+
+	var content HyperNode
+	switch embed.Type {
+	case "photo":
+		attrs := []Attribute{AttrSrc(embed.URL), AttrAlt(embed.Title), AttrLoading(LoadingLazy)}
+		if embed.Width > 0 && embed.Height > 0 {
+			attrs = append(attrs, AttrWidth(strconv.Itoa(embed.Width)), AttrHeight(strconv.Itoa(embed.Height)))
+		}
+		content = IMG(attrs...)
+	case "video", "rich":
+		content = Sanitize(embed.HTML, OEmbedPolicy)
+	default:
+		content = A(AttrHref(me.url))(IfElse(embed.Title != "", embed.Title, me.url))
+	}
+	attrs := []Attribute{AttrClass(ClassEmbed), Attr("data-embed-type", embed.Type)}
+	if embed.ProviderName != "" {
+		attrs = append(attrs, Attr("data-provider", embed.ProviderName))
+	}
+	return FIGURE(attrs...)(content).render(buf)
+}
@@ -0,0 +1,109 @@
+package h
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestEmbed(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Query().Get("format") != "json" || r.URL.Query().Get("maxwidth") != "640" {
+			t.Errorf("unexpected query %q", r.URL.RawQuery)
+		}
+		switch r.URL.Query().Get("url") {
+		case "https://video.example/v/1":
+			w.Write([]byte(`{"type":"video","provider_name":"Example","width":"640","height":360,` +
+				`"html":"<iframe src=\"https://video.example/embed/1\" width=\"640\" height=\"360\" onload=\"x()\"></iframe><script src=\"https://video.example/widget.js\"></script>"}`))
+		case "https://video.example/p/2":
+			w.Write([]byte(`{"type":"photo","title":"Sunset","url":"https://video.example/2.jpg","width":800,"height":600}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := CachedOEmbed{
+		Client: HTTPOEmbed{
+			Providers: []OEmbedProvider{{Name: "Example", Endpoint: server.URL, URLs: []string{"https://video.example/*"}}},
+			MaxWidth:  640,
+		},
+		Cache: NewLRUCache(16),
+	}
+	ctx := WithValue(context.Background(), OEmbedClientKey, OEmbedClient(client))
+
+	tests := []struct {
+		name     string
+		url      string
+		expected string
+	}{
+		{
+			name: "video",
+			url:  "https://video.example/v/1",
+			expected: `<figure class="embed" data-embed-type="video" data-provider="Example">` +
+				`<iframe src="https://video.example/embed/1" width="640" height="360" loading="lazy" referrerpolicy="strict-origin-when-cross-origin" ` +
+				`sandbox="allow-scripts allow-same-origin allow-presentation allow-popups"></iframe></figure>`,
+		},
+		{
+			name:     "photo",
+			url:      "https://video.example/p/2",
+			expected: `<figure class="embed" data-embed-type="photo"><img src="https://video.example/2.jpg" alt="Sunset" loading="lazy" width="800" height="600"></figure>`,
+		},
+		{
+			name:     "failure",
+			url:      "https://video.example/missing",
+			expected: `<a href="https://video.example/missing">https://video.example/missing</a>`,
+		},
+		{
+			name:     "no provider",
+			url:      "https://other.example/",
+			expected: `<a href="https://other.example/">https://other.example/</a>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Render(&buf, Embed(ctx, tt.url)); err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, buf.String())
+			}
+		})
+	}
+
+	before := requests.Load()
+	if _, err := client.Resolve(ctx, "https://video.example/v/1"); err != nil {
+		t.Fatal(err)
+	}
+	if requests.Load() != before {
+		t.Error("expected resolved embeds to be cached")
+	}
+	if _, err := client.Resolve(ctx, "https://other.example/"); !errors.Is(err, ErrNoOEmbedProvider) {
+		t.Errorf("expected ErrNoOEmbedProvider, got %v", err)
+	}
+}
+
+func TestMatchWildcard(t *testing.T) {
+	tests := []struct {
+		pattern, s string
+		expected   bool
+	}{
+		{"https://x.com/*/status/*", "https://x.com/go/status/1", true},
+		{"https://x.com/*/status/*", "https://x.com/go/likes", false},
+		{"https://youtu.be/*", "https://youtu.be/", true},
+		{"https://youtu.be/*", "https://youtu.bee/", false},
+		{"https://example.com/", "https://example.com/", true},
+	}
+	for _, tt := range tests {
+		if got := matchWildcard(tt.pattern, tt.s); got != tt.expected {
+			t.Errorf("matchWildcard(%q, %q) = %v, expected %v", tt.pattern, tt.s, got, tt.expected)
+		}
+	}
+}
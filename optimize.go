@@ -0,0 +1,207 @@
+package h
+
+import (
+	"bytes"
+	"html"
+	"io"
+)
+
+// Optimize returns a node rendering the same HTML as node, with its static
+// parts folded ahead of time: adjacent [Text], [RawText] and element tags
+// are pre-rendered and merged into single nodes, so rendering the result
+// costs a few writes instead of one per node. The [Limits] in effect when
+// the result is rendered still apply to the elements folded into it.
+//
+// Nodes of other types (custom components, [Memo] fragments...) are kept as
+// they are and still rendered on every call, so Optimize is safe to use on
+// trees mixing static markup with dynamic parts. The content of wrappers,
+// such as [NamedNode], is optimized on its own. Elements with custom
+// [Attribute] types, or whose attributes fail to render, are kept too.
+//
+// Optimize walks the whole tree, so it pays off for trees built once and
+// rendered many times, such as layouts and static pages.
+//
+// Example:
+//
+//	var footer = Optimize(FOOTER(AttrClass("footer"))(
+//		NAV()(A(AttrHref("/about"))("About"), A(AttrHref("/privacy"))("Privacy")),
+//		P()("© 2025 Example Inc."),
+//	))
+func Optimize(node HyperNode) HyperNode {
+	var o optimizer
+	o.add(node)
+	o.flush()
+
+	if len(o.parts) == 1 {
+		return o.parts[0]
+	}
+	return Element{Children: o.parts}
+}
+
+type optimizer struct {
+	parts  []HyperNode
+	static bytes.Buffer // Static output not yet flushed to parts
+	folded staticHTML   // Elements folded into static
+}
+
+func (me *optimizer) flush() {
+	if me.static.Len() != 0 {
+		me.folded.html = me.static.String()
+		me.parts = append(me.parts, me.folded)
+		me.static.Reset()
+		me.folded = staticHTML{}
+	}
+}
+
+// fold records the sizes of element, whose markup is being added to the
+// static output.
+func (me *optimizer) fold(element Element) {
+	if me.folded.tag == "" {
+		me.folded.tag = element.Tag
+	}
+	if n := countAttributes(element.Attributes); n > me.folded.attributes.n {
+		me.folded.attributes = elementCount{element.Tag, n}
+	}
+	if n := countChildren(element.Children); n > me.folded.children.n {
+		me.folded.children = elementCount{element.Tag, n}
+	}
+}
+
+func (me *optimizer) dynamic(node HyperNode) {
+	me.flush()
+	me.parts = append(me.parts, node)
+}
+
+func (me *optimizer) add(node HyperNode) {
+	switch n := node.(type) {
+	case Text:
+		me.static.WriteString(html.EscapeString(string(n)))
+	case RawText:
+		me.static.WriteString(string(n))
+	case Element:
+		me.addElement(n)
+	case SensitiveNode:
+		// Its hx-history attribute goes on the element it wraps, which
+		// must stay an element.
+		if element, ok := n.Node.(Element); ok && element.Tag != "" {
+			element.Children = []HyperNode{Optimize(Element{Children: element.Children})}
+			me.dynamic(Sensitive(element))
+			return
+		}
+		me.dynamic(node)
+	case DeferredNode, idChecker:
+		// Deferred content is loaded on each render, too late to be
+		// optimized, and CheckIDs needs the elements to check.
+		me.dynamic(node)
+	case Wrapper:
+		me.dynamic(mapWrapped(n, Optimize))
+	default:
+		me.dynamic(node)
+	}
+}
+
+func (me *optimizer) addElement(element Element) {
+	if element.Tag == "" {
+		me.fold(element)
+		for _, child := range element.Children {
+			me.add(child)
+		}
+		return
+	}
+
+	var tag bytes.Buffer
+	tag.WriteByte('<')
+	tag.WriteString(element.Tag)
+	for _, attr := range element.Attributes {
+		if !isCoreAttribute(attr) || attr.Render(&tag) != nil {
+			me.dynamic(Element{
+				Tag:        element.Tag,
+				IsVoid:     element.IsVoid,
+				Attributes: element.Attributes,
+				Children:   []HyperNode{Optimize(Element{Children: element.Children})},
+			})
+			return
+		}
+	}
+	tag.WriteByte('>')
+	me.fold(element)
+	me.static.Write(tag.Bytes())
+
+	if element.IsVoid {
+		return
+	}
+	for _, child := range element.Children {
+		me.add(child)
+	}
+	me.static.WriteString("</")
+	me.static.WriteString(element.Tag)
+	me.static.WriteByte('>')
+}
+
+// staticHTML is markup pre-rendered by [Optimize]. It keeps the largest
+// attribute and child counts of the elements folded into it, to check them
+// against the [Limits] in effect when it is rendered.
+type staticHTML struct {
+	html       string
+	tag        string // Tag of the first element folded, for errors
+	attributes elementCount
+	children   elementCount
+}
+
+// elementCount is the number of attributes or children of an element.
+type elementCount struct {
+	tag string
+	n   int
+}
+
+func (me staticHTML) Render(w io.Writer) error {
+	if l := limits.Load(); l != nil {
+		if err := me.checkLimits(l); err != nil {
+			return err
+		}
+		if l.MaxOutputBytes > 0 && len(me.html) > l.MaxOutputBytes {
+			return LimitError{Limit: "output bytes", Tag: me.tag, Size: len(me.html), Max: l.MaxOutputBytes}
+		}
+	}
+	_, err := io.WriteString(w, me.html)
+	return err
+}
+
+func (me staticHTML) RenderToBuffer(buf *bytes.Buffer) error {
+	l := limits.Load()
+	if l == nil {
+		buf.WriteString(me.html)
+		return nil
+	}
+	if err := me.checkLimits(l); err != nil {
+		return err
+	}
+	buf.WriteString(me.html)
+	return checkOutputLimit(l, me.tag, buf)
+}
+
+func (me staticHTML) checkLimits(l *Limits) error {
+	if l.MaxAttributes > 0 && me.attributes.n > l.MaxAttributes {
+		return LimitError{Limit: "attributes", Tag: me.attributes.tag, Size: me.attributes.n, Max: l.MaxAttributes}
+	}
+	if l.MaxChildren > 0 && me.children.n > l.MaxChildren {
+		return LimitError{Limit: "children", Tag: me.children.tag, Size: me.children.n, Max: l.MaxChildren}
+	}
+	return nil
+}
+
+func isCoreAttribute(attr Attribute) bool {
+	switch a := attr.(type) {
+	case PairAttribute, BooleanAttribute:
+		return true
+	case Attributes:
+		for _, attr := range a {
+			if !isCoreAttribute(attr) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
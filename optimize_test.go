@@ -0,0 +1,105 @@
+package h
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+// counter is a dynamic node rendering a different value each time.
+type counter struct{ n *int }
+
+func (me counter) Render(w io.Writer) error {
+	*me.n++
+	_, err := io.WriteString(w, string(rune('0'+*me.n)))
+	return err
+}
+
+func TestOptimize(t *testing.T) {
+	n := 0
+	tests := []struct {
+		name  string
+		node  HyperNode
+		parts int // Expected number of children of the optimized group; 0 for a single node
+	}{
+		{
+			name: "Static",
+			node: DIV(AttrClass("card"), AttrHidden(true))(H1()("Title & more"), P()(RawText("<b>raw</b>")), BR()),
+		},
+		{
+			name:  "Dynamic child",
+			node:  UL()(LI()("one"), LI()(counter{&n}), LI()("three")),
+			parts: 3,
+		},
+		{
+			name:  "Named",
+			node:  DIV()(Named("Greeting", P()("hello")), "after"),
+			parts: 3,
+		},
+		{
+			name: "Group",
+			node: Group("a", Group("b", SPAN()("c"))),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n = 0
+			var expected bytes.Buffer
+			if err := Render(&expected, tt.node); err != nil {
+				t.Fatal(err)
+			}
+
+			optimized := Optimize(tt.node)
+			n = 0
+			var actual bytes.Buffer
+			if err := Render(&actual, optimized); err != nil {
+				t.Fatal(err)
+			}
+			if actual.String() != expected.String() {
+				t.Errorf("expected %q, got %q", expected.String(), actual.String())
+			}
+
+			if tt.parts == 0 {
+				if _, ok := optimized.(staticHTML); !ok {
+					t.Errorf("expected a single static node, got %T", optimized)
+				}
+			} else if group, ok := optimized.(Element); !ok || group.Tag != "" || len(group.Children) != tt.parts {
+				t.Errorf("expected a group of %d parts, got %#v", tt.parts, optimized)
+			}
+		})
+	}
+}
+
+func TestOptimizeKeepsRenderErrors(t *testing.T) {
+	node := DIV()(SPAN(Attr(" ", "x"))("bad"))
+	if err := Render(io.Discard, Optimize(node)); err == nil {
+		t.Error("expected the attribute error to surface at render time")
+	}
+}
+
+func deepTree(depth int) HyperNode {
+	if depth == 0 {
+		return SPAN(AttrClass("leaf"))("leaf")
+	}
+	return DIV(AttrClass("level"))(deepTree(depth-1), deepTree(depth-1))
+}
+
+func BenchmarkDeepNesting(b *testing.B) {
+	b.Run("Plain", func(b *testing.B) {
+		page := deepTree(8)
+		var buf bytes.Buffer
+		for b.Loop() {
+			buf.Reset()
+			page.Render(&buf)
+		}
+	})
+	b.Run("Optimized", func(b *testing.B) {
+		page := Optimize(deepTree(8))
+		var buf bytes.Buffer
+		for b.Loop() {
+			buf.Reset()
+			page.Render(&buf)
+		}
+	})
+}
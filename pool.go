@@ -0,0 +1,79 @@
+package h
+
+import (
+	"bytes"
+	"sync"
+	"sync/atomic"
+)
+
+// Buffers used during rendering are pooled by size class, so a large page
+// gets a buffer that already fits it instead of growing a small one (copying
+// its content each time), and small fragments don't pin large buffers.
+const (
+	smallBufferSize  = 1 << 10   // 1KB
+	mediumBufferSize = 16 << 10  // 16KB
+	largeBufferSize  = 128 << 10 // 128KB
+
+	// maxPooledBufferSize is the capacity above which buffers are dropped
+	// rather than pooled, bounding the memory held by the pools.
+	maxPooledBufferSize = 1 << 20 // 1MB
+)
+
+var bufferClasses = [...]struct {
+	size int
+	pool *sync.Pool
+}{
+	{smallBufferSize, newBufferPool(smallBufferSize)},
+	{mediumBufferSize, newBufferPool(mediumBufferSize)},
+	{largeBufferSize, newBufferPool(largeBufferSize)},
+}
+
+func newBufferPool(size int) *sync.Pool {
+	return &sync.Pool{
+		New: func() any {
+			var buf bytes.Buffer
+			buf.Grow(size)
+			return &buf
+		},
+	}
+}
+
+// getBuffer returns an empty buffer from the smallest size class fitting
+// estimate, or from the largest class.
+func getBuffer(estimate int) *bytes.Buffer {
+	for _, class := range bufferClasses[:len(bufferClasses)-1] {
+		if estimate <= class.size {
+			return class.pool.Get().(*bytes.Buffer)
+		}
+	}
+	return bufferClasses[len(bufferClasses)-1].pool.Get().(*bytes.Buffer)
+}
+
+// putBuffer returns buf to the largest size class its capacity covers, so
+// buffers that grew while rendering end up in the class they now fit.
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBufferSize {
+		return
+	}
+	buf.Reset()
+	for i := len(bufferClasses) - 1; i >= 0; i-- {
+		if buf.Cap() >= bufferClasses[i].size {
+			bufferClasses[i].pool.Put(buf)
+			return
+		}
+	}
+}
+
+// sizeHints remembers the last output size of elements per tag, used as the
+// estimate of their next render: the <html> of a page is consistently large,
+// a <li> consistently small. Tags share slots by hash; a collision only
+// makes an estimate less accurate.
+var sizeHints [64]atomic.Int64
+
+func sizeHint(tag string) *atomic.Int64 {
+	hash := uint32(2166136261)
+	for i := 0; i < len(tag); i++ {
+		hash = (hash ^ uint32(tag[i])) * 16777619
+	}
+	return &sizeHints[hash%uint32(len(sizeHints))]
+}
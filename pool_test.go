@@ -0,0 +1,57 @@
+package h
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestBufferPool(t *testing.T) {
+	tests := []struct {
+		estimate int
+		minCap   int
+	}{
+		{estimate: 0, minCap: smallBufferSize},
+		{estimate: 2 << 10, minCap: mediumBufferSize},
+		{estimate: 100 << 10, minCap: largeBufferSize},
+		{estimate: 4 << 20, minCap: largeBufferSize},
+	}
+	for _, tt := range tests {
+		buf := getBuffer(tt.estimate)
+		if buf.Len() != 0 || buf.Cap() < tt.minCap {
+			t.Errorf("getBuffer(%d): len %d, cap %d, want empty with cap >= %d", tt.estimate, buf.Len(), buf.Cap(), tt.minCap)
+		}
+		putBuffer(buf)
+	}
+
+	// Oversized buffers are dropped rather than pooled.
+	putBuffer(bytes.NewBuffer(make([]byte, 0, 2*maxPooledBufferSize)))
+	if buf := getBuffer(4 << 20); buf.Cap() > maxPooledBufferSize {
+		t.Errorf("oversized buffer was pooled")
+	}
+}
+
+func TestSizeHint(t *testing.T) {
+	page := SECTION()(strings.Repeat("x", 20<<10))
+	var buf bytes.Buffer
+	page.Render(&buf)
+	if hint := sizeHint("section").Load(); hint != int64(buf.Len()) {
+		t.Errorf("expected size hint %d, got %d", buf.Len(), hint)
+	}
+}
+
+func BenchmarkConcurrentPages(b *testing.B) {
+	rows := make([]any, 1000)
+	for i := range rows {
+		rows[i] = TR()(TD()("cell"), TD()("cell"), TD()("cell"))
+	}
+	page := HTML()(BODY()(TABLE()(rows...)), P()("small"))
+
+	b.RunParallel(func(pb *testing.PB) {
+		var buf bytes.Buffer
+		for pb.Next() {
+			buf.Reset()
+			page.Render(&buf)
+		}
+	})
+}
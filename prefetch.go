@@ -0,0 +1,87 @@
+package h
+
+import "strings"
+
+// PrefetchOptions customizes [PrefetchLinks]. All fields are optional.
+type PrefetchOptions struct {
+	// Selector matches the links to prefetch; defaults to "nav a[href]",
+	// the primary navigation.
+	Selector string
+	// Trigger is the event starting the prefetch, as a value of the preload
+	// attribute: "mouseover" (the default), "mousedown" or "preload:init".
+	Trigger string
+}
+
+// PrefetchLinks returns node with its navigation links prefetched by the
+// htmx preload extension, making navigation feel instant: the links get
+// the preload attribute, and their closest enclosing element matched by
+// the selector's first part (e.g. the <nav>) gets hx-ext="preload". Links
+// to other sites, anchors and links opting out with preload="false" are
+// left alone. The extension script must be included in the page.
+//
+// Example:
+//
+//	page = PrefetchLinks(page)
+//	// <nav hx-ext="preload"><a href="/docs" preload="mouseover">Docs</a></nav>
+func PrefetchLinks(node HyperNode, options ...PrefetchOptions) HyperNode {
+	var o PrefetchOptions
+	if len(options) != 0 {
+		o = options[0]
+	}
+	selector := IfElse(o.Selector != "", o.Selector, "nav a[href]")
+	trigger := IfElse(o.Trigger != "", o.Trigger, "mouseover")
+
+	parsed, err := ParseSelector(selector)
+	if err != nil {
+		return errorNode{err: err}
+	}
+	// The container is the outermost compound of the selector, e.g. "nav".
+	container, err := ParseSelector(strings.Fields(selector)[0])
+	if err != nil {
+		return errorNode{err: err}
+	}
+
+	return transform(node, nil, container.Matches, func(e Element) HyperNode {
+		var marked bool
+		e = transform(e, nil, parsed.Matches, func(link Element) HyperNode {
+			href, _ := link.Attribute("href")
+			if _, ok := link.Attribute("preload"); ok || !isInternalLink(href) {
+				return link
+			}
+			marked = true
+			link.Attributes = append(link.Attributes[:len(link.Attributes):len(link.Attributes)], Attr("preload", trigger))
+			return link
+		}).(Element)
+		if marked {
+			e.Attributes = appendExtension(e.Attributes, "preload")
+		}
+		return e
+	})
+}
+
+// isInternalLink reports whether href points to a page of the same site.
+func isInternalLink(href string) bool {
+	href = strings.TrimSpace(href)
+	switch {
+	case href == "", strings.HasPrefix(href, "#"), strings.HasPrefix(href, "//"):
+		return false
+	case strings.Contains(href, ":") && !strings.ContainsAny(href[:strings.Index(href, ":")], "/?#"):
+		// Has a scheme: http:, mailto:, javascript:...
+		return false
+	}
+	return true
+}
+
+// appendExtension returns a copy of attrs with ext added to the
+// comma-separated list of hx-ext.
+func appendExtension(attrs []Attribute, ext string) []Attribute {
+	if value, ok := lookupAttribute(attrs, "hx-ext"); ok {
+		for existing := range strings.SplitSeq(value, ",") {
+			if strings.TrimSpace(existing) == ext {
+				return attrs
+			}
+		}
+		return append(removeAttr(attrs, "hx-ext"), Attr("hx-ext", value+", "+ext))
+	}
+	return append(attrs[:len(attrs):len(attrs)], Attr("hx-ext", ext))
+}
@@ -0,0 +1,42 @@
+package h
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPrefetchLinks(t *testing.T) {
+	page := BODY()(
+		NAV(Attr("hx-ext", "head-support"))(
+			A(AttrHref("/docs"))("Docs"),
+			A(AttrHref("https://github.com/assaidy/hyper"))("GitHub"),
+			A(AttrHref("#top"))("Top"),
+			A(AttrHref("/logout"), Attr("preload", "false"))("Sign out"),
+		),
+		NAV()(A(AttrHref("mailto:hi@example.com"))("Contact")),
+		MAIN()(A(AttrHref("/blog"))("Blog")),
+	)
+
+	var buf bytes.Buffer
+	if err := Render(&buf, PrefetchLinks(page)); err != nil {
+		t.Fatal(err)
+	}
+	expected := `<body><nav hx-ext="head-support, preload">` +
+		`<a href="/docs" preload="mouseover">Docs</a>` +
+		`<a href="https://github.com/assaidy/hyper">GitHub</a>` +
+		`<a href="#top">Top</a>` +
+		`<a href="/logout" preload="false">Sign out</a></nav>` +
+		`<nav><a href="mailto:hi@example.com">Contact</a></nav>` +
+		`<main><a href="/blog">Blog</a></main></body>`
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+
+	buf.Reset()
+	if err := Render(&buf, PrefetchLinks(page, PrefetchOptions{Selector: "main a", Trigger: "mousedown"})); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte(`<main hx-ext="preload"><a href="/blog" preload="mousedown">Blog</a></main>`)) {
+		t.Errorf("unexpected output %q", buf.String())
+	}
+}
@@ -0,0 +1,175 @@
+package h
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// bindingMarker delimits binding placeholders in the output rendered by
+// [Prepare]. NUL bytes don't occur in HTML built from regular text.
+const bindingMarker = "\x00hyper-binding:"
+
+var errMalformedBinding = errors.New("h: malformed binding in prepared page")
+
+type bindingKind int
+
+const (
+	bindText bindingKind = iota
+	bindNode
+	bindAttr
+)
+
+type binding struct {
+	name string
+	kind bindingKind
+}
+
+// Binder declares the named bindings of a page built by [Prepare].
+type Binder struct {
+	bindings []binding
+}
+
+func (me *Binder) marker(name string, kind bindingKind) string {
+	me.bindings = append(me.bindings, binding{name: name, kind: kind})
+	return bindingMarker + strconv.Itoa(len(me.bindings)-1) + "\x00"
+}
+
+// Text declares a binding rendered as escaped text. Values are formatted
+// like the children of an element: strings as they are, other values with
+// fmt.Sprint.
+func (me *Binder) Text(name string) HyperNode {
+	return RawText(me.marker(name, bindText))
+}
+
+// Node declares a binding whose value is a [HyperNode], rendered in place.
+// String values are rendered as escaped text.
+func (me *Binder) Node(name string) HyperNode {
+	return RawText(me.marker(name, bindNode))
+}
+
+// Attr declares an attribute whose value is bound to name.
+func (me *Binder) Attr(key, name string) Attribute {
+	return PairAttribute{Key: key, Value: me.marker(name, bindAttr)}
+}
+
+// Prepared is a page rendered ahead of time except for its bindings. It is
+// safe for concurrent use.
+type Prepared struct {
+	static   []string // static[i] is written before bindings[i]; one more than bindings
+	bindings []binding
+	size     int // Length of the static output, as buffer size estimate
+	err      error
+}
+
+// Prepare renders the page built by builder once, leaving holes for the
+// bindings declared with b, and returns it ready to be executed with values
+// for each request. It is a middle ground between rebuilding the tree per
+// request and a fully static [Optimize]d page: the static markup is rendered
+// once, and only the bound values are written per request.
+//
+// The tree is built once, so builder must not branch on request data: anything
+// varying per request goes through a binding.
+//
+// Example:
+//
+//	var profilePage = Prepare(func(b *Binder) HyperNode {
+//		return Layout("Profile")(
+//			H1()("Hello, ", b.Text("name")),
+//			A(b.Attr("href", "url"))("Your profile"),
+//			b.Node("activity"),
+//		)
+//	})
+//
+//	profilePage.Execute(w, map[string]any{
+//		"name":     user.Name,
+//		"url":      "/users/" + user.ID,
+//		"activity": ActivityList(user.Activity),
+//	})
+func Prepare(builder func(b *Binder) HyperNode) *Prepared {
+	var b Binder
+	var buf bytes.Buffer
+	if err := Render(&buf, builder(&b)); err != nil {
+		return &Prepared{err: err}
+	}
+
+	prepared := &Prepared{}
+	rest := buf.String()
+	for {
+		start := strings.Index(rest, bindingMarker)
+		if start < 0 {
+			break
+		}
+		marker := rest[start+len(bindingMarker):]
+		end := strings.IndexByte(marker, 0)
+		if end < 0 {
+			return &Prepared{err: errMalformedBinding}
+		}
+		index, err := strconv.Atoi(marker[:end])
+		if err != nil || index >= len(b.bindings) {
+			return &Prepared{err: errMalformedBinding}
+		}
+		prepared.static = append(prepared.static, rest[:start])
+		prepared.bindings = append(prepared.bindings, b.bindings[index])
+		rest = marker[end+1:]
+	}
+	prepared.static = append(prepared.static, rest)
+	prepared.size = buf.Len()
+	return prepared
+}
+
+// Execute writes the page to w with values substituted for its bindings. A
+// binding missing from values is an error.
+func (me *Prepared) Execute(w io.Writer, values map[string]any) error {
+	if me.err != nil {
+		return me.err
+	}
+
+	buf := getBuffer(me.size)
+	defer putBuffer(buf)
+
+	for i, binding := range me.bindings {
+		buf.WriteString(me.static[i])
+		value, ok := values[binding.name]
+		if !ok {
+			return fmt.Errorf("h: missing value for binding %q", binding.name)
+		}
+		if err := writeBinding(buf, binding.kind, value); err != nil {
+			return fmt.Errorf("h: binding %q: %w", binding.name, err)
+		}
+	}
+	buf.WriteString(me.static[len(me.static)-1])
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func writeBinding(buf *bytes.Buffer, kind bindingKind, value any) error {
+	switch kind {
+	case bindNode:
+		if node, ok := value.(HyperNode); ok {
+			return Element{Children: []HyperNode{node}}.renderChildren(buf)
+		}
+		buf.WriteString(html.EscapeString(formatValue(value)))
+	case bindAttr:
+		writeAttrValue(buf, formatValue(value))
+	default:
+		buf.WriteString(html.EscapeString(formatValue(value)))
+	}
+	return nil
+}
+
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
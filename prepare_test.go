@@ -0,0 +1,63 @@
+package h
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPrepare(t *testing.T) {
+	page := Prepare(func(b *Binder) HyperNode {
+		return DIV(AttrClass("profile"))(
+			H1()("Hello, ", b.Text("name")),
+			A(b.Attr("href", "url"))("Profile"),
+			b.Node("activity"),
+			SPAN()(b.Text("count")),
+		)
+	})
+
+	var buf bytes.Buffer
+	err := page.Execute(&buf, map[string]any{
+		"name":     "<Ada>",
+		"url":      `/users/1?q="x"`,
+		"activity": UL()(LI()("Logged in")),
+		"count":    3,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `<div class="profile"><h1>Hello, &lt;Ada&gt;</h1><a href="/users/1?q=&quot;x&quot;">Profile</a><ul><li>Logged in</li></ul><span>3</span></div>`
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+
+	buf.Reset()
+	if err := page.Execute(&buf, map[string]any{"name": "x"}); err == nil {
+		t.Error("expected an error for missing bindings")
+	}
+}
+
+func BenchmarkPrepared(b *testing.B) {
+	rows := make([]any, 100)
+	for i := range rows {
+		rows[i] = LI(AttrClass("item"))("static item")
+	}
+
+	b.Run("Rebuild", func(b *testing.B) {
+		var buf bytes.Buffer
+		for b.Loop() {
+			buf.Reset()
+			DIV()(H1()("Hello, ", "Ada"), UL()(rows...)).Render(&buf)
+		}
+	})
+	b.Run("Prepared", func(b *testing.B) {
+		page := Prepare(func(binder *Binder) HyperNode {
+			return DIV()(H1()("Hello, ", binder.Text("name")), UL()(rows...))
+		})
+		values := map[string]any{"name": "Ada"}
+		var buf bytes.Buffer
+		for b.Loop() {
+			buf.Reset()
+			page.Execute(&buf, values)
+		}
+	})
+}
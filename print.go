@@ -0,0 +1,119 @@
+package h
+
+import (
+	"io"
+	"slices"
+)
+
+// Class* constants are the utility classes defined by [PrintBaseStyle].
+const (
+	// ClassPageBreakBefore starts the element on a new printed page.
+	ClassPageBreakBefore = "page-break-before"
+	// ClassPageBreakAfter starts a new printed page after the element.
+	ClassPageBreakAfter = "page-break-after"
+	// ClassAvoidPageBreak keeps the element on a single printed page when possible.
+	ClassAvoidPageBreak = "avoid-page-break"
+	// ClassNoPrint hides the element when printing. It is also removed by [PrintProfile].
+	ClassNoPrint = "no-print"
+	// ClassPrintOnly shows the element only when printing.
+	ClassPrintOnly = "print-only"
+)
+
+// PrintStyle creates a <style> element whose rules only apply when printing.
+//
+// Example:
+//
+//	HEAD()(
+//		PrintStyle("body{font-size:11pt} a[href]::after{content:' (' attr(href) ')'}"),
+//	)
+func PrintStyle(css string, attrs ...Attribute) HyperNode {
+	return STYLE(attrs...)(RawText("@media print{" + css + "}"))
+}
+
+// PrintBaseStyle creates a <style> element defining the page-break and
+// visibility utility classes (see the Class* constants).
+//
+// Example:
+//
+//	HEAD()(PrintBaseStyle())
+//	BODY()(
+//		SECTION(AttrClass(ClassAvoidPageBreak))(...),
+//		SECTION(AttrClass(ClassPageBreakBefore))(...),
+//	)
+func PrintBaseStyle(attrs ...Attribute) HyperNode {
+	return STYLE(attrs...)(RawText(
+		"." + ClassPrintOnly + "{display:none}" +
+			"@media print{" +
+			"." + ClassPageBreakBefore + "{break-before:page}" +
+			"." + ClassPageBreakAfter + "{break-after:page}" +
+			"." + ClassAvoidPageBreak + "{break-inside:avoid}" +
+			"." + ClassNoPrint + "{display:none!important}" +
+			"." + ClassPrintOnly + "{display:revert}" +
+			"}",
+	))
+}
+
+// PrintProfile describes what to remove from a page when rendering a
+// dedicated printable version, such as an invoice or report download.
+//
+// Unlike print stylesheets, which only hide content, a profile removes it from
+// the output altogether, so the markup is also suitable for HTML-to-PDF tools.
+type PrintProfile struct {
+	StripTags  []string // Elements removed with their content
+	StripClass string   // Elements with this class are removed with their content
+}
+
+// DefaultPrintProfile removes navigation, interactive controls and scripts,
+// and elements marked with [ClassNoPrint].
+var DefaultPrintProfile = PrintProfile{
+	StripTags:  []string{"nav", "button", "dialog", "script", "noscript", "template", "iframe"},
+	StripClass: ClassNoPrint,
+}
+
+// Apply returns a copy of node without the elements matched by the profile.
+// The original tree is not modified. Elements inside the wrappers of this
+// package, such as [Named], are inspected too; other node types are kept as
+// they are.
+func (me PrintProfile) Apply(node HyperNode) HyperNode {
+	result, _ := me.apply(node)
+	return result
+}
+
+func (me PrintProfile) apply(node HyperNode) (HyperNode, bool) {
+	if wrapper, ok := node.(Wrapper); ok {
+		return mapWrapped(wrapper, func(child HyperNode) HyperNode {
+			if child, keep := me.apply(child); keep {
+				return child
+			}
+			return Group()
+		}), true
+	}
+	element, ok := node.(Element)
+	if !ok {
+		return node, true
+	}
+
+	if slices.Contains(me.StripTags, element.Tag) || (me.StripClass != "" && element.HasClass(me.StripClass)) {
+		return nil, false
+	}
+
+	children := make([]HyperNode, 0, len(element.Children))
+	for _, child := range element.Children {
+		if child, keep := me.apply(child); keep {
+			children = append(children, child)
+		}
+	}
+	element.Children = children
+	return element, true
+}
+
+// RenderPrintable renders node with [DefaultPrintProfile] applied.
+//
+// Example:
+//
+//	func invoicePDF(w http.ResponseWriter, r *http.Request) {
+//		RenderPrintable(w, invoicePage(invoice))
+//	}
+func RenderPrintable(w io.Writer, node HyperNode) error {
+	return Render(w, DefaultPrintProfile.Apply(node))
+}
@@ -0,0 +1,66 @@
+package h
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPrintStyle(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, PrintStyle("body{color:#000}")); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if expected := "<style>@media print{body{color:#000}}</style>"; buf.String() != expected {
+		t.Errorf("PrintStyle() = %q, want %q", buf.String(), expected)
+	}
+}
+
+func TestPrintProfile_Apply(t *testing.T) {
+	page := BODY()(
+		NAV()(A(AttrHref("/"))("Home")),
+		MAIN()(
+			H1()("Invoice #42"),
+			P(AttrClass("note no-print"))("Pay online"),
+			BUTTON()("Print"),
+			TABLE(AttrClass(ClassAvoidPageBreak))(TR()(TD()("Total"))),
+		),
+		SCRIPT()("track()"),
+	)
+
+	tests := []struct {
+		name     string
+		node     HyperNode
+		expected string
+	}{
+		{
+			name:     "Default profile",
+			node:     DefaultPrintProfile.Apply(page),
+			expected: `<body><main><h1>Invoice #42</h1><table class="avoid-page-break"><tr><td>Total</td></tr></table></main></body>`,
+		},
+		{
+			name:     "Custom profile",
+			node:     PrintProfile{StripTags: []string{"table"}}.Apply(page),
+			expected: `<body><nav><a href="/">Home</a></nav><main><h1>Invoice #42</h1><p class="note no-print">Pay online</p><button>Print</button></main><script>track()</script></body>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Render(&buf, tt.node); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("Apply() = %q, want %q", buf.String(), tt.expected)
+			}
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := Render(&buf, page); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("<nav>")) {
+		t.Error("Apply() should not modify the original tree")
+	}
+}
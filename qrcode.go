@@ -0,0 +1,125 @@
+package h
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/assaidy/hyper/v2/internal/qrcode"
+)
+
+// QRLevel is the error correction level of a QR code. Higher levels survive
+// more damage (or a logo on top) at the cost of a denser symbol.
+type QRLevel int
+
+const (
+	QRLevelM QRLevel = iota // Recovers ~15% of damaged data (default)
+	QRLevelL                // Recovers ~7% of damaged data
+	QRLevelQ                // Recovers ~25% of damaged data
+	QRLevelH                // Recovers ~30% of damaged data
+)
+
+var qrLevels = map[QRLevel]qrcode.Level{
+	QRLevelL: qrcode.LevelL,
+	QRLevelM: qrcode.LevelM,
+	QRLevelQ: qrcode.LevelQ,
+	QRLevelH: qrcode.LevelH,
+}
+
+// QROptions customizes a QR code rendered by [QRCode]. All fields are optional.
+type QROptions struct {
+	Level      QRLevel     // Error correction level; defaults to QRLevelM
+	QuietZone  int         // Light border width in modules; defaults to 4 (the minimum the standard allows)
+	Foreground string      // Color of dark modules; defaults to "#000000"
+	Background string      // Color of light modules; defaults to "#ffffff"
+	Title      string      // Accessible label of the image; defaults to "QR code", keeping the data, such as 2FA secrets, out of the markup
+	Attributes []Attribute // Extra attributes appended to the <svg> element
+}
+
+// QRCode creates an inline SVG QR code encoding data, size pixels wide and high.
+//
+// The code is encoded in pure Go when QRCode is called, so pages can show
+// login links, 2FA setup secrets or tickets without an image endpoint.
+// Rendering the returned node fails if data is too long to fit in a QR
+// code, or if the level is invalid.
+//
+// Example:
+//
+//	QRCode(otpauthURL, 200, QROptions{Level: QRLevelQ, Title: "Scan with your authenticator app"})
+func QRCode(data string, size int, options ...QROptions) HyperNode {
+	var opts QROptions
+	if len(options) != 0 {
+		opts = options[0]
+	}
+
+	level, ok := qrLevels[opts.Level]
+	if !ok {
+		return errorNode{err: fmt.Errorf("h: invalid QR code level %d", opts.Level)}
+	}
+
+	code, err := qrcode.Encode([]byte(data), level)
+	if err != nil {
+		return errorNode{err: err}
+	}
+
+	quietZone := opts.QuietZone
+	if quietZone <= 0 {
+		quietZone = 4
+	}
+	foreground := IfElse(opts.Foreground != "", opts.Foreground, "#000000")
+	background := IfElse(opts.Background != "", opts.Background, "#ffffff")
+	title := IfElse(opts.Title != "", opts.Title, "QR code")
+
+	// All dark modules are drawn as a single path of 1x1 squares.
+	var path strings.Builder
+	for y := range code.Size {
+		for x := range code.Size {
+			if code.Dark(x, y) {
+				path.WriteByte('M')
+				path.WriteString(strconv.Itoa(x + quietZone))
+				path.WriteByte(',')
+				path.WriteString(strconv.Itoa(y + quietZone))
+				path.WriteString("h1v1h-1z")
+			}
+		}
+	}
+
+	dimension := strconv.Itoa(size)
+	viewBox := strconv.Itoa(code.Size + 2*quietZone)
+
+	svg := Element{Tag: "svg"}
+	svg.Attributes = append(svg.Attributes,
+		Attr("xmlns", "http://www.w3.org/2000/svg"),
+		AttrWidth(dimension),
+		AttrHeight(dimension),
+		Attr("viewBox", "0 0 "+viewBox+" "+viewBox),
+		Attr("shape-rendering", "crispEdges"),
+		AttrRole("img"),
+		AttrAriaLabel(title),
+	)
+	svg.Attributes = append(svg.Attributes, opts.Attributes...)
+	svg.Children = append(svg.Children,
+		Element{Tag: "rect", Attributes: []Attribute{
+			AttrWidth("100%"),
+			AttrHeight("100%"),
+			Attr("fill", background),
+		}},
+		Element{Tag: "path", Attributes: []Attribute{
+			Attr("d", path.String()),
+			Attr("fill", foreground),
+		}},
+	)
+	return svg
+}
+
+// errorNode is a node that fails to render with a fixed error. It lets
+// constructors that cannot return an error report failures at render time,
+// the same way invalid attributes do.
+type errorNode struct {
+	err error
+}
+
+func (me errorNode) Render(io.Writer) error {
+	return me.err
+}
@@ -0,0 +1,45 @@
+package h
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestQRCode(t *testing.T) {
+	var buf bytes.Buffer
+	err := Render(&buf, QRCode("https://example.com", 128, QROptions{Foreground: "#111"}))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	// "https://example.com" fits in a version 2 (25x25) symbol at level M,
+	// plus a quiet zone of 4 modules on each side.
+	for _, want := range []string{
+		`width="128" height="128" viewBox="0 0 33 33"`,
+		`aria-label="QR code"`,
+		`<path d="M4,4h1v1h-1z`,
+		`fill="#111"`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("QRCode() = %q, want it to contain %q", buf.String(), want)
+		}
+	}
+	if strings.Contains(buf.String(), "example.com") {
+		t.Errorf("QRCode() = %q, want the data kept out of the markup", buf.String())
+	}
+}
+
+func TestQRCode_DataTooLong(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, DIV()(QRCode(strings.Repeat("a", 3000), 128))); err == nil {
+		t.Error("Render() should return error for data that does not fit in a QR code")
+	}
+}
+
+func TestQRCode_InvalidLevel(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, DIV()(QRCode("hello", 128, QROptions{Level: QRLevel(42)}))); err == nil {
+		t.Error("Render() should return error for an invalid level")
+	}
+}
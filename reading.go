@@ -0,0 +1,93 @@
+package h
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ReadingSpeed is the number of words read per minute by [ReadingTime]:
+// the average silent reading speed of adults for non-fiction.
+var ReadingSpeed = 238
+
+// WordCount returns the number of words of the text of node. Words are
+// separated by spaces and block elements (<p>, <li>...); each CJK
+// character counts as a word. The content of <script>, <style> and
+// <template> elements, and of custom node types, is not counted.
+//
+// Example:
+//
+//	WordCount(article) // 1234
+func WordCount(node HyperNode) int {
+	var text strings.Builder
+	collectText(&text, node)
+
+	words := 0
+	inWord := false
+	for _, r := range text.String() {
+		switch {
+		case unicode.IsSpace(r):
+			inWord = false
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul):
+			words++
+			inWord = false
+		case !inWord:
+			words++
+			inWord = true
+		}
+	}
+	return words
+}
+
+// collectText writes the text of node to text, separating the content of
+// block elements.
+func collectText(text *strings.Builder, node HyperNode) {
+	switch n := node.(type) {
+	case Text:
+		text.WriteString(string(n))
+	case RawText:
+		text.WriteString(stripTags(string(n)))
+	case staticHTML:
+		text.WriteString(stripTags(n.html))
+	case Element:
+		switch {
+		case n.Tag == "script", n.Tag == "style", n.Tag == "template":
+			return
+		case blockTags[n.Tag]:
+			text.WriteByte(' ')
+			defer text.WriteByte(' ')
+		}
+		for _, child := range n.Children {
+			collectText(text, child)
+		}
+	case Wrapper:
+		for _, child := range n.Unwrap() {
+			collectText(text, child)
+		}
+	}
+}
+
+// ReadingTime returns how long reading the text of node takes, at
+// [ReadingSpeed].
+func ReadingTime(node HyperNode) time.Duration {
+	return time.Duration(WordCount(node)) * time.Minute / time.Duration(ReadingSpeed)
+}
+
+// ReadingStats returns the reading time of node, in whole minutes rounded
+// up, and its number of words formatted for the locale, in a <span>.
+//
+// Example:
+//
+//	ReadingStats(article) // <span><time datetime="PT6M">6 min read</time> · 1,234 words</span>
+func ReadingStats(node HyperNode, options ...FormatOptions) Element {
+	o := formatOptions(options)
+	words := WordCount(node)
+	minutes := max(1, int(math.Ceil(float64(words)/float64(ReadingSpeed))))
+	return SPAN(o.Attributes...)(
+		TIME(AttrDateTime("PT"+strconv.Itoa(minutes)+"M"))(strconv.Itoa(minutes)+" min read"),
+		" · ",
+		formatFor(o.Locale).number(float64(words), 0, false)+IfElse(words == 1, " word", " words"),
+	)
+}
@@ -0,0 +1,47 @@
+package h
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWordCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		node     HyperNode
+		expected int
+	}{
+		{"text", P()("Hello, wide world"), 3},
+		{"inline elements join words", P()("Hel", B()("lo"), " world"), 2},
+		{"block elements separate words", DIV()(P()("Hello"), P()("world")), 2},
+		{"scripts are not counted", DIV()(P()("Hello"), SCRIPT()(RawText("var a = 1"))), 1},
+		{"raw HTML", RawText("<p>Hello</p><p>wide <em>world</em></p>"), 2},
+		{"CJK", P()("日本語の文章 and English"), 8},
+		{"named", Named("Intro", P()("One two")), 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WordCount(tt.node); got != tt.expected {
+				t.Errorf("expected %d words, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestReadingTime(t *testing.T) {
+	article := ARTICLE()(P()(strings.Repeat("word ", 476)), P()("one more"))
+
+	if got := ReadingTime(article); got != 478*time.Minute/238 {
+		t.Errorf("expected about 2 minutes, got %v", got)
+	}
+
+	var buf bytes.Buffer
+	if err := Render(&buf, ReadingStats(article, FormatOptions{Locale: "de"})); err != nil {
+		t.Fatal(err)
+	}
+	if expected := `<span><time datetime="PT3M">3 min read</time> · 478 words</span>`; buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
@@ -0,0 +1,252 @@
+// Package rediscache implements an h.RenderCache stored in Redis, so the
+// instances of a multi-instance deployment share their rendered fragments.
+//
+// It speaks the Redis protocol (RESP) directly, needing only GET, SET and
+// EVAL (to release locks), so it adds no dependency.
+//
+// Example:
+//
+//	h.DefaultRenderCache = rediscache.New("localhost:6379", rediscache.Options{Prefix: "myapp:"})
+package rediscache
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+// Options configures a [Cache]. All fields are optional.
+type Options struct {
+	Password    string        // Sent with AUTH when set
+	DB          int           // Selected with SELECT when non-zero
+	Prefix      string        // Prepended to all keys, to share a Redis database between apps
+	DialTimeout time.Duration // Defaults to 5 seconds
+	PoolSize    int           // Maximum number of idle connections kept; defaults to 10
+	// Timeout bounds each command when the context has no earlier
+	// deadline, so a stalled server slows renders down rather than
+	// blocking them; defaults to 1 second.
+	Timeout time.Duration
+}
+
+// Cache is an [h.RenderCache] backed by Redis.
+type Cache struct {
+	addr    string
+	options Options
+	idle    chan *conn
+}
+
+var (
+	_ h.RenderCache = (*Cache)(nil)
+	_ h.CacheLocker = (*Cache)(nil)
+)
+
+// New creates a [Cache] connecting to the Redis server at addr ("host:port").
+// Connections are opened lazily.
+func New(addr string, options ...Options) *Cache {
+	var o Options
+	if len(options) != 0 {
+		o = options[0]
+	}
+	if o.DialTimeout <= 0 {
+		o.DialTimeout = 5 * time.Second
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = time.Second
+	}
+	if o.PoolSize <= 0 {
+		o.PoolSize = 10
+	}
+	return &Cache{addr: addr, options: o, idle: make(chan *conn, o.PoolSize)}
+}
+
+func (me *Cache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	reply, err := me.do(ctx, "GET", me.options.Prefix+key)
+	if err != nil {
+		return nil, false, err
+	}
+	if reply == nil {
+		return nil, false, nil
+	}
+	return reply, true, nil
+}
+
+func (me *Cache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	args := []string{"SET", me.options.Prefix + key, string(value)}
+	if ttl > 0 {
+		args = append(args, "PX", strconv.FormatInt(max(ttl.Milliseconds(), 1), 10))
+	}
+	_, err := me.do(ctx, args...)
+	return err
+}
+
+// unlockScript deletes a lock only if it still holds the token of its
+// holder, so an expired lock taken over by another instance isn't released.
+const unlockScript = `if redis.call("GET", KEYS[1]) == ARGV[1] then return redis.call("DEL", KEYS[1]) else return 0 end`
+
+// TryLock implements [h.CacheLocker], with SET NX: the lock expires after
+// ttl if its holder doesn't release it.
+func (me *Cache) TryLock(ctx context.Context, key string, ttl time.Duration) (func(), bool, error) {
+	b := make([]byte, 16)
+	rand.Read(b)
+	token := hex.EncodeToString(b)
+
+	key = me.options.Prefix + key
+	reply, err := me.do(ctx, "SET", key, token, "NX", "PX", strconv.FormatInt(max(ttl.Milliseconds(), 1), 10))
+	if err != nil || reply == nil {
+		return nil, false, err
+	}
+	return func() {
+		// Released even when the request was canceled, not to leave other
+		// instances waiting for the lock to expire.
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), me.options.DialTimeout)
+		defer cancel()
+		me.do(ctx, "EVAL", unlockScript, "1", key, token)
+	}, true, nil
+}
+
+// Close closes the idle connections.
+func (me *Cache) Close() error {
+	for {
+		select {
+		case c := <-me.idle:
+			c.Close()
+		default:
+			return nil
+		}
+	}
+}
+
+type conn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+// do sends a command and returns its reply: the value of bulk strings, nil
+// for nil replies, and the text of simple strings. The command fails when
+// it takes longer than the timeout or ctx is done.
+func (me *Cache) do(ctx context.Context, args ...string) ([]byte, error) {
+	c, err := me.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	me.setDeadline(ctx, c)
+	// Expiring the connection interrupts the command when ctx is done.
+	stop := context.AfterFunc(ctx, func() { c.SetDeadline(time.Unix(1, 0)) })
+
+	reply, err := c.command(args...)
+	if !stop() {
+		c.Close() // The deadline was changed behind the command
+		return nil, fmt.Errorf("rediscache: %w", context.Cause(ctx))
+	}
+	var redisErr redisError
+	if err != nil && !errors.As(err, &redisErr) {
+		c.Close() // The connection state is unknown
+		return nil, fmt.Errorf("rediscache: %w", err)
+	}
+	me.put(c)
+	return reply, err
+}
+
+// setDeadline sets the deadline of c to the one of ctx, or to the timeout
+// when it comes first.
+func (me *Cache) setDeadline(ctx context.Context, c *conn) {
+	deadline := time.Now().Add(me.options.Timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	c.SetDeadline(deadline)
+}
+
+func (me *Cache) get(ctx context.Context) (*conn, error) {
+	select {
+	case c := <-me.idle:
+		return c, nil
+	default:
+	}
+
+	dialer := net.Dialer{Timeout: me.options.DialTimeout}
+	netConn, err := dialer.DialContext(ctx, "tcp", me.addr)
+	if err != nil {
+		return nil, fmt.Errorf("rediscache: %w", err)
+	}
+	c := &conn{Conn: netConn, reader: bufio.NewReader(netConn)}
+	me.setDeadline(ctx, c)
+	if me.options.Password != "" {
+		if _, err := c.command("AUTH", me.options.Password); err != nil {
+			c.Close()
+			return nil, fmt.Errorf("rediscache: auth: %w", err)
+		}
+	}
+	if me.options.DB != 0 {
+		if _, err := c.command("SELECT", strconv.Itoa(me.options.DB)); err != nil {
+			c.Close()
+			return nil, fmt.Errorf("rediscache: select: %w", err)
+		}
+	}
+	return c, nil
+}
+
+func (me *Cache) put(c *conn) {
+	select {
+	case me.idle <- c:
+	default:
+		c.Close()
+	}
+}
+
+// redisError is an error reply sent by the server.
+type redisError string
+
+func (me redisError) Error() string {
+	return "rediscache: " + string(me)
+}
+
+func (me *conn) command(args ...string) ([]byte, error) {
+	request := "*" + strconv.Itoa(len(args)) + "\r\n"
+	for _, arg := range args {
+		request += "$" + strconv.Itoa(len(arg)) + "\r\n" + arg + "\r\n"
+	}
+	if _, err := io.WriteString(me.Conn, request); err != nil {
+		return nil, err
+	}
+
+	line, err := me.reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 {
+		return nil, errors.New("malformed reply")
+	}
+	line = line[:len(line)-2]
+
+	switch line[0] {
+	case '+', ':':
+		return []byte(line[1:]), nil
+	case '-':
+		return nil, redisError(line[1:])
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, errors.New("malformed bulk reply")
+		}
+		if size < 0 {
+			return nil, nil
+		}
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(me.reader, data); err != nil {
+			return nil, err
+		}
+		return data[:size], nil
+	default:
+		return nil, fmt.Errorf("unexpected reply %q", line)
+	}
+}
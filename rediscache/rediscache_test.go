@@ -0,0 +1,199 @@
+package rediscache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeRedis is a minimal in-memory server understanding GET, SET and the
+// EVAL of the unlock script.
+type fakeRedis struct {
+	mu       sync.Mutex
+	data     map[string]string
+	commands []string
+}
+
+func startFakeRedis(t *testing.T) (*fakeRedis, string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	server := &fakeRedis{data: map[string]string{}}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn)
+		}
+	}()
+	return server, listener.Addr().String()
+}
+
+func (me *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+		me.mu.Lock()
+		me.commands = append(me.commands, strings.Join(args, " "))
+		switch strings.ToUpper(args[0]) {
+		case "GET":
+			if value, ok := me.data[args[1]]; ok {
+				fmt.Fprintf(conn, "$%d\r\n%s\r\n", len(value), value)
+			} else {
+				io.WriteString(conn, "$-1\r\n")
+			}
+		case "SET":
+			if _, exists := me.data[args[1]]; exists && len(args) > 3 && args[3] == "NX" {
+				io.WriteString(conn, "$-1\r\n")
+				break
+			}
+			me.data[args[1]] = args[2]
+			io.WriteString(conn, "+OK\r\n")
+		case "EVAL":
+			deleted := 0
+			if me.data[args[3]] == args[4] {
+				delete(me.data, args[3])
+				deleted = 1
+			}
+			fmt.Fprintf(conn, ":%d\r\n", deleted)
+		default:
+			io.WriteString(conn, "-ERR unknown command\r\n")
+		}
+		me.mu.Unlock()
+	}
+}
+
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	count, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+	args := make([]string, count)
+	for i := range args {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return nil, err
+		}
+		args[i] = string(data[:size])
+	}
+	return args, nil
+}
+
+func TestCache(t *testing.T) {
+	server, addr := startFakeRedis(t)
+	cache := New(addr, Options{Prefix: "app:"})
+	defer cache.Close()
+	ctx := context.Background()
+
+	if _, ok, err := cache.Get(ctx, "footer"); ok || err != nil {
+		t.Fatalf("expected a miss, got ok=%v err=%v", ok, err)
+	}
+	if err := cache.Set(ctx, "footer", []byte("<footer>\r\n</footer>"), 1500*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	value, ok, err := cache.Get(ctx, "footer")
+	if err != nil || !ok || string(value) != "<footer>\r\n</footer>" {
+		t.Errorf("unexpected value %q (ok=%v err=%v)", value, ok, err)
+	}
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	if server.commands[1] != "SET app:footer <footer>\r\n</footer> PX 1500" {
+		t.Errorf("unexpected SET command %q", server.commands[1])
+	}
+}
+
+func TestCacheUnavailable(t *testing.T) {
+	cache := New("127.0.0.1:1", Options{DialTimeout: 100 * time.Millisecond})
+	if _, _, err := cache.Get(context.Background(), "x"); err == nil {
+		t.Error("expected a connection error")
+	}
+}
+
+func TestCacheStalled(t *testing.T) {
+	// The server accepts connections but never replies.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen: %v", err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	cache := New(listener.Addr().String(), Options{Timeout: 50 * time.Millisecond})
+	defer cache.Close()
+	start := time.Now()
+	if _, _, err := cache.Get(context.Background(), "x"); err == nil {
+		t.Error("expected a timeout without a context deadline")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the command to time out after 50ms, took %v", elapsed)
+	}
+
+	cache = New(listener.Addr().String(), Options{Timeout: time.Minute})
+	defer cache.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	start = time.Now()
+	if _, _, err := cache.Get(ctx, "x"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the command to be canceled with its context, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the command to stop when canceled, took %v", elapsed)
+	}
+}
+
+func TestCacheLock(t *testing.T) {
+	server, addr := startFakeRedis(t)
+	cache := New(addr, Options{Prefix: "app:"})
+	defer cache.Close()
+	ctx := context.Background()
+
+	unlock, ok, err := cache.TryLock(ctx, "widget:lock", time.Second)
+	if err != nil || !ok {
+		t.Fatalf("expected to acquire the lock, got ok=%v err=%v", ok, err)
+	}
+	if _, ok, err := cache.TryLock(ctx, "widget:lock", time.Second); ok || err != nil {
+		t.Fatalf("expected the lock to be held, got ok=%v err=%v", ok, err)
+	}
+	unlock()
+	if _, ok, err := cache.TryLock(ctx, "widget:lock", time.Second); !ok || err != nil {
+		t.Fatalf("expected the released lock to be free, got ok=%v err=%v", ok, err)
+	}
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	if !strings.HasSuffix(server.commands[0], " NX PX 1000") || !strings.HasPrefix(server.commands[0], "SET app:widget:lock ") {
+		t.Errorf("unexpected SET command %q", server.commands[0])
+	}
+}
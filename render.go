@@ -1,6 +1,11 @@
 package h
 
-import "io"
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"io"
+)
 
 // Render writes the HTML representation of a Node to the provided io.Writer.
 //
@@ -14,6 +19,144 @@ func Render(w io.Writer, node HyperNode) error {
 	return node.Render(w)
 }
 
+// RenderHashed renders node to w like [Render] and returns the SHA-256 digest
+// of the written bytes, computed as they are written. It saves rendering
+// twice when the digest is needed alongside the output: ETags, cache keys,
+// or content-addressed file names of statically generated pages.
+//
+// Example:
+//
+//	var buf bytes.Buffer
+//	digest, err := RenderHashed(&buf, page)
+//	w.Header().Set("ETag", `"`+hex.EncodeToString(digest[:16])+`"`)
+func RenderHashed(w io.Writer, node HyperNode) ([]byte, error) {
+	hash := sha256.New()
+	if err := node.Render(io.MultiWriter(w, hash)); err != nil {
+		return nil, err
+	}
+	return hash.Sum(nil), nil
+}
+
+// RenderCtx renders node to w like [Render], passing ctx to the nodes
+// implementing [NodeCtx] inside it, so they can read request-scoped values
+// (locale, user, CSP nonce...) while rendering. Other nodes render as
+// usual. [Handler] renders pages with the request context.
+//
+// NodeCtx nodes are found in elements and in the wrappers of this package
+// (see [Wrapper]), which are copied to pass them ctx; a tree without them
+// is rendered as is. Custom wrappers pass ctx on by implementing NodeCtx.
+//
+// Example:
+//
+//	err := RenderCtx(r.Context(), w, Layout(CtxFunc(func(ctx context.Context) HyperNode {
+//		user, _ := CurrentUser[User](ctx)
+//		return P()("Signed in as ", user.Name)
+//	})))
+func RenderCtx(ctx context.Context, w io.Writer, node HyperNode) error {
+	node, _ = bindCtx(node, ctx)
+	return node.Render(w)
+}
+
+// bindCtx returns a copy of node with its NodeCtx nodes bound to ctx, or
+// node itself when it holds none.
+func bindCtx(node HyperNode, ctx context.Context) (HyperNode, bool) {
+	switch n := node.(type) {
+	case NodeCtx:
+		return ctxNode{node: n, ctx: ctx}, true
+	case Element:
+		var children []HyperNode
+		for i, child := range n.Children {
+			bound, ok := bindCtx(child, ctx)
+			if !ok {
+				continue
+			}
+			if children == nil {
+				children = append(make([]HyperNode, 0, len(n.Children)), n.Children...)
+			}
+			children[i] = bound
+		}
+		if children == nil {
+			return node, false
+		}
+		n.Children = children
+		return n, true
+	case Wrapper:
+		bound := false
+		mapped := mapWrapped(n, func(child HyperNode) HyperNode {
+			child, ok := bindCtx(child, ctx)
+			bound = bound || ok
+			return child
+		})
+		if !bound {
+			return node, false
+		}
+		return mapped, true
+	default:
+		return node, false
+	}
+}
+
+// NodeCtx is implemented by nodes reading request-scoped values while
+// rendering. [RenderCtx] calls RenderCtx with its context; Render is called
+// by the rest of the package, e.g. [Render], and usually renders with
+// context.Background(). Use [CtxFunc] to write one as a function.
+type NodeCtx interface {
+	HyperNode
+	RenderCtx(ctx context.Context, w io.Writer) error
+}
+
+// CtxFunc is a [NodeCtx] built by a function of the render context.
+//
+// Example:
+//
+//	func LocaleBadge() HyperNode {
+//		return CtxFunc(func(ctx context.Context) HyperNode {
+//			return SPAN(AttrClass("locale"))(Locale(ctx))
+//		})
+//	}
+type CtxFunc func(ctx context.Context) HyperNode
+
+func (me CtxFunc) Render(w io.Writer) error {
+	return me.RenderCtx(context.Background(), w)
+}
+
+func (me CtxFunc) RenderCtx(ctx context.Context, w io.Writer) error {
+	node := me(ctx)
+	if node == nil {
+		return nil
+	}
+	return RenderCtx(ctx, w, node)
+}
+
+// AsNodeCtx adapts node to [NodeCtx], so plain nodes can be used where a
+// NodeCtx is expected. They render as usual, passing the context on to the
+// NodeCtx nodes inside them.
+func AsNodeCtx(node HyperNode) NodeCtx {
+	if n, ok := node.(NodeCtx); ok {
+		return n
+	}
+	return plainNode{node}
+}
+
+// plainNode is a node ignoring the render context.
+type plainNode struct {
+	HyperNode
+}
+
+func (me plainNode) RenderCtx(ctx context.Context, w io.Writer) error {
+	return RenderCtx(ctx, w, me.HyperNode)
+}
+
+// ctxNode is a [NodeCtx] bound to the context of a render.
+type ctxNode struct {
+	node NodeCtx
+	ctx  context.Context
+}
+
+func (me ctxNode) Render(w io.Writer) error {
+	return me.node.RenderCtx(me.ctx, w)
+}
+
 // HyperNode represents any renderable HTML element or text content.
 //
 // The HyperNode interface is the core abstraction that allows both HTML elements
@@ -28,3 +171,23 @@ func Render(w io.Writer, node HyperNode) error {
 type HyperNode interface {
 	Render(io.Writer) error
 }
+
+// BufferRenderer can be implemented by custom nodes to render straight into
+// the buffer of the element they are a child of.
+//
+// Elements render their children into a single pooled [bytes.Buffer]. Other
+// nodes are rendered through their io.Writer-based Render method, which
+// costs an extra copy when the node buffers its own output, as the built-in
+// [Element] does. Implementing RenderToBuffer keeps them on the fast path.
+//
+// Example:
+//
+//	type Badge struct{ Label string }
+//
+//	func (me Badge) node() Element { return SPAN(AttrClass("badge"))(me.Label) }
+//
+//	func (me Badge) Render(w io.Writer) error                { return me.node().Render(w) }
+//	func (me Badge) RenderToBuffer(buf *bytes.Buffer) error { return me.node().RenderToBuffer(buf) }
+type BufferRenderer interface {
+	RenderToBuffer(*bytes.Buffer) error
+}
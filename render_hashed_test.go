@@ -0,0 +1,25 @@
+package h
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+)
+
+func TestRenderHashed(t *testing.T) {
+	var buf bytes.Buffer
+	digest, err := RenderHashed(&buf, DIV(AttrClass("x"))("Hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != `<div class="x">Hello</div>` {
+		t.Errorf("unexpected output %q", buf.String())
+	}
+	if expected := sha256.Sum256(buf.Bytes()); !bytes.Equal(digest, expected[:]) {
+		t.Errorf("digest doesn't match the output")
+	}
+
+	if _, err := RenderHashed(&buf, SPAN(Attr("", "x"))()); err == nil {
+		t.Error("expected render errors to be returned")
+	}
+}
@@ -0,0 +1,181 @@
+package h
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// RouteParams are the values of the wildcards of a route pattern. Values not
+// used by the pattern are added to the query string.
+type RouteParams map[string]string
+
+// Routes maps route names to URL patterns, so links are built from names
+// (reverse routing) instead of hard-coded URLs that silently break when
+// routes change. Patterns use the syntax of [http.ServeMux]:
+// "GET /users/{id}", "/files/{path...}", "/{$}".
+type Routes struct {
+	mu       sync.RWMutex
+	patterns map[string]string
+}
+
+// DefaultRoutes is the registry used by [LinkTo], [AttrHrefTo] and [AttrHxGetTo].
+var DefaultRoutes = NewRoutes()
+
+// NewRoutes creates an empty route registry.
+func NewRoutes() *Routes {
+	return &Routes{patterns: map[string]string{}}
+}
+
+// Add registers pattern under name, replacing any pattern registered under
+// the same name. The method and host of the pattern, if any, are ignored
+// when building URLs.
+func (me *Routes) Add(name, pattern string) *Routes {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	me.patterns[name] = pattern
+	return me
+}
+
+// Handle registers pattern under name and handler for it on mux, keeping
+// routing and reverse routing in one place.
+//
+// Example:
+//
+//	routes.Handle(mux, "user", "GET /users/{id}", userHandler)
+func (me *Routes) Handle(mux *http.ServeMux, name, pattern string, handler http.Handler) {
+	me.Add(name, pattern)
+	mux.Handle(pattern, handler)
+}
+
+// URL builds the path of the route called name with params.
+//
+// Example:
+//
+//	routes.URL("user", RouteParams{"id": "42", "tab": "posts"}) // "/users/42?tab=posts"
+func (me *Routes) URL(name string, params RouteParams) (string, error) {
+	me.mu.RLock()
+	pattern, ok := me.patterns[name]
+	me.mu.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("h: unknown route %q", name)
+	}
+
+	// Strip the method and host: "GET example.com/users/{id}" -> "/users/{id}"
+	if _, path, ok := strings.Cut(pattern, " "); ok {
+		pattern = strings.TrimSpace(path)
+	}
+	if i := strings.IndexByte(pattern, '/'); i > 0 {
+		pattern = pattern[i:]
+	}
+
+	var path strings.Builder
+	used := map[string]bool{}
+	for {
+		start := strings.IndexByte(pattern, '{')
+		if start < 0 {
+			path.WriteString(pattern)
+			break
+		}
+		end := strings.IndexByte(pattern[start:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("h: route %q: malformed pattern", name)
+		}
+		path.WriteString(pattern[:start])
+		wildcard := pattern[start+1 : start+end]
+		pattern = pattern[start+end+1:]
+
+		if wildcard == "$" {
+			continue
+		}
+		key, remainder := strings.CutSuffix(wildcard, "...")
+		value, ok := params[key]
+		if !ok {
+			return "", fmt.Errorf("h: route %q: missing param %q", name, key)
+		}
+		used[key] = true
+		if remainder {
+			segments := strings.Split(value, "/")
+			for i, segment := range segments {
+				segments[i] = url.PathEscape(segment)
+			}
+			path.WriteString(strings.Join(segments, "/"))
+		} else {
+			path.WriteString(url.PathEscape(value))
+		}
+	}
+
+	query := url.Values{}
+	for key, value := range params {
+		if !used[key] {
+			query.Set(key, value)
+		}
+	}
+	if len(query) != 0 {
+		path.WriteString("?" + query.Encode())
+	}
+	return path.String(), nil
+}
+
+// Names returns the registered route names, sorted.
+func (me *Routes) Names() []string {
+	me.mu.RLock()
+	defer me.mu.RUnlock()
+	names := make([]string, 0, len(me.patterns))
+	for name := range me.patterns {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// routeAttribute is an attribute whose value is the URL of a route. It
+// fails to render when the URL can't be built.
+type routeAttribute struct {
+	key    string
+	routes *Routes
+	name   string
+	params RouteParams
+}
+
+func (me routeAttribute) Render(buf *bytes.Buffer) error {
+	u, err := me.routes.URL(me.name, me.params)
+	if err != nil {
+		return err
+	}
+	return PairAttribute{Key: me.key, Value: u}.Render(buf)
+}
+
+// RouteAttr returns an attribute called key whose value is the URL of the
+// route called name in [DefaultRoutes]. Rendering fails when the route is
+// unknown or a param is missing.
+func RouteAttr(key, name string, params RouteParams) Attribute {
+	return routeAttribute{key: key, routes: DefaultRoutes, name: name, params: params}
+}
+
+// AttrHrefTo returns an href attribute pointing to the route called name.
+func AttrHrefTo(name string, params RouteParams) Attribute {
+	return RouteAttr("href", name, params)
+}
+
+// AttrHxGetTo returns an hx-get attribute requesting the route called name.
+func AttrHxGetTo(name string, params RouteParams) Attribute {
+	return RouteAttr("hx-get", name, params)
+}
+
+// LinkTo creates an <a> element linking to the route called name in
+// [DefaultRoutes].
+//
+// Example:
+//
+//	DefaultRoutes.Add("user", "GET /users/{id}")
+//
+//	LinkTo("user", RouteParams{"id": user.ID}, AttrClass("link"))(user.Name)
+//	// <a href="/users/42" class="link">Ada</a>
+func LinkTo(name string, params RouteParams, attrs ...Attribute) ElementBuilder {
+	return A(append([]Attribute{AttrHrefTo(name, params)}, attrs...)...)
+}
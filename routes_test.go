@@ -0,0 +1,60 @@
+package h
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+)
+
+func TestRoutesURL(t *testing.T) {
+	routes := NewRoutes().
+		Add("home", "GET /{$}").
+		Add("user", "GET /users/{id}").
+		Add("file", "example.com/files/{path...}").
+		Add("broken", "/x/{id")
+
+	tests := []struct {
+		name     string
+		params   RouteParams
+		expected string
+		err      bool
+	}{
+		{name: "home", expected: "/"},
+		{name: "user", params: RouteParams{"id": "a b/c"}, expected: "/users/a%20b%2Fc"},
+		{name: "user", params: RouteParams{"id": "42", "tab": "posts"}, expected: "/users/42?tab=posts"},
+		{name: "file", params: RouteParams{"path": "docs/read me.txt"}, expected: "/files/docs/read%20me.txt"},
+		{name: "user", err: true},
+		{name: "missing", err: true},
+		{name: "broken", params: RouteParams{"id": "1"}, err: true},
+	}
+	for _, tt := range tests {
+		u, err := routes.URL(tt.name, tt.params)
+		if (err != nil) != tt.err || u != tt.expected {
+			t.Errorf("URL(%q, %v) = %q, %v; want %q", tt.name, tt.params, u, err, tt.expected)
+		}
+	}
+}
+
+func TestLinkTo(t *testing.T) {
+	defer func(routes *Routes) { DefaultRoutes = routes }(DefaultRoutes)
+	DefaultRoutes = NewRoutes()
+	mux := http.NewServeMux()
+	DefaultRoutes.Handle(mux, "user", "GET /users/{id}", http.NotFoundHandler())
+
+	var buf bytes.Buffer
+	node := DIV()(
+		LinkTo("user", RouteParams{"id": "42"}, AttrClass("link"))("Ada"),
+		BUTTON(AttrHxGetTo("user", RouteParams{"id": "7"}))("Load"),
+	)
+	if err := Render(&buf, node); err != nil {
+		t.Fatal(err)
+	}
+	expected := `<div><a href="/users/42" class="link">Ada</a><button hx-get="/users/7">Load</button></div>`
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+
+	if err := Render(&buf, LinkTo("nope", nil)("x")); err == nil {
+		t.Error("expected an error for an unknown route")
+	}
+}
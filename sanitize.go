@@ -0,0 +1,498 @@
+package h
+
+import (
+	"html"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// SanitizePolicy is an allowlist of the markup kept by [Sanitize]. Whatever
+// it doesn't list is removed: disallowed elements are unwrapped, keeping
+// their content, except for elements whose content is code or embedded
+// documents (<script>, <style>, <iframe>...), which are removed whole.
+//
+// Event handler attributes (on*) and srcdoc are always removed, and URL
+// attributes (href, src...) are only kept with an allowed scheme.
+type SanitizePolicy struct {
+	// Elements maps the tags kept to the attributes kept on them.
+	Elements map[string][]string
+	// GlobalAttributes are kept on all the elements kept. A trailing "*"
+	// matches a prefix, as in "aria-*".
+	GlobalAttributes []string
+	// URLSchemes are the schemes allowed in URL attributes besides
+	// relative URLs; http, https and mailto when nil.
+	URLSchemes []string
+	// SetAttributes maps tags to attributes set on all their elements,
+	// replacing the values of the input, e.g. rel="nofollow" on links.
+	SetAttributes map[string]map[string]string
+}
+
+// SanitizeComments is the [SanitizePolicy] for short user content such as
+// comments and chat messages. It keeps text formatting only:
+//
+//   - p, br, em, strong, b, i, s, code, pre, blockquote, ul, ol, li
+//   - a with href (http, https and mailto), rendered with
+//     rel="nofollow ugc noopener" so spam links earn nothing
+var SanitizeComments = SanitizePolicy{
+	Elements: map[string][]string{
+		"p": nil, "br": nil, "em": nil, "strong": nil, "b": nil, "i": nil, "s": nil,
+		"code": nil, "pre": nil, "blockquote": nil, "ul": nil, "ol": nil, "li": nil,
+		"a": {"href"},
+	},
+	SetAttributes: map[string]map[string]string{
+		"a": {"rel": "nofollow ugc " + RelNoOpener},
+	},
+}
+
+// SanitizeBlogPost is the [SanitizePolicy] for articles written by trusted
+// but not privileged users, such as posts of a CMS. It keeps what
+// [SanitizeComments] keeps, and:
+//
+//   - h2 to h6, hr, span, div, small, sub, sup, mark, del, ins, kbd, cite,
+//     abbr with title, q with cite, dl, dt, dd, details, summary
+//   - a with title, rendered with rel="noopener" instead of nofollow
+//   - img with src, srcset, alt, title, width and height, lazy-loaded;
+//     figure and figcaption
+//   - tables: table, caption, thead, tbody, tfoot, tr, th with colspan,
+//     rowspan and scope, td with colspan and rowspan
+//   - class, id, lang, dir and title on all elements
+var SanitizeBlogPost = SanitizePolicy{
+	Elements: mergeSanitizeElements(SanitizeComments.Elements, map[string][]string{
+		"h2": nil, "h3": nil, "h4": nil, "h5": nil, "h6": nil, "hr": nil,
+		"span": nil, "div": nil, "small": nil, "sub": nil, "sup": nil, "mark": nil,
+		"del": nil, "ins": nil, "kbd": nil, "cite": nil, "abbr": nil, "q": {"cite"},
+		"dl": nil, "dt": nil, "dd": nil, "details": nil, "summary": nil,
+		"img":    {"src", "srcset", "alt", "width", "height"},
+		"figure": nil, "figcaption": nil,
+		"table": nil, "caption": nil, "thead": nil, "tbody": nil, "tfoot": nil, "tr": nil,
+		"th": {"colspan", "rowspan", "scope"}, "td": {"colspan", "rowspan"},
+	}),
+	GlobalAttributes: []string{"class", "id", "lang", "dir", "title"},
+	SetAttributes: map[string]map[string]string{
+		"a":   {"rel": RelNoOpener},
+		"img": {"loading": LoadingLazy},
+	},
+}
+
+// SanitizeFullPage is the [SanitizePolicy] for whole documents authored by
+// privileged users, such as landing pages of a site builder. It keeps what
+// [SanitizeBlogPost] keeps, and:
+//
+//   - h1, header, footer, main, nav, section, article, aside, address
+//   - time with datetime, col with span, colgroup
+//   - picture; source with src, srcset, type and media; video and audio
+//     with src, controls, poster, width, height, loop, muted and preload
+//   - role, aria-* and data-* on all elements
+//
+// Scripts, styles, forms and embedded documents are still removed.
+var SanitizeFullPage = SanitizePolicy{
+	Elements: mergeSanitizeElements(SanitizeBlogPost.Elements, map[string][]string{
+		"h1": nil, "header": nil, "footer": nil, "main": nil, "nav": nil,
+		"section": nil, "article": nil, "aside": nil, "address": nil,
+		"time": {"datetime"}, "colgroup": nil, "col": {"span"},
+		"picture": nil, "source": {"src", "srcset", "type", "media"},
+		"video": {"src", "controls", "poster", "width", "height", "loop", "muted", "preload"},
+		"audio": {"src", "controls", "loop", "muted", "preload"},
+	}),
+	GlobalAttributes: append(slices.Clone(SanitizeBlogPost.GlobalAttributes), "role", "aria-*", "data-*"),
+	SetAttributes: map[string]map[string]string{
+		"a":   {"rel": RelNoOpener},
+		"img": {"loading": LoadingLazy},
+	},
+}
+
+func mergeSanitizeElements(base, extra map[string][]string) map[string][]string {
+	elements := maps.Clone(base)
+	for tag, attrs := range extra {
+		elements[tag] = append(slices.Clone(elements[tag]), attrs...)
+	}
+	return elements
+}
+
+// Sanitize returns htmlText, typically user or third-party content, keeping
+// only the markup allowed by policy. The output is rebuilt from the parsed
+// markup, so it is well-formed: quotes and entities are escaped and the
+// elements left open are closed. Pick policy among [SanitizeComments],
+// [SanitizeBlogPost] and [SanitizeFullPage] rather than designing one from
+// scratch.
+//
+// Example:
+//
+//	Sanitize(comment.Body, SanitizeComments)
+func Sanitize(htmlText string, policy SanitizePolicy) HyperNode {
+	return RawText(SanitizeHTML(htmlText, policy))
+}
+
+// SanitizeHTML is like [Sanitize] but returns the sanitized markup.
+func SanitizeHTML(htmlText string, policy SanitizePolicy) string {
+	var out strings.Builder
+	var open []string // The kept elements left open
+	skip := ""        // The removed element whose content is being skipped
+	skipDepth := 0
+
+	for _, token := range tokenizeHTML(htmlText) {
+		if skip != "" {
+			switch {
+			case token.kind == htmlStartTagToken && token.name == skip && !token.selfClosing:
+				skipDepth++
+			case token.kind == htmlEndTagToken && token.name == skip:
+				skipDepth--
+				if skipDepth == 0 {
+					skip = ""
+				}
+			}
+			continue
+		}
+
+		switch token.kind {
+		case htmlTextToken:
+			out.WriteString(html.EscapeString(token.text))
+		case htmlStartTagToken:
+			allowed, ok := policy.Elements[token.name]
+			if !ok {
+				if unsafeContentTags[token.name] && !token.selfClosing && !voidTags[token.name] {
+					skip, skipDepth = token.name, 1
+				}
+				continue
+			}
+			out.WriteByte('<')
+			out.WriteString(token.name)
+			set := policy.SetAttributes[token.name]
+			for _, a := range token.attrs {
+				if _, ok := set[a.name]; ok || !policy.allowsAttr(allowed, a) {
+					continue
+				}
+				writeSanitizedAttr(&out, a.name, a.value, a.boolean)
+			}
+			for _, name := range sortedKeys(set) {
+				writeSanitizedAttr(&out, name, set[name], false)
+			}
+			out.WriteByte('>')
+			if !voidTags[token.name] {
+				open = append(open, token.name)
+			}
+		case htmlEndTagToken:
+			// Closing an element closes the elements opened in it; end
+			// tags of elements not open are dropped.
+			i := len(open) - 1
+			for i >= 0 && open[i] != token.name {
+				i--
+			}
+			if i < 0 {
+				continue
+			}
+			for len(open) > i {
+				out.WriteString("</" + open[len(open)-1] + ">")
+				open = open[:len(open)-1]
+			}
+		}
+	}
+	for i := len(open) - 1; i >= 0; i-- {
+		out.WriteString("</" + open[i] + ">")
+	}
+	return out.String()
+}
+
+// unsafeContentTags are the elements whose content is removed with them
+// when not allowed, as it is code, an embedded document, or raw text that
+// would read as garbage unwrapped.
+var unsafeContentTags = map[string]bool{
+	"script":   true,
+	"style":    true,
+	"iframe":   true,
+	"object":   true,
+	"embed":    true,
+	"template": true,
+	"noscript": true,
+	"textarea": true,
+	"title":    true,
+	"svg":      true,
+	"math":     true,
+	"select":   true,
+}
+
+// urlAttrs are the attributes holding URLs, checked against the allowed
+// schemes.
+var urlAttrs = map[string]bool{
+	"href":       true,
+	"src":        true,
+	"action":     true,
+	"formaction": true,
+	"cite":       true,
+	"poster":     true,
+	"background": true,
+	"longdesc":   true,
+}
+
+func (me SanitizePolicy) allowsAttr(allowed []string, a htmlAttr) bool {
+	if strings.HasPrefix(a.name, "on") || a.name == "srcdoc" {
+		return false
+	}
+	if !slices.Contains(allowed, a.name) && !slices.ContainsFunc(me.GlobalAttributes, func(pattern string) bool {
+		prefix, wildcard := strings.CutSuffix(pattern, "*")
+		return a.name == pattern || wildcard && strings.HasPrefix(a.name, prefix)
+	}) {
+		return false
+	}
+	if urlAttrs[a.name] {
+		return me.allowsURL(a.value)
+	}
+	if a.name == "srcset" {
+		for candidate := range strings.SplitSeq(a.value, ",") {
+			if fields := strings.Fields(candidate); len(fields) != 0 && !me.allowsURL(fields[0]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// allowsURL reports whether value is a relative URL or one with an allowed
+// scheme. Browsers ignore whitespace and control characters in schemes
+// ("java\tscript:"), so they are ignored here too.
+func (me SanitizePolicy) allowsURL(value string) bool {
+	cleaned := strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, value)
+	end := strings.IndexAny(cleaned, ":/?#")
+	if end < 0 || cleaned[end] != ':' {
+		return true
+	}
+	schemes := me.URLSchemes
+	if schemes == nil {
+		schemes = []string{"http", "https", "mailto"}
+	}
+	return slices.Contains(schemes, strings.ToLower(cleaned[:end]))
+}
+
+func writeSanitizedAttr(out *strings.Builder, name, value string, boolean bool) {
+	out.WriteByte(' ')
+	out.WriteString(name)
+	if boolean {
+		return
+	}
+	out.WriteString(`="`)
+	out.WriteString(html.EscapeString(value))
+	out.WriteByte('"')
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+type htmlTokenKind int
+
+const (
+	htmlTextToken htmlTokenKind = iota
+	htmlStartTagToken
+	htmlEndTagToken
+)
+
+type htmlToken struct {
+	kind        htmlTokenKind
+	name        string // Lowercase tag name
+	attrs       []htmlAttr
+	text        string // Unescaped text
+	selfClosing bool
+}
+
+type htmlAttr struct {
+	name    string // Lowercase
+	value   string // Unescaped
+	boolean bool   // Without value
+}
+
+// rawTextTags are the elements whose content is text up to their end tag,
+// not markup.
+var rawTextTags = map[string]bool{
+	"script":   true,
+	"style":    true,
+	"textarea": true,
+	"title":    true,
+	"xmp":      true,
+	"iframe":   true,
+	"noembed":  true,
+	"noframes": true,
+}
+
+// tokenizeHTML splits htmlText into text, start tags and end tags, the way
+// browsers do for the markup found in content. Comments, doctypes and
+// processing instructions are dropped, as are tags cut by the end of the
+// input.
+func tokenizeHTML(htmlText string) []htmlToken {
+	var tokens []htmlToken
+	var text strings.Builder
+	flush := func() {
+		if text.Len() != 0 {
+			tokens = append(tokens, htmlToken{kind: htmlTextToken, text: html.UnescapeString(text.String())})
+			text.Reset()
+		}
+	}
+
+	for rest := htmlText; rest != ""; {
+		i := strings.IndexByte(rest, '<')
+		if i < 0 {
+			text.WriteString(rest)
+			break
+		}
+		text.WriteString(rest[:i])
+		rest = rest[i:]
+
+		switch {
+		case strings.HasPrefix(rest, "<!--"):
+			flush()
+			end := strings.Index(rest[4:], "-->")
+			if end < 0 {
+				return tokens
+			}
+			rest = rest[4+end+3:]
+		case strings.HasPrefix(rest, "<!"), strings.HasPrefix(rest, "<?"):
+			flush()
+			end := strings.IndexByte(rest, '>')
+			if end < 0 {
+				return tokens
+			}
+			rest = rest[end+1:]
+		case len(rest) > 2 && rest[1] == '/' && isASCIILetter(rest[2]):
+			flush()
+			end := strings.IndexByte(rest, '>')
+			if end < 0 {
+				return tokens
+			}
+			name, _ := readTagName(rest[2:end])
+			tokens = append(tokens, htmlToken{kind: htmlEndTagToken, name: name})
+			rest = rest[end+1:]
+		case len(rest) > 1 && isASCIILetter(rest[1]):
+			flush()
+			token, n, ok := readStartTag(rest)
+			if !ok {
+				return tokens
+			}
+			tokens = append(tokens, token)
+			rest = rest[n:]
+			if rawTextTags[token.name] && !token.selfClosing {
+				end := indexEndTag(rest, token.name)
+				if end < 0 {
+					end = len(rest)
+				}
+				if end > 0 {
+					tokens = append(tokens, htmlToken{kind: htmlTextToken, text: rest[:end]})
+				}
+				rest = rest[end:]
+			}
+		default:
+			// A "<" not starting a tag is text.
+			text.WriteByte('<')
+			rest = rest[1:]
+		}
+	}
+	flush()
+	return tokens
+}
+
+// readStartTag reads the start tag at the beginning of s, returning it and
+// its length.
+func readStartTag(s string) (htmlToken, int, bool) {
+	name, i := readTagName(s[1:])
+	token := htmlToken{kind: htmlStartTagToken, name: name}
+	i++
+	for i < len(s) {
+		switch c := s[i]; {
+		case c == '>':
+			return token, i + 1, true
+		case c == '/':
+			token.selfClosing = i+1 < len(s) && s[i+1] == '>'
+			i++
+		case isHTMLSpace(c):
+			i++
+		default:
+			a, n := readAttr(s[i:])
+			if !slices.ContainsFunc(token.attrs, func(b htmlAttr) bool { return b.name == a.name }) {
+				token.attrs = append(token.attrs, a)
+			}
+			i += n
+		}
+	}
+	return token, 0, false
+}
+
+func readTagName(s string) (string, int) {
+	i := 0
+	for i < len(s) && !isHTMLSpace(s[i]) && s[i] != '/' && s[i] != '>' {
+		i++
+	}
+	return strings.ToLower(s[:i]), i
+}
+
+// readAttr reads the attribute at the beginning of s, returning it and its
+// length.
+func readAttr(s string) (htmlAttr, int) {
+	i := 1 // The first character is part of the name, even if it is "=".
+	for i < len(s) && !isHTMLSpace(s[i]) && s[i] != '/' && s[i] != '>' && s[i] != '=' {
+		i++
+	}
+	a := htmlAttr{name: strings.ToLower(s[:i]), boolean: true}
+
+	j := i
+	for j < len(s) && isHTMLSpace(s[j]) {
+		j++
+	}
+	if j >= len(s) || s[j] != '=' {
+		return a, i
+	}
+	j++
+	for j < len(s) && isHTMLSpace(s[j]) {
+		j++
+	}
+	a.boolean = false
+	if j < len(s) && (s[j] == '"' || s[j] == '\'') {
+		end := strings.IndexByte(s[j+1:], s[j])
+		if end < 0 {
+			a.value = html.UnescapeString(s[j+1:])
+			return a, len(s)
+		}
+		a.value = html.UnescapeString(s[j+1 : j+1+end])
+		return a, j + 1 + end + 1
+	}
+	start := j
+	for j < len(s) && !isHTMLSpace(s[j]) && s[j] != '>' {
+		j++
+	}
+	a.value = html.UnescapeString(s[start:j])
+	return a, j
+}
+
+// indexEndTag returns the index in s of the end tag of the raw text element
+// name, or -1.
+func indexEndTag(s, name string) int {
+	for offset := 0; ; {
+		i := strings.Index(s[offset:], "</")
+		if i < 0 {
+			return -1
+		}
+		i += offset
+		after := i + 2 + len(name)
+		if after <= len(s) && strings.EqualFold(s[i+2:after], name) &&
+			(after == len(s) || isHTMLSpace(s[after]) || s[after] == '>' || s[after] == '/') {
+			return i
+		}
+		offset = i + 2
+	}
+}
+
+func isASCIILetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+func isHTMLSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
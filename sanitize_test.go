@@ -0,0 +1,96 @@
+package h
+
+import "testing"
+
+func TestSanitizeHTML(t *testing.T) {
+	policy := SanitizePolicy{
+		Elements: map[string][]string{
+			"p":   nil,
+			"a":   {"href", "title"},
+			"em":  nil,
+			"img": {"src", "alt", "srcset"},
+			"br":  nil,
+		},
+		GlobalAttributes: []string{"class"},
+		SetAttributes:    map[string]map[string]string{"a": {"rel": "nofollow"}},
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"allowed markup", `<p class="intro">Hello <em>world</em></p>`, `<p class="intro">Hello <em>world</em></p>`},
+		{"unwraps disallowed elements", `<div><p>Hi</p></div>`, `<p>Hi</p>`},
+		{"removes scripts with their content", `<p>a<script>alert("<p>")</script>b</p>`, `<p>ab</p>`},
+		{"removes nested unsafe elements", `<object><object>x</object>y</object>z`, `z`},
+		{"removes disallowed attributes", `<p id="x" onclick="evil()">Hi</p>`, `<p>Hi</p>`},
+		{"removes event handlers even if allowed", `<a href="/" title="t" onmouseover="evil()">x</a>`, `<a href="/" title="t" rel="nofollow">x</a>`},
+		{"sets attributes", `<a href="https://example.com" rel="me">x</a>`, `<a href="https://example.com" rel="nofollow">x</a>`},
+		{"removes javascript URLs", `<a href="java	script:alert(1)">x</a>`, `<a rel="nofollow">x</a>`},
+		{"removes disallowed schemes", `<img src="data:image/png;base64,AAAA" alt="a">`, `<img alt="a">`},
+		{"checks srcset", `<img srcset="a.png 1x, javascript:x 2x">`, `<img>`},
+		{"escapes text and attributes", `<p title="x">1 &lt; 2 & "q"</p><a title='a"b'>x</a>`, `<p>1 &lt; 2 &amp; &#34;q&#34;</p><a title="a&#34;b" rel="nofollow">x</a>`},
+		{"closes open elements", `<p><em>open`, `<p><em>open</em></p>`},
+		{"drops stray end tags", `</em>text</p>`, `text`},
+		{"closes elements opened inside", `<p><em>a</p>b`, `<p><em>a</em></p>b`},
+		{"drops comments", `<p>a<!-- <script>x</script> -->b</p>`, `<p>ab</p>`},
+		{"keeps lone angle brackets as text", `a < b`, `a &lt; b`},
+		{"drops truncated tags", `<p>a</p><img src="x`, `<p>a</p>`},
+		{"uppercase tags", `<P CLASS="x">a<BR></P>`, `<p class="x">a<br></p>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SanitizeHTML(tt.input, policy); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSanitizePolicies(t *testing.T) {
+	input := `<h1 id="top">Title</h1><p class="lead" data-x="1">Read <a href="https://example.com">this</a>` +
+		`<img src="/a.png" alt="A"></p><section role="note"><table><tr><td colspan="2">x</td></tr></table></section>` +
+		`<style>p{}</style><form><input name="q"></form>`
+
+	tests := []struct {
+		name     string
+		policy   SanitizePolicy
+		expected string
+	}{
+		{
+			name:     "Comments",
+			policy:   SanitizeComments,
+			expected: `Title<p>Read <a href="https://example.com" rel="nofollow ugc noopener">this</a></p>x`,
+		},
+		{
+			name:   "BlogPost",
+			policy: SanitizeBlogPost,
+			expected: `Title<p class="lead">Read <a href="https://example.com" rel="noopener">this</a>` +
+				`<img src="/a.png" alt="A" loading="lazy"></p><table><tr><td colspan="2">x</td></tr></table>`,
+		},
+		{
+			name:   "FullPage",
+			policy: SanitizeFullPage,
+			expected: `<h1 id="top">Title</h1><p class="lead" data-x="1">Read <a href="https://example.com" rel="noopener">this</a>` +
+				`<img src="/a.png" alt="A" loading="lazy"></p><section role="note"><table><tr><td colspan="2">x</td></tr></table></section>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SanitizeHTML(input, tt.policy); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSanitizePoliciesAreIndependent(t *testing.T) {
+	page := SanitizeFullPage
+	page.SetAttributes["a"]["target"] = "_blank"
+	defer delete(page.SetAttributes["a"], "target")
+
+	if _, ok := SanitizeBlogPost.SetAttributes["a"]["target"]; ok {
+		t.Error("expected changing SanitizeFullPage to leave SanitizeBlogPost unchanged")
+	}
+}
@@ -0,0 +1,202 @@
+package h
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// ScriptStore moves inline scripts and event handler attributes out of
+// pages into external scripts it serves, so pages comply with a strict
+// Content-Security-Policy (no 'unsafe-inline', Trusted Types) without
+// rewriting the components producing them. Set it as
+// [SecurityHeaders.Scripts] to apply it to the pages served by [Handler],
+// whose policy then allows the scripts of each page by hash.
+//
+// The scripts are served from the origin of the pages and carry an
+// integrity hash. They are stored by content, so scripts embedding
+// per-request data produce one script per variant; keep such data in
+// data-* attributes or JSON script blocks, which are left in place.
+//
+// By default the scripts are kept in memory, by the instance rendering the
+// pages: behind a load balancer, or once a script has been dropped to keep
+// the store bounded, a page already delivered would miss its scripts. Set
+// [ScriptStoreOptions.Cache] to a cache shared by the instances, such as
+// the one of the rediscache package, so any of them serves the scripts.
+type ScriptStore struct {
+	prefix  string
+	scripts RenderCache // Content by file name
+	hashes  *LRUCache   // CSP hash sources of the scripts recently added
+}
+
+// ScriptStoreOptions configures [NewScriptStore]. All fields are optional.
+type ScriptStoreOptions struct {
+	// Cache stores the scripts, which never expire; an in-memory
+	// [LRUCache] of MaxScripts scripts by default.
+	Cache RenderCache
+	// MaxScripts is the number of scripts kept in memory, and of hashes
+	// returned by [ScriptStore.Hashes]; defaults to 1024. Without a shared
+	// Cache, it must exceed the number of scripts of the pages served at a
+	// time, as a dropped script is missing from the pages referencing it
+	// until they are rendered again.
+	MaxScripts int
+}
+
+// scriptKeyPrefix starts the cache keys of the scripts, so they don't
+// collide with the fragments of [Memo] in a shared cache.
+const scriptKeyPrefix = "hyper-script:"
+
+// NewScriptStore creates a store serving its scripts under prefix, e.g.
+// "/_scripts/".
+//
+// Example:
+//
+//	scripts := NewScriptStore("/_scripts/", ScriptStoreOptions{Cache: redisCache})
+//	mux.Handle("GET /_scripts/", scripts)
+//	options := HandlerOptions{SecurityHeaders: &SecurityHeaders{Scripts: scripts, TrustedTypes: true}}
+func NewScriptStore(prefix string, options ...ScriptStoreOptions) *ScriptStore {
+	var o ScriptStoreOptions
+	if len(options) != 0 {
+		o = options[0]
+	}
+	maxScripts := IfElse(o.MaxScripts > 0, o.MaxScripts, 1024)
+	if o.Cache == nil {
+		o.Cache = NewLRUCache(maxScripts)
+	}
+	return &ScriptStore{prefix: prefix, scripts: o.Cache, hashes: NewLRUCache(maxScripts)}
+}
+
+// add stores script and returns its URL and its integrity hash.
+func (me *ScriptStore) add(script string) (src, hash string, err error) {
+	sum := sha256.Sum256([]byte(script))
+	hash = "sha256-" + base64.StdEncoding.EncodeToString(sum[:])
+	name := base64.RawURLEncoding.EncodeToString(sum[:12]) + ".js"
+	if err := me.scripts.Set(context.Background(), scriptKeyPrefix+name, []byte(script), 0); err != nil {
+		return "", "", fmt.Errorf("h: storing script %s: %w", name, err)
+	}
+	me.hashes.Set(context.Background(), "'"+hash+"'", nil, 0)
+	return me.prefix + name, hash, nil
+}
+
+// Hashes returns the CSP hash sources of the scripts most recently added
+// by this instance, up to [ScriptStoreOptions.MaxScripts], sorted. The
+// policy of [SecurityHeaders] includes them, for pages whose headers are
+// set before they are rendered, as by [SecurityHeaders.Middleware];
+// [Handler] only includes the hashes of the scripts of each page.
+func (me *ScriptStore) Hashes() []string {
+	hashes := me.hashes.keys()
+	slices.Sort(hashes)
+	return hashes
+}
+
+// ServeHTTP serves the stored scripts. Their names are derived from their
+// content, so they are cached forever.
+func (me *ScriptStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	script, ok, err := me.scripts.Get(r.Context(), scriptKeyPrefix+strings.TrimPrefix(r.URL.Path, me.prefix))
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
+	}
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/javascript; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	w.Write(script)
+}
+
+// Externalize returns node with its inline scripts replaced by external
+// ones, and its event handler attributes (onclick...) by listeners bound
+// by external scripts when the document has been parsed. Handlers of
+// content inserted later, e.g. by htmx swaps, are bound when the swapped
+// content is itself externalized, as its scripts run on insertion; the
+// elements already bound are marked in data-on-bound and skipped.
+// Elements wrapped by [Named] and [Key] are handled too; custom node types
+// are opaque.
+//
+// A handler bound by addEventListener doesn't behave exactly like the
+// attribute it replaces: returning false no longer cancels the event, so
+// call event.preventDefault() instead. this is still the element in the
+// handler's own code, but bare names are no longer looked up on the
+// element, its form and the document, so write this.form.submit() rather
+// than submit(), and this.value rather than value.
+func (me *ScriptStore) Externalize(node HyperNode) HyperNode {
+	node, _ = me.externalize(node)
+	return node
+}
+
+// externalize is [ScriptStore.Externalize], also returning the CSP hash
+// sources of the scripts of node.
+func (me *ScriptStore) externalize(node HyperNode) (HyperNode, []string) {
+	var hashes []string
+	bound := map[string]bool{}
+	node = transform(node, nil, func(Element, []Element) bool { return true }, func(e Element) HyperNode {
+		if e.Tag == "script" {
+			return me.externalizeScript(e, &hashes)
+		}
+
+		var handlers []string
+		var scripts []HyperNode
+		var attrs []Attribute
+		for _, attr := range e.Attributes {
+			a, ok := attr.(PairAttribute)
+			if !ok || !strings.HasPrefix(strings.ToLower(a.Key), "on") {
+				attrs = append(attrs, attr)
+				continue
+			}
+			event := strings.ToLower(a.Key[2:])
+			sum := sha256.Sum256([]byte(event + "\x00" + a.Value))
+			id := base64.RawURLEncoding.EncodeToString(sum[:9])
+			handler := event + ":" + id
+			handlers = append(handlers, handler)
+			if !bound[id] {
+				bound[id] = true
+				// Elements are marked as bound in data-on-bound, so the
+				// script running again for swapped content doesn't bind
+				// the elements already on the page twice.
+				script := fmt.Sprintf(`document.querySelectorAll('[data-on~="%s"]:not([data-on-bound~="%s"])').forEach(function(el){el.dataset.onBound=(el.dataset.onBound?el.dataset.onBound+" ":"")+%q;el.addEventListener(%q,function(event){%s})})`, handler, handler, handler, event, a.Value)
+				src, hash, err := me.add(script)
+				if err != nil {
+					return errorNode{err: err}
+				}
+				hashes = append(hashes, "'"+hash+"'")
+				scripts = append(scripts, SCRIPT(AttrSrc(src), Attr("integrity", hash), AttrDefer(true))())
+			}
+		}
+		if len(handlers) == 0 {
+			return e
+		}
+		e.Attributes = append(attrs, Attr("data-on", strings.Join(handlers, " ")))
+		return Element{Children: append([]HyperNode{e}, scripts...)}
+	})
+	return node, hashes
+}
+
+// externalizeScript moves the content of an inline script to the store,
+// adding its CSP hash source to hashes.
+func (me *ScriptStore) externalizeScript(e Element, hashes *[]string) HyperNode {
+	if _, ok := e.Attribute("src"); ok || len(e.Children) == 0 {
+		return e
+	}
+	if kind, _ := e.Attribute("type"); kind != "" && kind != "module" && !strings.Contains(kind, "javascript") {
+		// Data blocks (JSON, import maps...) aren't executed.
+		return e
+	}
+	var content strings.Builder
+	if err := (Element{Children: e.Children}).Render(&content); err != nil {
+		return errorNode{err: err}
+	}
+	src, hash, err := me.add(content.String())
+	if err != nil {
+		return errorNode{err: err}
+	}
+	*hashes = append(*hashes, "'"+hash+"'")
+	e.Attributes = append(removeAttr(e.Attributes, "nonce"), AttrSrc(src), Attr("integrity", hash))
+	e.Children = nil
+	return e
+}
@@ -0,0 +1,138 @@
+package h
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestScriptStoreExternalize(t *testing.T) {
+	store := NewScriptStore("/_scripts/")
+	page := BODY()(
+		BUTTON(Attr("onclick", "save()"))("Save"),
+		BUTTON(Attr("onclick", "save()"), AttrClass("secondary"))("Save again"),
+		SCRIPT(AttrNonce("abc"))(RawText("init()")),
+		SCRIPT(AttrType("application/ld+json"))(RawText(`{"@type":"Thing"}`)),
+		SCRIPT(AttrSrc("/app.js"))(),
+	)
+
+	var buf bytes.Buffer
+	if err := Render(&buf, store.Externalize(page)); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	if strings.Contains(out, "onclick") || strings.Contains(out, "init()") {
+		t.Errorf("expected inline code to be removed, got %q", out)
+	}
+	if !strings.Contains(out, `{"@type":"Thing"}`) || !strings.Contains(out, `<script src="/app.js"></script>`) {
+		t.Errorf("expected data blocks and external scripts to be kept, got %q", out)
+	}
+	if n := strings.Count(out, `data-on="click:`); n != 2 {
+		t.Errorf("expected both buttons to be bound, got %d in %q", n, out)
+	}
+	if n := strings.Count(out, "<script src=\"/_scripts/"); n != 2 {
+		t.Errorf("expected one script for the shared handler and one for init, got %d in %q", n, out)
+	}
+	if strings.Contains(out, `nonce="abc"`) {
+		t.Errorf("expected the nonce of externalized scripts to be dropped, got %q", out)
+	}
+
+	// The scripts are served, with the hash announced in integrity.
+	src := regexp.MustCompile(`<script src="(/_scripts/[^"]+)" integrity="(sha256-[^"]+)"></script>`).FindStringSubmatch(out)
+	if src == nil {
+		t.Fatalf("expected the externalized init script, got %q", out)
+	}
+	w := httptest.NewRecorder()
+	store.ServeHTTP(w, httptest.NewRequest(http.MethodGet, src[1], nil))
+	if w.Body.String() != "init()" || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/javascript") {
+		t.Errorf("unexpected script response: %q %q", w.Header().Get("Content-Type"), w.Body.String())
+	}
+
+	// The handler script skips the elements it has already bound.
+	handler := regexp.MustCompile(`<script src="(/_scripts/[^"]+)" integrity="sha256-[^"]+" defer></script>`).FindStringSubmatch(out)
+	if handler == nil {
+		t.Fatalf("expected the externalized handler script, got %q", out)
+	}
+	w = httptest.NewRecorder()
+	store.ServeHTTP(w, httptest.NewRequest(http.MethodGet, handler[1], nil))
+	if !strings.Contains(w.Body.String(), `:not([data-on-bound~="click:`) || !strings.Contains(w.Body.String(), "el.dataset.onBound=") {
+		t.Errorf("expected the handler script to mark bound elements, got %q", w.Body.String())
+	}
+
+	hashes := store.Hashes()
+	if len(hashes) != 2 || !slices.Contains(hashes, "'"+src[2]+"'") {
+		t.Errorf("expected the hashes of the two scripts, got %q", hashes)
+	}
+	policy := SecurityHeaders{Scripts: store, TrustedTypes: true}.Policy("")
+	if !strings.Contains(policy, "script-src 'self' "+strings.Join(hashes, " ")) || !strings.Contains(policy, "require-trusted-types-for 'script'") {
+		t.Errorf("expected the policy to allow the scripts by hash and require Trusted Types, got %q", policy)
+	}
+}
+
+func TestHandlerExternalizesScripts(t *testing.T) {
+	store := NewScriptStore("/_scripts/")
+	handler := Handler(func(r *http.Request) (HyperNode, error) {
+		return SCRIPT()(RawText("boot()")), nil
+	}, HandlerOptions{SecurityHeaders: &SecurityHeaders{Scripts: store}})
+
+	store.add("other()")
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if strings.Contains(w.Body.String(), "boot()") {
+		t.Errorf("expected the script to be externalized, got %q", w.Body.String())
+	}
+	hash := regexp.MustCompile(`integrity="(sha256-[^"]+)"`).FindStringSubmatch(w.Body.String())
+	if hash == nil {
+		t.Fatalf("expected the externalized script, got %q", w.Body.String())
+	}
+	// Only the scripts of the page are allowed by hash.
+	if policy := w.Header().Get("Content-Security-Policy"); !strings.Contains(policy, "script-src 'self' '"+hash[1]+"' 'nonce-") {
+		t.Errorf("expected the policy to allow the script of the page by hash, got %q", policy)
+	}
+}
+
+func TestScriptStoreMaxScripts(t *testing.T) {
+	store := NewScriptStore("/_scripts/", ScriptStoreOptions{MaxScripts: 2})
+	var srcs []string
+	for _, script := range []string{"a()", "b()", "c()"} {
+		src, _, _ := store.add(script)
+		srcs = append(srcs, src)
+	}
+
+	for i, src := range srcs {
+		w := httptest.NewRecorder()
+		store.ServeHTTP(w, httptest.NewRequest(http.MethodGet, src, nil))
+		if expected := IfElse(i == 0, http.StatusNotFound, http.StatusOK); w.Code != expected {
+			t.Errorf("script %d: expected status %d, got %d", i, expected, w.Code)
+		}
+	}
+}
+
+func TestScriptStoreSharedCache(t *testing.T) {
+	// Two instances sharing a cache serve the scripts of each other's pages.
+	cache := NewLRUCache(16)
+	rendering := NewScriptStore("/_scripts/", ScriptStoreOptions{Cache: cache, MaxScripts: 1})
+	serving := NewScriptStore("/_scripts/", ScriptStoreOptions{Cache: cache, MaxScripts: 1})
+
+	var buf bytes.Buffer
+	if err := Render(&buf, rendering.Externalize(DIV()(SCRIPT()(RawText("a()")), SCRIPT()(RawText("b()"))))); err != nil {
+		t.Fatal(err)
+	}
+	srcs := regexp.MustCompile(`src="(/_scripts/[^"]+)"`).FindAllStringSubmatch(buf.String(), -1)
+	if len(srcs) != 2 {
+		t.Fatalf("expected two externalized scripts, got %q", buf.String())
+	}
+	for _, src := range srcs {
+		w := httptest.NewRecorder()
+		serving.ServeHTTP(w, httptest.NewRequest(http.MethodGet, src[1], nil))
+		if w.Code != http.StatusOK {
+			t.Errorf("%s: expected status 200, got %d", src[1], w.Code)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package h
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSecurityHeadersPolicy(t *testing.T) {
+	policy := SecurityHeaders{
+		ScriptSources:  []string{"https://cdn.example.com"},
+		FrameAncestors: []string{"https://partner.example.com"},
+	}.Policy("abc")
+
+	for _, directive := range []string{
+		"default-src 'self'",
+		"script-src 'self' 'nonce-abc' https://cdn.example.com",
+		"style-src 'self' 'nonce-abc'",
+		"object-src 'none'",
+		"frame-ancestors 'self' https://partner.example.com",
+	} {
+		if !strings.Contains(policy, directive+";") && !strings.HasSuffix(policy, directive) {
+			t.Errorf("expected %q in %q", directive, policy)
+		}
+	}
+}
+
+func TestHandlerSecurityHeaders(t *testing.T) {
+	var nonce string
+	handler := Handler(func(r *http.Request) (HyperNode, error) {
+		nonce = Nonce(r.Context())
+		return SCRIPT(AttrNonce(Nonce(r.Context())))(RawText("init()")), nil
+	}, HandlerOptions{SecurityHeaders: &SecurityHeaders{}})
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if nonce == "" {
+		t.Fatal("expected the request to get a nonce")
+	}
+	if csp := w.Header().Get("Content-Security-Policy"); !strings.Contains(csp, "'nonce-"+nonce+"'") {
+		t.Errorf("expected the policy to allow the nonce of the page, got %q", csp)
+	}
+	if !strings.Contains(w.Body.String(), `nonce="`+nonce+`"`) {
+		t.Errorf("expected the script to carry the nonce, got %q", w.Body.String())
+	}
+	for key, expected := range map[string]string{
+		"X-Content-Type-Options": "nosniff",
+		"Referrer-Policy":        "strict-origin-when-cross-origin",
+		"X-Frame-Options":        "DENY",
+	} {
+		if got := w.Header().Get(key); got != expected {
+			t.Errorf("expected %s: %s, got %q", key, expected, got)
+		}
+	}
+}
+
+func TestSecurityHeadersMiddlewareKeepsNonce(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r = r.WithContext(WithValue(r.Context(), NonceKey, "existing"))
+	w := httptest.NewRecorder()
+	SecurityHeaders{ReportOnly: true}.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if Nonce(r.Context()) != "existing" {
+			t.Errorf("expected the existing nonce to be kept, got %q", Nonce(r.Context()))
+		}
+	})).ServeHTTP(w, r)
+
+	if csp := w.Header().Get("Content-Security-Policy-Report-Only"); !strings.Contains(csp, "'nonce-existing'") {
+		t.Errorf("expected a report-only policy with the nonce, got %q", csp)
+	}
+}
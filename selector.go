@@ -0,0 +1,350 @@
+package h
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Selector is a parsed CSS selector, matched against the elements of a tree.
+//
+// The supported subset covers what is useful to pick parts of a page:
+// type (div), universal (*), id (#main), class (.card) and attribute
+// selectors ([name], [name=value], [name~=value], [name^=value],
+// [name$=value], [name*=value]), compounds of them (li.active[data-id]),
+// descendant ("ul li") and child ("ul > li") combinators, and lists
+// ("h1, h2"). Pseudo-classes are not supported.
+type Selector struct {
+	source    string
+	complexes [][]selectorStep // Alternatives of the list; steps from left to right
+}
+
+type selectorStep struct {
+	combinator byte // ' ' (descendant) or '>' (child), linking the step to the previous one
+	tag        string
+	id         string
+	classes    []string
+	attributes []attributeSelector
+}
+
+type attributeSelector struct {
+	key, operator, value string
+}
+
+// ParseSelector parses a CSS selector.
+func ParseSelector(source string) (Selector, error) {
+	selector := Selector{source: source}
+	for _, part := range strings.Split(source, ",") {
+		steps, err := parseComplexSelector(strings.TrimSpace(part))
+		if err != nil {
+			return Selector{}, fmt.Errorf("h: invalid selector %q: %w", source, err)
+		}
+		selector.complexes = append(selector.complexes, steps)
+	}
+	return selector, nil
+}
+
+// MustParseSelector is like [ParseSelector] but panics on invalid selectors.
+// It simplifies the initialization of global selectors.
+func MustParseSelector(source string) Selector {
+	selector, err := ParseSelector(source)
+	if err != nil {
+		panic(err)
+	}
+	return selector
+}
+
+func (me Selector) String() string {
+	return me.source
+}
+
+func parseComplexSelector(source string) ([]selectorStep, error) {
+	if source == "" {
+		return nil, fmt.Errorf("empty selector")
+	}
+
+	var steps []selectorStep
+	combinator := byte(' ')
+	for i := 0; i < len(source); {
+		switch source[i] {
+		case ' ':
+			i++
+		case '>':
+			if len(steps) == 0 || combinator == '>' {
+				return nil, fmt.Errorf("unexpected '>'")
+			}
+			combinator = '>'
+			i++
+		default:
+			step, n, err := parseCompoundSelector(source[i:])
+			if err != nil {
+				return nil, err
+			}
+			if len(steps) != 0 {
+				step.combinator = combinator
+			}
+			steps = append(steps, step)
+			combinator = ' '
+			i += n
+		}
+	}
+	if combinator == '>' {
+		return nil, fmt.Errorf("dangling '>'")
+	}
+	return steps, nil
+}
+
+// parseCompoundSelector parses a compound selector at the start of source
+// and returns it with the number of bytes consumed.
+func parseCompoundSelector(source string) (selectorStep, int, error) {
+	var step selectorStep
+	i := 0
+	if i < len(source) && source[i] == '*' {
+		i++
+	} else {
+		n := identifierLength(source[i:])
+		step.tag = strings.ToLower(source[i : i+n])
+		i += n
+	}
+
+	for i < len(source) && source[i] != ' ' && source[i] != '>' {
+		switch source[i] {
+		case '#', '.':
+			n := identifierLength(source[i+1:])
+			if n == 0 {
+				return step, 0, fmt.Errorf("expected a name after %q", source[i])
+			}
+			if source[i] == '#' {
+				step.id = source[i+1 : i+1+n]
+			} else {
+				step.classes = append(step.classes, source[i+1:i+1+n])
+			}
+			i += 1 + n
+		case '[':
+			end := strings.IndexByte(source[i:], ']')
+			if end < 0 {
+				return step, 0, fmt.Errorf("unclosed attribute selector")
+			}
+			attribute, err := parseAttributeSelector(source[i+1 : i+end])
+			if err != nil {
+				return step, 0, err
+			}
+			step.attributes = append(step.attributes, attribute)
+			i += end + 1
+		default:
+			return step, 0, fmt.Errorf("unexpected %q", source[i])
+		}
+	}
+	if i == 0 {
+		return step, 0, fmt.Errorf("unexpected %q", source[0])
+	}
+	return step, i, nil
+}
+
+func parseAttributeSelector(source string) (attributeSelector, error) {
+	source = strings.TrimSpace(source)
+	n := identifierLength(source)
+	if n == 0 {
+		return attributeSelector{}, fmt.Errorf("expected an attribute name")
+	}
+	attribute := attributeSelector{key: strings.ToLower(source[:n])}
+	rest := strings.TrimSpace(source[n:])
+	if rest == "" {
+		return attribute, nil
+	}
+
+	for _, operator := range []string{"~=", "^=", "$=", "*=", "="} {
+		if value, ok := strings.CutPrefix(rest, operator); ok {
+			value = strings.TrimSpace(value)
+			if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+				value = value[1 : len(value)-1]
+			}
+			attribute.operator, attribute.value = operator, value
+			return attribute, nil
+		}
+	}
+	return attributeSelector{}, fmt.Errorf("unsupported attribute selector %q", source)
+}
+
+func identifierLength(source string) int {
+	for i := 0; i < len(source); i++ {
+		c := source[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c >= 0x80) {
+			return i
+		}
+	}
+	return len(source)
+}
+
+// Matches reports whether element matches the selector, given its
+// ancestors from the root down to its parent.
+func (me Selector) Matches(element Element, ancestors []Element) bool {
+	for _, steps := range me.complexes {
+		if matchSteps(steps, element, ancestors) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchSteps(steps []selectorStep, element Element, ancestors []Element) bool {
+	last := steps[len(steps)-1]
+	if !last.matches(element) {
+		return false
+	}
+	if len(steps) == 1 {
+		return true
+	}
+
+	switch last.combinator {
+	case '>':
+		if len(ancestors) == 0 {
+			return false
+		}
+		return matchSteps(steps[:len(steps)-1], ancestors[len(ancestors)-1], ancestors[:len(ancestors)-1])
+	default:
+		for i := len(ancestors) - 1; i >= 0; i-- {
+			if matchSteps(steps[:len(steps)-1], ancestors[i], ancestors[:i]) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func (me selectorStep) matches(element Element) bool {
+	if element.Tag == "" || (me.tag != "" && !strings.EqualFold(element.Tag, me.tag)) {
+		return false
+	}
+	if me.id != "" {
+		if id, _ := element.Attribute("id"); id != me.id {
+			return false
+		}
+	}
+	for _, class := range me.classes {
+		if !element.HasClass(class) {
+			return false
+		}
+	}
+	for _, attribute := range me.attributes {
+		value, ok := element.Attribute(attribute.key)
+		if !ok || !attribute.matches(value) {
+			return false
+		}
+	}
+	return true
+}
+
+func (me attributeSelector) matches(value string) bool {
+	switch me.operator {
+	case "":
+		return true
+	case "=":
+		return value == me.value
+	case "~=":
+		return slices.Contains(strings.Fields(value), me.value)
+	case "^=":
+		return me.value != "" && strings.HasPrefix(value, me.value)
+	case "$=":
+		return me.value != "" && strings.HasSuffix(value, me.value)
+	default: // "*="
+		return me.value != "" && strings.Contains(value, me.value)
+	}
+}
+
+// Query returns the elements of node matching selector, in document order.
+// Elements wrapped by [Named] and [Key] are searched too; custom node types
+// are opaque.
+//
+// Example:
+//
+//	links, err := Query(page, "nav a[href^=http]")
+func Query(node HyperNode, selector string) ([]Element, error) {
+	parsed, err := ParseSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	var matches []Element
+	walkElementsWithAncestors(node, nil, func(element Element, ancestors []Element) bool {
+		if parsed.Matches(element, ancestors) {
+			matches = append(matches, element)
+		}
+		return true
+	})
+	return matches, nil
+}
+
+// QueryFirst returns the first element of node matching selector, and
+// whether there is one.
+func QueryFirst(node HyperNode, selector string) (Element, bool, error) {
+	parsed, err := ParseSelector(selector)
+	if err != nil {
+		return Element{}, false, err
+	}
+	var match Element
+	var found bool
+	walkElementsWithAncestors(node, nil, func(element Element, ancestors []Element) bool {
+		if parsed.Matches(element, ancestors) {
+			match, found = element, true
+			return false
+		}
+		return true
+	})
+	return match, found, nil
+}
+
+// walkElementsWithAncestors calls fn for each element of node in document
+// order with its ancestors, until fn returns false.
+func walkElementsWithAncestors(node HyperNode, ancestors []Element, fn func(Element, []Element) bool) bool {
+	switch n := node.(type) {
+	case Element:
+		if n.Tag == "" {
+			for _, child := range n.Children {
+				if !walkElementsWithAncestors(child, ancestors, fn) {
+					return false
+				}
+			}
+			return true
+		}
+		if !fn(n, ancestors) {
+			return false
+		}
+		ancestors = append(ancestors[:len(ancestors):len(ancestors)], n)
+		for _, child := range n.Children {
+			if !walkElementsWithAncestors(child, ancestors, fn) {
+				return false
+			}
+		}
+	case Wrapper:
+		for _, child := range n.Unwrap() {
+			if !walkElementsWithAncestors(child, ancestors, fn) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// SelectFragment returns the first element of node matching selector, so a
+// handler can build the full page and return only the part an htmx request
+// asks for, without maintaining a second tree for it. When nothing matches,
+// the returned node fails to render with an [HTTPError] with status 404.
+//
+// Example:
+//
+//	page := UsersPage(users)
+//	if target := r.Header.Get("HX-Target"); target != "" {
+//		return SelectFragment(page, "#"+target), nil
+//	}
+//	return page, nil
+func SelectFragment(node HyperNode, selector string) HyperNode {
+	match, found, err := QueryFirst(node, selector)
+	if err != nil {
+		return errorNode{err: err}
+	}
+	if !found {
+		return errorNode{err: HTTPError{Status: http.StatusNotFound, Err: fmt.Errorf("h: no element matches %q", selector)}}
+	}
+	return match
+}
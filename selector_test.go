@@ -0,0 +1,107 @@
+package h
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestQuery(t *testing.T) {
+	page := BODY()(
+		NAV(AttrID("nav"))(
+			UL()(
+				LI(AttrClass("item active"))(A(AttrHref("https://example.com"))("External")),
+				LI(AttrClass("item"))(A(AttrHref("/about"), Attr("data-kind", "internal link"))("About")),
+			),
+		),
+		Named("Main", MAIN()(
+			Range([]string{"a", "b"}, func(s string) HyperNode { return Key(s, P(AttrClass("text"))(s)) }),
+			SECTION()(P()("nested")),
+		)),
+	)
+
+	tests := []struct {
+		selector string
+		expected int
+	}{
+		{selector: "li", expected: 2},
+		{selector: "LI.item.active", expected: 1},
+		{selector: "#nav a", expected: 2},
+		{selector: "nav > a", expected: 0},
+		{selector: "ul > li > a", expected: 2},
+		{selector: "a[href^=http]", expected: 1},
+		{selector: `a[data-kind~="link"]`, expected: 1},
+		{selector: "a[href$='about']", expected: 1},
+		{selector: "a[href*=example]", expected: 1},
+		{selector: "[data-kind]", expected: 1},
+		{selector: "main > p", expected: 2},
+		{selector: "main p", expected: 3},
+		{selector: "main>p.text , section p", expected: 3},
+		{selector: "*", expected: 12},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			matches, err := Query(page, tt.selector)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(matches) != tt.expected {
+				t.Errorf("expected %d matches, got %d", tt.expected, len(matches))
+			}
+		})
+	}
+}
+
+func TestQueryWrappers(t *testing.T) {
+	page := DIV()(
+		Optional(A(AttrHref("/related"))("Related"), 1),
+		Sensitive(A(AttrHref("/account"))("Account")),
+		Slot("footer", A(AttrHref("/about"))("About")),
+		ESIInclude("/menu", A(AttrHref("/login"))("Sign in")),
+		Memo(context.Background(), "query-wrappers", func() HyperNode { return A(AttrHref("/"))("Home") }),
+		card{Body: A(AttrHref("/card"))("Card")},
+	)
+
+	matches, err := Query(page, "div a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 6 {
+		t.Errorf("expected 6 matches, got %d", len(matches))
+	}
+}
+
+// card is a custom wrapper.
+type card struct{ Body HyperNode }
+
+func (me card) Render(w io.Writer) error { return DIV(AttrClass("card"))(me.Body).Render(w) }
+func (me card) Unwrap() []HyperNode      { return []HyperNode{me.Body} }
+
+func TestParseSelectorErrors(t *testing.T) {
+	for _, selector := range []string{"", "div >", "> div", "div > > p", "a[href", "p..x", "a:hover", "div,"} {
+		if _, err := ParseSelector(selector); err == nil {
+			t.Errorf("expected an error for %q", selector)
+		}
+	}
+}
+
+func TestSelectFragment(t *testing.T) {
+	page := HTML()(BODY()(H1()("Users"), TABLE(AttrID("user-table"))(TR()(TD()("Ada")))))
+
+	var buf bytes.Buffer
+	if err := Render(&buf, SelectFragment(page, "#user-table")); err != nil {
+		t.Fatal(err)
+	}
+	if expected := `<table id="user-table"><tr><td>Ada</td></tr></table>`; buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+
+	var httpErr HTTPError
+	if err := Render(&buf, SelectFragment(page, "#missing")); !errors.As(err, &httpErr) || httpErr.Status != http.StatusNotFound {
+		t.Errorf("expected a 404 error, got %v", err)
+	}
+}
@@ -0,0 +1,148 @@
+package h
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"slices"
+)
+
+// SensitiveNode is a region of a page that must not be kept in the htmx
+// history cache, created with [Sensitive].
+type SensitiveNode struct {
+	Node HyperNode
+}
+
+// Sensitive marks node as holding data that must not land in the
+// localStorage history cache of htmx, such as tokens or personal data.
+//
+// The first element of node, found in groups and in wrappers such as
+// [Named] and [Key], is rendered with hx-history="false", which keeps htmx
+// from saving the page to the cache. Content without one, such as text or
+// a [CtxFunc], is preceded by an empty <template hx-history="false">, which
+// doesn't affect the layout. Pages served to history restore requests
+// (see [IsHistoryRestore]), made by htmx when a page isn't cached, are
+// rendered without the sensitive regions by [Handler]; use [StripSensitive]
+// with other handlers.
+//
+// Example:
+//
+//	Sensitive(DIV(AttrClass("api-key"))(key.Secret))
+func Sensitive(node HyperNode) SensitiveNode {
+	return SensitiveNode{Node: node}
+}
+
+func (me SensitiveNode) Render(w io.Writer) error {
+	return me.node().Render(w)
+}
+
+// RenderToBuffer implements [BufferRenderer].
+func (me SensitiveNode) RenderToBuffer(buf *bytes.Buffer) error {
+	return Element{Children: []HyperNode{me.node()}}.render(buf)
+}
+
+// Unwrap implements [Wrapper].
+func (me SensitiveNode) Unwrap() []HyperNode {
+	return []HyperNode{me.Node}
+}
+
+func (me SensitiveNode) mapNodes(fn func(HyperNode) HyperNode) HyperNode {
+	return Sensitive(fn(me.Node))
+}
+
+func (me SensitiveNode) node() HyperNode {
+	if node, ok := markHistory(me.Node); ok {
+		return node
+	}
+	return Element{Children: []HyperNode{
+		Element{Tag: "template", Attributes: []Attribute{Attr("hx-history", "false")}},
+		me.Node,
+	}}
+}
+
+// markHistory returns node with hx-history="false" set on its first
+// element, looked for in groups and in the wrappers rendering exactly what
+// they wrap, and whether it found one.
+func markHistory(node HyperNode) (HyperNode, bool) {
+	switch n := node.(type) {
+	case Element:
+		if n.Tag != "" {
+			n.Attributes = append(n.Attributes[:len(n.Attributes):len(n.Attributes)], Attr("hx-history", "false"))
+			return n, true
+		}
+		for i, child := range n.Children {
+			if marked, ok := markHistory(child); ok {
+				n.Children = slices.Clone(n.Children)
+				n.Children[i] = marked
+				return n, true
+			}
+		}
+	case NamedNode, KeyedNode, OptionalNode, SlotDef, SensitiveNode:
+		found := false
+		node = n.(mapper).mapNodes(func(child HyperNode) HyperNode {
+			if !found {
+				child, found = markHistory(child)
+			}
+			return child
+		})
+		return node, found
+	}
+	return node, false
+}
+
+// IsHistoryRestore reports whether r is an htmx history restore request,
+// made when navigating back to a page missing from the history cache.
+func IsHistoryRestore(r *http.Request) bool {
+	return r.Header.Get("HX-History-Restore-Request") == "true"
+}
+
+// StripSensitive returns node without its [Sensitive] regions, found in
+// elements and in wrappers (see [Wrapper]). [Memo] fragments and custom
+// wrappers holding sensitive regions can't be rebuilt without them, and are
+// replaced by their stripped content, uncached.
+func StripSensitive(node HyperNode) HyperNode {
+	switch n := node.(type) {
+	case SensitiveNode:
+		return Group()
+	case Element:
+		children := make([]HyperNode, len(n.Children))
+		for i, child := range n.Children {
+			children[i] = StripSensitive(child)
+		}
+		n.Children = children
+		return n
+	case mapper:
+		return n.mapNodes(StripSensitive)
+	case Wrapper:
+		nodes := n.Unwrap()
+		if !containsSensitive(nodes) {
+			return node
+		}
+		stripped := make([]HyperNode, len(nodes))
+		for i, child := range nodes {
+			stripped[i] = StripSensitive(child)
+		}
+		return Element{Children: stripped}
+	default:
+		return node
+	}
+}
+
+// containsSensitive reports whether nodes hold a [Sensitive] region.
+func containsSensitive(nodes []HyperNode) bool {
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case SensitiveNode:
+			return true
+		case Element:
+			if containsSensitive(n.Children) {
+				return true
+			}
+		case Wrapper:
+			if containsSensitive(n.Unwrap()) {
+				return true
+			}
+		}
+	}
+	return false
+}
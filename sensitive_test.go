@@ -0,0 +1,107 @@
+package h
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSensitive(t *testing.T) {
+	page := MAIN()(
+		H1()("Settings"),
+		Sensitive(P(AttrClass("token"))("secret")),
+		Named("Email", Sensitive(Text("ada@example.com"))),
+	)
+
+	var buf bytes.Buffer
+	if err := Render(&buf, page); err != nil {
+		t.Fatal(err)
+	}
+	expected := `<main><h1>Settings</h1><p class="token" hx-history="false">secret</p><template hx-history="false"></template>ada@example.com</main>`
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+
+	handler := Handler(func(*http.Request) (HyperNode, error) { return page, nil })
+	r := httptest.NewRequest(http.MethodGet, "/settings", nil)
+	r.Header.Set("HX-History-Restore-Request", "true")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if expected := `<main><h1>Settings</h1></main>`; w.Body.String() != expected {
+		t.Errorf("expected sensitive regions to be stripped from history restores, got %q", w.Body.String())
+	}
+}
+
+func TestSensitiveHistoryAttribute(t *testing.T) {
+	tests := []struct {
+		name     string
+		node     HyperNode
+		expected string
+	}{
+		{name: "Named", node: Sensitive(Named("Token", P()("secret"))), expected: `<p hx-history="false">secret</p>`},
+		{name: "Group", node: Sensitive(Group("Token: ", CODE()("secret"), EM()("!"))), expected: `Token: <code hx-history="false">secret</code><em>!</em>`},
+		{name: "Keyed", node: Sensitive(Key("a", LI()("secret"))), expected: `<li hx-history="false">secret</li>`},
+		{name: "Nested groups", node: Sensitive(Group(Group(), Named("Token", Group(SPAN()("secret"))))), expected: `<span hx-history="false">secret</span>`},
+		{
+			name:     "CtxFunc",
+			node:     Sensitive(CtxFunc(func(context.Context) HyperNode { return P()("secret") })),
+			expected: `<template hx-history="false"></template><p>secret</p>`,
+		},
+		{name: "Text", node: Sensitive(Text("secret")), expected: `<template hx-history="false"></template>secret`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Render(&buf, DIV()(tt.node)); err != nil {
+				t.Fatal(err)
+			}
+			if expected := "<div>" + tt.expected + "</div>"; buf.String() != expected {
+				t.Errorf("expected %q, got %q", expected, buf.String())
+			}
+		})
+	}
+}
+
+func TestStripSensitive(t *testing.T) {
+	secret := func() HyperNode { return Sensitive(SPAN()("SECRET")) }
+	tests := []struct {
+		name     string
+		node     HyperNode
+		expected string
+	}{
+		{name: "Optional", node: DIV()(Optional(secret(), 1)), expected: "<div></div>"},
+		{name: "Slot", node: DIV()(Slot("main", secret())), expected: "<div></div>"},
+		{name: "Keyed", node: UL()(Key("a", LI()(secret()))), expected: "<ul><li></li></ul>"},
+		{name: "ESI fallback", node: ESIInclude("/menu", secret()), expected: `<esi:include src="/menu" onerror="continue"/><esi:remove></esi:remove>`},
+		{
+			name: "Memo",
+			node: Memo(context.Background(), "strip-sensitive", func() HyperNode {
+				return P()("public", secret())
+			}),
+			expected: "<p>public</p>",
+		},
+		{
+			name: "Defer",
+			node: Defer(context.Background(), nil, func(context.Context) (HyperNode, error) {
+				return DIV()(secret()), nil
+			}),
+			expected: "<div></div>",
+		},
+		{name: "Custom wrapper", node: card{Body: secret()}, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Render(&buf, StripSensitive(tt.node)); err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, buf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package h
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// shortcutModifiers are the modifiers of shortcuts, in their canonical
+// order. "mod" is Meta (⌘) on macOS and Ctrl elsewhere.
+var shortcutModifiers = []string{"mod", "ctrl", "alt", "shift", "meta"}
+
+// shortcutKeyNames are the labels of the keys of shortcuts in help dialogs.
+var shortcutKeyNames = map[string]string{
+	"mod":        "Ctrl",
+	"ctrl":       "Ctrl",
+	"alt":        "Alt",
+	"shift":      "Shift",
+	"meta":       "Meta",
+	"escape":     "Esc",
+	"enter":      "Enter",
+	"arrowup":    "↑",
+	"arrowdown":  "↓",
+	"arrowleft":  "←",
+	"arrowright": "→",
+}
+
+// ariaKeyNames are the names of keys in aria-keyshortcuts.
+var ariaKeyNames = map[string]string{
+	"mod":   "Control",
+	"ctrl":  "Control",
+	"alt":   "Alt",
+	"shift": "Shift",
+	"meta":  "Meta",
+}
+
+// parseShortcut splits keys, such as "Ctrl+Shift+K", into its modifiers in
+// canonical order and its key, lowercased. Shift is dropped for keys that
+// are a symbol, such as "?", as it is part of typing them.
+func parseShortcut(keys string) (modifiers []string, key string) {
+	parts := strings.Split(strings.ToLower(keys), "+")
+	key = strings.TrimSpace(parts[len(parts)-1])
+	if key == "" && len(parts) > 1 { // "ctrl++"
+		key, parts = "+", parts[:len(parts)-1]
+	}
+	if key == "esc" {
+		key = "escape"
+	}
+	for _, modifier := range shortcutModifiers {
+		if !slices.ContainsFunc(parts[:len(parts)-1], func(part string) bool { return strings.TrimSpace(part) == modifier }) {
+			continue
+		}
+		if modifier == "shift" && utf8.RuneCountInString(key) == 1 && !isASCIILetter(key[0]) {
+			continue
+		}
+		modifiers = append(modifiers, modifier)
+	}
+	return modifiers, key
+}
+
+// normalizeShortcut returns the canonical form of keys, matched by the
+// script of [Shortcuts].
+func normalizeShortcut(keys string) string {
+	modifiers, key := parseShortcut(keys)
+	return strings.Join(append(modifiers, key), "+")
+}
+
+// Kbd renders the keys of a shortcut, such as "Ctrl+K", as nested <kbd>
+// elements: <kbd><kbd>Ctrl</kbd>+<kbd>K</kbd></kbd>.
+func Kbd(keys string) Element {
+	modifiers, key := parseShortcut(keys)
+	var children []any
+	for _, name := range append(modifiers, key) {
+		label, ok := shortcutKeyNames[name]
+		if !ok {
+			label = strings.ToUpper(name[:1]) + name[1:]
+		}
+		if len(children) != 0 {
+			children = append(children, "+")
+		}
+		children = append(children, KBD()(label))
+	}
+	return KBD()(children...)
+}
+
+// Shortcut returns the attributes binding the shortcut keys of
+// [Shortcuts] to an element: the shortcut clicks it, or focuses it for
+// form fields. They include aria-keyshortcuts, announcing the shortcut to
+// assistive technologies.
+//
+// Example:
+//
+//	INPUT(AttrType(TypeSearch), Shortcut("/"))
+func Shortcut(keys string) Attributes {
+	modifiers, key := parseShortcut(keys)
+	aria := make([]string, 0, len(modifiers)+1)
+	for _, modifier := range modifiers {
+		aria = append(aria, ariaKeyNames[modifier])
+	}
+	if len(key) == 1 {
+		aria = append(aria, strings.ToUpper(key))
+	} else {
+		aria = append(aria, strings.ToUpper(key[:1])+key[1:])
+	}
+	return Attributes{
+		Attr("data-shortcut", normalizeShortcut(keys)),
+		AttrAriaKeyShortcuts(strings.Join(aria, "+")),
+	}
+}
+
+// shortcutsScript activates the element bound to a shortcut (see
+// [Shortcut]) and opens the help dialog with "?". Shortcuts without
+// modifiers are ignored while typing in form fields.
+const shortcutsScript = `(function(){var bindings=%s,mac=/Mac|iP(hone|ad)/.test(navigator.platform);
+function combo(e,mod){var key=e.key.toLowerCase(),parts=[];if(mod&&(mac?e.metaKey:e.ctrlKey))parts.push("mod");
+if(e.ctrlKey&&!(mod&&!mac))parts.push("ctrl");if(e.altKey)parts.push("alt");if(e.shiftKey&&(key.length>1||/[a-z]/.test(key)))parts.push("shift");
+if(e.metaKey&&!(mod&&mac))parts.push("meta");parts.push(key);return parts.join("+")}
+document.addEventListener("keydown",function(e){var t=e.target,typing=t.isContentEditable||/^(INPUT|TEXTAREA|SELECT)$/.test(t.tagName);
+var keys=[combo(e,true),combo(e,false)].filter(function(k){return bindings.indexOf(k)>=0})[0];
+if(keys&&!(typing&&!e.ctrlKey&&!e.metaKey&&!e.altKey)){var el=document.querySelector('[data-shortcut="'+keys+'"]');if(el){e.preventDefault();/^(INPUT|TEXTAREA|SELECT)$/.test(el.tagName)?el.focus():el.click()}return}
+if(e.key==="?"&&!typing){var d=document.querySelector("dialog[data-shortcuts]");if(d&&!d.open){e.preventDefault();d.showModal()}}})})()`
+
+// Shortcuts returns a help dialog listing the keyboard shortcuts of
+// bindings, mapping keys (such as "Ctrl+K" or "?") to what they do, and
+// the script registering them, so the shortcuts documented are the ones
+// that work. Bind each shortcut to the element it activates with
+// [Shortcut]. The dialog opens with "?", unless bound; the script carries
+// the CSP nonce of ctx (see [NonceKey]).
+//
+// Modifiers are Ctrl, Alt, Shift, Meta, and Mod: Meta (⌘) on macOS and
+// Ctrl elsewhere.
+//
+// Example:
+//
+//	Shortcuts(ctx, map[string]string{
+//		"Mod+K": "Open the command palette",
+//		"/":     "Search",
+//		"N":     "New issue",
+//	})
+//
+//	BUTTON(Shortcut("Mod+K"))("Commands")
+//	INPUT(AttrType(TypeSearch), Shortcut("/"))
+//	A(AttrHref("/issues/new"), Shortcut("N"))("New issue")
+func Shortcuts(ctx context.Context, bindings map[string]string) HyperNode {
+	keys := make([]string, 0, len(bindings))
+	for k := range bindings {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, func(a, b string) int {
+		return strings.Compare(normalizeShortcut(a), normalizeShortcut(b))
+	})
+
+	items := make([]any, len(keys))
+	normalized := make([]string, len(keys))
+	for i, k := range keys {
+		items[i] = DIV()(DT()(Kbd(k)), DD()(bindings[k]))
+		normalized[i] = normalizeShortcut(k)
+	}
+	data, _ := json.Marshal(normalized) // Can't fail for strings
+
+	var scriptAttrs []Attribute
+	if nonce := Nonce(ctx); nonce != "" {
+		scriptAttrs = append(scriptAttrs, AttrNonce(nonce))
+	}
+	return Group(
+		DIALOG(Attr("data-shortcuts", true), AttrAriaLabelledBy("shortcuts-title"))(
+			H2(AttrID("shortcuts-title"))("Keyboard shortcuts"),
+			DL()(items...),
+			FORM(AttrMethod(MethodDialog))(BUTTON()("Close")),
+		),
+		SCRIPT(scriptAttrs...)(RawText(fmt.Sprintf(shortcutsScript, data))),
+	)
+}
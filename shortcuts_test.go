@@ -0,0 +1,56 @@
+package h
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeShortcut(t *testing.T) {
+	tests := map[string]string{
+		"Ctrl+K":       "ctrl+k",
+		"shift+ctrl+k": "ctrl+shift+k",
+		"Mod+Shift+P":  "mod+shift+p",
+		"Shift+?":      "?",
+		"Ctrl++":       "ctrl++",
+		"Esc":          "escape",
+		"/":            "/",
+	}
+	for keys, expected := range tests {
+		if got := normalizeShortcut(keys); got != expected {
+			t.Errorf("normalizeShortcut(%q) = %q, expected %q", keys, got, expected)
+		}
+	}
+}
+
+func TestShortcuts(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, Kbd("Mod+Shift+p")); err != nil {
+		t.Fatal(err)
+	}
+	if expected := `<kbd><kbd>Ctrl</kbd>+<kbd>Shift</kbd>+<kbd>P</kbd></kbd>`; buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+
+	buf.Reset()
+	if err := Render(&buf, BUTTON(Shortcut("Mod+K"))("Commands")); err != nil {
+		t.Fatal(err)
+	}
+	if expected := `<button data-shortcut="mod+k" aria-keyshortcuts="Control+K">Commands</button>`; buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+
+	buf.Reset()
+	ctx := WithValue(context.Background(), NonceKey, "abc")
+	if err := Render(&buf, Shortcuts(ctx, map[string]string{"Mod+K": "Open the command palette", "/": "Search"})); err != nil {
+		t.Fatal(err)
+	}
+	expected := `<dialog data-shortcuts aria-labelledby="shortcuts-title"><h2 id="shortcuts-title">Keyboard shortcuts</h2><dl>` +
+		`<div><dt><kbd><kbd>/</kbd></kbd></dt><dd>Search</dd></div>` +
+		`<div><dt><kbd><kbd>Ctrl</kbd>+<kbd>K</kbd></kbd></dt><dd>Open the command palette</dd></div>` +
+		`</dl><form method="dialog"><button>Close</button></form></dialog><script nonce="abc">(function(){var bindings=["/","mod+k"],`
+	if !strings.HasPrefix(buf.String(), expected) {
+		t.Errorf("expected prefix %q, got %q", expected, buf.String())
+	}
+}
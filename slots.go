@@ -0,0 +1,116 @@
+package h
+
+import (
+	"fmt"
+	"io"
+	"slices"
+)
+
+// SlotDef marks a place in a layout where pages insert their content with
+// [Fill]. Its children are the fallback content rendered when the page
+// doesn't fill it, like the fallback content of a web-component <slot>.
+type SlotDef struct {
+	Name     string
+	Children []HyperNode
+}
+
+// Slot defines a slot called name, with optional fallback content.
+//
+// Example:
+//
+//	var appLayout = HTML()(
+//		HEAD()(TITLE()(Slot("title", "My App"))),
+//		BODY()(
+//			HEADER()(Slot("header", NAV()(A(AttrHref("/"))("Home")))),
+//			MAIN()(Slot("main")),
+//			FOOTER()(Slot("footer", "© Example Inc.")),
+//		),
+//	)
+func Slot(name string, fallback ...any) SlotDef {
+	element := Element{}
+	InsertChildren(&element, fallback...)
+	return SlotDef{Name: name, Children: element.Children}
+}
+
+// Render renders the fallback content, so a layout renders on its own.
+func (me SlotDef) Render(w io.Writer) error {
+	return Element{Children: me.Children}.Render(w)
+}
+
+// Unwrap implements [Wrapper], returning the fallback content.
+func (me SlotDef) Unwrap() []HyperNode {
+	return me.Children
+}
+
+func (me SlotDef) mapNodes(fn func(HyperNode) HyperNode) HyperNode {
+	children := make([]HyperNode, len(me.Children))
+	for i, child := range me.Children {
+		children[i] = fn(child)
+	}
+	return SlotDef{Name: me.Name, Children: children}
+}
+
+// SlotFill is the content a page provides for a slot of a layout.
+type SlotFill struct {
+	Name     string
+	Children []HyperNode
+}
+
+// Fill provides children for the slot called name. Filling a slot more than
+// once appends to its content.
+func Fill(name string, children ...any) SlotFill {
+	element := Element{}
+	InsertChildren(&element, children...)
+	return SlotFill{Name: name, Children: element.Children}
+}
+
+// WithSlots returns layout with its slots replaced by the content filling
+// them, or by their fallback content when not filled. Filling a slot the
+// layout doesn't define makes the render fail, catching typos early.
+//
+// Example:
+//
+//	WithSlots(appLayout,
+//		Fill("title", "Settings"),
+//		Fill("main", SettingsForm(user)),
+//	)
+func WithSlots(layout HyperNode, fills ...SlotFill) HyperNode {
+	content := map[string][]HyperNode{}
+	for _, fill := range fills {
+		content[fill.Name] = append(content[fill.Name], fill.Children...)
+	}
+
+	var defined []string
+	result := fillSlots(layout, content, &defined)
+	for _, fill := range fills {
+		if !slices.Contains(defined, fill.Name) {
+			return errorNode{err: fmt.Errorf("h: layout has no slot %q", fill.Name)}
+		}
+	}
+	return result
+}
+
+func fillSlots(node HyperNode, content map[string][]HyperNode, defined *[]string) HyperNode {
+	switch n := node.(type) {
+	case SlotDef:
+		*defined = append(*defined, n.Name)
+		children, ok := content[n.Name]
+		if !ok {
+			children = n.Children
+		}
+		return fillSlots(Element{Children: children}, content, defined)
+	case Element:
+		children := make([]HyperNode, len(n.Children))
+		for i, child := range n.Children {
+			children[i] = fillSlots(child, content, defined)
+		}
+		n.Children = children
+		return n
+	case Wrapper:
+		return mapWrapped(n, func(child HyperNode) HyperNode {
+			return fillSlots(child, content, defined)
+		})
+	default:
+		return node
+	}
+}
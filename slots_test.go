@@ -0,0 +1,60 @@
+package h
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWithSlots(t *testing.T) {
+	layout := BODY()(
+		HEADER()(Slot("header", "Default header")),
+		MAIN()(Slot("main")),
+		FOOTER()(Slot("footer", "©")),
+	)
+
+	tests := []struct {
+		name     string
+		node     HyperNode
+		expected string
+	}{
+		{
+			name:     "Layout alone renders fallbacks",
+			node:     layout,
+			expected: `<body><header>Default header</header><main></main><footer>©</footer></body>`,
+		},
+		{
+			name:     "Filled slots",
+			node:     WithSlots(layout, Fill("main", P()("Hello")), Fill("footer", "Custom"), Fill("main", P()("World"))),
+			expected: `<body><header>Default header</header><main><p>Hello</p><p>World</p></main><footer>Custom</footer></body>`,
+		},
+		{
+			name:     "Empty fill overrides fallback",
+			node:     WithSlots(layout, Fill("header")),
+			expected: `<body><header></header><main></main><footer>©</footer></body>`,
+		},
+		{
+			name:     "Nested slots in fallback",
+			node:     WithSlots(DIV()(Slot("outer", SPAN()(Slot("inner", "x")))), Fill("inner", "y")),
+			expected: `<div><span>y</span></div>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Render(&buf, tt.node); err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, buf.String())
+			}
+		})
+	}
+}
+
+func TestWithSlotsUnknownSlot(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, WithSlots(DIV()(Slot("main")), Fill("mian", "typo"))); err == nil {
+		t.Error("expected an error for an unknown slot")
+	}
+}
@@ -0,0 +1,171 @@
+package h
+
+import (
+	"bytes"
+	"html"
+	"io"
+	"net/http"
+)
+
+// StreamOptions configures [RenderStream]. All fields are optional.
+type StreamOptions struct {
+	// FlushAfter selects the elements after which the output is flushed,
+	// e.g. "head, header, main > section"; defaults to "head", so the
+	// browser fetches styles and scripts while the body renders.
+	FlushAfter string
+	// FlushBytes flushes the output whenever that many bytes are pending,
+	// bounding the memory used by large pages; no limit when zero.
+	FlushBytes int
+}
+
+// RenderStream renders node to w like [Render], but writes the output as
+// it goes instead of buffering the whole page: it is written and flushed
+// (when w is an [http.Flusher], such as an http.ResponseWriter) after the
+// elements selected by opts.FlushAfter, and whenever opts.FlushBytes are
+// pending. Large pages then start arriving at the browser before their
+// rendering completes.
+//
+// An error stops the render, leaving the output written so far, so the
+// status of an HTTP response can no longer be changed. Prefer [Handler]
+// for pages that may fail, and [Stream] for pages waiting on slow data.
+// Custom nodes are rendered whole, between element boundaries.
+//
+// Example:
+//
+//	mux.HandleFunc("GET /reports/yearly", func(w http.ResponseWriter, r *http.Request) {
+//		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+//		err := RenderStream(w, ReportPage(report), StreamOptions{FlushAfter: "head, tbody > tr", FlushBytes: 64 << 10})
+//		if err != nil {
+//			slog.ErrorContext(r.Context(), "render failed", "error", err)
+//		}
+//	})
+func RenderStream(w io.Writer, node HyperNode, opts StreamOptions) error {
+	selector, err := ParseSelector(IfElse(opts.FlushAfter != "", opts.FlushAfter, "head"))
+	if err != nil {
+		return err
+	}
+
+	buf := getBuffer(mediumBufferSize)
+	defer putBuffer(buf)
+	s := streamer{w: w, buf: buf, selector: selector, options: opts, limits: limits.Load()}
+	if err := s.node(node, nil); err != nil {
+		return err
+	}
+	return s.flush()
+}
+
+type streamer struct {
+	w        io.Writer
+	buf      *bytes.Buffer
+	selector Selector
+	options  StreamOptions
+	limits   *Limits
+	written  int // Bytes written to w so far
+}
+
+func (me *streamer) node(node HyperNode, ancestors []Element) error {
+	// Wrappers such as Named and Key are buffer renderers too, which would
+	// render their content whole.
+	if nodes, ok := inlined(node); ok {
+		for _, child := range nodes {
+			if err := me.node(child, ancestors); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	switch n := node.(type) {
+	case Element:
+		if err := me.element(n, ancestors); err != nil {
+			return err
+		}
+	case Text:
+		me.buf.WriteString(html.EscapeString(string(n)))
+	case RawText:
+		me.buf.WriteString(string(n))
+	case BufferRenderer:
+		if err := n.RenderToBuffer(me.buf); err != nil {
+			return err
+		}
+	default:
+		if err := n.Render(me.buf); err != nil {
+			return err
+		}
+	}
+
+	if me.limits != nil && me.limits.MaxOutputBytes > 0 && me.written+me.buf.Len() > me.limits.MaxOutputBytes {
+		tag := ""
+		if len(ancestors) != 0 {
+			tag = ancestors[len(ancestors)-1].Tag
+		}
+		return LimitError{Limit: "output bytes", Tag: tag, Size: me.written + me.buf.Len(), Max: me.limits.MaxOutputBytes}
+	}
+	if me.options.FlushBytes > 0 && me.buf.Len() >= me.options.FlushBytes {
+		return me.flush()
+	}
+	return nil
+}
+
+func (me *streamer) element(element Element, ancestors []Element) error {
+	if me.limits != nil {
+		if err := checkElementLimits(me.limits, element); err != nil {
+			return err
+		}
+	}
+
+	if element.Tag != "" {
+		me.buf.WriteByte('<')
+		me.buf.WriteString(element.Tag)
+		if err := element.renderAttrs(me.buf); err != nil {
+			return err
+		}
+		me.buf.WriteByte('>')
+		if element.IsVoid {
+			return me.flushAfter(element, ancestors)
+		}
+	}
+
+	children := ancestors
+	if element.Tag != "" {
+		children = append(ancestors[:len(ancestors):len(ancestors)], element)
+	}
+	for _, child := range element.Children {
+		if err := me.node(child, children); err != nil {
+			return err
+		}
+	}
+
+	if element.Tag == "" {
+		return nil
+	}
+	me.buf.WriteString("</")
+	me.buf.WriteString(element.Tag)
+	me.buf.WriteByte('>')
+	return me.flushAfter(element, ancestors)
+}
+
+// flushAfter flushes the output when element is a flush boundary.
+func (me *streamer) flushAfter(element Element, ancestors []Element) error {
+	if me.selector.Matches(element, ancestors) {
+		return me.flush()
+	}
+	return nil
+}
+
+// flush writes the pending output to w and flushes it.
+func (me *streamer) flush() error {
+	if me.buf.Len() == 0 {
+		return nil
+	}
+	n, err := me.w.Write(me.buf.Bytes())
+	me.written += n
+	me.buf.Reset()
+	if err != nil {
+		return err
+	}
+	if flusher, ok := me.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package h
+
+import (
+	"bytes"
+	"errors"
+	"net/http/httptest"
+	"testing"
+)
+
+// flushRecorder records the output written before each flush.
+type flushRecorder struct {
+	*httptest.ResponseRecorder
+	chunks []string
+}
+
+func (me *flushRecorder) Flush() {
+	me.chunks = append(me.chunks, me.Body.String())
+	me.ResponseRecorder.Flush()
+}
+
+func TestRenderStream(t *testing.T) {
+	page := Group(
+		DOCTYPE(),
+		HTML()(
+			HEAD()(TITLE()("Report")),
+			BODY()(
+				TABLE()(TBODY()(Named("Rows", Group(
+					Key("a", TR()(TD()("a & b"))),
+					Key("c", TR()(TD()(RawText("<b>c</b>")))),
+				)))),
+				IMG(AttrSrc("/chart.png"), AttrAlt("")),
+			),
+		),
+	)
+
+	var expected bytes.Buffer
+	if err := Render(&expected, page); err != nil {
+		t.Fatal(err)
+	}
+
+	w := &flushRecorder{ResponseRecorder: httptest.NewRecorder()}
+	if err := RenderStream(w, page, StreamOptions{FlushAfter: "head, tbody > tr"}); err != nil {
+		t.Fatal(err)
+	}
+	if w.Body.String() != expected.String() {
+		t.Errorf("expected %q, got %q", expected.String(), w.Body.String())
+	}
+	chunks := []string{
+		"<!DOCTYPE html><html><head><title>Report</title></head>",
+		"<!DOCTYPE html><html><head><title>Report</title></head><body><table><tbody><tr><td>a &amp; b</td></tr>",
+		"<!DOCTYPE html><html><head><title>Report</title></head><body><table><tbody><tr><td>a &amp; b</td></tr><tr><td><b>c</b></td></tr>",
+		expected.String(),
+	}
+	if len(w.chunks) != len(chunks) {
+		t.Fatalf("expected %d flushes, got %q", len(chunks), w.chunks)
+	}
+	for i, chunk := range chunks {
+		if w.chunks[i] != chunk {
+			t.Errorf("flush %d: expected %q, got %q", i, chunk, w.chunks[i])
+		}
+	}
+
+	w = &flushRecorder{ResponseRecorder: httptest.NewRecorder()}
+	RenderStream(w, page, StreamOptions{FlushAfter: "nav", FlushBytes: 16})
+	if len(w.chunks) < 4 || w.Body.String() != expected.String() {
+		t.Errorf("expected flushes every 16 bytes, got %q", w.chunks)
+	}
+}
+
+func TestRenderStreamError(t *testing.T) {
+	var buf bytes.Buffer
+	err := RenderStream(&buf, DIV()(HEAD()(), failingNode{}), StreamOptions{})
+	if err == nil || err.Error() != "boom" {
+		t.Errorf("expected the error of the failing node, got %v", err)
+	}
+	if buf.String() != "<div><head></head>" {
+		t.Errorf("expected the output before the failure to be written, got %q", buf.String())
+	}
+
+	SetLimits(Limits{MaxOutputBytes: 10})
+	defer SetLimits(Limits{})
+	var limitErr LimitError
+	if err := RenderStream(&buf, P()("far too long for the limit"), StreamOptions{}); !errors.As(err, &limitErr) {
+		t.Errorf("expected a LimitError, got %v", err)
+	}
+}
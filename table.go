@@ -0,0 +1,182 @@
+package h
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Column is a column of a table built by [TableFrom].
+type Column[T any] struct {
+	Key    string // Identifies the column in sort parameters
+	Header any    // Header content (string or node)
+	// Cell returns the content of the column for a row: a string, a node...
+	Cell     func(row T) any
+	Sortable bool // Makes the header a link sorting the table by the column
+	// Export returns the value of the column for a row in exports (see
+	// [ExportTable]) and editors (see [EditableGrid]); defaults to the text
+	// of Cell.
+	Export func(row T) string
+	// Editable makes the cells of the column editable in an [EditableGrid].
+	Editable bool
+	// InputType is the type of the <input> editing the column in an
+	// [EditableGrid]; defaults to text.
+	InputType string
+}
+
+// TableOptions configures [TableFrom]. All fields are optional.
+type TableOptions struct {
+	// Query is the sort of the rows, for the headers of sortable columns
+	// to mark it with aria-sort and link to the opposite order.
+	Query TableQuery
+	// URL is the URL of the page, whose query parameters the sort links
+	// preserve (filters, page size...).
+	URL        *url.URL
+	Attributes []Attribute // Extra attributes for the <table>
+}
+
+// TableFrom creates a table of rows, with a column per definition of
+// columns. Keep the definitions in a variable to share them between pages
+// and exports.
+//
+// Example:
+//
+//	var userColumns = []Column[User]{
+//		{Key: "name", Header: "Name", Cell: func(u User) any { return u.Name }, Sortable: true},
+//		{Key: "email", Header: "Email", Cell: func(u User) any { return A(AttrHref("mailto:" + u.Email))(u.Email) }},
+//	}
+//
+//	query := ParseTableQuery(r, TableQueryOptions{Sorts: []string{"name"}})
+//	TableFrom(loadUsers(query), userColumns, TableOptions{Query: query, URL: r.URL})
+func TableFrom[T any](rows []T, columns []Column[T], options ...TableOptions) Element {
+	var o TableOptions
+	if len(options) != 0 {
+		o = options[0]
+	}
+	return buildTable(rows, columns, o, func(row T, column Column[T]) Element {
+		return TD()(column.Cell(row))
+	})
+}
+
+// buildTable creates the table of [TableFrom], with the cells created by
+// cell.
+func buildTable[T any](rows []T, columns []Column[T], o TableOptions, cell func(row T, column Column[T]) Element) Element {
+	headers := make([]any, len(columns))
+	for i, column := range columns {
+		if !column.Sortable {
+			headers[i] = TH(AttrScope("col"))(column.Header)
+			continue
+		}
+		sort := "none"
+		if o.Query.Sort == column.Key {
+			sort = IfElse(o.Query.Desc, "descending", "ascending")
+		}
+		headers[i] = TH(AttrScope("col"), Attr("aria-sort", sort))(
+			A(AttrHref(o.Query.SortURL(o.URL, column.Key)))(column.Header),
+		)
+	}
+
+	body := make([]any, len(rows))
+	for i, row := range rows {
+		cells := make([]any, len(columns))
+		for j, column := range columns {
+			cells[j] = cell(row, column)
+		}
+		body[i] = TR()(cells...)
+	}
+
+	return TABLE(o.Attributes...)(
+		THEAD()(TR()(headers...)),
+		TBODY()(body...),
+	)
+}
+
+// TableQuery is the sort, filters and page of a table, parsed from query
+// parameters by [ParseTableQuery]:
+//
+//	?sort=name          sort by name, ascending
+//	?sort=-name         sort by name, descending
+//	?status=active      filter on status
+//	?page=2&per_page=50 page 2, 50 rows per page
+type TableQuery struct {
+	Sort    string            // Key of the column sorted by; "" when unsorted
+	Desc    bool              // Sorts in descending order
+	Filters map[string]string // Values of the filters set
+	Page    int               // Page number, from 1
+	PerPage int               // Rows per page
+}
+
+// TableQueryOptions restricts what [ParseTableQuery] accepts. All fields
+// are optional.
+type TableQueryOptions struct {
+	Sorts          []string // Accepted sort keys; any when empty
+	DefaultSort    string   // Sort used when none is given, "-" prefixed for descending
+	Filters        []string // Parameters read as filters
+	DefaultPerPage int      // Defaults to 20
+	MaxPerPage     int      // Defaults to 100
+}
+
+// ParseTableQuery parses the table query parameters of r. Invalid values
+// are ignored rather than rejected, since they come from URLs users can
+// edit: unknown sorts fall back to the default one, and out of range pages
+// are clamped.
+func ParseTableQuery(r *http.Request, options ...TableQueryOptions) TableQuery {
+	var o TableQueryOptions
+	if len(options) != 0 {
+		o = options[0]
+	}
+	defaultPerPage := IfElse(o.DefaultPerPage > 0, o.DefaultPerPage, 20)
+	maxPerPage := IfElse(o.MaxPerPage > 0, o.MaxPerPage, 100)
+
+	values := r.URL.Query()
+	query := TableQuery{Page: 1, PerPage: defaultPerPage}
+
+	sort := values.Get("sort")
+	key := strings.TrimPrefix(sort, "-")
+	if key == "" || len(o.Sorts) != 0 && !slices.Contains(o.Sorts, key) {
+		sort = o.DefaultSort
+	}
+	query.Sort, query.Desc = strings.TrimPrefix(sort, "-"), strings.HasPrefix(sort, "-")
+
+	for _, filter := range o.Filters {
+		if value := values.Get(filter); value != "" {
+			if query.Filters == nil {
+				query.Filters = map[string]string{}
+			}
+			query.Filters[filter] = value
+		}
+	}
+
+	if page, err := strconv.Atoi(values.Get("page")); err == nil && page > 1 {
+		query.Page = page
+	}
+	if perPage, err := strconv.Atoi(values.Get("per_page")); err == nil && perPage > 0 {
+		query.PerPage = min(perPage, maxPerPage)
+	}
+	return query
+}
+
+// Offset returns the number of rows before the page, for SQL OFFSET.
+func (me TableQuery) Offset() int {
+	return (max(me.Page, 1) - 1) * me.PerPage
+}
+
+// SortURL returns u with the sort parameter set to sort by key: in
+// ascending order, or descending when the table is already sorted by key
+// in ascending order. The other parameters are preserved, except the page,
+// reset to the first one.
+func (me TableQuery) SortURL(u *url.URL, key string) string {
+	var values url.Values
+	path := ""
+	if u != nil {
+		values = u.Query()
+		path = u.Path
+	} else {
+		values = url.Values{}
+	}
+	values.Del("page")
+	values.Set("sort", IfElse(me.Sort == key && !me.Desc, "-"+key, key))
+	return path + "?" + values.Encode()
+}
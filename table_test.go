@@ -0,0 +1,71 @@
+package h
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+type tableUser struct {
+	Name, Email string
+}
+
+var tableUserColumns = []Column[tableUser]{
+	{Key: "name", Header: "Name", Cell: func(u tableUser) any { return u.Name }, Sortable: true},
+	{Key: "email", Header: "Email", Cell: func(u tableUser) any { return A(AttrHref("mailto:" + u.Email))(u.Email) }},
+}
+
+func TestTableFrom(t *testing.T) {
+	u, _ := url.Parse("/users?status=active&page=3&sort=name")
+	query := TableQuery{Sort: "name"}
+
+	var buf bytes.Buffer
+	node := TableFrom([]tableUser{{"Ada", "ada@example.com"}}, tableUserColumns, TableOptions{Query: query, URL: u, Attributes: []Attribute{AttrClass("users")}})
+	if err := Render(&buf, node); err != nil {
+		t.Fatal(err)
+	}
+	expected := `<table class="users"><thead><tr>` +
+		`<th scope="col" aria-sort="ascending"><a href="/users?sort=-name&status=active">Name</a></th>` +
+		`<th scope="col">Email</th></tr></thead>` +
+		`<tbody><tr><td>Ada</td><td><a href="mailto:ada@example.com">ada@example.com</a></td></tr></tbody></table>`
+	if buf.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, buf.String())
+	}
+}
+
+func TestParseTableQuery(t *testing.T) {
+	options := TableQueryOptions{Sorts: []string{"name", "created"}, DefaultSort: "-created", Filters: []string{"status"}, MaxPerPage: 50}
+	tests := []struct {
+		query    string
+		expected TableQuery
+	}{
+		{query: "", expected: TableQuery{Sort: "created", Desc: true, Page: 1, PerPage: 20}},
+		{query: "sort=name&page=3&per_page=10", expected: TableQuery{Sort: "name", Page: 3, PerPage: 10}},
+		{query: "sort=-password&page=-1&per_page=500", expected: TableQuery{Sort: "created", Desc: true, Page: 1, PerPage: 50}},
+		{query: "status=active&role=admin", expected: TableQuery{Sort: "created", Desc: true, Filters: map[string]string{"status": "active"}, Page: 1, PerPage: 20}},
+	}
+	for _, tt := range tests {
+		got := ParseTableQuery(httptest.NewRequest("GET", "/?"+tt.query, nil), options)
+		if got.Sort != tt.expected.Sort || got.Desc != tt.expected.Desc || got.Page != tt.expected.Page ||
+			got.PerPage != tt.expected.PerPage || len(got.Filters) != len(tt.expected.Filters) || got.Filters["status"] != tt.expected.Filters["status"] {
+			t.Errorf("%q: expected %+v, got %+v", tt.query, tt.expected, got)
+		}
+	}
+
+	if offset := (TableQuery{Page: 3, PerPage: 10}).Offset(); offset != 20 {
+		t.Errorf("expected offset 20, got %d", offset)
+	}
+}
+
+func TestTableQuerySortURL(t *testing.T) {
+	u, _ := url.Parse("/users?sort=-name&q=x")
+	query := TableQuery{Sort: "name", Desc: true}
+	if got := query.SortURL(u, "name"); !strings.Contains(got, "sort=name") {
+		t.Errorf("expected ascending sort after descending, got %q", got)
+	}
+	if got := query.SortURL(u, "email"); got != "/users?q=x&sort=email" {
+		t.Errorf("unexpected sort URL %q", got)
+	}
+}
@@ -0,0 +1,269 @@
+package h
+
+import (
+	"strconv"
+	"strings"
+)
+
+// TextDiffOptions configures [TextDiff]. All fields are optional.
+type TextDiffOptions struct {
+	SideBySide bool // Shows the old and new text in two columns rather than one
+	// Context is the number of unchanged lines shown around changes; the
+	// others are collapsed. All lines are shown when zero.
+	Context    int
+	Attributes []Attribute // Extra attributes for the <table>
+}
+
+// Class names set on the elements rendered by [TextDiff], as styling hooks.
+const (
+	ClassDiff         = "diff"
+	ClassDiffLine     = "diff-line"     // Line number cells
+	ClassDiffEqual    = "diff-equal"    // Rows of unchanged lines
+	ClassDiffDelete   = "diff-delete"   // Rows and cells of removed lines
+	ClassDiffInsert   = "diff-insert"   // Rows and cells of added lines
+	ClassDiffCollapse = "diff-collapse" // Rows standing for collapsed unchanged lines
+)
+
+// textDiffLine is a line of a line diff.
+type textDiffLine struct {
+	op       byte // ' ' (unchanged), '-' (removed) or '+' (added)
+	text     string
+	old, new int    // Line numbers, 0 when the line isn't in that version
+	mark     [2]int // Byte range of text that changed, for lines paired with their replacement
+	hasMark  bool
+}
+
+// TextDiff renders a line diff between old and new, as a table with line
+// numbers: removed lines are in <del>, added lines in <ins>, and the
+// changed part of a modified line in <mark>. Use it for audit logs and
+// admin tools showing what an edit changed.
+//
+// The diff is computed in O(len(old lines) × len(new lines)) time and
+// memory, fine for documents and records but not for large files.
+//
+// Example:
+//
+//	TextDiff(revision.Before, revision.After, TextDiffOptions{Context: 3})
+func TextDiff(old, new string, options ...TextDiffOptions) Element {
+	var o TextDiffOptions
+	if len(options) != 0 {
+		o = options[0]
+	}
+
+	lines := diffLines(splitLines(old), splitLines(new))
+	markChanges(lines)
+	visible := visibleLines(lines, o.Context)
+
+	columns := IfElse(o.SideBySide, "4", "3")
+	var rows []any
+	for i := 0; i < len(lines); {
+		switch {
+		case !visible[i]:
+			start := i
+			for i < len(lines) && !visible[i] {
+				i++
+			}
+			rows = append(rows, TR(AttrClass(ClassDiffCollapse))(
+				TD(AttrColSpan(columns))("⋯ "+strconv.Itoa(i-start)+" unchanged lines"),
+			))
+		case !o.SideBySide:
+			rows = append(rows, unifiedRow(lines[i]))
+			i++
+		case lines[i].op == ' ':
+			rows = append(rows, sideBySideRow(&lines[i], &lines[i]))
+			i++
+		default:
+			// Show the k-th removed line of a change next to its k-th
+			// added line.
+			deletes := i
+			for i < len(lines) && lines[i].op == '-' {
+				i++
+			}
+			inserts := i
+			for i < len(lines) && lines[i].op == '+' {
+				i++
+			}
+			for k := range max(inserts-deletes, i-inserts) {
+				var removed, added *textDiffLine
+				if deletes+k < inserts {
+					removed = &lines[deletes+k]
+				}
+				if inserts+k < i {
+					added = &lines[inserts+k]
+				}
+				rows = append(rows, sideBySideRow(removed, added))
+			}
+		}
+	}
+
+	attrs := append([]Attribute{AttrClass(ClassDiff)}, o.Attributes...)
+	return TABLE(attrs...)(TBODY()(rows...))
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// diffLines computes a line diff with the longest common subsequence.
+func diffLines(a, b []string) []textDiffLine {
+	// lcs[i*(len(b)+1)+j] is the length of the LCS of a[i:] and b[j:].
+	width := len(b) + 1
+	lcs := make([]int, (len(a)+1)*width)
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i*width+j] = lcs[(i+1)*width+j+1] + 1
+			} else {
+				lcs[i*width+j] = max(lcs[(i+1)*width+j], lcs[i*width+j+1])
+			}
+		}
+	}
+
+	var lines []textDiffLine
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, textDiffLine{op: ' ', text: a[i], old: i + 1, new: j + 1})
+			i++
+			j++
+		case j == len(b) || i < len(a) && lcs[(i+1)*width+j] >= lcs[i*width+j+1]:
+			lines = append(lines, textDiffLine{op: '-', text: a[i], old: i + 1})
+			i++
+		default:
+			lines = append(lines, textDiffLine{op: '+', text: b[j], new: j + 1})
+			j++
+		}
+	}
+	return lines
+}
+
+// markChanges pairs the removed lines of each change with the lines added
+// in their place, and marks the part of each pair that differs.
+func markChanges(lines []textDiffLine) {
+	for i := 0; i < len(lines); {
+		deletes := i
+		for i < len(lines) && lines[i].op == '-' {
+			i++
+		}
+		inserts := i
+		for i < len(lines) && lines[i].op == '+' {
+			i++
+		}
+		for k := 0; k < min(inserts-deletes, i-inserts); k++ {
+			removed, added := &lines[deletes+k], &lines[inserts+k]
+			prefix := commonPrefix(removed.text, added.text)
+			suffix := commonSuffix(removed.text[prefix:], added.text[prefix:])
+			removed.mark, removed.hasMark = [2]int{prefix, len(removed.text) - suffix}, true
+			added.mark, added.hasMark = [2]int{prefix, len(added.text) - suffix}, true
+		}
+		if i == deletes {
+			i++
+		}
+	}
+}
+
+func commonPrefix(a, b string) int {
+	n := 0
+	for _, r := range a {
+		size := len(string(r))
+		if n+size > len(b) || b[n:n+size] != string(r) {
+			break
+		}
+		n += size
+	}
+	return n
+}
+
+func commonSuffix(a, b string) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[len(a)-1-n] == b[len(b)-1-n] {
+		n++
+	}
+	// Don't split a multi-byte rune.
+	for n > 0 && n < len(a) && !isRuneStart(a[len(a)-n]) {
+		n--
+	}
+	return n
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+// visibleLines reports which lines are shown: all of them when context is
+// zero, else the changes and the context unchanged lines around them.
+func visibleLines(lines []textDiffLine, context int) []bool {
+	visible := make([]bool, len(lines))
+	for i, line := range lines {
+		if context <= 0 || line.op != ' ' {
+			visible[i] = true
+			continue
+		}
+		for k := max(0, i-context); k <= min(len(lines)-1, i+context); k++ {
+			if lines[k].op != ' ' {
+				visible[i] = true
+				break
+			}
+		}
+	}
+	return visible
+}
+
+func lineNumber(n int) HyperNode {
+	return TD(AttrClass(ClassDiffLine))(IfElse(n == 0, "", strconv.Itoa(n)))
+}
+
+func lineContent(line textDiffLine) HyperNode {
+	var content []any
+	if line.hasMark && line.mark[0] < line.mark[1] {
+		content = []any{line.text[:line.mark[0]], MARK()(line.text[line.mark[0]:line.mark[1]]), line.text[line.mark[1]:]}
+	} else {
+		content = []any{line.text}
+	}
+	switch line.op {
+	case '-':
+		return TD(AttrClass(ClassDiffDelete))(DEL()(content...))
+	case '+':
+		return TD(AttrClass(ClassDiffInsert))(INS()(content...))
+	default:
+		return TD()(content...)
+	}
+}
+
+func unifiedRow(line textDiffLine) HyperNode {
+	class := map[byte]string{' ': ClassDiffEqual, '-': ClassDiffDelete, '+': ClassDiffInsert}[line.op]
+	return TR(AttrClass(class))(lineNumber(line.old), lineNumber(line.new), lineContent(line))
+}
+
+// sideBySideRow renders a row showing the old line on the left and the
+// new one on the right; either is nil when the line has no counterpart.
+func sideBySideRow(old, new *textDiffLine) HyperNode {
+	var class []string
+	cells := make([]any, 0, 4)
+	if old != nil {
+		cells = append(cells, lineNumber(old.old), lineContent(*old))
+	} else {
+		cells = append(cells, lineNumber(0), TD()())
+	}
+	if new != nil {
+		cells = append(cells, lineNumber(new.new), lineContent(*new))
+	} else {
+		cells = append(cells, lineNumber(0), TD()())
+	}
+	switch {
+	case old == new:
+		class = append(class, ClassDiffEqual)
+	default:
+		if old != nil {
+			class = append(class, ClassDiffDelete)
+		}
+		if new != nil {
+			class = append(class, ClassDiffInsert)
+		}
+	}
+	return TR(AttrClass(strings.Join(class, " ")))(cells...)
+}
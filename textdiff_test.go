@@ -0,0 +1,56 @@
+package h
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestTextDiff(t *testing.T) {
+	old := "alpha\nbeta\ngamma\n"
+	new := "alpha\nbeta!\ngamma\ndelta\n"
+
+	var buf bytes.Buffer
+	if err := Render(&buf, TextDiff(old, new)); err != nil {
+		t.Fatal(err)
+	}
+	expected := `<table class="diff"><tbody>` +
+		`<tr class="diff-equal"><td class="diff-line">1</td><td class="diff-line">1</td><td>alpha</td></tr>` +
+		`<tr class="diff-delete"><td class="diff-line">2</td><td class="diff-line"></td><td class="diff-delete"><del>beta</del></td></tr>` +
+		`<tr class="diff-insert"><td class="diff-line"></td><td class="diff-line">2</td><td class="diff-insert"><ins>beta<mark>!</mark></ins></td></tr>` +
+		`<tr class="diff-equal"><td class="diff-line">3</td><td class="diff-line">3</td><td>gamma</td></tr>` +
+		`<tr class="diff-insert"><td class="diff-line"></td><td class="diff-line">4</td><td class="diff-insert"><ins>delta</ins></td></tr>` +
+		`</tbody></table>`
+	if buf.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, buf.String())
+	}
+}
+
+func TestTextDiffSideBySide(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, TextDiff("a\nb\nc", "a\nB\nc\nd", TextDiffOptions{SideBySide: true})); err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		`<tr class="diff-equal"><td class="diff-line">1</td><td>a</td><td class="diff-line">1</td><td>a</td></tr>`,
+		`<tr class="diff-delete diff-insert"><td class="diff-line">2</td><td class="diff-delete"><del><mark>b</mark></del></td><td class="diff-line">2</td><td class="diff-insert"><ins><mark>B</mark></ins></td></tr>`,
+		`<tr class="diff-insert"><td class="diff-line"></td><td></td><td class="diff-line">4</td><td class="diff-insert"><ins>d</ins></td></tr>`,
+	} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("expected %q in %q", expected, buf.String())
+		}
+	}
+}
+
+func TestTextDiffContext(t *testing.T) {
+	old := "1\n2\n3\n4\n5\n6\n7\n8\n"
+	new := "1\n2\n3\n4\n5\n6\n7\neight\n"
+
+	var buf bytes.Buffer
+	if err := Render(&buf, TextDiff(old, new, TextDiffOptions{Context: 2})); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(buf.String(), `<table class="diff"><tbody><tr class="diff-collapse"><td colspan="3">⋯ 5 unchanged lines</td></tr><tr class="diff-equal"><td class="diff-line">6</td>`) {
+		t.Errorf("expected the first 5 lines collapsed, got %q", buf.String())
+	}
+}
@@ -0,0 +1,88 @@
+package theme
+
+import (
+	"encoding/json"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+// DefaultStorageKey is the localStorage key used by [DarkMode] when
+// StorageKey is empty.
+const DefaultStorageKey = "theme"
+
+// DarkMode turns a light theme and a set of dark overrides into the CSS and
+// script needed for dark mode:
+//
+//   - the light tokens apply by default,
+//   - the dark overrides apply when the OS prefers a dark color scheme,
+//   - an explicit choice, stored in localStorage by the toggle script and
+//     reflected as data-theme="light|dark" on <html>, wins over the OS setting.
+//
+// Example:
+//
+//	var Mode = theme.DarkMode{
+//		Light: theme.New(Background, Foreground),
+//		Dark: theme.New(
+//			theme.NewColor("background", "#0f172a"),
+//			theme.NewColor("foreground", "#f8fafc"),
+//		),
+//	}
+//
+//	HEAD()(Mode.Head(h.AttrNonce(nonce)))
+//	BUTTON(h.AttrType(h.TypeButton), h.AttrOnClick("toggleTheme()"))("Toggle theme")
+type DarkMode struct {
+	Light      Theme  // Tokens used in light mode (and as the base for dark mode)
+	Dark       Theme  // Tokens overridden in dark mode
+	StorageKey string // localStorage key remembering the user's choice; defaults to [DefaultStorageKey]
+}
+
+// CSS returns the stylesheet declaring the light tokens on :root, and the dark
+// overrides inside a prefers-color-scheme media block and on [data-theme=dark].
+func (me DarkMode) CSS() string {
+	dark := me.Dark.Declarations()
+	return ":root{color-scheme:light dark;" + me.Light.Declarations() + "}" +
+		"@media (prefers-color-scheme: dark){:root:not([data-theme=light]){" + dark + "}}" +
+		":root[data-theme=light]{color-scheme:light;}" +
+		":root[data-theme=dark]{color-scheme:dark;" + dark + "}"
+}
+
+// Style returns a <style> element with the dark mode stylesheet.
+func (me DarkMode) Style(attrs ...h.Attribute) h.HyperNode {
+	return h.STYLE(attrs...)(h.RawText(me.CSS()))
+}
+
+// Script returns the toggle script. It must be placed in <head>, before any
+// stylesheet, so the stored choice is applied before the first paint and the
+// page never flashes the wrong theme. It defines a global toggleTheme()
+// function switching between light and dark and remembering the choice.
+func (me DarkMode) Script(attrs ...h.Attribute) h.HyperNode {
+	key := me.StorageKey
+	if key == "" {
+		key = DefaultStorageKey
+	}
+	// json.Marshal escapes <, > and &, so the key cannot break out of the script.
+	quotedKey, _ := json.Marshal(key)
+
+	return h.SCRIPT(attrs...)(h.RawText(`(function(){var k=` + string(quotedKey) + `,d=document.documentElement;` +
+		`try{var t=localStorage.getItem(k);if(t==="light"||t==="dark")d.dataset.theme=t}catch(e){}` +
+		`window.toggleTheme=function(){var dark=d.dataset.theme?d.dataset.theme==="dark":matchMedia("(prefers-color-scheme: dark)").matches;` +
+		`d.dataset.theme=dark?"light":"dark";try{localStorage.setItem(k,d.dataset.theme)}catch(e){}}})();`))
+}
+
+// Head returns the color-scheme meta tag, the toggle script and the style
+// element, in the order they belong in <head>. attrs are applied to both the
+// script and the style element (typically a CSP nonce).
+func (me DarkMode) Head(attrs ...h.Attribute) h.HyperNode {
+	return h.Group(
+		ColorSchemeMeta("light dark"),
+		me.Script(attrs...),
+		me.Style(attrs...),
+	)
+}
+
+// ColorSchemeMeta returns the <meta name="color-scheme"> tag telling the
+// browser which color schemes the page supports (e.g. "light dark"), so
+// form controls and scrollbars match before any CSS loads.
+func ColorSchemeMeta(schemes string) h.HyperNode {
+	return h.META(h.AttrName("color-scheme"), h.AttrContent(schemes))
+}
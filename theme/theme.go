@@ -0,0 +1,150 @@
+// Package theme declares design tokens (colors, spacing, fonts) in Go and
+// renders them as CSS custom properties.
+//
+// Each token kind is its own type, so a spacing token cannot be passed where a
+// color is expected. Tokens are referenced from styles with their Var method:
+//
+//	var (
+//		Primary = theme.NewColor("primary", "#3b82f6")
+//		Gap     = theme.NewSpacing("gap", "1rem")
+//		Sans    = theme.NewFont("sans", "Inter, system-ui, sans-serif")
+//
+//		Default = theme.New(Primary, Gap, Sans)
+//	)
+//
+//	HEAD()(Default.Style())
+//	DIV(h.StyleAttr(map[string]string{"color": Primary.Var(), "gap": Gap.Var()}))
+//
+// The property names follow the Tailwind CSS v4 theme namespaces
+// (--color-*, --spacing-*, --font-*), so tokens can also back utility classes.
+package theme
+
+import (
+	"strings"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+// Token is a design token rendered as a CSS custom property.
+type Token interface {
+	// Property returns the custom property name, e.g. "--color-primary".
+	Property() string
+	// Value returns the CSS value of the token.
+	Value() string
+}
+
+type token struct {
+	property string
+	value    string
+}
+
+func newToken(namespace, name, value string) token {
+	if !isValidName(name) {
+		panic("invalid theme token name: " + name)
+	}
+	if strings.ContainsAny(value, ";{}<>") {
+		panic("invalid theme token value: " + value)
+	}
+	return token{property: "--" + namespace + "-" + name, value: value}
+}
+
+func (me token) Property() string { return me.property }
+func (me token) Value() string    { return me.value }
+
+// Var returns a var() reference to the token, for use in CSS values.
+func (me token) Var() string { return "var(" + me.property + ")" }
+
+// String returns the same as Var, so tokens can be used directly where a
+// fmt.Stringer is accepted.
+func (me token) String() string { return me.Var() }
+
+// Color is a color token (--color-*).
+type Color struct{ token }
+
+// NewColor creates a color token. name may contain letters, digits, '-' and '_'.
+func NewColor(name, value string) Color {
+	return Color{newToken("color", name, value)}
+}
+
+// Spacing is a length token used for margins, paddings and gaps (--spacing-*).
+type Spacing struct{ token }
+
+// NewSpacing creates a spacing token. name may contain letters, digits, '-' and '_'.
+func NewSpacing(name, value string) Spacing {
+	return Spacing{newToken("spacing", name, value)}
+}
+
+// Font is a font-family token (--font-*).
+type Font struct{ token }
+
+// NewFont creates a font-family token. name may contain letters, digits, '-' and '_'.
+func NewFont(name, value string) Font {
+	return Font{newToken("font", name, value)}
+}
+
+// Theme is an ordered set of design tokens.
+type Theme struct {
+	Tokens []Token
+}
+
+// New creates a theme from tokens. Later tokens override earlier ones with
+// the same property, which makes deriving themes a matter of appending.
+func New(tokens ...Token) Theme {
+	return Theme{Tokens: tokens}
+}
+
+// With returns a copy of the theme with tokens added (or overridden).
+func (me Theme) With(tokens ...Token) Theme {
+	return Theme{Tokens: append(append([]Token{}, me.Tokens...), tokens...)}
+}
+
+// Declarations returns the tokens as CSS declarations ("--name:value;..."),
+// without a selector.
+func (me Theme) Declarations() string {
+	var css strings.Builder
+	for _, t := range me.resolved() {
+		css.WriteString(t.Property())
+		css.WriteByte(':')
+		css.WriteString(t.Value())
+		css.WriteByte(';')
+	}
+	return css.String()
+}
+
+// CSS returns the tokens declared on the :root selector.
+func (me Theme) CSS() string {
+	return ":root{" + me.Declarations() + "}"
+}
+
+// Style returns a <style> element declaring the tokens on :root.
+func (me Theme) Style(attrs ...h.Attribute) h.HyperNode {
+	return h.STYLE(attrs...)(h.RawText(me.CSS()))
+}
+
+// resolved returns the tokens with overridden properties removed, keeping the
+// position of the first declaration so the output order is stable.
+func (me Theme) resolved() []Token {
+	index := make(map[string]int, len(me.Tokens))
+	result := make([]Token, 0, len(me.Tokens))
+	for _, t := range me.Tokens {
+		if i, ok := index[t.Property()]; ok {
+			result[i] = t
+			continue
+		}
+		index[t.Property()] = len(result)
+		result = append(result, t)
+	}
+	return result
+}
+
+func isValidName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return false
+		}
+	}
+	return true
+}
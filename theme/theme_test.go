@@ -0,0 +1,111 @@
+package theme
+
+import (
+	"bytes"
+	"testing"
+
+	h "github.com/assaidy/hyper/v2"
+)
+
+func TestTheme(t *testing.T) {
+	primary := NewColor("primary", "#3b82f6")
+	gap := NewSpacing("gap", "1rem")
+	sans := NewFont("sans", "Inter, sans-serif")
+
+	base := New(primary, gap, sans)
+	derived := base.With(NewColor("primary", "#2563eb"))
+
+	tests := []struct {
+		name     string
+		theme    Theme
+		expected string
+	}{
+		{
+			name:     "Base theme",
+			theme:    base,
+			expected: ":root{--color-primary:#3b82f6;--spacing-gap:1rem;--font-sans:Inter, sans-serif;}",
+		},
+		{
+			name:     "Override keeps the original position",
+			theme:    derived,
+			expected: ":root{--color-primary:#2563eb;--spacing-gap:1rem;--font-sans:Inter, sans-serif;}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.theme.CSS(); got != tt.expected {
+				t.Errorf("CSS() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+
+	if primary.Var() != "var(--color-primary)" {
+		t.Errorf("Var() = %q, want %q", primary.Var(), "var(--color-primary)")
+	}
+}
+
+func TestTheme_Style(t *testing.T) {
+	var buf bytes.Buffer
+	if err := h.Render(&buf, New(NewColor("fg", "#000")).Style()); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if expected := "<style>:root{--color-fg:#000;}</style>"; buf.String() != expected {
+		t.Errorf("Style() = %q, want %q", buf.String(), expected)
+	}
+
+	buf.Reset()
+	attr := h.StyleAttr(map[string]string{"gap": NewSpacing("md", "1rem").Var(), "color": "red", "margin": ""})
+	if err := attr.Render(&buf); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if expected := ` style="color:red;gap:var(--spacing-md)"`; buf.String() != expected {
+		t.Errorf("StyleAttr() = %q, want %q", buf.String(), expected)
+	}
+}
+
+func TestNewToken_Invalid(t *testing.T) {
+	for _, fn := range []func(){
+		func() { NewColor("bad name", "#000") },
+		func() { NewColor("fg", "red;}body{display:none") },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic for invalid token")
+				}
+			}()
+			fn()
+		}()
+	}
+}
+
+func TestDarkMode(t *testing.T) {
+	mode := DarkMode{
+		Light:      New(NewColor("bg", "#fff")),
+		Dark:       New(NewColor("bg", "#000")),
+		StorageKey: "</script>",
+	}
+
+	expected := ":root{color-scheme:light dark;--color-bg:#fff;}" +
+		"@media (prefers-color-scheme: dark){:root:not([data-theme=light]){--color-bg:#000;}}" +
+		":root[data-theme=light]{color-scheme:light;}" +
+		":root[data-theme=dark]{color-scheme:dark;--color-bg:#000;}"
+	if got := mode.CSS(); got != expected {
+		t.Errorf("CSS() = %q, want %q", got, expected)
+	}
+
+	var buf bytes.Buffer
+	if err := h.Render(&buf, mode.Head(h.AttrNonce("abc"))); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	for _, want := range []string{
+		`<meta name="color-scheme" content="light dark">`,
+		`<script nonce="abc">(function(){var k="\u003c/script\u003e"`,
+		`<style nonce="abc">:root{`,
+	} {
+		if !bytes.Contains(buf.Bytes(), []byte(want)) {
+			t.Errorf("Head() = %q, want it to contain %q", buf.String(), want)
+		}
+	}
+}
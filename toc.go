@@ -0,0 +1,95 @@
+package h
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// tocEntry is a heading listed by [TOC], with the headings nested under it.
+type tocEntry struct {
+	level    int
+	id, text string
+	children []*tocEntry
+}
+
+// TOC returns a table of contents of the headings of node, from <h1> to
+// <h{maxDepth}> (all six when maxDepth is out of 1-6): a nested list of
+// links, each heading nested under the closest previous heading of a
+// higher level. Headings without id get one derived from their text
+// ("Getting started" becomes "getting-started"), so TOC also returns node
+// with those ids, to render instead of node.
+//
+// Example:
+//
+//	content, toc := TOC(article, 3)
+//	MAIN()(ASIDE()(toc), content)
+func TOC(node HyperNode, maxDepth int) (HyperNode, HyperNode) {
+	if maxDepth < 1 || maxDepth > 6 {
+		maxDepth = 6
+	}
+	tags := make([]string, maxDepth)
+	for i := range tags {
+		tags[i] = "h" + strconv.Itoa(i+1)
+	}
+
+	root := &tocEntry{}
+	stack := []*tocEntry{root}
+	used := map[string]int{}
+	node = Transform(node, strings.Join(tags, ", "), func(e Element) HyperNode {
+		text := strings.TrimSpace(plainText(Element{Children: e.Children}))
+		id, ok := e.Attribute("id")
+		if !ok {
+			id = headingID(text)
+			if used[id]++; used[id] > 1 {
+				id += "-" + strconv.Itoa(used[id])
+			}
+			e.Attributes = append(e.Attributes, AttrID(id))
+		}
+
+		entry := &tocEntry{level: int(e.Tag[1] - '0'), id: id, text: text}
+		for stack[len(stack)-1].level >= entry.level {
+			stack = stack[:len(stack)-1]
+		}
+		parent := stack[len(stack)-1]
+		parent.children = append(parent.children, entry)
+		stack = append(stack, entry)
+		return e
+	})
+
+	if len(root.children) == 0 {
+		return node, Group()
+	}
+	return node, tocList(root.children)
+}
+
+func tocList(entries []*tocEntry) Element {
+	return UL()(Range(entries, func(entry *tocEntry) HyperNode {
+		return LI()(
+			A(AttrHref("#"+entry.id))(entry.text),
+			If(len(entry.children) != 0, tocList(entry.children)),
+		)
+	}))
+}
+
+// headingID returns the id of a heading of text: its letters and digits,
+// lowercase, with dashes between words.
+func headingID(text string) string {
+	var id strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(text) {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			dash = true
+			continue
+		}
+		if dash && id.Len() != 0 {
+			id.WriteByte('-')
+		}
+		dash = false
+		id.WriteRune(r)
+	}
+	if id.Len() == 0 {
+		return "section"
+	}
+	return id.String()
+}
@@ -0,0 +1,85 @@
+package h
+
+// Transform returns a copy of node in which the elements matching selector
+// are replaced by what fn returns for them, so a tree built by someone else
+// (a layout, a third-party component) can be adjusted without changing its
+// code. Matching elements are passed to fn with their children already
+// transformed. Elements inside wrappers of this package, such as [Named]
+// and [Optional], are transformed too; other node types are kept as they
+// are (see [Wrapper]). When selector is invalid, the returned node fails to
+// render.
+//
+// Example:
+//
+//	page = Transform(page, "a[href^=http]", func(e Element) HyperNode {
+//		e.Attributes = append(e.Attributes, AttrTarget(TargetBlank), AttrRel(RelNoOpener))
+//		return e
+//	})
+func Transform(node HyperNode, selector string, fn func(Element) HyperNode) HyperNode {
+	parsed, err := ParseSelector(selector)
+	if err != nil {
+		return errorNode{err: err}
+	}
+	return transform(node, nil, parsed.Matches, fn)
+}
+
+func transform(node HyperNode, ancestors []Element, match func(Element, []Element) bool, fn func(Element) HyperNode) HyperNode {
+	switch n := node.(type) {
+	case Element:
+		childAncestors := ancestors
+		if n.Tag != "" {
+			childAncestors = append(ancestors[:len(ancestors):len(ancestors)], n)
+		}
+		matched := n.Tag != "" && match(n, ancestors)
+		children := make([]HyperNode, len(n.Children))
+		for i, child := range n.Children {
+			children[i] = transform(child, childAncestors, match, fn)
+		}
+		n.Children = children
+		if matched {
+			return fn(n)
+		}
+		return n
+	case Wrapper:
+		return mapWrapped(n, func(child HyperNode) HyperNode {
+			return transform(child, ancestors, match, fn)
+		})
+	default:
+		return node
+	}
+}
+
+// appendAttrToken returns a copy of attrs with token added to the
+// space-separated list of the attribute called key, such as class.
+func appendAttrToken(attrs []Attribute, key, token string) []Attribute {
+	attrs = append([]Attribute{}, attrs...)
+	for i, attr := range attrs {
+		if a, ok := attr.(PairAttribute); ok && a.Key == key {
+			if a.Value != "" {
+				token = a.Value + " " + token
+			}
+			attrs[i] = PairAttribute{Key: key, Value: token}
+			return attrs
+		}
+	}
+	return append(attrs, PairAttribute{Key: key, Value: token})
+}
+
+// removeAttr returns a copy of attrs without the attributes called key.
+func removeAttr(attrs []Attribute, key string) []Attribute {
+	var kept []Attribute
+	for _, attr := range attrs {
+		switch a := attr.(type) {
+		case PairAttribute:
+			if a.Key == key {
+				continue
+			}
+		case BooleanAttribute:
+			if a.Key == key {
+				continue
+			}
+		}
+		kept = append(kept, attr)
+	}
+	return kept
+}
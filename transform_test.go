@@ -0,0 +1,65 @@
+package h
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTransform(t *testing.T) {
+	node := DIV()(
+		A(AttrHref("/about"))("About"),
+		UL()(Key("1", LI()(A(AttrHref("https://example.com"))("Out")))),
+	)
+	transformed := Transform(node, "ul a[href^=http]", func(e Element) HyperNode {
+		e.Attributes = append(e.Attributes, AttrTarget("_blank"))
+		return e
+	})
+
+	var buf bytes.Buffer
+	if err := Render(&buf, transformed); err != nil {
+		t.Fatal(err)
+	}
+	expected := `<div><a href="/about">About</a><ul><li><a href="https://example.com" target="_blank">Out</a></li></ul></div>`
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+
+	// The original tree is untouched.
+	buf.Reset()
+	Render(&buf, node)
+	if bytes.Contains(buf.Bytes(), []byte("_blank")) {
+		t.Error("Transform modified its input")
+	}
+
+	if err := Render(&buf, Transform(node, "a[", nil)); err == nil {
+		t.Error("expected an error for an invalid selector")
+	}
+}
+
+func TestTransformWrappers(t *testing.T) {
+	node := DIV()(
+		Optional(A(AttrHref("/related"))("Related"), 1),
+		Sensitive(A(AttrHref("/account"))("Account")),
+		Slot("footer", A(AttrHref("/about"))("About")),
+		card{Body: A(AttrHref("/card"))("Card")},
+	)
+	transformed := Transform(node, "a", func(e Element) HyperNode {
+		e.Attributes = append(e.Attributes, AttrClass("link"))
+		return e
+	})
+
+	var buf bytes.Buffer
+	if err := Render(&buf, transformed); err != nil {
+		t.Fatal(err)
+	}
+	expected := `<div><a href="/related" class="link">Related</a>` +
+		`<a href="/account" class="link" hx-history="false">Account</a>` +
+		`<a href="/about" class="link">About</a>` +
+		`<div class="card"><a href="/card">Card</a></div></div>`
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+	if _, ok := transformed.(Element).Children[0].(OptionalNode); !ok {
+		t.Errorf("expected the optional node to be kept, got %T", transformed.(Element).Children[0])
+	}
+}
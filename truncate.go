@@ -0,0 +1,177 @@
+package h
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// Truncate returns text cut to at most n runes, at a word boundary when
+// possible, followed by ellipsis. Truncated text is rendered in a <span>
+// whose title attribute holds the full text, so it stays readable on hover;
+// text short enough is rendered as it is.
+//
+// Example:
+//
+//	Truncate("The quick brown fox jumps", 15, "…")
+//	// <span title="The quick brown fox jumps">The quick brown…</span>
+func Truncate(text string, n int, ellipsis string) HyperNode {
+	truncated, ok := truncateWords(text, n)
+	if !ok {
+		return Text(text)
+	}
+	return SPAN(AttrTitle(text))(truncated + ellipsis)
+}
+
+// truncateWords cuts s to at most n runes, backing up to the last word
+// boundary when the cut falls inside a word. It reports whether s was cut.
+func truncateWords(s string, n int) (string, bool) {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s, false
+	}
+	cut := runes[:max(n, 0)]
+	if !unicode.IsSpace(runes[len(cut)]) {
+		for i := len(cut) - 1; i > 0; i-- {
+			if unicode.IsSpace(cut[i]) {
+				cut = cut[:i]
+				break
+			}
+		}
+	}
+	return strings.TrimRightFunc(string(cut), func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	}), true
+}
+
+// voidTags are the elements without closing tag.
+var voidTags = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "source": true, "track": true, "wbr": true,
+}
+
+// blockTags are the elements separating words.
+var blockTags = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "br": true, "dd": true,
+	"div": true, "dl": true, "dt": true, "figcaption": true, "footer": true, "h1": true, "h2": true,
+	"h3": true, "h4": true, "h5": true, "h6": true, "header": true, "hr": true, "li": true,
+	"ol": true, "p": true, "pre": true, "section": true, "table": true, "td": true, "th": true,
+	"tr": true, "ul": true,
+}
+
+// tagName returns the lowercase name of the tag whose content, between
+// angle brackets, is tag: "a" for `a href="/"` and `/a`.
+func tagName(tag string) string {
+	fields := strings.FieldsFunc(tag, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '/'
+	})
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.ToLower(fields[0])
+}
+
+// Excerpt returns htmlText cut to at most n runes of text, at a word
+// boundary when possible, followed by "…", with the tags left open by the
+// cut closed. The tags are kept as they are, so htmlText must already be
+// sanitized. Truncated HTML is rendered in a <span> whose title attribute
+// holds the full text, without tags.
+//
+// Example:
+//
+//	Excerpt("<p>Hello <b>wide world</b></p>", 10)
+//	// <span title="Hello wide world"><p>Hello <b>wide…</b></p></span>
+func Excerpt(htmlText string, n int) HyperNode {
+	var text strings.Builder                       // The text of htmlText, entities unescaped
+	type textSpan struct{ start, end, offset int } // Byte ranges of text in htmlText, and their offset in text
+	var spans []textSpan
+	for rest, i := htmlText, 0; rest != ""; {
+		if rest[0] == '<' {
+			end := strings.IndexByte(rest, '>')
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			// Block elements separate words: "<p>a</p><p>b</p>" reads "a b".
+			if name := tagName(rest[1:max(end, 1)]); blockTags[name] && text.Len() != 0 && !strings.HasSuffix(text.String(), " ") {
+				text.WriteByte(' ')
+			}
+			i += end + 1
+			rest = rest[end+1:]
+			continue
+		}
+		end := strings.IndexByte(rest, '<')
+		if end < 0 {
+			end = len(rest)
+		}
+		spans = append(spans, textSpan{start: i, end: i + end, offset: text.Len()})
+		text.WriteString(html.UnescapeString(rest[:end]))
+		i += end
+		rest = rest[end:]
+	}
+
+	truncated, ok := truncateWords(text.String(), n)
+	if !ok {
+		return RawText(htmlText)
+	}
+
+	// Copy htmlText up to the text kept, tracking the open tags.
+	var out strings.Builder
+	var open []string
+	kept := len(truncated)
+	position := 0
+	for _, span := range spans {
+		if span.offset >= kept {
+			break
+		}
+		open = trackTags(htmlText[position:span.start], open)
+		out.WriteString(htmlText[position:span.start])
+		position = span.end
+
+		segment := htmlText[span.start:span.end]
+		unescaped := html.UnescapeString(segment)
+		if span.offset+len(unescaped) <= kept {
+			out.WriteString(segment)
+			continue
+		}
+		out.WriteString(html.EscapeString(unescaped[:kept-span.offset]))
+		break
+	}
+	out.WriteString("…")
+	for i := len(open) - 1; i >= 0; i-- {
+		out.WriteString("</" + open[i] + ">")
+	}
+	return SPAN(AttrTitle(strings.TrimSpace(text.String())))(RawText(out.String()))
+}
+
+// trackTags updates the stack of open tags with the tags of markup.
+func trackTags(markup string, open []string) []string {
+	for {
+		start := strings.IndexByte(markup, '<')
+		if start < 0 {
+			return open
+		}
+		end := strings.IndexByte(markup[start:], '>')
+		if end < 0 {
+			return open
+		}
+		tag := markup[start+1 : start+end]
+		markup = markup[start+end+1:]
+
+		closing := strings.HasPrefix(tag, "/")
+		selfClosing := strings.HasSuffix(tag, "/")
+		name := tagName(tag)
+		switch {
+		case name == "":
+		case strings.HasPrefix(tag, "!"), strings.HasPrefix(tag, "?"), selfClosing, voidTags[name]:
+		case closing:
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] == name {
+					open = open[:i]
+					break
+				}
+			}
+		default:
+			open = append(open, name)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package h
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		text     string
+		n        int
+		expected string
+	}{
+		{text: "short", n: 10, expected: `short`},
+		{text: "The quick brown fox jumps", n: 15, expected: `<span title="The quick brown fox jumps">The quick brown…</span>`},
+		{text: "The quick brown fox jumps", n: 13, expected: `<span title="The quick brown fox jumps">The quick…</span>`},
+		{text: "Hello, world", n: 7, expected: `<span title="Hello, world">Hello…</span>`},
+		{text: "Ünïcödé wörds här", n: 9, expected: `<span title="Ünïcödé wörds här">Ünïcödé…</span>`},
+		{text: "Supercalifragilistic", n: 5, expected: `<span title="Supercalifragilistic">Super…</span>`},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := Render(&buf, Truncate(tt.text, tt.n, "…")); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != tt.expected {
+			t.Errorf("Truncate(%q, %d): expected %q, got %q", tt.text, tt.n, tt.expected, buf.String())
+		}
+	}
+}
+
+func TestExcerpt(t *testing.T) {
+	tests := []struct {
+		html     string
+		n        int
+		expected string
+	}{
+		{html: "<p>Short</p>", n: 10, expected: `<p>Short</p>`},
+		{html: "<", n: 0, expected: `<`},
+		{html: "<p>Hello <b>wide world</b></p>", n: 10, expected: `<span title="Hello wide world"><p>Hello <b>wide…</b></p></span>`},
+		{html: "<p>Hello</p><p>wide world</p>", n: 7, expected: `<span title="Hello wide world"><p>Hello…</p></span>`},
+		{html: "<p>Fish &amp; chips<br>for <i>two</i></p>", n: 12, expected: `<span title="Fish & chips for two"><p>Fish &amp; chips…</p></span>`},
+		{html: `<div><img src="a.png"><a href="/x">Read the rest</a></div>`, n: 8, expected: `<span title="Read the rest"><div><img src="a.png"><a href="/x">Read the…</a></div></span>`},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := Render(&buf, Excerpt(tt.html, tt.n)); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != tt.expected {
+			t.Errorf("Excerpt(%q, %d): expected %q, got %q", tt.html, tt.n, tt.expected, buf.String())
+		}
+	}
+}
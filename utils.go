@@ -82,6 +82,39 @@ func Range[T any](input []T, f func(T) HyperNode) HyperNode {
 	return result
 }
 
+// GroupBy splits a slice into groups of the items with the same key, in the
+// order their keys first appear, and transforms each group into a Node.
+// The items keep their order within a group, so sorting the input by key
+// first renders contiguous sections.
+//
+// Example:
+//
+//	GroupBy(contacts, func(c Contact) string { return c.Name[:1] },
+//		func(letter string, contacts []Contact) HyperNode {
+//			return SECTION()(
+//				H2()(letter),
+//				UL()(Range(contacts, func(c Contact) HyperNode { return LI()(c.Name) })),
+//			)
+//		},
+//	)
+func GroupBy[T any, K comparable](input []T, key func(T) K, f func(key K, items []T) HyperNode) HyperNode {
+	var keys []K
+	groups := map[K][]T{}
+	for _, item := range input {
+		k := key(item)
+		if _, ok := groups[k]; !ok {
+			keys = append(keys, k)
+		}
+		groups[k] = append(groups[k], item)
+	}
+
+	result := Element{Tag: "", Children: make([]HyperNode, 0, len(keys))}
+	for _, k := range keys {
+		result.Children = append(result.Children, f(k, groups[k]))
+	}
+	return result
+}
+
 // Group groups multiple children without wrapping them in a tag.
 // It creates a container Element with an empty Tag, which renders only its children.
 //
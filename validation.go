@@ -0,0 +1,137 @@
+package h
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Number is the constraint of the numeric validation helpers.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+func formatNumber[T Number](n T) string {
+	return strconv.FormatFloat(float64(n), 'f', -1, 64)
+}
+
+// Pattern returns a pattern attribute matching re, so only compiled (thus
+// valid) regular expressions reach the page. Browsers match the pattern
+// against the whole value, and with JavaScript's syntax, which agrees with
+// Go's for common expressions.
+//
+// Example:
+//
+//	var zipCode = regexp.MustCompile(`[0-9]{5}`)
+//
+//	INPUT(AttrName("zip"), Pattern(zipCode))
+func Pattern(re *regexp.Regexp) PairAttribute {
+	return AttrPattern(re.String())
+}
+
+// MinMax returns the min and max attributes of a number or range input.
+//
+// Example:
+//
+//	INPUT(AttrType(TypeNumber), MinMax(1, 10), Step(0.5))
+func MinMax[T Number](min, max T) Attributes {
+	return Attributes{AttrMin(formatNumber(min)), AttrMax(formatNumber(max))}
+}
+
+// Step returns the step attribute of a number or range input.
+func Step[T Number](step T) PairAttribute {
+	return AttrStep(formatNumber(step))
+}
+
+// MinLength returns the minlength attribute of a text input.
+func MinLength(n int) PairAttribute {
+	return AttrMinLength(strconv.Itoa(n))
+}
+
+// MaxLength returns the maxlength attribute of a text input.
+func MaxLength(n int) PairAttribute {
+	return AttrMaxLength(strconv.Itoa(n))
+}
+
+// errorAttribute is an attribute failing to render, for helpers that can't
+// return an error.
+type errorAttribute struct {
+	err error
+}
+
+func (me errorAttribute) Render(buf *bytes.Buffer) error {
+	return me.err
+}
+
+// ConstraintAttrs returns the HTML constraint validation attributes
+// mirroring the `validate` struct tag of the field called field of v (a
+// struct or pointer to a struct), in the syntax of
+// github.com/go-playground/validator, so the browser checks what the server
+// checks without keeping both in sync by hand.
+//
+// The mirrored rules are required, min, max, gte, lte and len (lengths for
+// strings, values for numbers), email and url (input types), alpha, alphanum
+// and numeric (patterns). Other rules are left to the server. The returned
+// attribute fails to render when v has no such field.
+//
+// Example:
+//
+//	type Signup struct {
+//		Name string `validate:"required,min=2,max=50"`
+//		Age  int    `validate:"gte=18,lte=130"`
+//	}
+//
+//	INPUT(AttrName("name"), ConstraintAttrs(Signup{}, "Name"))
+//	// <input name="name" required minlength="2" maxlength="50">
+func ConstraintAttrs(v any, field string) Attribute {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return errorAttribute{err: fmt.Errorf("h: ConstraintAttrs: %T is not a struct", v)}
+	}
+	structField, ok := t.FieldByName(field)
+	if !ok {
+		return errorAttribute{err: fmt.Errorf("h: ConstraintAttrs: %s has no field %s", t, field)}
+	}
+
+	fieldType := structField.Type
+	if fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+	}
+	isString := fieldType.Kind() == reflect.String
+
+	var attrs Attributes
+	for _, rule := range strings.Split(structField.Tag.Get("validate"), ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		switch name {
+		case "required":
+			attrs = append(attrs, AttrRequired(true))
+		case "min", "gte":
+			attrs = append(attrs, IfElse(isString, AttrMinLength(param), AttrMin(param)))
+		case "max", "lte":
+			attrs = append(attrs, IfElse(isString, AttrMaxLength(param), AttrMax(param)))
+		case "len":
+			if isString {
+				attrs = append(attrs, AttrMinLength(param), AttrMaxLength(param))
+			}
+		case "email":
+			attrs = append(attrs, AttrType(TypeEmail))
+		case "url":
+			attrs = append(attrs, AttrType(TypeUrl))
+		case "alpha":
+			attrs = append(attrs, AttrPattern("[a-zA-Z]*"))
+		case "alphanum":
+			attrs = append(attrs, AttrPattern("[a-zA-Z0-9]*"))
+		case "numeric":
+			attrs = append(attrs, AttrPattern(`[-+]?[0-9]*\.?[0-9]+`))
+		}
+	}
+	return attrs
+}
@@ -0,0 +1,75 @@
+package h
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+)
+
+func TestValidationAttributes(t *testing.T) {
+	tests := []struct {
+		attr     Attribute
+		expected string
+	}{
+		{attr: Pattern(regexp.MustCompile(`[0-9]{5}`)), expected: ` pattern="[0-9]{5}"`},
+		{attr: MinMax(1, 10), expected: ` min="1" max="10"`},
+		{attr: MinMax(0.5, 2.25), expected: ` min="0.5" max="2.25"`},
+		{attr: Step(uint8(5)), expected: ` step="5"`},
+		{attr: MinLength(2), expected: ` minlength="2"`},
+		{attr: MaxLength(50), expected: ` maxlength="50"`},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := tt.attr.Render(&buf); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != tt.expected {
+			t.Errorf("expected %q, got %q", tt.expected, buf.String())
+		}
+	}
+}
+
+func TestConstraintAttrs(t *testing.T) {
+	type signup struct {
+		Name    string  `validate:"required,min=2,max=50"`
+		Code    string  `validate:"omitempty,len=6,alphanum"`
+		Email   *string `validate:"required,email"`
+		Age     int     `validate:"gte=18,lte=130"`
+		Comment string
+	}
+
+	tests := []struct {
+		field    string
+		expected string
+	}{
+		{field: "Name", expected: ` required minlength="2" maxlength="50"`},
+		{field: "Code", expected: ` minlength="6" maxlength="6" pattern="[a-zA-Z0-9]*"`},
+		{field: "Email", expected: ` required type="email"`},
+		{field: "Age", expected: ` min="18" max="130"`},
+		{field: "Comment", expected: ``},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := ConstraintAttrs(&signup{}, tt.field).Render(&buf); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.field, tt.expected, buf.String())
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := ConstraintAttrs(signup{}, "Missing").Render(&buf); err == nil {
+		t.Error("expected an error for a missing field")
+	}
+	if err := ConstraintAttrs("not a struct", "Name").Render(&buf); err == nil {
+		t.Error("expected an error for a non-struct value")
+	}
+}
+
+func TestGroupedAttributeLookup(t *testing.T) {
+	element := INPUT(AttrName("n"), MinMax(1, 3)).(Element)
+	if value, ok := element.Attribute("max"); !ok || value != "3" {
+		t.Errorf("expected max=3, got %q, %v", value, ok)
+	}
+}
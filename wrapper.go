@@ -0,0 +1,53 @@
+package h
+
+// Wrapper is implemented by nodes wrapping other nodes without being
+// elements, such as [NamedNode], [OptionalNode] and [SensitiveNode]. Unwrap
+// returns the wrapped nodes, so the functions walking trees, such as
+// [Query], [Transform] and [StripSensitive], see the elements inside.
+//
+// Custom wrappers implement it to be seen through too. Functions returning
+// a modified copy of a tree, such as Transform, can't rebuild them, and
+// keep them as they are, like [Memo] fragments, whose output is cached.
+// For the same reason, [RenderCtx] can't pass its context to the nodes
+// inside them: wrappers rendering [NodeCtx] nodes implement NodeCtx too,
+// rendering their content with [RenderCtx].
+//
+// Example:
+//
+//	type Card struct{ Body HyperNode }
+//
+//	func (me Card) Render(w io.Writer) error { return DIV(AttrClass("card"))(me.Body).Render(w) }
+//	func (me Card) Unwrap() []HyperNode      { return []HyperNode{me.Body} }
+type Wrapper interface {
+	HyperNode
+	Unwrap() []HyperNode
+}
+
+// mapper is implemented by the wrappers of this package, which rebuild
+// themselves around their content mapped by fn.
+type mapper interface {
+	mapNodes(fn func(HyperNode) HyperNode) HyperNode
+}
+
+// mapWrapped returns a copy of wrapper with fn applied to each of its
+// wrapped nodes. Custom wrappers and memoized fragments are returned as
+// they are.
+func mapWrapped(wrapper Wrapper, fn func(HyperNode) HyperNode) HyperNode {
+	if m, ok := wrapper.(mapper); ok {
+		return m.mapNodes(fn)
+	}
+	return wrapper
+}
+
+// inlined returns the nodes rendered in place of node when it is a wrapper
+// of this package rendering exactly what it wraps, which renderers such as
+// [RenderStream] descend into.
+func inlined(node HyperNode) ([]HyperNode, bool) {
+	switch n := node.(type) {
+	case NamedNode, KeyedNode, OptionalNode, SlotDef:
+		return n.(Wrapper).Unwrap(), true
+	case SensitiveNode:
+		return []HyperNode{n.node()}, true
+	}
+	return nil, false
+}